@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 )
 
 type Config struct {
@@ -14,6 +15,33 @@ type Config struct {
 	SessionSecret        string
 	SessionEncryptionKey string
 
+	// SMTP server email notifications are sent through; email channels are
+	// unavailable while SMTPHost is empty.
+	SMTPHost             string
+	SMTPPort             string
+	SMTPUsername         string
+	SMTPPassword         string
+	SMTPFrom             string
+
+	// VAPIDSubject is the contact (a mailto: or https URL) Web Push
+	// services are given with each push; BaseURL unless set.
+	VAPIDSubject         string
+
+	// APNs provider for the iOS app: the .p8 token signing key, its key ID,
+	// the Apple developer team ID and the app's bundle ID (the topic). Native
+	// push is off while APNSKeyPath is empty. APNSURL is production unless
+	// set, e.g. to the sandbox for development builds.
+	APNSKeyPath          string
+	APNSKeyID            string
+	APNSTeamID           string
+	APNSTopic            string
+	APNSURL              string
+
+	// NotifyPrivateHosts are hostnames webhook, ntfy and Gotify channels may
+	// reach on a private network, e.g. a self-hosted ntfy server on the LAN.
+	// Every other private address is refused.
+	NotifyPrivateHosts   []string
+
 	BaseURL              string
 	LogLevel             string
 	Port                 string
@@ -30,12 +58,32 @@ func Load() (Config, error) {
 		SessionSecret:        os.Getenv("SESSION_SECRET"),
 		SessionEncryptionKey: os.Getenv("SESSION_ENCRYPTION_KEY"),
 
+		SMTPHost:             os.Getenv("SMTP_HOST"),
+		SMTPPort:             envOrDefault("SMTP_PORT", "587"),
+		SMTPUsername:         os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:         os.Getenv("SMTP_PASSWORD"),
+		SMTPFrom:             os.Getenv("SMTP_FROM"),
+
+		VAPIDSubject:         os.Getenv("VAPID_SUBJECT"),
+
+		APNSKeyPath:          os.Getenv("APNS_KEY_PATH"),
+		APNSKeyID:            os.Getenv("APNS_KEY_ID"),
+		APNSTeamID:           os.Getenv("APNS_TEAM_ID"),
+		APNSTopic:            os.Getenv("APNS_TOPIC"),
+		APNSURL:              envOrDefault("APNS_URL", "https://api.push.apple.com"),
+
+		NotifyPrivateHosts:   listFromEnv("NOTIFY_PRIVATE_HOSTS"),
+
 		BaseURL:              envOrDefault("BASE_URL", "http://localhost:8080"),
 		LogLevel:             envOrDefault("LOG_LEVEL", "info"),
 		Port:                 envOrDefault("PORT", "8080"),
 		DevMode:              os.Getenv("DEV_MODE") == "true",
 	}
 
+	if config.VAPIDSubject == "" {
+		config.VAPIDSubject = config.BaseURL
+	}
+
 	if config.SessionSecret == "" {
 		return Config{}, fmt.Errorf("SESSION_SECRET is required")
 	}
@@ -44,6 +92,10 @@ func Load() (Config, error) {
 		return Config{}, fmt.Errorf("SESSION_SECRET must be at least 32 characters")
 	}
 
+	if config.APNSKeyPath != "" && (config.APNSKeyID == "" || config.APNSTeamID == "" || config.APNSTopic == "") {
+		return Config{}, fmt.Errorf("APNS_KEY_ID, APNS_TEAM_ID and APNS_TOPIC are required with APNS_KEY_PATH")
+	}
+
 	if config.OIDCIssuer == "" && !config.DevMode {
 		return Config{}, fmt.Errorf("OIDC_ISSUER is required (set DEV_MODE=true to bypass for local development)")
 	}
@@ -57,3 +109,14 @@ func envOrDefault(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// listFromEnv splits a comma-separated variable, dropping blank entries.
+func listFromEnv(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,4 @@
+-- Optional RFC 5545 RRULE for a series. When set it takes precedence over the
+-- simple recurrence_type/recurrence_value pair, which is still derived from the
+-- rule's FREQ so filtering by recurrence type keeps working.
+ALTER TABLE chore_series ADD COLUMN recurrence_rule TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,66 @@
+-- Skip and snooze for individual occurrences. Adds the 'skipped' status to both
+-- chores and chore_assignments (a CHECK constraint change, so both tables are
+-- rebuilt as in 016) and records the originally scheduled date of a snoozed
+-- occurrence so seeding keeps the series on its original cadence. Runs without
+-- a transaction wrapper (NoTxWrap) so foreign_keys can be toggled off.
+
+PRAGMA foreign_keys=OFF;
+
+CREATE TABLE chores_new (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_by_user_id TEXT NOT NULL REFERENCES users(id),
+    category_id TEXT REFERENCES categories(id) ON DELETE SET NULL,
+    assigned_to_user_id TEXT REFERENCES users(id),
+    last_assigned_index INTEGER NOT NULL DEFAULT 0,
+    due_date TIMESTAMP,
+    due_time TEXT,
+    original_due_date TIMESTAMP,
+    series_id TEXT REFERENCES chore_series(id),
+    status TEXT NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'completed', 'overdue', 'skipped')),
+    completed_at TIMESTAMP,
+    completed_by_user_id TEXT REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO chores_new (
+    id, name, description, created_by_user_id, category_id,
+    assigned_to_user_id, last_assigned_index, due_date, due_time, series_id,
+    status, completed_at, completed_by_user_id, created_at, updated_at)
+SELECT
+    id, name, description, created_by_user_id, category_id,
+    assigned_to_user_id, last_assigned_index, due_date, due_time, series_id,
+    status, completed_at, completed_by_user_id, created_at, updated_at
+FROM chores;
+
+DROP TABLE chores;
+ALTER TABLE chores_new RENAME TO chores;
+
+CREATE INDEX idx_chores_status ON chores(status);
+CREATE INDEX idx_chores_assigned_to ON chores(assigned_to_user_id);
+CREATE INDEX idx_chores_due_date ON chores(due_date);
+CREATE INDEX idx_chores_series_id ON chores(series_id);
+
+CREATE TABLE chore_assignments_new (
+    id TEXT PRIMARY KEY,
+    chore_id TEXT NOT NULL REFERENCES chores(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id),
+    assigned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP,
+    status TEXT NOT NULL DEFAULT 'assigned'
+        CHECK (status IN ('assigned', 'completed', 'reassigned', 'skipped'))
+);
+
+INSERT INTO chore_assignments_new (id, chore_id, user_id, assigned_at, completed_at, status)
+SELECT id, chore_id, user_id, assigned_at, completed_at, status FROM chore_assignments;
+
+DROP TABLE chore_assignments;
+ALTER TABLE chore_assignments_new RENAME TO chore_assignments;
+
+CREATE INDEX idx_chore_assignments_chore_id ON chore_assignments(chore_id);
+CREATE INDEX idx_chore_assignments_user_id ON chore_assignments(user_id);
+
+PRAGMA foreign_keys=ON;
@@ -0,0 +1,13 @@
+CREATE TABLE chore_swaps (
+    id TEXT PRIMARY KEY,
+    chore_id TEXT NOT NULL REFERENCES chores(id) ON DELETE CASCADE,
+    requested_chore_id TEXT REFERENCES chores(id) ON DELETE CASCADE,
+    from_user_id TEXT NOT NULL REFERENCES users(id),
+    to_user_id TEXT NOT NULL REFERENCES users(id),
+    status TEXT NOT NULL DEFAULT 'pending' CHECK(status IN ('pending', 'accepted', 'declined', 'cancelled')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    responded_at TIMESTAMP
+);
+
+CREATE INDEX idx_chore_swaps_to_user ON chore_swaps(to_user_id, status);
+CREATE INDEX idx_chore_swaps_from_user ON chore_swaps(from_user_id, status);
@@ -0,0 +1,8 @@
+-- Per-series assignment strategy. round_robin keeps using
+-- rotation_cursor_user_id; fixed always assigns fixed_assignee_user_id while
+-- they remain eligible. effort_points weighs a completion for the
+-- effort-weighted strategy.
+ALTER TABLE chore_series ADD COLUMN assignment_strategy TEXT NOT NULL DEFAULT 'round_robin'
+    CHECK(assignment_strategy IN ('round_robin', 'least_loaded', 'effort_weighted', 'random', 'fixed'));
+ALTER TABLE chore_series ADD COLUMN fixed_assignee_user_id TEXT REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE chore_series ADD COLUMN effort_points INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,39 @@
+-- One-off chores have no series row, so their points value lives on the chore.
+ALTER TABLE chores ADD COLUMN effort_points INTEGER NOT NULL DEFAULT 1;
+
+CREATE TABLE points_ledger (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    points INTEGER NOT NULL,
+    reason TEXT NOT NULL CHECK(reason IN ('chore', 'redemption')),
+    chore_id TEXT REFERENCES chores(id) ON DELETE SET NULL,
+    redemption_id TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_points_ledger_user ON points_ledger(user_id, created_at);
+
+CREATE TABLE rewards (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    cost INTEGER NOT NULL CHECK(cost > 0),
+    active INTEGER NOT NULL DEFAULT 1,
+    created_by_user_id TEXT NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE reward_redemptions (
+    id TEXT PRIMARY KEY,
+    reward_id TEXT NOT NULL REFERENCES rewards(id),
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    cost INTEGER NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK(status IN ('pending', 'approved', 'rejected')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP,
+    resolved_by_user_id TEXT REFERENCES users(id)
+);
+
+CREATE INDEX idx_reward_redemptions_status ON reward_redemptions(status, created_at);
+CREATE INDEX idx_reward_redemptions_user ON reward_redemptions(user_id, created_at);
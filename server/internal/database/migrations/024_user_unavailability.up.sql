@@ -0,0 +1,13 @@
+CREATE TABLE user_unavailability (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    start_date TEXT NOT NULL,
+    end_date TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_by_user_id TEXT NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK(start_date <= end_date)
+);
+
+CREATE INDEX idx_user_unavailability_dates ON user_unavailability(end_date, start_date);
+CREATE INDEX idx_user_unavailability_user ON user_unavailability(user_id, end_date);
@@ -0,0 +1,25 @@
+-- Ordered checklist items. A series keeps the template list, which is copied
+-- onto each occurrence as it is created; ticks are recorded per occurrence.
+-- checklist_required blocks completion until every item is ticked. It lives
+-- on the series like effort_points, with the chores column covering one-off
+-- chores that have no series.
+CREATE TABLE chore_series_checklist_items (
+    series_id TEXT NOT NULL REFERENCES chore_series(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    PRIMARY KEY (series_id, position)
+);
+
+CREATE TABLE chore_checklist_items (
+    id TEXT PRIMARY KEY,
+    chore_id TEXT NOT NULL REFERENCES chores(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    checked_at TIMESTAMP,
+    checked_by_user_id TEXT REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_chore_checklist_items_chore ON chore_checklist_items(chore_id, position);
+
+ALTER TABLE chore_series ADD COLUMN checklist_required INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE chores ADD COLUMN checklist_required INTEGER NOT NULL DEFAULT 0;
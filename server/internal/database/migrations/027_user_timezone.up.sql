@@ -0,0 +1,3 @@
+-- Per-member timezone override. Empty means "use the household timezone"
+-- (the `timezone` setting), which in turn falls back to the server's zone.
+ALTER TABLE users ADD COLUMN timezone TEXT NOT NULL DEFAULT '';
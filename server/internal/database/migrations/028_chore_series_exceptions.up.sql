@@ -0,0 +1,14 @@
+-- Exceptions to a series' schedule, one per slot (the date the rule generated
+-- for an occurrence, before any snooze or move). chore_id is the occurrence
+-- that was edited on its own for that slot; NULL means the occurrence was
+-- deleted. Seeding never regenerates an excepted slot, and series-wide edits
+-- leave individually edited occurrences alone.
+CREATE TABLE chore_series_exceptions (
+    series_id TEXT NOT NULL REFERENCES chore_series(id) ON DELETE CASCADE,
+    occurrence_date TEXT NOT NULL,
+    chore_id TEXT REFERENCES chores(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (series_id, occurrence_date)
+);
+
+CREATE INDEX idx_chore_series_exceptions_chore ON chore_series_exceptions(chore_id);
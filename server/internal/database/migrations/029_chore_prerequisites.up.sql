@@ -0,0 +1,65 @@
+-- Chore dependencies. A series can list other series as prerequisites; each
+-- of its occurrences waits in the new 'blocked' status (a CHECK constraint
+-- change, so chores is rebuilt as in 026) until the prerequisite occurrence
+-- for the same cycle is done, then falls due prerequisite_delay_minutes after
+-- that completion. Runs without a transaction wrapper (NoTxWrap) so
+-- foreign_keys can be toggled off.
+
+PRAGMA foreign_keys=OFF;
+
+CREATE TABLE chores_new (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_by_user_id TEXT NOT NULL REFERENCES users(id),
+    category_id TEXT REFERENCES categories(id) ON DELETE SET NULL,
+    assigned_to_user_id TEXT REFERENCES users(id),
+    last_assigned_index INTEGER NOT NULL DEFAULT 0,
+    due_date TIMESTAMP,
+    due_time TEXT,
+    original_due_date TIMESTAMP,
+    series_id TEXT REFERENCES chore_series(id),
+    effort_points INTEGER NOT NULL DEFAULT 1,
+    checklist_required INTEGER NOT NULL DEFAULT 0,
+    requires_approval INTEGER NOT NULL DEFAULT 0,
+    proof_image_data TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'completed', 'overdue', 'skipped', 'awaiting_approval', 'blocked')),
+    completed_at TIMESTAMP,
+    completed_by_user_id TEXT REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO chores_new (
+    id, name, description, created_by_user_id, category_id,
+    assigned_to_user_id, last_assigned_index, due_date, due_time, original_due_date, series_id,
+    effort_points, checklist_required, requires_approval, proof_image_data,
+    status, completed_at, completed_by_user_id, created_at, updated_at)
+SELECT
+    id, name, description, created_by_user_id, category_id,
+    assigned_to_user_id, last_assigned_index, due_date, due_time, original_due_date, series_id,
+    effort_points, checklist_required, requires_approval, proof_image_data,
+    status, completed_at, completed_by_user_id, created_at, updated_at
+FROM chores;
+
+DROP TABLE chores;
+ALTER TABLE chores_new RENAME TO chores;
+
+CREATE INDEX idx_chores_status ON chores(status);
+CREATE INDEX idx_chores_assigned_to ON chores(assigned_to_user_id);
+CREATE INDEX idx_chores_due_date ON chores(due_date);
+CREATE INDEX idx_chores_series_id ON chores(series_id);
+
+CREATE TABLE chore_series_prerequisites (
+    series_id TEXT NOT NULL REFERENCES chore_series(id) ON DELETE CASCADE,
+    prerequisite_series_id TEXT NOT NULL REFERENCES chore_series(id) ON DELETE CASCADE,
+    PRIMARY KEY (series_id, prerequisite_series_id),
+    CHECK (series_id != prerequisite_series_id)
+);
+
+CREATE INDEX idx_chore_series_prerequisites_prerequisite ON chore_series_prerequisites(prerequisite_series_id);
+
+ALTER TABLE chore_series ADD COLUMN prerequisite_delay_minutes INTEGER NOT NULL DEFAULT 0;
+
+PRAGMA foreign_keys=ON;
@@ -0,0 +1,9 @@
+-- Group chores. A group chore is assigned to every available member of its
+-- pool at once, each holding their own chore_assignments row, and completes
+-- once group_quorum of them have done their part (0 means all of them). Both
+-- live on the series like requires_approval, with the chores columns covering
+-- one-off chores.
+ALTER TABLE chore_series ADD COLUMN group_chore INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE chore_series ADD COLUMN group_quorum INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE chores ADD COLUMN group_chore INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE chores ADD COLUMN group_quorum INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,21 @@
+-- Escalation of overdue chores. A series can remind its assignee once an
+-- occurrence has been overdue for escalation_remind_hours, hand it to the next
+-- person in the rotation after escalation_reassign_hours, and tell the admins
+-- when the last of those steps is taken. 0 turns a step off.
+ALTER TABLE chore_series ADD COLUMN escalation_remind_hours INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE chore_series ADD COLUMN escalation_reassign_hours INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE chore_series ADD COLUMN escalation_notify_admins INTEGER NOT NULL DEFAULT 0;
+
+-- Each escalation step taken on an occurrence, so every step runs once and the
+-- history can be shown. user_id is who was reminded or handed the chore and
+-- from_user_id who it was taken from; both are NULL when the admins were told.
+CREATE TABLE chore_escalations (
+    id TEXT PRIMARY KEY,
+    chore_id TEXT NOT NULL REFERENCES chores(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK(kind IN ('reminded', 'reassigned', 'admins_notified')),
+    user_id TEXT REFERENCES users(id) ON DELETE SET NULL,
+    from_user_id TEXT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_chore_escalations_chore ON chore_escalations(chore_id, created_at);
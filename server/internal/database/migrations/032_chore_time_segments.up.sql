@@ -0,0 +1,15 @@
+-- Time tracking. Each segment is one stretch of a member working on an
+-- occurrence, from starting (or resuming) their timer to pausing or stopping
+-- it; ended_at is NULL while the timer runs, and a member has at most one
+-- running segment per occurrence. Times are stored in UTC so SQLite's date
+-- functions can total them.
+CREATE TABLE chore_time_segments (
+    id TEXT PRIMARY KEY,
+    chore_id TEXT NOT NULL REFERENCES chores(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    started_at TIMESTAMP NOT NULL,
+    ended_at TIMESTAMP
+);
+
+CREATE INDEX idx_chore_time_segments_chore ON chore_time_segments(chore_id, started_at);
+CREATE UNIQUE INDEX idx_chore_time_segments_running ON chore_time_segments(chore_id, user_id) WHERE ended_at IS NULL;
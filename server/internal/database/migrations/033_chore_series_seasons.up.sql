@@ -0,0 +1,13 @@
+-- Seasonal series. A series with seasons only generates occurrences on dates
+-- inside one of its windows; with none it runs all year. Each window runs from
+-- start_month/start_day to end_month/end_day inclusive and wraps over the new
+-- year when the end comes before the start (November to February).
+CREATE TABLE chore_series_seasons (
+    series_id TEXT NOT NULL REFERENCES chore_series(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    start_month INTEGER NOT NULL CHECK (start_month BETWEEN 1 AND 12),
+    start_day INTEGER NOT NULL CHECK (start_day BETWEEN 1 AND 31),
+    end_month INTEGER NOT NULL CHECK (end_month BETWEEN 1 AND 12),
+    end_day INTEGER NOT NULL CHECK (end_day BETWEEN 1 AND 31),
+    PRIMARY KEY (series_id, position)
+);
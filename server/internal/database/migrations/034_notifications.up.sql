@@ -0,0 +1,42 @@
+-- Notification channels each user has set up. target is the webhook URL, ntfy
+-- topic URL, email address or Gotify server URL; token is the ntfy access
+-- token or Gotify application token, '' when not needed.
+CREATE TABLE notification_channels (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK(kind IN ('webhook', 'ntfy', 'email', 'gotify')),
+    target TEXT NOT NULL,
+    token TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_notification_channels_user ON notification_channels(user_id);
+
+-- The events each channel is sent.
+CREATE TABLE notification_channel_events (
+    channel_id TEXT NOT NULL REFERENCES notification_channels(id) ON DELETE CASCADE,
+    event TEXT NOT NULL,
+    PRIMARY KEY (channel_id, event)
+);
+
+-- The delivery queue. A pending notification is sent once next_attempt_at
+-- has passed; a failed attempt is retried later until it is given up on as
+-- failed. dedupe_key keeps a reminder from being queued twice on a channel.
+CREATE TABLE notifications (
+    id TEXT PRIMARY KEY,
+    channel_id TEXT NOT NULL REFERENCES notification_channels(id) ON DELETE CASCADE,
+    event TEXT NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    link TEXT NOT NULL DEFAULT '',
+    dedupe_key TEXT,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK(status IN ('pending', 'sent', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_notifications_dedupe ON notifications(channel_id, dedupe_key) WHERE dedupe_key IS NOT NULL;
+CREATE INDEX idx_notifications_due ON notifications(status, next_attempt_at);
@@ -0,0 +1,21 @@
+-- Family digests. Each member is sent a daily digest at daily_time and, on
+-- Sundays, a week-ahead digest at weekly_time, both local times ('' turns
+-- one off). daily_sent_on and weekly_sent_on are the local dates they were
+-- last sent, so each goes out once a day. Members without a row get the
+-- defaults.
+CREATE TABLE digest_schedules (
+    user_id TEXT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    daily_time TEXT NOT NULL DEFAULT '07:00',
+    weekly_time TEXT NOT NULL DEFAULT '18:00',
+    daily_sent_on TEXT NOT NULL DEFAULT '',
+    weekly_sent_on TEXT NOT NULL DEFAULT ''
+);
+
+-- A digest carries an HTML version for email alongside its plain text body.
+ALTER TABLE notifications ADD COLUMN html TEXT NOT NULL DEFAULT '';
+
+-- Existing channels are sent every event, so they get the digests too.
+INSERT INTO notification_channel_events (channel_id, event)
+SELECT id, 'daily_digest' FROM notification_channels;
+INSERT INTO notification_channel_events (channel_id, event)
+SELECT id, 'weekly_digest' FROM notification_channels;
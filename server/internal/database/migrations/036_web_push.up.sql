@@ -0,0 +1,41 @@
+-- Browser Web Push. A member's browsers each register a push subscription:
+-- the push service endpoint and the keys its payloads are encrypted to. They
+-- share one 'webpush' notification channel per member, which picks the events
+-- pushed; adding that kind is a CHECK constraint change, so
+-- notification_channels is rebuilt as chores was in 026. Runs without a
+-- transaction wrapper (NoTxWrap) so foreign_keys can be toggled off.
+
+PRAGMA foreign_keys=OFF;
+
+CREATE TABLE notification_channels_new (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK(kind IN ('webhook', 'ntfy', 'email', 'gotify', 'webpush')),
+    target TEXT NOT NULL,
+    token TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO notification_channels_new (id, user_id, kind, target, token, created_at)
+SELECT id, user_id, kind, target, token, created_at FROM notification_channels;
+
+DROP TABLE notification_channels;
+ALTER TABLE notification_channels_new RENAME TO notification_channels;
+
+CREATE INDEX idx_notification_channels_user ON notification_channels(user_id);
+
+PRAGMA foreign_keys=ON;
+
+-- endpoint is unique: a browser that subscribes again, perhaps for another
+-- member, replaces its old subscription.
+CREATE TABLE push_subscriptions (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    endpoint TEXT NOT NULL UNIQUE,
+    p256dh TEXT NOT NULL,
+    auth TEXT NOT NULL,
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_push_subscriptions_user ON push_subscriptions(user_id);
@@ -0,0 +1,36 @@
+-- Native push to the iOS app through APNs. Each signed-in device registers
+-- its APNs device token; a member's devices share one 'apns' notification
+-- channel, as browsers share the 'webpush' one. Adding the kind rebuilds
+-- notification_channels again, as in 036. Runs without a transaction wrapper
+-- (NoTxWrap) so foreign_keys can be toggled off.
+
+PRAGMA foreign_keys=OFF;
+
+CREATE TABLE notification_channels_new (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK(kind IN ('webhook', 'ntfy', 'email', 'gotify', 'webpush', 'apns')),
+    target TEXT NOT NULL,
+    token TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO notification_channels_new (id, user_id, kind, target, token, created_at)
+SELECT id, user_id, kind, target, token, created_at FROM notification_channels;
+
+DROP TABLE notification_channels;
+ALTER TABLE notification_channels_new RENAME TO notification_channels;
+
+CREATE INDEX idx_notification_channels_user ON notification_channels(user_id);
+
+PRAGMA foreign_keys=ON;
+
+-- token is unique: a device that signs in as another member moves to them.
+CREATE TABLE apns_devices (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_apns_devices_user ON apns_devices(user_id);
@@ -0,0 +1,41 @@
+-- Outgoing webhooks admins register for home automation (Home Assistant,
+-- Node-RED). Each is posted the domain events it subscribes to, signed with
+-- its secret. An inactive webhook keeps its settings but is posted nothing.
+CREATE TABLE webhooks (
+    id TEXT PRIMARY KEY,
+    url TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    secret TEXT NOT NULL,
+    active INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- The events each webhook is posted.
+CREATE TABLE webhook_events (
+    webhook_id TEXT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event TEXT NOT NULL,
+    PRIMARY KEY (webhook_id, event)
+);
+
+-- The delivery queue, kept as each webhook's delivery log. payload is the
+-- exact JSON body posted, so a replay sends the same event again. As with
+-- notifications, a pending delivery is attempted once next_attempt_at has
+-- passed and retried with backoff until it is given up on as failed.
+-- response_status is the HTTP status of the last attempt, 0 when there was
+-- no response.
+CREATE TABLE webhook_deliveries (
+    id TEXT PRIMARY KEY,
+    webhook_id TEXT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK(status IN ('pending', 'sent', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    response_status INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP
+);
+
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(status, next_attempt_at);
+CREATE INDEX idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, created_at);
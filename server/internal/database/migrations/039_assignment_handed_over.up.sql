@@ -0,0 +1,4 @@
+-- Marks an assignment that did not come from the rotation: a swap, cover for
+-- an away member, an escalation or an edit to a single occurrence. Handing a
+-- skipper their turn back re-rotates the series around those occurrences.
+ALTER TABLE chore_assignments ADD COLUMN handed_over INTEGER NOT NULL DEFAULT 0;
@@ -3,10 +3,12 @@ package handlers
 import (
 	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/bensuskins/family-hub/internal/middleware"
 	"github.com/bensuskins/family-hub/internal/models"
 	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
 	"github.com/bensuskins/family-hub/templates/pages"
 	"github.com/go-chi/chi/v5"
 )
@@ -59,12 +61,25 @@ func (handler *AdminHandler) Users(w http.ResponseWriter, r *http.Request) {
 		familyName = "Family"
 	}
 
+	allowancePerPoint, err := handler.settingsRepo.Get(ctx, repository.SettingsKeyAllowancePerPoint)
+	if err != nil {
+		slog.Error("getting allowance per point", "error", err)
+	}
+
+	timezone, err := handler.settingsRepo.Get(ctx, repository.SettingsKeyTimezone)
+	if err != nil {
+		slog.Debug("getting timezone", "error", err)
+	}
+
 	component := pages.AdminUsers(pages.AdminUsersProps{
-		User:       user,
-		AllUsers:   users,
-		APITokens:  tokens,
-		Categories: categories,
-		FamilyName: familyName,
+		User:              user,
+		AllUsers:          users,
+		APITokens:         tokens,
+		Categories:        categories,
+		FamilyName:        familyName,
+		AllowancePerPoint: allowancePerPoint,
+		Timezone:          timezone,
+		DefaultTimezone:   services.DefaultLocation.String(),
 	})
 	component.Render(ctx, w)
 }
@@ -144,5 +159,35 @@ func (handler *AdminHandler) UpdateSettings(w http.ResponseWriter, r *http.Reque
 		}
 	}
 
+	if r.PostForm.Has(repository.SettingsKeyAllowancePerPoint) {
+		allowance := strings.TrimSpace(r.PostFormValue(repository.SettingsKeyAllowancePerPoint))
+		if allowance != "" {
+			if _, err := services.ParseAllowanceRate(allowance); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		if err := handler.settingsRepo.Set(ctx, repository.SettingsKeyAllowancePerPoint, allowance); err != nil {
+			slog.Error("updating allowance per point", "error", err)
+			http.Error(w, "Error updating settings", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if r.PostForm.Has(repository.SettingsKeyTimezone) {
+		timezone := strings.TrimSpace(r.PostFormValue(repository.SettingsKeyTimezone))
+		if timezone != "" {
+			if _, err := services.ParseTimezone(timezone); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		if err := handler.settingsRepo.Set(ctx, repository.SettingsKeyTimezone, timezone); err != nil {
+			slog.Error("updating timezone", "error", err)
+			http.Error(w, "Error updating settings", http.StatusInternalServerError)
+			return
+		}
+	}
+
 	http.Redirect(w, r, "/admin/users", http.StatusFound)
 }
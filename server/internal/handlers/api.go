@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"crypto/rand"
 	"database/sql"
 	"encoding/hex"
@@ -31,6 +32,12 @@ type APIHandler struct {
 	inventoryRepo   repository.InventoryRepository
 	icalFetcher      *services.ICalFetcher
 	recipeExtractor  *services.RecipeExtractor
+	swapService      *services.ChoreSwapService
+	pointsRepo       repository.PointsRepository
+	rewardService    *services.RewardService
+	notifications    *services.NotificationService
+	webhooks         *services.WebhookService
+	icalSubRepo      repository.ICalSubscriptionRepository
 	oidcUserInfoURL  string
 	clientID        string
 	oidcIssuer      string
@@ -49,6 +56,12 @@ func NewAPIHandler(
 	inventoryRepo repository.InventoryRepository,
 	icalFetcher *services.ICalFetcher,
 	recipeExtractor *services.RecipeExtractor,
+	swapService *services.ChoreSwapService,
+	pointsRepo repository.PointsRepository,
+	rewardService *services.RewardService,
+	notifications *services.NotificationService,
+	webhooks *services.WebhookService,
+	icalSubRepo repository.ICalSubscriptionRepository,
 	oidcUserInfoURL string,
 	clientID string,
 	oidcIssuer string,
@@ -66,6 +79,12 @@ func NewAPIHandler(
 		inventoryRepo:   inventoryRepo,
 		icalFetcher:      icalFetcher,
 		recipeExtractor:  recipeExtractor,
+		swapService:      swapService,
+		pointsRepo:       pointsRepo,
+		rewardService:    rewardService,
+		notifications:    notifications,
+		webhooks:         webhooks,
+		icalSubRepo:      icalSubRepo,
 		oidcUserInfoURL:  oidcUserInfoURL,
 		clientID:        clientID,
 		oidcIssuer:      oidcIssuer,
@@ -210,6 +229,25 @@ func (handler *APIHandler) GetChore(w http.ResponseWriter, r *http.Request) {
 		writeJSONError(w, http.StatusNotFound, "chore not found")
 		return
 	}
+	checklist, err := handler.choreService.Checklist(ctx, chore.ID)
+	if err != nil {
+		slog.Error("loading checklist via API", "error", err)
+	}
+	chore.Checklist = checklist
+	escalations, err := handler.choreService.Escalations(ctx, chore.ID)
+	if err != nil {
+		slog.Error("loading escalations via API", "error", err)
+	}
+	chore.Escalations = escalations
+	if chore.SeriesID != nil {
+		if series, err := handler.choreService.SeriesByID(ctx, *chore.SeriesID); err != nil {
+			slog.Error("loading series via API", "error", err)
+		} else if series != nil {
+			chore.Prerequisites = series.Prerequisites
+			chore.PrerequisiteDelayMinutes = series.PrerequisiteDelayMinutes
+			chore.Seasons = series.Seasons
+		}
+	}
 	writeJSON(w, http.StatusOK, chore)
 }
 
@@ -243,16 +281,27 @@ func (handler *APIHandler) ListCategories(w http.ResponseWriter, r *http.Request
 	writeJSON(w, http.StatusOK, categories)
 }
 
+// leaderboardEntry is one row of the /api/dashboard leaderboard, ranked by
+// points earned in the requested period.
+type leaderboardEntry struct {
+	Rank      int    `json:"rank"`
+	UserID    string `json:"user_id"`
+	UserName  string `json:"user_name"`
+	Points    int    `json:"points"`
+	Completed int    `json:"completed"`
+	Pending   int    `json:"pending"`
+}
+
 func (handler *APIHandler) DashboardStats(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	now := time.Now()
+	now := time.Now().In(middleware.GetLocation(ctx))
 
-	choresDueToday, err := handler.choreRepo.FindDueToday(ctx)
+	choresDueToday, err := handler.choreRepo.FindDueToday(ctx, now)
 	if err != nil {
 		writeJSONError(w, http.StatusInternalServerError, "failed to load chores due today")
 		return
 	}
-	overdueChores, err := handler.choreRepo.FindOverdueChores(ctx)
+	overdueChores, err := handler.choreRepo.FindOverdueChores(ctx, now)
 	if err != nil {
 		writeJSONError(w, http.StatusInternalServerError, "failed to load overdue chores")
 		return
@@ -268,7 +317,7 @@ func (handler *APIHandler) DashboardStats(w http.ResponseWriter, r *http.Request
 	mealsThisWeek := []models.MealPlan{}
 	todayMeals := []models.MealPlan{}
 	if handler.mealPlanRepo != nil {
-		startOfToday := now.Truncate(24 * time.Hour)
+		startOfToday := repository.CivilDate(now)
 		sevenDaysOut := startOfToday.AddDate(0, 0, 7)
 		if meals, err := handler.mealPlanRepo.FindAll(ctx, repository.MealPlanFilter{
 			DateFrom: startOfToday.Format(DateFormat),
@@ -281,6 +330,29 @@ func (handler *APIHandler) DashboardStats(w http.ResponseWriter, r *http.Request
 		}
 	}
 
+	period := r.URL.Query().Get("period")
+	if period == "" {
+		period = "week"
+	}
+	leaderboard := []leaderboardEntry{}
+	if handler.assignmentRepo != nil && handler.pointsRepo != nil {
+		if users, err := handler.userRepo.FindAll(ctx); err == nil {
+			userStats := collectUserStats(ctx, users, handler.choreRepo, handler.assignmentRepo, handler.pointsRepo)
+			rankUserStats(userStats, period)
+			for index, stat := range userStats {
+				points, completed := stat.forPeriod(period)
+				leaderboard = append(leaderboard, leaderboardEntry{
+					Rank:      index + 1,
+					UserID:    stat.UserID,
+					UserName:  stat.UserName,
+					Points:    points,
+					Completed: completed,
+					Pending:   stat.AssignedPending,
+				})
+			}
+		}
+	}
+
 	stats := map[string]interface{}{
 		"chores_due_today":      len(choresDueToday),
 		"chores_overdue":        len(overdueChores),
@@ -288,6 +360,8 @@ func (handler *APIHandler) DashboardStats(w http.ResponseWriter, r *http.Request
 		"chores_overdue_list":   overdueChores,
 		"meals_this_week":       len(mealsThisWeek),
 		"today_meals":           todayMeals,
+		"leaderboard_period":    period,
+		"leaderboard":           leaderboard,
 	}
 	writeJSON(w, http.StatusOK, stats)
 }
@@ -357,10 +431,28 @@ func (handler *APIHandler) CompleteChore(w http.ResponseWriter, r *http.Request)
 	user := middleware.GetUser(ctx)
 	choreID := chi.URLParam(r, "id")
 
-	if err := handler.choreService.CompleteChore(ctx, choreID, user.ID); err != nil {
+	// A multipart body may carry an optional "photo" for the approver.
+	imageBytes, contentType, ok := readOptionalUploadedImage(w, r, "photo", maxRecipeImageBytes)
+	if !ok {
+		return
+	}
+	var proofImage string
+	if imageBytes != nil {
+		proofImage = encodeDataURI(contentType, imageBytes)
+	}
+
+	if err := handler.choreService.CompleteChoreWithProof(ctx, choreID, user.ID, proofImage); err != nil {
 		switch {
 		case errors.Is(err, services.ErrChoreAlreadyComplete):
 			writeJSONError(w, http.StatusConflict, "chore is already complete")
+		case errors.Is(err, services.ErrChoreAwaitingApproval):
+			writeJSONError(w, http.StatusConflict, "chore is already awaiting approval")
+		case errors.Is(err, services.ErrChoreNotOpen):
+			writeJSONError(w, http.StatusConflict, "chore is not pending or overdue")
+		case errors.Is(err, services.ErrChecklistIncomplete), errors.Is(err, services.ErrPartAlreadyDone):
+			writeJSONError(w, http.StatusConflict, err.Error())
+		case errors.Is(err, services.ErrNotParticipant):
+			writeJSONError(w, http.StatusForbidden, err.Error())
 		case errors.Is(err, sql.ErrNoRows):
 			writeJSONError(w, http.StatusNotFound, "chore not found")
 		default:
@@ -372,6 +464,111 @@ func (handler *APIHandler) CompleteChore(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// UncompleteChore undoes a completion by the token's user (admins may undo
+// anyone's) and returns the reopened chore.
+func (handler *APIHandler) UncompleteChore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+	choreID := chi.URLParam(r, "id")
+
+	if err := handler.choreService.UncompleteChore(ctx, user, choreID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrChoreNotCompleted):
+			writeJSONError(w, http.StatusConflict, "chore is not completed")
+		case errors.Is(err, services.ErrUndoForbidden):
+			writeJSONError(w, http.StatusForbidden, err.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			writeJSONError(w, http.StatusNotFound, "chore not found")
+		default:
+			slog.Error("undoing completion", "error", err, "chore_id", choreID)
+			writeJSONError(w, http.StatusInternalServerError, "failed to undo completion")
+		}
+		return
+	}
+
+	chore, err := handler.choreRepo.FindByID(ctx, choreID)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "failed to reload chore")
+		return
+	}
+	writeJSON(w, http.StatusOK, chore)
+}
+
+type skipChoreRequest struct {
+	AdvanceRotation *bool `json:"advanceRotation"`
+}
+
+// SkipChore skips an open occurrence. By default the rotation moves on as if
+// the skipper had taken their turn; "advanceRotation": false keeps the turn
+// with them for the series' next occurrence.
+func (handler *APIHandler) SkipChore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	choreID := chi.URLParam(r, "id")
+
+	var request skipChoreRequest
+	if r.ContentLength > 0 && !decodeJSONBody(w, r, &request) {
+		return
+	}
+	advanceRotation := request.AdvanceRotation == nil || *request.AdvanceRotation
+
+	if err := handler.choreService.SkipChore(ctx, choreID, advanceRotation); err != nil {
+		switch {
+		case errors.Is(err, services.ErrChoreNotOpen):
+			writeJSONError(w, http.StatusConflict, "chore is not pending or overdue")
+		case errors.Is(err, sql.ErrNoRows):
+			writeJSONError(w, http.StatusNotFound, "chore not found")
+		default:
+			writeJSONError(w, http.StatusInternalServerError, "failed to skip chore")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type snoozeChoreRequest struct {
+	DueDate string  `json:"dueDate"`
+	DueTime *string `json:"dueTime"`
+}
+
+func (handler *APIHandler) SnoozeChore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	choreID := chi.URLParam(r, "id")
+
+	var request snoozeChoreRequest
+	if !decodeJSONBody(w, r, &request) {
+		return
+	}
+	dueDate, err := time.Parse(DateFormat, request.DueDate)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid dueDate format, use YYYY-MM-DD")
+		return
+	}
+	if request.DueTime != nil {
+		if _, err := time.Parse("15:04", *request.DueTime); err != nil {
+			writeJSONError(w, http.StatusBadRequest, "invalid dueTime format, use HH:MM")
+			return
+		}
+	}
+
+	chore, err := handler.choreService.SnoozeChore(ctx, choreID, dueDate, request.DueTime)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrChoreNotOpen):
+			writeJSONError(w, http.StatusConflict, "chore is not pending or overdue")
+		case errors.Is(err, services.ErrSnoozeInPast):
+			writeJSONError(w, http.StatusBadRequest, "snooze date is in the past")
+		case errors.Is(err, sql.ErrNoRows):
+			writeJSONError(w, http.StatusNotFound, "chore not found")
+		default:
+			writeJSONError(w, http.StatusInternalServerError, "failed to snooze chore")
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, chore)
+}
+
 func (handler *APIHandler) ListMeals(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -405,7 +602,8 @@ func (handler *APIHandler) ListMeals(w http.ResponseWriter, r *http.Request) {
 
 func (handler *APIHandler) ListCalendar(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	now := time.Now()
+	location := middleware.GetLocation(ctx)
+	now := time.Now().In(location)
 
 	view := r.URL.Query().Get("view")
 	if view == "" {
@@ -422,7 +620,7 @@ func (handler *APIHandler) ListCalendar(w http.ResponseWriter, r *http.Request)
 			}
 		}
 		offset := (int(date.Weekday()) + 6) % 7
-		start = time.Date(date.Year(), date.Month(), date.Day()-offset, 0, 0, 0, 0, time.Local)
+		start = time.Date(date.Year(), date.Month(), date.Day()-offset, 0, 0, 0, 0, location)
 		end = start.AddDate(0, 0, 7)
 
 	case "day":
@@ -432,7 +630,7 @@ func (handler *APIHandler) ListCalendar(w http.ResponseWriter, r *http.Request)
 				date = d
 			}
 		}
-		start = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
+		start = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, location)
 		end = start.AddDate(0, 0, 1)
 
 	default: // "month"
@@ -445,13 +643,14 @@ func (handler *APIHandler) ListCalendar(w http.ResponseWriter, r *http.Request)
 			writeJSONError(w, http.StatusBadRequest, "invalid month format, use YYYY-MM")
 			return
 		}
-		start = monthStart
-		end = monthStart.AddDate(0, 1, -1)
+		start = time.Date(monthStart.Year(), monthStart.Month(), 1, 0, 0, 0, 0, location)
+		end = start.AddDate(0, 1, -1)
 	}
 
+	dueAfter, dueBefore := repository.CivilDate(start), repository.CivilDate(end)
 	chores, err := handler.choreRepo.FindAll(ctx, repository.ChoreFilter{
-		DueAfter:  &start,
-		DueBefore: &end,
+		DueAfter:  &dueAfter,
+		DueBefore: &dueBefore,
 	})
 	if err != nil {
 		writeJSONError(w, http.StatusInternalServerError, "failed to load chores")
@@ -467,7 +666,7 @@ func (handler *APIHandler) ListCalendar(w http.ResponseWriter, r *http.Request)
 		if err != nil {
 			slog.Error("fetching ical events for calendar API", "error", err)
 		} else {
-			events = fetchedEvents
+			events = eventsInLocation(fetchedEvents, location)
 		}
 	}
 	if events == nil {
@@ -565,6 +764,7 @@ func (handler *APIHandler) SaveMeal(w http.ResponseWriter, r *http.Request) {
 		writeJSONError(w, http.StatusInternalServerError, "failed to retrieve saved meal")
 		return
 	}
+	handler.webhooks.Emit(ctx, models.WebhookMealPlanned, saved)
 
 	writeJSON(w, http.StatusOK, saved)
 }
@@ -604,15 +804,61 @@ type choreAPIBody struct {
 	RecurrenceDays       []string `json:"recurrenceDays,omitempty"`
 	RecurrenceDayOfMonth int      `json:"recurrenceDayOfMonth,omitempty"`
 	RecurrenceUnit       string   `json:"recurrenceUnit,omitempty"`
+	RecurrenceRule       string   `json:"recurrenceRule,omitempty"`
+	// Calendar recurrence (recurrenceType "calendar"): the iCal subscription
+	// whose events drive occurrences, an optional summary match, and how many
+	// hours before each event the occurrence is due.
+	CalendarSubscriptionID string `json:"calendarSubscriptionId,omitempty"`
+	CalendarSummary        string `json:"calendarSummary,omitempty"`
+	CalendarLeadHours      int    `json:"calendarLeadHours,omitempty"`
 	RecurrenceUntil      *string  `json:"recurrenceUntil,omitempty"`
 	RecurrenceCount      *int     `json:"recurrenceCount,omitempty"`
 	RecurOnComplete      bool     `json:"recurOnComplete,omitempty"`
+	// Assignment: strategy (round_robin, least_loaded, effort_weighted,
+	// random, fixed), the owner for fixed, and effort points per completion.
+	AssignmentStrategy string `json:"assignmentStrategy,omitempty"`
+	FixedAssigneeID    string `json:"fixedAssigneeId,omitempty"`
+	EffortPoints       int    `json:"effortPoints,omitempty"`
+	// Checklist replaces the chore's (and its series') checklist when present;
+	// omit it to leave the list unchanged. checklistRequired blocks completion
+	// until every item is ticked.
+	Checklist         *[]string `json:"checklist,omitempty"`
+	ChecklistRequired bool      `json:"checklistRequired,omitempty"`
+	// RequiresApproval holds each non-admin completion as awaiting_approval
+	// until an admin approves or rejects it.
+	RequiresApproval bool `json:"requiresApproval,omitempty"`
+	// GroupChore assigns the chore to everyone in the pool at once; each
+	// completes their own part and it completes once groupQuorum of them have
+	// (0 means all).
+	GroupChore  bool `json:"groupChore,omitempty"`
+	GroupQuorum int  `json:"groupQuorum,omitempty"`
+	// Escalation of overdue occurrences: remind the assignee after
+	// escalationRemindHours overdue, hand the chore to the next person in the
+	// rotation after escalationReassignHours (0 or omitted turns either off),
+	// and with escalationNotifyAdmins tell the admins at the last step.
+	EscalationRemindHours   int  `json:"escalationRemindHours,omitempty"`
+	EscalationReassignHours int  `json:"escalationReassignHours,omitempty"`
+	EscalationNotifyAdmins  bool `json:"escalationNotifyAdmins,omitempty"`
+	// Prerequisites replaces the series the chore's series waits on when
+	// present (omit it to leave them unchanged); occurrences then fall due
+	// prerequisiteDelayMinutes after the prerequisites are done.
+	Prerequisites            *[]string `json:"prerequisites,omitempty"`
+	PrerequisiteDelayMinutes int       `json:"prerequisiteDelayMinutes,omitempty"`
+	// Seasons replaces the series' active seasons when present, each written
+	// "MM-DD..MM-DD"; an empty list means all year. Omit it to leave them
+	// unchanged.
+	Seasons *[]string `json:"seasons,omitempty"`
+	// AssignedToUserID reassigns a single occurrence; it is only read by an
+	// update with scope=occurrence.
+	AssignedToUserID *string `json:"assignedToUserId,omitempty"`
 }
 
 // applyTo writes the body's schedule, category and recurrence fields onto a
 // chore. Name/Description are set by the caller. Absent optional fields clear
-// their target so an edit can remove a value.
-func (b choreAPIBody) applyTo(chore *models.Chore) {
+// their target so an edit can remove a value. A recurrenceRule takes precedence
+// over the structured recurrence fields; an invalid rule or an unknown calendar
+// subscription is returned as an error.
+func (b choreAPIBody) applyTo(ctx context.Context, subscriptions repository.ICalSubscriptionRepository, chore *models.Chore) error {
 	recurrenceType := models.RecurrenceNone
 	if b.RecurrenceType != "" {
 		recurrenceType = models.RecurrenceType(b.RecurrenceType)
@@ -621,7 +867,17 @@ func (b choreAPIBody) applyTo(chore *models.Chore) {
 	chore.RecurrenceValue = buildRecurrenceValueFrom(
 		recurrenceType, b.RecurrenceInterval, b.RecurrenceDays, b.RecurrenceDayOfMonth, b.RecurrenceUnit,
 	)
+	chore.RecurrenceRule = ""
 	chore.RecurOnComplete = b.RecurOnComplete
+	chore.ChecklistRequired = b.ChecklistRequired
+	chore.RequiresApproval = b.RequiresApproval
+	if b.Seasons != nil {
+		seasons, err := services.ParseSeasons(strings.Join(*b.Seasons, ","))
+		if err != nil {
+			return err
+		}
+		chore.Seasons = seasons
+	}
 
 	if b.CategoryID != nil && *b.CategoryID != "" {
 		chore.CategoryID = b.CategoryID
@@ -656,6 +912,24 @@ func (b choreAPIBody) applyTo(chore *models.Chore) {
 	} else {
 		chore.RecurrenceCount = nil
 	}
+
+	if err := applyAssignmentSettings(chore, models.AssignmentStrategy(b.AssignmentStrategy), b.FixedAssigneeID, b.EffortPoints); err != nil {
+		return err
+	}
+	if err := applyGroupSettings(chore, b.GroupChore, b.GroupQuorum); err != nil {
+		return err
+	}
+	if err := applyEscalationSettings(chore, b.EscalationRemindHours, b.EscalationReassignHours, b.EscalationNotifyAdmins); err != nil {
+		return err
+	}
+
+	if b.RecurrenceRule != "" {
+		return applyRecurrenceRule(chore, b.RecurrenceRule)
+	}
+	if recurrenceType == models.RecurrenceCalendar {
+		return applyCalendarLink(ctx, subscriptions, chore, b.CalendarSubscriptionID, b.CalendarSummary, b.CalendarLeadHours)
+	}
+	return nil
 }
 
 func (handler *APIHandler) CreateChore(w http.ResponseWriter, r *http.Request) {
@@ -676,38 +950,30 @@ func (handler *APIHandler) CreateChore(w http.ResponseWriter, r *http.Request) {
 		Description:     body.Description,
 		CreatedByUserID: user.ID,
 	}
-	body.applyTo(&chore)
+	if err := body.applyTo(ctx, handler.icalSubRepo, &chore); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	created, err := handler.choreRepo.Create(ctx, chore)
+	var checklist []string
+	if body.Checklist != nil {
+		checklist = *body.Checklist
+	}
+	assigned, err := handler.choreService.CreateChore(ctx, chore, body.Assignees, checklist)
 	if err != nil {
 		slog.Error("creating chore via API", "error", err)
 		writeJSONError(w, http.StatusInternalServerError, "failed to create chore")
 		return
 	}
 
-	if len(body.Assignees) > 0 {
-		if err := handler.choreRepo.SetEligibleAssignees(ctx, created.ID, body.Assignees); err != nil {
-			slog.Error("setting eligible assignees via API", "error", err)
+	if body.Prerequisites != nil && assigned.SeriesID != nil {
+		if !handler.setPrerequisites(w, r, *assigned.SeriesID, body) {
+			return
 		}
 	}
-
-	assigned, err := handler.choreService.AssignNextUser(ctx, created)
-	if err != nil {
-		slog.Error("assigning chore via API", "error", err)
-		assigned = created
-	}
-
-	if created.RecurrenceType != models.RecurrenceNone {
-		seriesID := created.ID
-		assigned.SeriesID = &seriesID
-		if err := handler.choreService.SyncSeriesDefinition(ctx, assigned, body.Assignees); err != nil {
-			slog.Error("creating series definition via API", "error", err)
-		} else if err := handler.choreRepo.Update(ctx, assigned); err != nil {
-			slog.Error("setting series_id on new chore via API", "error", err)
-		} else if !created.RecurOnComplete {
-			if err := handler.choreService.SeedFutureOccurrences(ctx, assigned, services.SeedHorizonFrom(time.Now())); err != nil {
-				slog.Error("seeding future occurrences via API", "error", err)
-			}
+	if body.Seasons != nil {
+		if err := handler.choreService.SetSeasons(ctx, assigned, chore.Seasons); err != nil {
+			slog.Error("setting seasons via API", "error", err)
 		}
 	}
 
@@ -719,10 +985,19 @@ func (handler *APIHandler) CreateChore(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusCreated, final)
 }
 
+// UpdateChore edits a chore. For a recurring chore the scope query parameter
+// picks this occurrence only, this and the following occurrences, or the whole
+// series (the default).
 func (handler *APIHandler) UpdateChore(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	choreID := chi.URLParam(r, "id")
 
+	scope, err := services.ParseEditScope(r.URL.Query().Get("scope"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	chore, err := handler.choreRepo.FindByID(ctx, choreID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -742,44 +1017,47 @@ func (handler *APIHandler) UpdateChore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	oldRecurrenceType := chore.RecurrenceType
-	oldRecurrenceValue := chore.RecurrenceValue
-	oldRecurrenceEnd := recurrenceEndKey(chore.RecurrenceUntil, chore.RecurrenceCount)
+	if scope == services.EditScopeOccurrence && chore.SeriesID != nil {
+		handler.updateOccurrence(w, r, chore, body)
+		return
+	}
+	before := chore
 
 	chore.Name = body.Name
 	chore.Description = body.Description
-	body.applyTo(&chore)
+	if err := body.applyTo(ctx, handler.icalSubRepo, &chore); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	if err := handler.choreRepo.Update(ctx, chore); err != nil {
+	if scope == services.EditScopeFollowing {
+		chore, err = handler.choreService.SplitSeries(ctx, before, chore, body.Assignees)
+	} else {
+		err = handler.choreService.UpdateChore(ctx, before, chore, body.Assignees)
+	}
+	if err != nil {
 		slog.Error("updating chore via API", "error", err)
 		writeJSONError(w, http.StatusInternalServerError, "failed to update chore")
 		return
 	}
 
-	if body.Assignees != nil {
-		if err := handler.choreRepo.SetEligibleAssignees(ctx, chore.ID, body.Assignees); err != nil {
-			slog.Error("setting eligible assignees via API", "error", err)
+	if body.Checklist != nil {
+		if err := handler.choreService.SetChecklist(ctx, chore, *body.Checklist); err != nil {
+			slog.Error("setting checklist on update via API", "error", err)
 		}
 	}
-
-	if chore.SeriesID != nil {
-		if err := handler.choreService.SyncSeriesDefinition(ctx, chore, body.Assignees); err != nil {
-			slog.Error("syncing series definition on update via API", "error", err)
+	if body.Prerequisites != nil && chore.SeriesID != nil {
+		if !handler.setPrerequisites(w, r, *chore.SeriesID, body) {
+			return
 		}
 	}
-
-	recurrenceChanged := chore.RecurrenceType != oldRecurrenceType ||
-		chore.RecurrenceValue != oldRecurrenceValue ||
-		recurrenceEndKey(chore.RecurrenceUntil, chore.RecurrenceCount) != oldRecurrenceEnd
-	if recurrenceChanged && chore.SeriesID != nil && !chore.RecurOnComplete {
-		if err := handler.choreRepo.DeleteFuturePendingBySeries(ctx, *chore.SeriesID); err != nil {
-			slog.Error("deleting stale future instances via API", "error", err)
-		} else if err := handler.choreService.SeedFutureOccurrences(ctx, chore, services.SeedHorizonFrom(time.Now())); err != nil {
-			slog.Error("re-seeding after recurrence change via API", "error", err)
+	if body.Seasons != nil {
+		if err := handler.choreService.SetSeasons(ctx, chore, chore.Seasons); err != nil {
+			slog.Error("setting seasons on update via API", "error", err)
 		}
 	}
 
-	updated, err := handler.choreRepo.FindByID(ctx, choreID)
+	updated, err := handler.choreRepo.FindByID(ctx, chore.ID)
 	if err != nil {
 		writeJSON(w, http.StatusOK, chore)
 		return
@@ -787,10 +1065,65 @@ func (handler *APIHandler) UpdateChore(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, updated)
 }
 
+// setPrerequisites stores the body's prerequisites on a series, writing the
+// error response and returning false when they are refused.
+func (handler *APIHandler) setPrerequisites(w http.ResponseWriter, r *http.Request, seriesID string, body choreAPIBody) bool {
+	err := handler.choreService.SetPrerequisites(r.Context(), seriesID, *body.Prerequisites, body.PrerequisiteDelayMinutes)
+	switch {
+	case errors.Is(err, services.ErrPrerequisiteCycle), errors.Is(err, services.ErrPrerequisiteNotFound):
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return false
+	case err != nil:
+		slog.Error("setting prerequisites via API", "error", err)
+	}
+	return true
+}
+
+// updateOccurrence applies the body's name, description, due date and time and
+// assignedToUserId to one occurrence, leaving its series as it is.
+func (handler *APIHandler) updateOccurrence(w http.ResponseWriter, r *http.Request, chore models.Chore, body choreAPIBody) {
+	edit := services.OccurrenceEdit{
+		Name:             body.Name,
+		Description:      body.Description,
+		AssignedToUserID: body.AssignedToUserID,
+	}
+	if body.DueDate != nil {
+		dueDate, err := time.Parse(DateFormat, *body.DueDate)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "dueDate must be YYYY-MM-DD")
+			return
+		}
+		edit.DueDate = &dueDate
+	}
+	if body.DueTime != nil && *body.DueTime != "" {
+		edit.DueTime = body.DueTime
+	}
+
+	updated, err := handler.choreService.EditOccurrence(r.Context(), chore.ID, edit)
+	switch {
+	case errors.Is(err, services.ErrChoreNotOpen), errors.Is(err, services.ErrAssigneeNotFound), errors.Is(err, services.ErrGroupChoreAssignee):
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+	case err != nil:
+		slog.Error("updating occurrence via API", "error", err)
+		writeJSONError(w, http.StatusInternalServerError, "failed to update chore")
+	default:
+		writeJSON(w, http.StatusOK, updated)
+	}
+}
+
+// DeleteChore deletes a chore. For a recurring chore the scope query parameter
+// picks this occurrence only, this and the following occurrences, or the whole
+// series (the default).
 func (handler *APIHandler) DeleteChore(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	choreID := chi.URLParam(r, "id")
 
+	scope, err := services.ParseEditScope(r.URL.Query().Get("scope"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	chore, err := handler.choreRepo.FindByID(ctx, choreID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -801,16 +1134,7 @@ func (handler *APIHandler) DeleteChore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if chore.SeriesID != nil {
-		if err := handler.choreRepo.DeleteFuturePendingBySeries(ctx, *chore.SeriesID); err != nil {
-			slog.Error("deleting future pending siblings via API", "error", err)
-		}
-		if err := handler.choreService.DeleteSeriesDefinition(ctx, *chore.SeriesID); err != nil {
-			slog.Error("soft-deleting series definition via API", "error", err)
-		}
-	}
-
-	if err := handler.choreRepo.Delete(ctx, choreID); err != nil {
+	if err := handler.choreService.DeleteChore(ctx, chore, scope); err != nil {
 		slog.Error("deleting chore via API", "error", err)
 		writeJSONError(w, http.StatusInternalServerError, "failed to delete chore")
 		return
@@ -872,6 +1196,7 @@ func (handler *APIHandler) CreateRecipe(w http.ResponseWriter, r *http.Request)
 			created.HasImage = true
 		}
 	}
+	handler.webhooks.Emit(ctx, models.WebhookRecipeCreated, created)
 
 	writeJSON(w, http.StatusCreated, created)
 }
@@ -1040,6 +1365,36 @@ func (handler *APIHandler) DeleteAvatar(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// UpdateTimezone sets the caller's timezone override ({"timezone": "Europe/London"});
+// an empty timezone follows the household again. Returns the updated user.
+func (handler *APIHandler) UpdateTimezone(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	var body struct {
+		Timezone string `json:"timezone"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+
+	timezone := strings.TrimSpace(body.Timezone)
+	if timezone != "" {
+		if _, err := services.ParseTimezone(timezone); err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if err := handler.userRepo.UpdateTimezone(ctx, user.ID, timezone); err != nil {
+		slog.Error("updating timezone via API", "error", err)
+		writeJSONError(w, http.StatusInternalServerError, "failed to update timezone")
+		return
+	}
+
+	user.Timezone = timezone
+	writeJSON(w, http.StatusOK, user)
+}
+
 func (handler *APIHandler) PromoteUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	userID := chi.URLParam(r, "id")
@@ -1082,30 +1437,79 @@ func (handler *APIHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		familyName = "Family"
 	}
+	allowancePerPoint, _ := handler.settingsRepo.Get(ctx, repository.SettingsKeyAllowancePerPoint)
+	timezone, _ := handler.settingsRepo.Get(ctx, repository.SettingsKeyTimezone)
 	writeJSON(w, http.StatusOK, map[string]string{
-		"family_name": familyName,
+		"family_name":         familyName,
+		"allowance_per_point": allowancePerPoint,
+		"timezone":            timezone,
 	})
 }
 
+// PatchSettings updates whichever settings the body includes. An empty
+// allowance_per_point turns the allowance off; an empty timezone falls back to
+// the server's zone.
 func (handler *APIHandler) PatchSettings(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var body struct {
-		FamilyName string `json:"family_name"`
+		FamilyName        *string `json:"family_name"`
+		AllowancePerPoint *string `json:"allowance_per_point"`
+		Timezone          *string `json:"timezone"`
 	}
 	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 
-	if body.FamilyName == "" {
+	if body.FamilyName == nil && body.AllowancePerPoint == nil && body.Timezone == nil {
+		writeJSONError(w, http.StatusBadRequest, "family_name, allowance_per_point or timezone is required")
+		return
+	}
+	if body.FamilyName != nil && *body.FamilyName == "" {
 		writeJSONError(w, http.StatusBadRequest, "family_name is required")
 		return
 	}
+	allowance := ""
+	if body.AllowancePerPoint != nil {
+		allowance = strings.TrimSpace(*body.AllowancePerPoint)
+		if allowance != "" {
+			if _, err := services.ParseAllowanceRate(allowance); err != nil {
+				writeJSONError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+	}
+	timezone := ""
+	if body.Timezone != nil {
+		timezone = strings.TrimSpace(*body.Timezone)
+		if timezone != "" {
+			if _, err := services.ParseTimezone(timezone); err != nil {
+				writeJSONError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+	}
 
-	if err := handler.settingsRepo.Set(ctx, repository.SettingsKeyFamilyName, body.FamilyName); err != nil {
-		slog.Error("updating family name via API", "error", err)
-		writeJSONError(w, http.StatusInternalServerError, "failed to update settings")
-		return
+	if body.FamilyName != nil {
+		if err := handler.settingsRepo.Set(ctx, repository.SettingsKeyFamilyName, *body.FamilyName); err != nil {
+			slog.Error("updating family name via API", "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "failed to update settings")
+			return
+		}
+	}
+	if body.AllowancePerPoint != nil {
+		if err := handler.settingsRepo.Set(ctx, repository.SettingsKeyAllowancePerPoint, allowance); err != nil {
+			slog.Error("updating allowance via API", "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "failed to update settings")
+			return
+		}
+	}
+	if body.Timezone != nil {
+		if err := handler.settingsRepo.Set(ctx, repository.SettingsKeyTimezone, timezone); err != nil {
+			slog.Error("updating timezone via API", "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "failed to update settings")
+			return
+		}
 	}
 
 	w.WriteHeader(http.StatusNoContent)
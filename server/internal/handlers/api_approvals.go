@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/go-chi/chi/v5"
+)
+
+// ApproveChoreCompletion accepts a completion waiting for approval; the
+// completer is credited and the series moves on.
+func (handler *APIHandler) ApproveChoreCompletion(w http.ResponseWriter, r *http.Request) {
+	handler.reviewChoreCompletion(w, r, handler.choreService.ApproveCompletion, "failed to approve completion")
+}
+
+// RejectChoreCompletion returns a completion waiting for approval to pending
+// for the same assignee.
+func (handler *APIHandler) RejectChoreCompletion(w http.ResponseWriter, r *http.Request) {
+	handler.reviewChoreCompletion(w, r, handler.choreService.RejectCompletion, "failed to reject completion")
+}
+
+func (handler *APIHandler) reviewChoreCompletion(w http.ResponseWriter, r *http.Request, review func(context.Context, string) error, failure string) {
+	ctx := r.Context()
+	choreID := chi.URLParam(r, "id")
+
+	if err := review(ctx, choreID); err != nil {
+		writeApprovalError(w, err, failure)
+		return
+	}
+
+	chore, err := handler.choreRepo.FindByID(ctx, choreID)
+	if err != nil {
+		writeApprovalError(w, err, "failed to reload chore")
+		return
+	}
+	writeJSON(w, http.StatusOK, chore)
+}
+
+func writeApprovalError(w http.ResponseWriter, err error, failure string) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		writeJSONError(w, http.StatusNotFound, "chore not found")
+	case errors.Is(err, services.ErrChoreNotAwaitingApproval):
+		writeJSONError(w, http.StatusConflict, err.Error())
+	default:
+		slog.Error(failure, "error", err)
+		writeJSONError(w, http.StatusInternalServerError, failure)
+	}
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bensuskins/family-hub/internal/middleware"
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestChoreApproval_API(t *testing.T) {
+	database := testutil.NewTestDatabase(t)
+	choreRepo := repository.NewChoreRepository(database)
+	userRepo := repository.NewUserRepository(database)
+	assignmentRepo := repository.NewChoreAssignmentRepository(database)
+	ctx := context.Background()
+
+	admin, _ := userRepo.Create(ctx, models.User{OIDCSubject: "sub-parent", Email: "parent@example.com", Name: "Parent", Role: models.RoleAdmin})
+	child, _ := userRepo.Create(ctx, models.User{OIDCSubject: "sub-child", Email: "child@example.com", Name: "Child", Role: models.RoleMember})
+
+	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, repository.NewChoreSeriesRepository(database), nil, nil, nil, nil, nil, nil, nil, nil)
+	handler := NewAPIHandler(choreRepo, userRepo, nil, assignmentRepo, nil, nil, choreService, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
+	choreHandler := NewChoreHandler(choreRepo, nil, userRepo, choreService, nil, nil)
+
+	current := admin
+	router := chi.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), middleware.UserContextKey, current)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+	router.Post("/api/chores", handler.CreateChore)
+	router.Post("/api/chores/{id}/complete", handler.CompleteChore)
+	router.Post("/api/chores/{id}/approve", handler.ApproveChoreCompletion)
+	router.Post("/api/chores/{id}/reject", handler.RejectChoreCompletion)
+	router.Get("/api/chores/{id}/proof", choreHandler.ServeProof)
+
+	request := httptest.NewRequest(http.MethodPost, "/api/chores",
+		strings.NewReader(`{"name":"Wash up","assignees":["`+child.ID+`"],"requiresApproval":true}`))
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var chore models.Chore
+	json.Unmarshal(recorder.Body.Bytes(), &chore)
+
+	completeWithPhoto := func() *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, _ := writer.CreateFormFile("photo", "sink.png")
+		part.Write([]byte("\x89PNG\r\n\x1a\n0000"))
+		writer.Close()
+		request := httptest.NewRequest(http.MethodPost, "/api/chores/"+chore.ID+"/complete", &body)
+		request.Header.Set("Content-Type", writer.FormDataContentType())
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	current = child
+	if recorder := completeWithPhoto(); recorder.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := completeWithPhoto(); recorder.Code != http.StatusConflict {
+		t.Errorf("expected 409 completing twice, got %d", recorder.Code)
+	}
+
+	current = admin
+	request = httptest.NewRequest(http.MethodGet, "/api/chores/"+chore.ID+"/proof", nil)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusOK || recorder.Header().Get("Content-Type") != "image/png" {
+		t.Errorf("expected the PNG proof, got %d %q", recorder.Code, recorder.Header().Get("Content-Type"))
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/chores/"+chore.ID+"/reject", nil)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	json.Unmarshal(recorder.Body.Bytes(), &chore)
+	if chore.Status != models.ChoreStatusPending || chore.HasProof {
+		t.Errorf("expected pending without proof after rejection, got %s (proof %v)", chore.Status, chore.HasProof)
+	}
+
+	current = child
+	if recorder := completeWithPhoto(); recorder.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	current = admin
+	request = httptest.NewRequest(http.MethodPost, "/api/chores/"+chore.ID+"/approve", nil)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	json.Unmarshal(recorder.Body.Bytes(), &chore)
+	if chore.Status != models.ChoreStatusCompleted {
+		t.Errorf("expected completed after approval, got %s", chore.Status)
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/chores/"+chore.ID+"/approve", nil)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusConflict {
+		t.Errorf("expected 409 approving twice, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/bensuskins/family-hub/internal/middleware"
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/go-chi/chi/v5"
+)
+
+type checklistItemRequest struct {
+	Checked bool `json:"checked"`
+}
+
+// GetChoreChecklist returns the occurrence's checklist items in order.
+func (handler *APIHandler) GetChoreChecklist(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	choreID := chi.URLParam(r, "id")
+
+	if _, err := handler.choreRepo.FindByID(ctx, choreID); err != nil {
+		writeChecklistError(w, err, "failed to load checklist")
+		return
+	}
+	items, err := handler.choreService.Checklist(ctx, choreID)
+	if err != nil {
+		writeChecklistError(w, err, "failed to load checklist")
+		return
+	}
+	if items == nil {
+		items = []models.ChecklistItem{}
+	}
+	writeJSON(w, http.StatusOK, items)
+}
+
+// SetChoreChecklistItem ticks ({"checked": true}) or clears an item on an
+// open occurrence and returns the updated checklist.
+func (handler *APIHandler) SetChoreChecklistItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+	choreID := chi.URLParam(r, "id")
+
+	var body checklistItemRequest
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+
+	if err := handler.choreService.TickChecklistItem(ctx, choreID, chi.URLParam(r, "itemId"), user.ID, body.Checked); err != nil {
+		writeChecklistError(w, err, "failed to update checklist item")
+		return
+	}
+
+	items, err := handler.choreService.Checklist(ctx, choreID)
+	if err != nil {
+		writeChecklistError(w, err, "failed to load checklist")
+		return
+	}
+	writeJSON(w, http.StatusOK, items)
+}
+
+func writeChecklistError(w http.ResponseWriter, err error, failure string) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		writeJSONError(w, http.StatusNotFound, "not found")
+	case errors.Is(err, services.ErrChoreNotOpen):
+		writeJSONError(w, http.StatusConflict, "chore is not pending or overdue")
+	default:
+		slog.Error(failure, "error", err)
+		writeJSONError(w, http.StatusInternalServerError, failure)
+	}
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bensuskins/family-hub/internal/middleware"
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestChoreChecklist_API(t *testing.T) {
+	database := testutil.NewTestDatabase(t)
+	choreRepo := repository.NewChoreRepository(database)
+	userRepo := repository.NewUserRepository(database)
+	assignmentRepo := repository.NewChoreAssignmentRepository(database)
+	ctx := context.Background()
+
+	user, _ := userRepo.Create(ctx, models.User{
+		OIDCSubject: "sub-checklist",
+		Email:       "checklist@example.com",
+		Name:        "Checklist User",
+		Role:        models.RoleAdmin,
+	})
+
+	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, repository.NewChoreSeriesRepository(database), nil, nil, nil, nil, nil, nil, nil, nil)
+	handler := NewAPIHandler(choreRepo, userRepo, nil, assignmentRepo, nil, nil, choreService, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
+
+	router := chi.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), middleware.UserContextKey, user)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+	router.Post("/api/chores", handler.CreateChore)
+	router.Post("/api/chores/{id}/complete", handler.CompleteChore)
+	router.Get("/api/chores/{id}/checklist", handler.GetChoreChecklist)
+	router.Put("/api/chores/{id}/checklist/{itemId}", handler.SetChoreChecklistItem)
+
+	request := httptest.NewRequest(http.MethodPost, "/api/chores",
+		strings.NewReader(`{"name":"Tidy room","checklist":["Bed","Floor"],"checklistRequired":true}`))
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var chore models.Chore
+	json.Unmarshal(recorder.Body.Bytes(), &chore)
+	if !chore.ChecklistRequired {
+		t.Error("expected checklistRequired to be stored")
+	}
+
+	request = httptest.NewRequest(http.MethodGet, "/api/chores/"+chore.ID+"/checklist", nil)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var items []models.ChecklistItem
+	json.Unmarshal(recorder.Body.Bytes(), &items)
+	if len(items) != 2 || items[0].Title != "Bed" {
+		t.Fatalf("unexpected checklist %+v", items)
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/chores/"+chore.ID+"/complete", nil)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusConflict {
+		t.Errorf("expected 409 while items are unticked, got %d", recorder.Code)
+	}
+
+	for _, item := range items {
+		request = httptest.NewRequest(http.MethodPut, "/api/chores/"+chore.ID+"/checklist/"+item.ID, strings.NewReader(`{"checked":true}`))
+		recorder = httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected 200 ticking %s, got %d: %s", item.Title, recorder.Code, recorder.Body.String())
+		}
+	}
+
+	request = httptest.NewRequest(http.MethodPut, "/api/chores/"+chore.ID+"/checklist/missing", strings.NewReader(`{"checked":true}`))
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown item, got %d", recorder.Code)
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/chores/"+chore.ID+"/complete", nil)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusNoContent {
+		t.Errorf("expected 204 once every item is ticked, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
@@ -174,6 +174,8 @@ func (handler *APIHandler) CreateInventoryItem(w http.ResponseWriter, r *http.Re
 		writeJSONError(w, http.StatusInternalServerError, "failed to create item")
 		return
 	}
+	handler.notifications.InventoryChanged(ctx, user.ID, nil, created)
+	handler.webhooks.InventoryChanged(ctx, nil, &created)
 	writeJSON(w, http.StatusCreated, created)
 }
 
@@ -200,6 +202,7 @@ func (handler *APIHandler) UpdateInventoryItem(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	previous := item
 	item.Name = body.Name
 	item.TrackingMode = body.TrackingMode
 	item.Quantity = body.Quantity
@@ -215,9 +218,10 @@ func (handler *APIHandler) UpdateInventoryItem(w http.ResponseWriter, r *http.Re
 
 	updated, err := handler.inventoryRepo.FindItemByID(ctx, itemID)
 	if err != nil {
-		writeJSON(w, http.StatusOK, item)
-		return
+		updated = item
 	}
+	handler.notifications.InventoryChanged(ctx, middleware.GetUser(ctx).ID, &previous, updated)
+	handler.webhooks.InventoryChanged(ctx, &previous, &updated)
 	writeJSON(w, http.StatusOK, updated)
 }
 
@@ -225,7 +229,8 @@ func (handler *APIHandler) DeleteInventoryItem(w http.ResponseWriter, r *http.Re
 	ctx := r.Context()
 	itemID := chi.URLParam(r, "id")
 
-	if _, err := handler.inventoryRepo.FindItemByID(ctx, itemID); err != nil {
+	item, err := handler.inventoryRepo.FindItemByID(ctx, itemID)
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			writeJSONError(w, http.StatusNotFound, "item not found")
 		} else {
@@ -239,5 +244,6 @@ func (handler *APIHandler) DeleteInventoryItem(w http.ResponseWriter, r *http.Re
 		writeJSONError(w, http.StatusInternalServerError, "failed to delete item")
 		return
 	}
+	handler.webhooks.InventoryChanged(ctx, &item, nil)
 	w.WriteHeader(http.StatusNoContent)
 }
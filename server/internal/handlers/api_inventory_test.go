@@ -33,7 +33,7 @@ func TestListInventory_EmptyIsNotNull(t *testing.T) {
 	database := testutil.NewTestDatabase(t)
 	invRepo := repository.NewInventoryRepository(database)
 
-	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, invRepo, nil, nil, "", "", "")
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, invRepo, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Get("/api/inventory", handler.ListInventory)
@@ -56,7 +56,7 @@ func TestCreateInventoryArea_API(t *testing.T) {
 	userRepo := repository.NewUserRepository(database)
 	user := newInventoryTestUser(t, userRepo)
 
-	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, invRepo, nil, nil, "", "", "")
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, invRepo, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Post("/api/inventory/areas", func(w http.ResponseWriter, r *http.Request) {
@@ -90,7 +90,7 @@ func TestCreateInventoryArea_API_MissingName(t *testing.T) {
 	userRepo := repository.NewUserRepository(database)
 	user := newInventoryTestUser(t, userRepo)
 
-	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, invRepo, nil, nil, "", "", "")
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, invRepo, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Post("/api/inventory/areas", func(w http.ResponseWriter, r *http.Request) {
@@ -116,7 +116,7 @@ func TestInventory_FullFlow(t *testing.T) {
 	userRepo := repository.NewUserRepository(database)
 	user := newInventoryTestUser(t, userRepo)
 
-	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, invRepo, nil, nil, "", "", "")
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, invRepo, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	withUser := func(next http.HandlerFunc) http.HandlerFunc {
 		return func(w http.ResponseWriter, r *http.Request) {
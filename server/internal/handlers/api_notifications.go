@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/bensuskins/family-hub/internal/middleware"
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/go-chi/chi/v5"
+)
+
+// notificationChannelAPIBody is the JSON request body for creating/updating a
+// notification channel. Kind is only read on create.
+type notificationChannelAPIBody struct {
+	Kind   string    `json:"kind"`
+	Target string    `json:"target"`
+	Token  *string   `json:"token,omitempty"`
+	Events *[]string `json:"events,omitempty"`
+}
+
+func (body notificationChannelAPIBody) events() []models.NotificationEvent {
+	if body.Events == nil {
+		return nil
+	}
+	events := []models.NotificationEvent{}
+	for _, event := range *body.Events {
+		events = append(events, models.NotificationEvent(event))
+	}
+	return events
+}
+
+// ListNotificationChannels returns the current user's notification channels.
+func (handler *APIHandler) ListNotificationChannels(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	channels, err := handler.notifications.Channels(ctx, user.ID)
+	if err != nil {
+		slog.Error("listing notification channels via API", "error", err)
+		writeJSONError(w, http.StatusInternalServerError, "failed to load notification channels")
+		return
+	}
+	if channels == nil {
+		channels = []models.NotificationChannel{}
+	}
+	writeJSON(w, http.StatusOK, channels)
+}
+
+// CreateNotificationChannel sets up a channel for the current user. Omitted
+// events sends it every event.
+func (handler *APIHandler) CreateNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	var body notificationChannelAPIBody
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	channel := models.NotificationChannel{
+		UserID: user.ID,
+		Kind:   models.ChannelKind(body.Kind),
+		Target: strings.TrimSpace(body.Target),
+		Events: body.events(),
+	}
+	if body.Token != nil {
+		channel.Token = strings.TrimSpace(*body.Token)
+	}
+
+	created, err := handler.notifications.AddChannel(ctx, channel)
+	if err != nil {
+		writeNotificationChannelError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// UpdateNotificationChannel saves a channel's target, and its token and
+// events when given.
+func (handler *APIHandler) UpdateNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	channel, err := handler.notifications.Channel(ctx, user.ID, chi.URLParam(r, "id"))
+	if err != nil {
+		writeNotificationChannelError(w, err)
+		return
+	}
+
+	var body notificationChannelAPIBody
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	channel.Target = strings.TrimSpace(body.Target)
+	if body.Token != nil {
+		channel.Token = strings.TrimSpace(*body.Token)
+	}
+	if body.Events != nil {
+		channel.Events = body.events()
+	}
+
+	if err := handler.notifications.UpdateChannel(ctx, user.ID, channel); err != nil {
+		writeNotificationChannelError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, channel)
+}
+
+func (handler *APIHandler) DeleteNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	if err := handler.notifications.RemoveChannel(ctx, user.ID, chi.URLParam(r, "id")); err != nil {
+		writeNotificationChannelError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TestNotificationChannel queues a test message on one of the current user's
+// channels; it goes out with the next delivery run.
+func (handler *APIHandler) TestNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	if err := handler.notifications.SendTest(ctx, user.ID, chi.URLParam(r, "id")); err != nil {
+		writeNotificationChannelError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func writeNotificationChannelError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidChannel), errors.Is(err, services.ErrChannelKindDisabled):
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrChannelForbidden), errors.Is(err, sql.ErrNoRows):
+		writeJSONError(w, http.StatusNotFound, "notification channel not found")
+	default:
+		slog.Error("managing notification channel via API", "error", err)
+		writeJSONError(w, http.StatusInternalServerError, "failed to save notification channel")
+	}
+}
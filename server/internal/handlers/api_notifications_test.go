@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bensuskins/family-hub/internal/middleware"
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestNotificationChannels_API(t *testing.T) {
+	database := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(database)
+	notifications := services.NewNotificationService(repository.NewNotificationRepository(database), userRepo, map[models.ChannelKind]services.ChannelSender{
+		models.ChannelNtfy: services.NtfySender{Client: http.DefaultClient},
+	}, "")
+
+	owner, _ := userRepo.Create(context.Background(), models.User{OIDCSubject: "sub-owner", Email: "owner@example.com", Name: "Owner", Role: models.RoleMember})
+	other, _ := userRepo.Create(context.Background(), models.User{OIDCSubject: "sub-other", Email: "other@example.com", Name: "Other", Role: models.RoleMember})
+
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, notifications, nil, nil, "", "", "")
+
+	serve := func(user models.User, method, path, body string) *httptest.ResponseRecorder {
+		router := chi.NewRouter()
+		router.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), middleware.UserContextKey, user)))
+			})
+		})
+		router.Get("/api/notifications/channels", handler.ListNotificationChannels)
+		router.Post("/api/notifications/channels", handler.CreateNotificationChannel)
+		router.Put("/api/notifications/channels/{id}", handler.UpdateNotificationChannel)
+		router.Delete("/api/notifications/channels/{id}", handler.DeleteNotificationChannel)
+
+		request := httptest.NewRequest(method, path, strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	recorder := serve(owner, http.MethodPost, "/api/notifications/channels", `{"kind":"ntfy","target":"https://ntfy.sh/family","events":["chore_overdue"]}`)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var channel models.NotificationChannel
+	json.NewDecoder(recorder.Body).Decode(&channel)
+	if channel.Kind != models.ChannelNtfy || len(channel.Events) != 1 || channel.Events[0] != models.NotifyChoreOverdue {
+		t.Errorf("unexpected channel: %+v", channel)
+	}
+
+	if recorder := serve(owner, http.MethodPost, "/api/notifications/channels", `{"kind":"gotify","target":"https://gotify.local"}`); recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a kind the server cannot send, got %d", recorder.Code)
+	}
+
+	if recorder := serve(other, http.MethodGet, "/api/notifications/channels", ""); strings.TrimSpace(recorder.Body.String()) != "[]" {
+		t.Errorf("expected another user to see no channels, got %s", recorder.Body.String())
+	}
+	if recorder := serve(other, http.MethodDelete, "/api/notifications/channels/"+channel.ID, ""); recorder.Code != http.StatusNotFound {
+		t.Errorf("expected 404 removing another user's channel, got %d", recorder.Code)
+	}
+
+	if recorder := serve(owner, http.MethodDelete, "/api/notifications/channels/"+channel.ID, ""); recorder.Code != http.StatusNoContent {
+		t.Errorf("expected 204, got %d", recorder.Code)
+	}
+}
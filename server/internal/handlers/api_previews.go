@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/services"
+)
+
+// PreviewChore returns the next occurrences a draft chore would generate and
+// who each would go to, without saving anything. The body is the one
+// CreateChore takes, name optional; ?count= picks how many (default 5, at
+// most 20) and ?choreId= continues the rotation of that chore's series, for
+// previewing an edit.
+func (handler *APIHandler) PreviewChore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var body choreAPIBody
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	var draft models.Chore
+	if err := body.applyTo(ctx, handler.icalSubRepo, &draft); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if choreID := r.URL.Query().Get("choreId"); choreID != "" {
+		chore, err := handler.choreRepo.FindByID(ctx, choreID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				writeJSONError(w, http.StatusNotFound, "chore not found")
+			} else {
+				writeJSONError(w, http.StatusInternalServerError, "failed to load chore")
+			}
+			return
+		}
+		draft.SeriesID = chore.SeriesID
+	}
+
+	count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+	occurrences, err := handler.choreService.PreviewSeries(ctx, draft, body.Assignees, draft.Seasons, count)
+	switch {
+	case errors.Is(err, services.ErrPreviewCalendar), errors.Is(err, services.ErrPreviewNoAssignee):
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+	case err != nil:
+		slog.Error("previewing chore via API", "error", err)
+		writeJSONError(w, http.StatusInternalServerError, "failed to preview chore")
+	default:
+		writeJSON(w, http.StatusOK, occurrences)
+	}
+}
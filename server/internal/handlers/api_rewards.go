@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/bensuskins/family-hub/internal/middleware"
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/go-chi/chi/v5"
+)
+
+// pointsLedgerLimit is how many recent ledger entries the points endpoints show.
+const pointsLedgerLimit = 50
+
+// rewardAPIBody is the JSON request body for creating or updating a reward.
+// Active is only read on update; new rewards always start active.
+type rewardAPIBody struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Cost        int    `json:"cost"`
+	Active      *bool  `json:"active,omitempty"`
+}
+
+// redemptionAnswer is one of the RewardService methods an admin uses to
+// resolve a redemption.
+type redemptionAnswer func(ctx context.Context, redemptionID, adminID string) error
+
+// GetPoints returns the caller's balance, pending redemptions, allowance and
+// recent ledger entries.
+func (handler *APIHandler) GetPoints(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	summary, err := handler.rewardService.Summary(ctx, user.ID)
+	if err != nil {
+		slog.Error("loading points summary", "error", err)
+		writeJSONError(w, http.StatusInternalServerError, "failed to load points")
+		return
+	}
+	entries, err := handler.rewardService.Ledger(ctx, user.ID, pointsLedgerLimit)
+	if err != nil {
+		slog.Error("loading points ledger", "error", err)
+		writeJSONError(w, http.StatusInternalServerError, "failed to load points")
+		return
+	}
+	if entries == nil {
+		entries = []models.PointsEntry{}
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		services.PointsSummary
+		Entries []models.PointsEntry
+	}{summary, entries})
+}
+
+// ListRewards returns the active catalogue. Admins may pass
+// include_inactive=true to see retired rewards as well.
+func (handler *APIHandler) ListRewards(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	includeInactive := user.Role == models.RoleAdmin && r.URL.Query().Get("include_inactive") == "true"
+	rewards, err := handler.rewardService.Catalogue(ctx, includeInactive)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "failed to load rewards")
+		return
+	}
+	if rewards == nil {
+		rewards = []models.Reward{}
+	}
+	writeJSON(w, http.StatusOK, rewards)
+}
+
+func (handler *APIHandler) CreateReward(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	var body rewardAPIBody
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+
+	reward, err := handler.rewardService.CreateReward(ctx, models.Reward{
+		Name:            body.Name,
+		Description:     body.Description,
+		Cost:            body.Cost,
+		CreatedByUserID: user.ID,
+	})
+	if err != nil {
+		writeRewardError(w, err, "failed to create reward")
+		return
+	}
+	writeJSON(w, http.StatusCreated, reward)
+}
+
+func (handler *APIHandler) UpdateReward(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	reward, err := handler.rewardService.FindReward(ctx, chi.URLParam(r, "id"))
+	if err != nil {
+		writeRewardError(w, err, "failed to load reward")
+		return
+	}
+
+	var body rewardAPIBody
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	reward.Name = body.Name
+	reward.Description = body.Description
+	reward.Cost = body.Cost
+	if body.Active != nil {
+		reward.Active = *body.Active
+	}
+
+	if err := handler.rewardService.UpdateReward(ctx, reward); err != nil {
+		writeRewardError(w, err, "failed to update reward")
+		return
+	}
+	writeJSON(w, http.StatusOK, reward)
+}
+
+func (handler *APIHandler) RedeemReward(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	redemption, err := handler.rewardService.Redeem(ctx, user.ID, chi.URLParam(r, "id"))
+	if err != nil {
+		writeRewardError(w, err, "failed to redeem reward")
+		return
+	}
+	writeJSON(w, http.StatusCreated, redemption)
+}
+
+// ListRedemptions returns redemptions newest first, optionally filtered by
+// status. Members only see their own; admins see the whole family's.
+func (handler *APIHandler) ListRedemptions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	var filter repository.RedemptionFilter
+	if user.Role != models.RoleAdmin {
+		filter.UserID = &user.ID
+	}
+	if status := r.URL.Query().Get("status"); status != "" {
+		redemptionStatus := models.RedemptionStatus(status)
+		filter.Status = &redemptionStatus
+	}
+
+	redemptions, err := handler.rewardService.Redemptions(ctx, filter)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "failed to load redemptions")
+		return
+	}
+	if redemptions == nil {
+		redemptions = []models.RewardRedemption{}
+	}
+	writeJSON(w, http.StatusOK, redemptions)
+}
+
+func (handler *APIHandler) ApproveRedemption(w http.ResponseWriter, r *http.Request) {
+	handler.answerRedemption(w, r, handler.rewardService.ApproveRedemption, "failed to approve redemption")
+}
+
+func (handler *APIHandler) RejectRedemption(w http.ResponseWriter, r *http.Request) {
+	handler.answerRedemption(w, r, handler.rewardService.RejectRedemption, "failed to reject redemption")
+}
+
+func (handler *APIHandler) answerRedemption(w http.ResponseWriter, r *http.Request, answer redemptionAnswer, failure string) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	if err := answer(ctx, chi.URLParam(r, "id"), user.ID); err != nil {
+		writeRewardError(w, err, failure)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeRewardError(w http.ResponseWriter, err error, failure string) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		writeJSONError(w, http.StatusNotFound, "not found")
+	case errors.Is(err, services.ErrRewardInvalid):
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrRewardUnavailable), errors.Is(err, repository.ErrInsufficientPoints),
+		errors.Is(err, repository.ErrRedemptionResolved):
+		writeJSONError(w, http.StatusConflict, err.Error())
+	default:
+		slog.Error(failure, "error", err)
+		writeJSONError(w, http.StatusInternalServerError, failure)
+	}
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bensuskins/family-hub/internal/middleware"
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestRewardWorkflow_API(t *testing.T) {
+	database := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(database)
+	pointsRepo := repository.NewPointsRepository(database)
+	settingsRepo := repository.NewSettingsRepository(database)
+	rewardService := services.NewRewardService(repository.NewRewardRepository(database), pointsRepo, settingsRepo, nil)
+	ctx := context.Background()
+
+	parent, _ := userRepo.Create(ctx, models.User{OIDCSubject: "sub-parent", Email: "parent@example.com", Name: "Parent", Role: models.RoleAdmin})
+	kid, _ := userRepo.Create(ctx, models.User{OIDCSubject: "sub-kid", Email: "kid@example.com", Name: "Kid", Role: models.RoleMember})
+	pointsRepo.Create(ctx, models.PointsEntry{UserID: kid.ID, Points: 12, Reason: models.PointsReasonChore})
+
+	handler := NewAPIHandler(nil, userRepo, nil, nil, nil, settingsRepo, nil, nil, nil, nil, nil, nil, nil, pointsRepo, rewardService, nil, nil, nil, "", "", "")
+
+	routerAs := func(user models.User) *chi.Mux {
+		router := chi.NewRouter()
+		router.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctx := context.WithValue(r.Context(), middleware.UserContextKey, user)
+				next.ServeHTTP(w, r.WithContext(ctx))
+			})
+		})
+		router.Get("/api/points", handler.GetPoints)
+		router.Post("/api/rewards", handler.CreateReward)
+		router.Post("/api/rewards/{id}/redeem", handler.RedeemReward)
+		router.Get("/api/redemptions", handler.ListRedemptions)
+		router.Post("/api/redemptions/{id}/approve", handler.ApproveRedemption)
+		return router
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/api/rewards", strings.NewReader(`{"name":"Late bedtime","cost":0}`))
+	recorder := httptest.NewRecorder()
+	routerAs(parent).ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a zero cost, got %d", recorder.Code)
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/rewards", strings.NewReader(`{"name":"Late bedtime","cost":10}`))
+	recorder = httptest.NewRecorder()
+	routerAs(parent).ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var reward models.Reward
+	json.Unmarshal(recorder.Body.Bytes(), &reward)
+
+	request = httptest.NewRequest(http.MethodPost, "/api/rewards/"+reward.ID+"/redeem", nil)
+	recorder = httptest.NewRecorder()
+	routerAs(kid).ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var redemption models.RewardRedemption
+	json.Unmarshal(recorder.Body.Bytes(), &redemption)
+
+	request = httptest.NewRequest(http.MethodPost, "/api/rewards/"+reward.ID+"/redeem", nil)
+	recorder = httptest.NewRecorder()
+	routerAs(kid).ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusConflict {
+		t.Errorf("expected 409 when available points are spoken for, got %d", recorder.Code)
+	}
+
+	request = httptest.NewRequest(http.MethodGet, "/api/redemptions?status=pending", nil)
+	recorder = httptest.NewRecorder()
+	routerAs(parent).ServeHTTP(recorder, request)
+	var pending []models.RewardRedemption
+	json.Unmarshal(recorder.Body.Bytes(), &pending)
+	if len(pending) != 1 || pending[0].ID != redemption.ID {
+		t.Fatalf("expected the parent to see the pending redemption, got %s", recorder.Body.String())
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/redemptions/"+redemption.ID+"/approve", nil)
+	recorder = httptest.NewRecorder()
+	routerAs(parent).ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	request = httptest.NewRequest(http.MethodGet, "/api/points", nil)
+	recorder = httptest.NewRecorder()
+	routerAs(kid).ServeHTTP(recorder, request)
+	var points struct {
+		Balance   int
+		Pending   int
+		Available int
+		Entries   []models.PointsEntry
+	}
+	json.Unmarshal(recorder.Body.Bytes(), &points)
+	if points.Balance != 2 || points.Pending != 0 || points.Available != 2 || len(points.Entries) != 2 {
+		t.Errorf("unexpected points after approval: %s", recorder.Body.String())
+	}
+}
+
+func TestPatchSettings_AllowancePerPoint(t *testing.T) {
+	database := testutil.NewTestDatabase(t)
+	settingsRepo := repository.NewSettingsRepository(database)
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, settingsRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantValue  string
+	}{
+		{name: "sets rate", body: `{"allowance_per_point":"0.10"}`, wantStatus: http.StatusNoContent, wantValue: "0.10"},
+		{name: "rejects negative", body: `{"allowance_per_point":"-1"}`, wantStatus: http.StatusBadRequest, wantValue: "0.10"},
+		{name: "rejects text", body: `{"allowance_per_point":"lots"}`, wantStatus: http.StatusBadRequest, wantValue: "0.10"},
+		{name: "empty disables", body: `{"allowance_per_point":""}`, wantStatus: http.StatusNoContent, wantValue: ""},
+		{name: "requires a field", body: `{}`, wantStatus: http.StatusBadRequest, wantValue: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPatch, "/api/settings", strings.NewReader(tt.body))
+			recorder := httptest.NewRecorder()
+			handler.PatchSettings(recorder, request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("want status %d, got %d: %s", tt.wantStatus, recorder.Code, recorder.Body.String())
+			}
+			value, _ := settingsRepo.Get(context.Background(), repository.SettingsKeyAllowancePerPoint)
+			if value != tt.wantValue {
+				t.Errorf("want stored rate %q, got %q", tt.wantValue, value)
+			}
+		})
+	}
+}
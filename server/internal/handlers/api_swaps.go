@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/bensuskins/family-hub/internal/middleware"
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/go-chi/chi/v5"
+)
+
+// swapAPIBody is the JSON request body for proposing a swap. Omitting
+// requestedChoreId makes it a one-way handoff.
+type swapAPIBody struct {
+	ChoreID          string  `json:"choreId"`
+	ToUserID         string  `json:"toUserId"`
+	RequestedChoreID *string `json:"requestedChoreId,omitempty"`
+}
+
+// swapAnswer is one of the ChoreSwapService methods that respond to a swap on
+// behalf of a user.
+type swapAnswer func(ctx context.Context, swapID, userID string) error
+
+// ListSwaps returns the open swaps the caller proposed or has to answer.
+func (handler *APIHandler) ListSwaps(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	swaps, err := handler.swapService.PendingSwaps(ctx, user.ID)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "failed to load swaps")
+		return
+	}
+	if swaps == nil {
+		swaps = []models.ChoreSwap{}
+	}
+	writeJSON(w, http.StatusOK, swaps)
+}
+
+func (handler *APIHandler) ProposeSwap(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	var body swapAPIBody
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if body.RequestedChoreID != nil && *body.RequestedChoreID == "" {
+		body.RequestedChoreID = nil
+	}
+
+	swap, err := handler.swapService.ProposeSwap(ctx, user.ID, body.ChoreID, body.ToUserID, body.RequestedChoreID)
+	if err != nil {
+		writeSwapError(w, err, "failed to propose swap")
+		return
+	}
+	writeJSON(w, http.StatusCreated, swap)
+}
+
+func (handler *APIHandler) AcceptSwap(w http.ResponseWriter, r *http.Request) {
+	handler.answerSwap(w, r, handler.swapService.AcceptSwap, "failed to accept swap")
+}
+
+func (handler *APIHandler) DeclineSwap(w http.ResponseWriter, r *http.Request) {
+	handler.answerSwap(w, r, handler.swapService.DeclineSwap, "failed to decline swap")
+}
+
+func (handler *APIHandler) CancelSwap(w http.ResponseWriter, r *http.Request) {
+	handler.answerSwap(w, r, handler.swapService.CancelSwap, "failed to cancel swap")
+}
+
+func (handler *APIHandler) answerSwap(w http.ResponseWriter, r *http.Request, answer swapAnswer, failure string) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	if err := answer(ctx, chi.URLParam(r, "id"), user.ID); err != nil {
+		writeSwapError(w, err, failure)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeSwapError(w http.ResponseWriter, err error, failure string) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		writeJSONError(w, http.StatusNotFound, "not found")
+	case errors.Is(err, services.ErrSwapForbidden), errors.Is(err, services.ErrSwapNotAssignee):
+		writeJSONError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrSwapInvalid):
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrSwapNotPending), errors.Is(err, services.ErrChoreNotOpen),
+		errors.Is(err, repository.ErrSwapStale):
+		writeJSONError(w, http.StatusConflict, err.Error())
+	default:
+		slog.Error(failure, "error", err)
+		writeJSONError(w, http.StatusInternalServerError, failure)
+	}
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bensuskins/family-hub/internal/middleware"
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestSwapWorkflow_API(t *testing.T) {
+	database := testutil.NewTestDatabase(t)
+	choreRepo := repository.NewChoreRepository(database)
+	userRepo := repository.NewUserRepository(database)
+	choreService := services.NewChoreService(choreRepo, repository.NewChoreAssignmentRepository(database), userRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	swapService := services.NewChoreSwapService(repository.NewChoreSwapRepository(database), choreRepo, userRepo, nil, choreService)
+	ctx := context.Background()
+
+	alice, _ := userRepo.Create(ctx, models.User{OIDCSubject: "sub-alice", Email: "alice@example.com", Name: "Alice", Role: models.RoleMember})
+	bob, _ := userRepo.Create(ctx, models.User{OIDCSubject: "sub-bob", Email: "bob@example.com", Name: "Bob", Role: models.RoleMember})
+
+	dishes, _ := choreRepo.Create(ctx, models.Chore{Name: "Dishes", CreatedByUserID: alice.ID, AssignedToUserID: &alice.ID, Status: models.ChoreStatusPending})
+	bins, _ := choreRepo.Create(ctx, models.Chore{Name: "Bins", CreatedByUserID: bob.ID, AssignedToUserID: &bob.ID, Status: models.ChoreStatusPending})
+
+	handler := NewAPIHandler(choreRepo, userRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, swapService, nil, nil, nil, nil, nil, "", "", "")
+
+	routerAs := func(user models.User) *chi.Mux {
+		router := chi.NewRouter()
+		router.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctx := context.WithValue(r.Context(), middleware.UserContextKey, user)
+				next.ServeHTTP(w, r.WithContext(ctx))
+			})
+		})
+		router.Get("/api/swaps", handler.ListSwaps)
+		router.Post("/api/swaps", handler.ProposeSwap)
+		router.Post("/api/swaps/{id}/accept", handler.AcceptSwap)
+		return router
+	}
+
+	body := `{"choreId":"` + dishes.ID + `","toUserId":"` + bob.ID + `","requestedChoreId":"` + bins.ID + `"}`
+	request := httptest.NewRequest(http.MethodPost, "/api/swaps", strings.NewReader(body))
+	recorder := httptest.NewRecorder()
+	routerAs(alice).ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var swap models.ChoreSwap
+	if err := json.Unmarshal(recorder.Body.Bytes(), &swap); err != nil {
+		t.Fatalf("decoding swap: %v", err)
+	}
+
+	request = httptest.NewRequest(http.MethodGet, "/api/swaps", nil)
+	recorder = httptest.NewRecorder()
+	routerAs(bob).ServeHTTP(recorder, request)
+
+	var pending []models.ChoreSwap
+	json.Unmarshal(recorder.Body.Bytes(), &pending)
+	if len(pending) != 1 || pending[0].ID != swap.ID {
+		t.Fatalf("expected bob to see the swap, got %s", recorder.Body.String())
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/swaps/"+swap.ID+"/accept", nil)
+	recorder = httptest.NewRecorder()
+	routerAs(alice).ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("expected 403 when the proposer accepts, got %d", recorder.Code)
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/swaps/"+swap.ID+"/accept", nil)
+	recorder = httptest.NewRecorder()
+	routerAs(bob).ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	updated, _ := choreRepo.FindByID(ctx, dishes.ID)
+	if *updated.AssignedToUserID != bob.ID {
+		t.Errorf("expected dishes to move to bob, got %s", *updated.AssignedToUserID)
+	}
+}
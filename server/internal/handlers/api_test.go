@@ -44,7 +44,7 @@ func TestRequireUser_RejectsNonAPIScopedToken(t *testing.T) {
 		t.Fatalf("creating stale-scope token: %v", err)
 	}
 
-	apiHandler := NewAPIHandler(nil, nil, nil, nil, tokenRepo, nil, nil, nil, nil, nil, nil, nil, "", "", "")
+	apiHandler := NewAPIHandler(nil, nil, nil, nil, tokenRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Group(func(r chi.Router) {
@@ -87,7 +87,7 @@ func TestDeleteToken(t *testing.T) {
 		t.Fatalf("creating token: %v", err)
 	}
 
-	handler := NewAPIHandler(nil, nil, nil, nil, tokenRepo, nil, nil, nil, nil, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(nil, nil, nil, nil, tokenRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Delete("/api/tokens/{id}", handler.DeleteToken)
@@ -129,8 +129,8 @@ func TestCompleteChore_API(t *testing.T) {
 		Status:          models.ChoreStatusPending,
 	})
 
-	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, repository.NewChoreSeriesRepository(database))
-	handler := NewAPIHandler(choreRepo, userRepo, nil, assignmentRepo, nil, nil, choreService, nil, nil, nil, nil, nil, "", "", "")
+	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, repository.NewChoreSeriesRepository(database), nil, nil, nil, nil, nil, nil, nil, nil)
+	handler := NewAPIHandler(choreRepo, userRepo, nil, assignmentRepo, nil, nil, choreService, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Use(func(next http.Handler) http.Handler {
@@ -169,8 +169,8 @@ func TestCompleteChore_API_NotFound(t *testing.T) {
 		Role:        models.RoleMember,
 	})
 
-	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, repository.NewChoreSeriesRepository(database))
-	handler := NewAPIHandler(choreRepo, userRepo, nil, assignmentRepo, nil, nil, choreService, nil, nil, nil, nil, nil, "", "", "")
+	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, repository.NewChoreSeriesRepository(database), nil, nil, nil, nil, nil, nil, nil, nil)
+	handler := NewAPIHandler(choreRepo, userRepo, nil, assignmentRepo, nil, nil, choreService, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Use(func(next http.Handler) http.Handler {
@@ -210,8 +210,8 @@ func TestCompleteChore_API_AlreadyComplete(t *testing.T) {
 		Status:          models.ChoreStatusCompleted,
 	})
 
-	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, repository.NewChoreSeriesRepository(database))
-	handler := NewAPIHandler(choreRepo, userRepo, nil, assignmentRepo, nil, nil, choreService, nil, nil, nil, nil, nil, "", "", "")
+	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, repository.NewChoreSeriesRepository(database), nil, nil, nil, nil, nil, nil, nil, nil)
+	handler := NewAPIHandler(choreRepo, userRepo, nil, assignmentRepo, nil, nil, choreService, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Use(func(next http.Handler) http.Handler {
@@ -231,6 +231,199 @@ func TestCompleteChore_API_AlreadyComplete(t *testing.T) {
 	}
 }
 
+func TestSkipChore_API(t *testing.T) {
+	database := testutil.NewTestDatabase(t)
+	choreRepo := repository.NewChoreRepository(database)
+	userRepo := repository.NewUserRepository(database)
+	assignmentRepo := repository.NewChoreAssignmentRepository(database)
+	ctx := context.Background()
+
+	user, _ := userRepo.Create(ctx, models.User{
+		OIDCSubject: "sub-skip",
+		Email:       "skip@example.com",
+		Name:        "Skip User",
+		Role:        models.RoleMember,
+	})
+
+	chore, _ := choreRepo.Create(ctx, models.Chore{
+		Name:            "Chore to skip",
+		CreatedByUserID: user.ID,
+		Status:          models.ChoreStatusPending,
+	})
+
+	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, repository.NewChoreSeriesRepository(database), nil, nil, nil, nil, nil, nil, nil, nil)
+	handler := NewAPIHandler(choreRepo, userRepo, nil, assignmentRepo, nil, nil, choreService, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
+
+	router := chi.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), middleware.UserContextKey, user)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+	router.Post("/api/chores/{id}/skip", handler.SkipChore)
+
+	request := httptest.NewRequest(http.MethodPost, "/api/chores/"+chore.ID+"/skip", strings.NewReader(`{"advanceRotation":false}`))
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	updated, _ := choreRepo.FindByID(ctx, chore.ID)
+	if updated.Status != models.ChoreStatusSkipped {
+		t.Errorf("expected chore to be skipped, got %s", updated.Status)
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/chores/"+chore.ID+"/skip", nil)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusConflict {
+		t.Errorf("expected 409 skipping twice, got %d", recorder.Code)
+	}
+}
+
+func TestSnoozeChore_API(t *testing.T) {
+	database := testutil.NewTestDatabase(t)
+	choreRepo := repository.NewChoreRepository(database)
+	userRepo := repository.NewUserRepository(database)
+	assignmentRepo := repository.NewChoreAssignmentRepository(database)
+	ctx := context.Background()
+
+	user, _ := userRepo.Create(ctx, models.User{
+		OIDCSubject: "sub-snooze",
+		Email:       "snooze@example.com",
+		Name:        "Snooze User",
+		Role:        models.RoleMember,
+	})
+
+	dueDate := time.Now().AddDate(0, 0, -1).Truncate(24 * time.Hour)
+	chore, _ := choreRepo.Create(ctx, models.Chore{
+		Name:            "Chore to snooze",
+		CreatedByUserID: user.ID,
+		DueDate:         &dueDate,
+		Status:          models.ChoreStatusOverdue,
+	})
+
+	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, repository.NewChoreSeriesRepository(database), nil, nil, nil, nil, nil, nil, nil, nil)
+	handler := NewAPIHandler(choreRepo, userRepo, nil, assignmentRepo, nil, nil, choreService, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
+
+	router := chi.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), middleware.UserContextKey, user)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+	router.Post("/api/chores/{id}/snooze", handler.SnoozeChore)
+
+	snoozeTo := time.Now().AddDate(0, 0, 3).Format(DateFormat)
+	body := `{"dueDate":"` + snoozeTo + `","dueTime":"18:00"}`
+	request := httptest.NewRequest(http.MethodPost, "/api/chores/"+chore.ID+"/snooze", strings.NewReader(body))
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	updated, _ := choreRepo.FindByID(ctx, chore.ID)
+	if updated.Status != models.ChoreStatusPending {
+		t.Errorf("expected chore to be pending, got %s", updated.Status)
+	}
+	if updated.DueDate == nil || updated.DueDate.Format(DateFormat) != snoozeTo {
+		t.Errorf("expected due date %s, got %v", snoozeTo, updated.DueDate)
+	}
+	if updated.DueTime == nil || *updated.DueTime != "18:00" {
+		t.Errorf("expected due time 18:00, got %v", updated.DueTime)
+	}
+	if updated.OriginalDueDate == nil || !updated.OriginalDueDate.Equal(dueDate) {
+		t.Errorf("expected original due date %v, got %v", dueDate, updated.OriginalDueDate)
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/chores/"+chore.ID+"/snooze", strings.NewReader(`{"dueDate":"tomorrow"}`))
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid date, got %d", recorder.Code)
+	}
+}
+
+func TestUpdateChore_API_OccurrenceScope(t *testing.T) {
+	database := testutil.NewTestDatabase(t)
+	choreRepo := repository.NewChoreRepository(database)
+	userRepo := repository.NewUserRepository(database)
+	seriesRepo := repository.NewChoreSeriesRepository(database)
+	assignmentRepo := repository.NewChoreAssignmentRepository(database)
+	ctx := context.Background()
+
+	user, _ := userRepo.Create(ctx, models.User{
+		OIDCSubject: "sub-scope",
+		Email:       "scope@example.com",
+		Name:        "Scope User",
+		Role:        models.RoleMember,
+	})
+
+	dueDate := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 2)
+	chore, _ := choreRepo.Create(ctx, models.Chore{
+		Name:            "Water the plants",
+		CreatedByUserID: user.ID,
+		DueDate:         &dueDate,
+		Status:          models.ChoreStatusPending,
+		RecurrenceType:  models.RecurrenceDaily,
+		RecurrenceValue: `{"interval":1}`,
+	})
+	seriesRepo.Create(ctx, models.ChoreSeries{
+		ID:              chore.ID,
+		Name:            chore.Name,
+		CreatedByUserID: user.ID,
+		RecurrenceType:  models.RecurrenceDaily,
+		RecurrenceValue: `{"interval":1}`,
+	})
+	chore.SeriesID = &chore.ID
+	choreRepo.Update(ctx, chore)
+
+	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, seriesRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+	handler := NewAPIHandler(choreRepo, userRepo, nil, assignmentRepo, nil, nil, choreService, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
+
+	router := chi.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), middleware.UserContextKey, user)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+	router.Put("/api/chores/{id}", handler.UpdateChore)
+
+	request := httptest.NewRequest(http.MethodPut, "/api/chores/"+chore.ID+"?scope=occurrence", strings.NewReader(`{"name":"Water the seedlings","dueTime":"08:00"}`))
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	updated, _ := choreRepo.FindByID(ctx, chore.ID)
+	if updated.Name != "Water the seedlings" || updated.SeriesID == nil || !updated.DueDate.Equal(dueDate) {
+		t.Errorf("expected only the occurrence renamed, got %+v", updated)
+	}
+	series, _ := seriesRepo.FindByID(ctx, chore.ID)
+	if series.Name != "Water the plants" || len(series.Exceptions) != 1 {
+		t.Errorf("expected the series unchanged with one exception, got %+v", series)
+	}
+
+	request = httptest.NewRequest(http.MethodPut, "/api/chores/"+chore.ID+"?scope=sometimes", strings.NewReader(`{"name":"Water"}`))
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown scope, got %d", recorder.Code)
+	}
+}
+
 func TestListMeals_API(t *testing.T) {
 	database := testutil.NewTestDatabase(t)
 	mealPlanRepo := repository.NewMealPlanRepository(database)
@@ -251,7 +444,7 @@ func TestListMeals_API(t *testing.T) {
 		CreatedByUserID: user.ID,
 	})
 
-	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, mealPlanRepo, nil, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, mealPlanRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Get("/api/meals", handler.ListMeals)
@@ -303,7 +496,7 @@ func TestListMeals_API_UsesWeekParamAsStartDate(t *testing.T) {
 		CreatedByUserID: user.ID,
 	})
 
-	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, mealPlanRepo, nil, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, mealPlanRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Get("/api/meals", handler.ListMeals)
@@ -327,7 +520,7 @@ func TestListMeals_API_InvalidWeekParam(t *testing.T) {
 	database := testutil.NewTestDatabase(t)
 	mealPlanRepo := repository.NewMealPlanRepository(database)
 
-	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, mealPlanRepo, nil, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, mealPlanRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Get("/api/meals", handler.ListMeals)
@@ -345,7 +538,7 @@ func TestListMeals_API_DefaultsToCurrentWeek(t *testing.T) {
 	database := testutil.NewTestDatabase(t)
 	mealPlanRepo := repository.NewMealPlanRepository(database)
 
-	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, mealPlanRepo, nil, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, mealPlanRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Get("/api/meals", handler.ListMeals)
@@ -377,7 +570,7 @@ func TestListRecipes_API(t *testing.T) {
 		CreatedByUserID: user.ID,
 	})
 
-	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, recipeRepo, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, recipeRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Get("/api/recipes", handler.ListRecipes)
@@ -415,7 +608,7 @@ func TestGetRecipe_API(t *testing.T) {
 		CreatedByUserID: user.ID,
 	})
 
-	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, recipeRepo, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, recipeRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Get("/api/recipes/{id}", handler.GetRecipe)
@@ -439,7 +632,7 @@ func TestListRecipes_API_Empty(t *testing.T) {
 	database := testutil.NewTestDatabase(t)
 	recipeRepo := repository.NewRecipeRepository(database)
 
-	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, recipeRepo, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, recipeRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Get("/api/recipes", handler.ListRecipes)
@@ -462,7 +655,7 @@ func TestListMeals_API_Empty(t *testing.T) {
 	database := testutil.NewTestDatabase(t)
 	mealPlanRepo := repository.NewMealPlanRepository(database)
 
-	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, mealPlanRepo, nil, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, mealPlanRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Get("/api/meals", handler.ListMeals)
@@ -485,7 +678,7 @@ func TestGetRecipe_API_NotFound(t *testing.T) {
 	database := testutil.NewTestDatabase(t)
 	recipeRepo := repository.NewRecipeRepository(database)
 
-	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, recipeRepo, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, recipeRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Get("/api/recipes/{id}", handler.GetRecipe)
@@ -520,7 +713,7 @@ func TestListCalendar_API(t *testing.T) {
 		Status:          models.ChoreStatusPending,
 	})
 
-	handler := NewAPIHandler(choreRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(choreRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Get("/api/calendar", handler.ListCalendar)
@@ -549,7 +742,7 @@ func TestListCalendar_API_InvalidMonthParam(t *testing.T) {
 	database := testutil.NewTestDatabase(t)
 	choreRepo := repository.NewChoreRepository(database)
 
-	handler := NewAPIHandler(choreRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(choreRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Get("/api/calendar", handler.ListCalendar)
@@ -567,7 +760,7 @@ func TestListCalendar_API_DefaultsToCurrentMonth(t *testing.T) {
 	database := testutil.NewTestDatabase(t)
 	choreRepo := repository.NewChoreRepository(database)
 
-	handler := NewAPIHandler(choreRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(choreRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Get("/api/calendar", handler.ListCalendar)
@@ -585,7 +778,7 @@ func TestListCalendar_API_EmptyResult(t *testing.T) {
 	database := testutil.NewTestDatabase(t)
 	choreRepo := repository.NewChoreRepository(database)
 
-	handler := NewAPIHandler(choreRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(choreRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Get("/api/calendar", handler.ListCalendar)
@@ -618,7 +811,7 @@ func TestDashboardStats_EmptyListsAreNotNull(t *testing.T) {
 	choreRepo := repository.NewChoreRepository(database)
 	userRepo := repository.NewUserRepository(database)
 
-	handler := NewAPIHandler(choreRepo, userRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(choreRepo, userRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Get("/api/dashboard", handler.DashboardStats)
@@ -663,7 +856,7 @@ func TestListRecipes_NilIngredientItemsAreEmptyArray(t *testing.T) {
 		},
 	})
 
-	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, recipeRepo, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, recipeRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Get("/api/recipes", handler.ListRecipes)
@@ -709,7 +902,7 @@ func TestAPIHandler_ClientConfig(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", tt.clientID, tt.oidcIssuer)
+			handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", tt.clientID, tt.oidcIssuer)
 
 			request := httptest.NewRequest(http.MethodGet, "/api/client-config", nil)
 			recorder := httptest.NewRecorder()
@@ -765,7 +958,7 @@ func TestDashboardStats_IncludesChores(t *testing.T) {
 		Status:          models.ChoreStatusOverdue,
 	})
 
-	handler := NewAPIHandler(choreRepo, userRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(choreRepo, userRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Get("/api/dashboard", handler.DashboardStats)
@@ -823,7 +1016,7 @@ func TestCreateRecipe_API(t *testing.T) {
 		Role:        models.RoleMember,
 	})
 
-	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, recipeRepo, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, recipeRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Post("/api/recipes", func(w http.ResponseWriter, r *http.Request) {
@@ -870,7 +1063,7 @@ func TestCreateRecipe_API_MissingTitle(t *testing.T) {
 		Role:        models.RoleMember,
 	})
 
-	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, recipeRepo, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, recipeRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Post("/api/recipes", func(w http.ResponseWriter, r *http.Request) {
@@ -902,7 +1095,7 @@ func TestCreateRecipe_API_WithImage(t *testing.T) {
 		Role:        models.RoleMember,
 	})
 
-	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, recipeRepo, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, recipeRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Post("/api/recipes", func(w http.ResponseWriter, r *http.Request) {
@@ -950,7 +1143,7 @@ func TestUpdateRecipe_API(t *testing.T) {
 		CreatedByUserID: user.ID,
 	})
 
-	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, recipeRepo, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, recipeRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Put("/api/recipes/{id}", handler.UpdateRecipe)
@@ -979,7 +1172,7 @@ func TestUpdateRecipe_API_NotFound(t *testing.T) {
 	database := testutil.NewTestDatabase(t)
 	recipeRepo := repository.NewRecipeRepository(database)
 
-	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, recipeRepo, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, recipeRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Put("/api/recipes/{id}", handler.UpdateRecipe)
@@ -1013,7 +1206,7 @@ func TestUpdateRecipe_API_ImageHandling(t *testing.T) {
 		CreatedByUserID: user.ID,
 	})
 
-	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, recipeRepo, nil, nil, nil, "", "", "")
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, recipeRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Put("/api/recipes/{id}", handler.UpdateRecipe)
@@ -1070,8 +1263,8 @@ func TestCreateChore_API_FullOptions(t *testing.T) {
 
 	category, _ := categoryRepo.Create(ctx, models.Category{Name: "Kitchen", CreatedByUserID: user.ID})
 
-	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, seriesRepo)
-	handler := NewAPIHandler(choreRepo, userRepo, categoryRepo, assignmentRepo, nil, nil, choreService, nil, nil, nil, nil, nil, "", "", "")
+	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, seriesRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+	handler := NewAPIHandler(choreRepo, userRepo, categoryRepo, assignmentRepo, nil, nil, choreService, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 
 	router := chi.NewRouter()
 	router.Use(func(next http.Handler) http.Handler {
@@ -1140,3 +1333,227 @@ func TestCreateChore_API_FullOptions(t *testing.T) {
 		t.Errorf("expected recurrenceUntil set on series")
 	}
 }
+
+func TestCreateChore_API_RecurrenceRule(t *testing.T) {
+	database := testutil.NewTestDatabase(t)
+	choreRepo := repository.NewChoreRepository(database)
+	userRepo := repository.NewUserRepository(database)
+	assignmentRepo := repository.NewChoreAssignmentRepository(database)
+	seriesRepo := repository.NewChoreSeriesRepository(database)
+	ctx := context.Background()
+
+	user, _ := userRepo.Create(ctx, models.User{
+		OIDCSubject: "sub-rrule",
+		Email:       "rrule@example.com",
+		Name:        "Rule User",
+		Role:        models.RoleMember,
+	})
+
+	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, seriesRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+	handler := NewAPIHandler(choreRepo, userRepo, nil, assignmentRepo, nil, nil, choreService, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
+
+	router := chi.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), middleware.UserContextKey, user)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+	router.Post("/api/chores", handler.CreateChore)
+	router.Get("/api/chores/{id}", handler.GetChore)
+
+	body := `{
+		"name": "Pay bills",
+		"assignees": ["` + user.ID + `"],
+		"dueDate": "2026-06-01",
+		"recurrenceRule": "RRULE:FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;COUNT=6"
+	}`
+	request := httptest.NewRequest(http.MethodPost, "/api/chores", strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var created models.Chore
+	if err := json.NewDecoder(recorder.Body).Decode(&created); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+
+	request = httptest.NewRequest(http.MethodGet, "/api/chores/"+created.ID, nil)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	var fetched models.Chore
+	if err := json.NewDecoder(recorder.Body).Decode(&fetched); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+
+	if fetched.RecurrenceRule != "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1" {
+		t.Errorf("expected normalized rule without COUNT, got %q", fetched.RecurrenceRule)
+	}
+	if fetched.RecurrenceType != models.RecurrenceMonthly {
+		t.Errorf("expected recurrence type derived from FREQ, got %q", fetched.RecurrenceType)
+	}
+	if fetched.RecurrenceCount == nil || *fetched.RecurrenceCount != 6 {
+		t.Errorf("expected COUNT lifted into recurrence count, got %v", fetched.RecurrenceCount)
+	}
+}
+
+func TestCreateChore_API_AssignmentStrategy(t *testing.T) {
+	database := testutil.NewTestDatabase(t)
+	choreRepo := repository.NewChoreRepository(database)
+	userRepo := repository.NewUserRepository(database)
+	assignmentRepo := repository.NewChoreAssignmentRepository(database)
+	seriesRepo := repository.NewChoreSeriesRepository(database)
+	ctx := context.Background()
+
+	user, _ := userRepo.Create(ctx, models.User{
+		OIDCSubject: "sub-strategy",
+		Email:       "strategy@example.com",
+		Name:        "Strategy User",
+		Role:        models.RoleMember,
+	})
+	owner, _ := userRepo.Create(ctx, models.User{
+		OIDCSubject: "sub-owner",
+		Email:       "owner@example.com",
+		Name:        "Owner",
+		Role:        models.RoleMember,
+	})
+
+	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, seriesRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+	handler := NewAPIHandler(choreRepo, userRepo, nil, assignmentRepo, nil, nil, choreService, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
+
+	create := func(body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/api/chores", strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		request = request.WithContext(context.WithValue(request.Context(), middleware.UserContextKey, user))
+		recorder := httptest.NewRecorder()
+		handler.CreateChore(recorder, request)
+		return recorder
+	}
+
+	recorder := create(`{"name": "Bins", "dueDate": "2026-06-01", "recurrenceType": "weekly", "recurrenceValue": "{\"interval\":1}",
+		"assignmentStrategy": "fixed", "fixedAssigneeId": "` + owner.ID + `", "effortPoints": 3}`)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var created models.Chore
+	if err := json.NewDecoder(recorder.Body).Decode(&created); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+
+	fetched, err := choreRepo.FindByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("finding chore: %v", err)
+	}
+	if fetched.AssignmentStrategy != models.AssignmentFixed {
+		t.Errorf("expected fixed strategy, got %q", fetched.AssignmentStrategy)
+	}
+	if fetched.FixedAssigneeUserID == nil || *fetched.FixedAssigneeUserID != owner.ID {
+		t.Errorf("expected fixed owner %s, got %v", owner.ID, fetched.FixedAssigneeUserID)
+	}
+	if fetched.EffortPoints != 3 {
+		t.Errorf("expected 3 effort points, got %d", fetched.EffortPoints)
+	}
+	if fetched.AssignedToUserID == nil || *fetched.AssignedToUserID != owner.ID {
+		t.Errorf("expected the chore to go to its owner, got %v", fetched.AssignedToUserID)
+	}
+
+	if recorder := create(`{"name": "Ownerless", "assignmentStrategy": "fixed"}`); recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for fixed strategy without owner, got %d", recorder.Code)
+	}
+	if recorder := create(`{"name": "Unknown", "assignmentStrategy": "alphabetical"}`); recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for unknown strategy, got %d", recorder.Code)
+	}
+}
+
+func TestCreateChore_API_InvalidRecurrenceRule(t *testing.T) {
+	database := testutil.NewTestDatabase(t)
+	choreRepo := repository.NewChoreRepository(database)
+	userRepo := repository.NewUserRepository(database)
+	ctx := context.Background()
+
+	user, _ := userRepo.Create(ctx, models.User{
+		OIDCSubject: "sub-badrule",
+		Email:       "badrule@example.com",
+		Name:        "Bad Rule User",
+		Role:        models.RoleMember,
+	})
+
+	handler := NewAPIHandler(choreRepo, userRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
+
+	request := httptest.NewRequest(http.MethodPost, "/api/chores",
+		strings.NewReader(`{"name": "Broken", "recurrenceRule": "FREQ=WEEKLY;BYDAY=2MO"}`))
+	request.Header.Set("Content-Type", "application/json")
+	request = request.WithContext(context.WithValue(request.Context(), middleware.UserContextKey, user))
+	recorder := httptest.NewRecorder()
+	handler.CreateChore(recorder, request)
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	chores, _ := choreRepo.FindAll(ctx, repository.ChoreFilter{})
+	if len(chores) != 0 {
+		t.Errorf("expected no chore to be created, got %d", len(chores))
+	}
+}
+
+func TestDashboardStats_LeaderboardRanksByPoints(t *testing.T) {
+	database := testutil.NewTestDatabase(t)
+	choreRepo := repository.NewChoreRepository(database)
+	userRepo := repository.NewUserRepository(database)
+	assignmentRepo := repository.NewChoreAssignmentRepository(database)
+	pointsRepo := repository.NewPointsRepository(database)
+	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, repository.NewChoreSeriesRepository(database), pointsRepo, nil, nil, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	alice, _ := userRepo.Create(ctx, models.User{OIDCSubject: "sub-alice", Email: "alice@example.com", Name: "Alice", Role: models.RoleMember})
+	bob, _ := userRepo.Create(ctx, models.User{OIDCSubject: "sub-bob", Email: "bob@example.com", Name: "Bob", Role: models.RoleMember})
+
+	complete := func(name string, effortPoints int, user models.User) {
+		chore, _ := choreRepo.Create(ctx, models.Chore{
+			Name:             name,
+			CreatedByUserID:  user.ID,
+			AssignedToUserID: &user.ID,
+			Status:           models.ChoreStatusPending,
+			EffortPoints:     effortPoints,
+		})
+		assignmentRepo.Create(ctx, models.ChoreAssignment{ChoreID: chore.ID, UserID: user.ID, Status: models.AssignmentStatusAssigned})
+		if err := choreService.CompleteChore(ctx, chore.ID, user.ID); err != nil {
+			t.Fatalf("completing %s: %v", name, err)
+		}
+	}
+	// Bob does more chores, but Alice's one is worth more.
+	complete("Dishes", 1, bob)
+	complete("Bins", 1, bob)
+	complete("Clean garage", 5, alice)
+
+	handler := NewAPIHandler(choreRepo, userRepo, nil, assignmentRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, pointsRepo, nil, nil, nil, nil, "", "", "")
+
+	request := httptest.NewRequest(http.MethodGet, "/api/dashboard?period=month", nil)
+	recorder := httptest.NewRecorder()
+	handler.DashboardStats(recorder, request)
+
+	var body struct {
+		LeaderboardPeriod string             `json:"leaderboard_period"`
+		Leaderboard       []leaderboardEntry `json:"leaderboard"`
+	}
+	if err := json.NewDecoder(recorder.Body).Decode(&body); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+
+	if body.LeaderboardPeriod != "month" {
+		t.Errorf("expected period month, got %q", body.LeaderboardPeriod)
+	}
+	if len(body.Leaderboard) != 2 {
+		t.Fatalf("expected 2 leaderboard rows, got %d", len(body.Leaderboard))
+	}
+	first, second := body.Leaderboard[0], body.Leaderboard[1]
+	if first.UserID != alice.ID || first.Points != 5 || first.Completed != 1 || first.Rank != 1 {
+		t.Errorf("expected Alice first with 5 points, got %+v", first)
+	}
+	if second.UserID != bob.ID || second.Points != 2 || second.Completed != 2 || second.Rank != 2 {
+		t.Errorf("expected Bob second with 2 points, got %+v", second)
+	}
+}
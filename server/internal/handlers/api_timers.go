@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/bensuskins/family-hub/internal/middleware"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/go-chi/chi/v5"
+)
+
+// GetChoreTimer returns the time tracked on an occurrence.
+func (handler *APIHandler) GetChoreTimer(w http.ResponseWriter, r *http.Request) {
+	handler.writeChoreTimer(w, r)
+}
+
+// StartChoreTimer starts or resumes the caller's timer and returns the
+// updated timer.
+func (handler *APIHandler) StartChoreTimer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+	if err := handler.choreService.StartTimer(ctx, chi.URLParam(r, "id"), user.ID); err != nil {
+		writeTimerError(w, err, "failed to start timer")
+		return
+	}
+	handler.writeChoreTimer(w, r)
+}
+
+// PauseChoreTimer pauses the caller's timer and returns the updated timer.
+func (handler *APIHandler) PauseChoreTimer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+	if err := handler.choreService.PauseTimer(ctx, chi.URLParam(r, "id"), user.ID); err != nil {
+		writeTimerError(w, err, "failed to pause timer")
+		return
+	}
+	handler.writeChoreTimer(w, r)
+}
+
+// StopChoreTimer stops every timer on the occurrence and returns the
+// updated timer.
+func (handler *APIHandler) StopChoreTimer(w http.ResponseWriter, r *http.Request) {
+	if err := handler.choreService.StopTimer(r.Context(), chi.URLParam(r, "id")); err != nil {
+		writeTimerError(w, err, "failed to stop timer")
+		return
+	}
+	handler.writeChoreTimer(w, r)
+}
+
+func (handler *APIHandler) writeChoreTimer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	chore, err := handler.choreRepo.FindByID(ctx, chi.URLParam(r, "id"))
+	if err != nil {
+		writeTimerError(w, err, "failed to load timer")
+		return
+	}
+	timer, err := handler.choreService.Timer(ctx, chore)
+	if err != nil {
+		writeTimerError(w, err, "failed to load timer")
+		return
+	}
+	writeJSON(w, http.StatusOK, timer)
+}
+
+func writeTimerError(w http.ResponseWriter, err error, failure string) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		writeJSONError(w, http.StatusNotFound, "chore not found")
+	case errors.Is(err, services.ErrChoreNotOpen):
+		writeJSONError(w, http.StatusConflict, "chore is not pending or overdue")
+	case errors.Is(err, services.ErrTimerRunning), errors.Is(err, services.ErrTimerNotRunning):
+		writeJSONError(w, http.StatusConflict, err.Error())
+	default:
+		slog.Error(failure, "error", err)
+		writeJSONError(w, http.StatusInternalServerError, failure)
+	}
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bensuskins/family-hub/internal/middleware"
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/testutil"
+)
+
+func TestPatchSettings_Timezone(t *testing.T) {
+	database := testutil.NewTestDatabase(t)
+	settingsRepo := repository.NewSettingsRepository(database)
+	handler := NewAPIHandler(nil, nil, nil, nil, nil, settingsRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantValue  string
+	}{
+		{name: "sets zone", body: `{"timezone":"Europe/London"}`, wantStatus: http.StatusNoContent, wantValue: "Europe/London"},
+		{name: "rejects unknown zone", body: `{"timezone":"Europe/Atlantis"}`, wantStatus: http.StatusBadRequest, wantValue: "Europe/London"},
+		{name: "rejects server-local", body: `{"timezone":"Local"}`, wantStatus: http.StatusBadRequest, wantValue: "Europe/London"},
+		{name: "empty clears", body: `{"timezone":""}`, wantStatus: http.StatusNoContent, wantValue: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPatch, "/api/settings", strings.NewReader(tt.body))
+			recorder := httptest.NewRecorder()
+			handler.PatchSettings(recorder, request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("want status %d, got %d: %s", tt.wantStatus, recorder.Code, recorder.Body.String())
+			}
+			value, _ := settingsRepo.Get(context.Background(), repository.SettingsKeyTimezone)
+			if value != tt.wantValue {
+				t.Errorf("want stored zone %q, got %q", tt.wantValue, value)
+			}
+		})
+	}
+}
+
+func TestUpdateTimezone_API(t *testing.T) {
+	database := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(database)
+	handler := NewAPIHandler(nil, userRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
+	ctx := context.Background()
+
+	user, _ := userRepo.Create(ctx, models.User{OIDCSubject: "sub-tz", Email: "tz@example.com", Name: "Traveller", Role: models.RoleMember})
+	userCtx := context.WithValue(ctx, middleware.UserContextKey, user)
+
+	request := httptest.NewRequest(http.MethodPut, "/api/profile/timezone", strings.NewReader(`{"timezone":"America/New_York"}`)).WithContext(userCtx)
+	recorder := httptest.NewRecorder()
+	handler.UpdateTimezone(recorder, request)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var updated models.User
+	json.Unmarshal(recorder.Body.Bytes(), &updated)
+	if updated.Timezone != "America/New_York" {
+		t.Errorf("expected the override in the response, got %q", updated.Timezone)
+	}
+	if stored, _ := userRepo.FindByID(ctx, user.ID); stored.Timezone != "America/New_York" {
+		t.Errorf("expected the override stored, got %q", stored.Timezone)
+	}
+
+	request = httptest.NewRequest(http.MethodPut, "/api/profile/timezone", strings.NewReader(`{"timezone":"Nowhere"}`)).WithContext(userCtx)
+	recorder = httptest.NewRecorder()
+	handler.UpdateTimezone(recorder, request)
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown zone, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/middleware"
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/go-chi/chi/v5"
+)
+
+// ListUnavailability returns current and upcoming away windows, soonest first.
+// Members see their own; admins see the whole family's, optionally narrowed
+// with ?userId=.
+func (handler *APIHandler) ListUnavailability(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	today := time.Now().In(middleware.GetLocation(ctx)).Format(DateFormat)
+	filter := repository.UnavailabilityFilter{EndingFrom: &today}
+	if user.Role != models.RoleAdmin {
+		filter.UserID = &user.ID
+	} else if userID := r.URL.Query().Get("userId"); userID != "" {
+		filter.UserID = &userID
+	}
+
+	windows, err := handler.choreService.Unavailability(ctx, filter)
+	if err != nil {
+		writeUnavailabilityError(w, err, "failed to load away windows")
+		return
+	}
+	if windows == nil {
+		windows = []models.Unavailability{}
+	}
+	writeJSON(w, http.StatusOK, windows)
+}
+
+// CreateUnavailability records an away window for the caller, or for userId
+// when the caller is an admin. Upcoming chores inside the window are
+// reassigned before responding.
+func (handler *APIHandler) CreateUnavailability(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	var body struct {
+		UserID    string `json:"userId"`
+		StartDate string `json:"startDate"`
+		EndDate   string `json:"endDate"`
+		Reason    string `json:"reason"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+
+	window, err := handler.choreService.AddUnavailability(ctx, user, models.Unavailability{
+		UserID:    body.UserID,
+		StartDate: body.StartDate,
+		EndDate:   body.EndDate,
+		Reason:    body.Reason,
+	})
+	if err != nil {
+		writeUnavailabilityError(w, err, "failed to add away window")
+		return
+	}
+	writeJSON(w, http.StatusCreated, window)
+}
+
+func (handler *APIHandler) DeleteUnavailability(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	if err := handler.choreService.RemoveUnavailability(ctx, user, chi.URLParam(r, "id")); err != nil {
+		writeUnavailabilityError(w, err, "failed to remove away window")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeUnavailabilityError(w http.ResponseWriter, err error, failure string) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		writeJSONError(w, http.StatusNotFound, "not found")
+	case errors.Is(err, services.ErrUnavailabilityForbidden):
+		writeJSONError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrUnavailabilityInvalid):
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+	default:
+		slog.Error(failure, "error", err)
+		writeJSONError(w, http.StatusInternalServerError, failure)
+	}
+}
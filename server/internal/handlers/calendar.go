@@ -37,8 +37,9 @@ func NewCalendarHandler(
 func (handler *CalendarHandler) Calendar(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	user := middleware.GetUser(ctx)
+	location := middleware.GetLocation(ctx)
 
-	now := time.Now()
+	now := time.Now().In(location)
 	view := r.URL.Query().Get("view")
 	if view == "" {
 		view = "month"
@@ -57,8 +58,8 @@ func (handler *CalendarHandler) Calendar(w http.ResponseWriter, r *http.Request)
 			}
 		}
 		month = int(now.Month())
-		start = time.Date(year, 1, 1, 0, 0, 0, 0, time.Local)
-		end = time.Date(year+1, 1, 1, 0, 0, 0, 0, time.Local)
+		start = time.Date(year, 1, 1, 0, 0, 0, 0, location)
+		end = time.Date(year+1, 1, 1, 0, 0, 0, 0, location)
 		date = start
 
 	case "week":
@@ -70,7 +71,7 @@ func (handler *CalendarHandler) Calendar(w http.ResponseWriter, r *http.Request)
 		}
 		// Find the Monday that starts this week
 		offset := (int(date.Weekday()) + 6) % 7
-		start = time.Date(date.Year(), date.Month(), date.Day()-offset, 0, 0, 0, 0, time.Local)
+		start = time.Date(date.Year(), date.Month(), date.Day()-offset, 0, 0, 0, 0, location)
 		end = start.AddDate(0, 0, 7)
 		year = date.Year()
 		month = int(date.Month())
@@ -82,7 +83,7 @@ func (handler *CalendarHandler) Calendar(w http.ResponseWriter, r *http.Request)
 				date = d
 			}
 		}
-		start = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
+		start = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, location)
 		end = start.AddDate(0, 0, 1)
 		year = date.Year()
 		month = int(date.Month())
@@ -101,7 +102,7 @@ func (handler *CalendarHandler) Calendar(w http.ResponseWriter, r *http.Request)
 				month = m
 			}
 		}
-		start = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+		start = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, location)
 		end = start.AddDate(0, 1, 0)
 		date = start
 	}
@@ -110,10 +111,14 @@ func (handler *CalendarHandler) Calendar(w http.ResponseWriter, r *http.Request)
 	if err != nil {
 		slog.Error("fetching ical events for calendar", "error", err)
 	}
+	events = eventsInLocation(events, location)
 
+	// Due dates are stored as calendar dates, so filter on the range's dates
+	// rather than its instants.
+	dueAfter, dueBefore := repository.CivilDate(start), repository.CivilDate(end)
 	chores, err := handler.choreRepo.FindAll(ctx, repository.ChoreFilter{
-		DueAfter:  &start,
-		DueBefore: &end,
+		DueAfter:  &dueAfter,
+		DueBefore: &dueBefore,
 	})
 	if err != nil {
 		slog.Error("finding chores for calendar", "error", err)
@@ -141,6 +146,7 @@ func (handler *CalendarHandler) Calendar(w http.ResponseWriter, r *http.Request)
 
 	component := pages.Calendar(pages.CalendarProps{
 		User:          user,
+		Today:         now,
 		Year:          year,
 		Month:         month,
 		View:          view,
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/middleware"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/templates/pages"
+)
+
+// maxChoreImportBytes bounds an uploaded or posted chore file.
+const maxChoreImportBytes = 1 << 20
+
+// ChoreImportHandler serves chore import/export and the starter library, as
+// admin pages and as JSON under /api/chores.
+type ChoreImportHandler struct {
+	importService *services.ChoreImportService
+}
+
+func NewChoreImportHandler(importService *services.ChoreImportService) *ChoreImportHandler {
+	return &ChoreImportHandler{importService: importService}
+}
+
+// importRowAPI is one row of an import report.
+type importRowAPI struct {
+	Row         int      `json:"row"`
+	Name        string   `json:"name"`
+	NewCategory string   `json:"newCategory,omitempty"`
+	Errors      []string `json:"errors"`
+	Created     bool     `json:"created"`
+}
+
+// importReportAPI is an import report. Error is set when the import failed
+// partway, in which case created counts the rows that stay imported.
+type importReportAPI struct {
+	Imported bool           `json:"imported"`
+	Invalid  int            `json:"invalid"`
+	Created  int            `json:"created"`
+	Rows     []importRowAPI `json:"rows"`
+	Error    string         `json:"error,omitempty"`
+}
+
+type libraryChoreAPI struct {
+	Key string `json:"key"`
+	services.ChoreTemplate
+}
+
+func toImportReportAPI(report services.ImportReport, imported bool) importReportAPI {
+	response := importReportAPI{Imported: imported, Invalid: report.Invalid, Created: report.Created, Rows: make([]importRowAPI, 0, len(report.Rows))}
+	for _, row := range report.Rows {
+		errs := row.Errors
+		if errs == nil {
+			errs = []string{}
+		}
+		response.Rows = append(response.Rows, importRowAPI{Row: row.Row, Name: row.Name, NewCategory: row.NewCategory, Errors: errs, Created: row.Created})
+	}
+	return response
+}
+
+// importFormat picks json or csv from an explicit format, falling back to the
+// file name's extension and then to json.
+func importFormat(format, filename string) string {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
+	}
+	if format == "" {
+		format = "json"
+	}
+	return format
+}
+
+// writeChoreExport streams the current series as an attachment.
+func (handler *ChoreImportHandler) writeChoreExport(w http.ResponseWriter, r *http.Request, format string) {
+	templates, err := handler.importService.Export(r.Context())
+	if err != nil {
+		slog.Error("exporting chores", "error", err)
+		http.Error(w, "export failed", http.StatusInternalServerError)
+		return
+	}
+
+	contentType := "application/json"
+	if format == "csv" {
+		contentType = "text/csv; charset=utf-8"
+	}
+	filename := fmt.Sprintf("family-hub-chores-%s.%s", time.Now().Format(DateFormat), format)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.Header().Set("Content-Type", contentType)
+	if err := services.EncodeChoreTemplates(format, w, templates); err != nil {
+		slog.Error("writing chore export", "error", err)
+	}
+}
+
+// Export downloads every chore series (GET /admin/chores/export?format=json|csv).
+func (handler *ChoreImportHandler) Export(w http.ResponseWriter, r *http.Request) {
+	format := importFormat(r.URL.Query().Get("format"), "")
+	if format != "json" && format != "csv" {
+		http.Error(w, services.ErrUnknownImportFormat.Error(), http.StatusBadRequest)
+		return
+	}
+	handler.writeChoreExport(w, r, format)
+}
+
+// ExportAPI is Export for API clients (GET /api/chores/export?format=json|csv).
+func (handler *ChoreImportHandler) ExportAPI(w http.ResponseWriter, r *http.Request) {
+	format := importFormat(r.URL.Query().Get("format"), "")
+	if format != "json" && format != "csv" {
+		writeJSONError(w, http.StatusBadRequest, services.ErrUnknownImportFormat.Error())
+		return
+	}
+	handler.writeChoreExport(w, r, format)
+}
+
+func (handler *ChoreImportHandler) Page(w http.ResponseWriter, r *http.Request) {
+	handler.renderPage(w, r, http.StatusOK, pages.ChoreImportProps{})
+}
+
+func (handler *ChoreImportHandler) renderPage(w http.ResponseWriter, r *http.Request, status int, props pages.ChoreImportProps) {
+	ctx := r.Context()
+	props.User = middleware.GetUser(ctx)
+	props.Library = services.Library()
+	w.WriteHeader(status)
+	pages.ChoreImport(props).Render(ctx, w)
+}
+
+// Upload imports an uploaded JSON or CSV file, or only previews it when the
+// dry_run box is ticked. A file with invalid rows is shown back with its
+// report instead of being imported.
+func (handler *ChoreImportHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxChoreImportBytes)
+	if err := r.ParseMultipartForm(maxChoreImportBytes); err != nil {
+		handler.renderPage(w, r, http.StatusBadRequest, pages.ChoreImportProps{Error: "Choose a file of at most 1 MB to import."})
+		return
+	}
+	file, fileHeader, err := r.FormFile("file")
+	if err != nil {
+		handler.renderPage(w, r, http.StatusBadRequest, pages.ChoreImportProps{Error: "Choose a file to import."})
+		return
+	}
+	defer file.Close()
+
+	templates, err := services.DecodeChoreTemplates(importFormat(r.FormValue("format"), fileHeader.Filename), file)
+	if err != nil {
+		handler.renderPage(w, r, http.StatusBadRequest, pages.ChoreImportProps{Error: err.Error()})
+		return
+	}
+
+	dryRun := r.FormValue("dry_run") == "on"
+	var report services.ImportReport
+	if dryRun {
+		report, err = handler.importService.Preview(ctx, templates)
+	} else {
+		report, err = handler.importService.Import(ctx, user.ID, templates)
+	}
+	handler.finishImport(w, r, report, dryRun, err)
+}
+
+// ImportLibrary adds the ticked starter chores (form field chores).
+func (handler *ChoreImportHandler) ImportLibrary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+	templates, err := services.LibraryTemplates(r.Form["chores"])
+	if err != nil {
+		handler.renderPage(w, r, http.StatusBadRequest, pages.ChoreImportProps{Error: err.Error()})
+		return
+	}
+	report, err := handler.importService.Import(ctx, user.ID, templates)
+	handler.finishImport(w, r, report, false, err)
+}
+
+func (handler *ChoreImportHandler) finishImport(w http.ResponseWriter, r *http.Request, report services.ImportReport, dryRun bool, err error) {
+	switch {
+	case errors.Is(err, services.ErrImportInvalid):
+		handler.renderPage(w, r, http.StatusUnprocessableEntity, pages.ChoreImportProps{Report: &report, Error: err.Error()})
+	case errors.Is(err, services.ErrImportEmpty):
+		handler.renderPage(w, r, http.StatusBadRequest, pages.ChoreImportProps{Error: err.Error()})
+	case err != nil:
+		slog.Error("importing chores", "error", err)
+		message := fmt.Sprintf("The import failed at row %d. Rows before it were imported and stay.", report.Created+1)
+		handler.renderPage(w, r, http.StatusInternalServerError, pages.ChoreImportProps{Report: &report, Error: message})
+	case dryRun:
+		handler.renderPage(w, r, http.StatusOK, pages.ChoreImportProps{Report: &report, DryRun: true})
+	default:
+		http.Redirect(w, r, "/chores", http.StatusFound)
+	}
+}
+
+// Library lists the starter chores (GET /api/chores/library).
+func (handler *ChoreImportHandler) Library(w http.ResponseWriter, r *http.Request) {
+	library := services.Library()
+	response := make([]libraryChoreAPI, 0, len(library))
+	for _, chore := range library {
+		response = append(response, libraryChoreAPI{Key: chore.Key, ChoreTemplate: chore.ChoreTemplate})
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// ImportAPI imports the request body (POST /api/chores/import?format=json|csv).
+// With dry_run=true it only reports what would happen. Any invalid row fails
+// the whole import with 422 and the report. A failure partway is a 500 with
+// the report, whose created rows stay imported.
+func (handler *ChoreImportHandler) ImportAPI(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	format := importFormat(r.URL.Query().Get("format"), "")
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "text/csv") && r.URL.Query().Get("format") == "" {
+		format = "csv"
+	}
+	templates, err := services.DecodeChoreTemplates(format, io.LimitReader(r.Body, maxChoreImportBytes))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+	var report services.ImportReport
+	if dryRun {
+		report, err = handler.importService.Preview(ctx, templates)
+	} else {
+		report, err = handler.importService.Import(ctx, user.ID, templates)
+	}
+	switch {
+	case errors.Is(err, services.ErrImportInvalid):
+		writeJSON(w, http.StatusUnprocessableEntity, toImportReportAPI(report, false))
+	case errors.Is(err, services.ErrImportEmpty):
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+	case err != nil:
+		slog.Error("importing chores via API", "error", err)
+		response := toImportReportAPI(report, false)
+		response.Error = "failed to import chores"
+		writeJSON(w, http.StatusInternalServerError, response)
+	case dryRun:
+		writeJSON(w, http.StatusOK, toImportReportAPI(report, false))
+	default:
+		writeJSON(w, http.StatusCreated, toImportReportAPI(report, true))
+	}
+}
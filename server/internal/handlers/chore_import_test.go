@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bensuskins/family-hub/internal/middleware"
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestChoreImport_API(t *testing.T) {
+	database := testutil.NewTestDatabase(t)
+	choreRepo := repository.NewChoreRepository(database)
+	userRepo := repository.NewUserRepository(database)
+	seriesRepo := repository.NewChoreSeriesRepository(database)
+	categoryRepo := repository.NewCategoryRepository(database)
+	ctx := context.Background()
+
+	admin, _ := userRepo.Create(ctx, models.User{
+		OIDCSubject: "sub-import",
+		Email:       "admin@example.com",
+		Name:        "Admin",
+		Role:        models.RoleAdmin,
+	})
+
+	choreService := services.NewChoreService(choreRepo, repository.NewChoreAssignmentRepository(database), userRepo, seriesRepo, repository.NewPointsRepository(database), repository.NewUnavailabilityRepository(database), nil, nil, nil, nil, nil, nil)
+	handler := NewChoreImportHandler(services.NewChoreImportService(choreService, seriesRepo, userRepo, categoryRepo))
+
+	router := chi.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), middleware.UserContextKey, admin)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+	router.Post("/api/chores/import", handler.ImportAPI)
+	router.Get("/api/chores/export", handler.ExportAPI)
+
+	post := func(url, contentType, body string) (int, importReportAPI) {
+		request := httptest.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		request.Header.Set("Content-Type", contentType)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		var report importReportAPI
+		json.Unmarshal(recorder.Body.Bytes(), &report)
+		return recorder.Code, report
+	}
+
+	valid := `[{"name":"Walk the dog","recurrenceRule":"FREQ=DAILY","dueTime":"17:30","assignees":["admin@example.com"],"points":2}]`
+	code, report := post("/api/chores/import?dry_run=true", "application/json", valid)
+	if code != http.StatusOK || report.Imported || report.Invalid != 0 || len(report.Rows) != 1 {
+		t.Fatalf("dry run: unexpected %d %+v", code, report)
+	}
+	if chores, _ := choreRepo.FindAll(ctx, repository.ChoreFilter{}); len(chores) != 0 {
+		t.Fatalf("dry run created %d chores", len(chores))
+	}
+
+	code, report = post("/api/chores/import", "application/json", `[{"name":"Walk the dog"},{"name":"","points":-2}]`)
+	if code != http.StatusUnprocessableEntity || report.Invalid != 1 || len(report.Rows[1].Errors) == 0 {
+		t.Fatalf("invalid rows: unexpected %d %+v", code, report)
+	}
+
+	csv := "name,description,category,recurrence_rule,due_time,assignees,points\nWater the plants,,Garden,FREQ=WEEKLY;BYDAY=WE,,,1\n"
+	code, report = post("/api/chores/import", "text/csv", csv)
+	if code != http.StatusCreated || !report.Imported || report.Rows[0].NewCategory != "Garden" {
+		t.Fatalf("csv import: unexpected %d %+v", code, report)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/api/chores/export?format=csv", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), "Water the plants,,Garden,") {
+		t.Errorf("export: unexpected %d %q", recorder.Code, recorder.Body.String())
+	}
+
+	request = httptest.NewRequest(http.MethodGet, "/api/chores/export?format=xml", nil)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown format, got %d", recorder.Code)
+	}
+}
@@ -1,11 +1,16 @@
 package handlers
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bensuskins/family-hub/internal/middleware"
@@ -21,6 +26,8 @@ type ChoreHandler struct {
 	categoryRepo repository.CategoryRepository
 	userRepo     repository.UserRepository
 	choreService *services.ChoreService
+	icalSubRepo  repository.ICalSubscriptionRepository
+	swapService  *services.ChoreSwapService
 }
 
 func NewChoreHandler(
@@ -28,12 +35,16 @@ func NewChoreHandler(
 	categoryRepo repository.CategoryRepository,
 	userRepo repository.UserRepository,
 	choreService *services.ChoreService,
+	icalSubRepo repository.ICalSubscriptionRepository,
+	swapService *services.ChoreSwapService,
 ) *ChoreHandler {
 	return &ChoreHandler{
 		choreRepo:    choreRepo,
 		categoryRepo: categoryRepo,
 		userRepo:     userRepo,
 		choreService: choreService,
+		icalSubRepo:  icalSubRepo,
+		swapService:  swapService,
 	}
 }
 
@@ -110,7 +121,7 @@ func (handler *ChoreHandler) List(w http.ResponseWriter, r *http.Request) {
 		s := models.ChoreStatus(status)
 		filter.Status = &s
 	} else {
-		filter.Statuses = []models.ChoreStatus{models.ChoreStatusPending, models.ChoreStatusOverdue}
+		filter.Statuses = []models.ChoreStatus{models.ChoreStatusPending, models.ChoreStatusOverdue, models.ChoreStatusBlocked}
 	}
 
 	chores, err := handler.choreRepo.FindAll(ctx, filter)
@@ -136,15 +147,20 @@ func (handler *ChoreHandler) List(w http.ResponseWriter, r *http.Request) {
 		slog.Error("finding categories", "error", err)
 	}
 
+	swaps, swapCandidates := handler.swapPanel(ctx, user)
+
 	component := pages.ChoreList(pages.ChoreListProps{
-		User:          user,
-		Chores:        chores,
-		Categories:    categories,
-		Users:         users,
-		UserNameMap:   userNameMap,
-		UserAvatarMap: userAvatarMap,
-		Filter:        filter,
-		ActiveTab:     tab,
+		User:             user,
+		Chores:           chores,
+		Categories:       categories,
+		Users:            users,
+		UserNameMap:      userNameMap,
+		UserAvatarMap:    userAvatarMap,
+		Filter:           filter,
+		ActiveTab:        tab,
+		Swaps:            swaps,
+		SwapCandidates:   swapCandidates,
+		AwaitingApproval: handler.awaitingApproval(ctx, user),
 	})
 	component.Render(ctx, w)
 }
@@ -213,6 +229,8 @@ func (handler *ChoreHandler) CreateForm(w http.ResponseWriter, r *http.Request)
 		User:       user,
 		Categories: categories,
 		AllUsers:   users,
+		Calendars:  handler.calendarSubscriptions(ctx),
+		Series:     handler.activeSeries(ctx),
 		IsEdit:     false,
 	})
 	component.Render(ctx, w)
@@ -237,8 +255,36 @@ func (handler *ChoreHandler) Create(w http.ResponseWriter, r *http.Request) {
 		RecurrenceType:  recurrenceType,
 		RecurrenceValue: recurrenceValue,
 		RecurOnComplete: r.FormValue("recur_on_complete") == "on",
+
+		ChecklistRequired: r.FormValue("checklist_required") == "on",
+		RequiresApproval:  r.FormValue("requires_approval") == "on",
 	}
 	chore.RecurrenceUntil, chore.RecurrenceCount = parseRecurrenceEnd(r)
+	if err := handler.applyFormRecurrenceSource(&chore, recurrenceType, r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := formAssignmentSettings(&chore, r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := formGroupSettings(&chore, r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := formEscalationSettings(&chore, r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := formPrerequisites(&chore, r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	seasons, err := services.ParseSeasons(r.FormValue("seasons"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	if categoryID := r.FormValue("category_id"); categoryID != "" {
 		chore.CategoryID = &categoryID
@@ -255,39 +301,19 @@ func (handler *ChoreHandler) Create(w http.ResponseWriter, r *http.Request) {
 		chore.DueTime = &dueTime
 	}
 
-	created, err := handler.choreRepo.Create(ctx, chore)
+	created, err := handler.choreService.CreateChore(ctx, chore, r.Form["assignees"], formChecklist(r))
 	if err != nil {
 		slog.Error("creating chore", "error", err)
 		http.Error(w, "Error creating chore", http.StatusInternalServerError)
 		return
 	}
-
-	assignees := r.Form["assignees"]
-	if len(assignees) > 0 {
-		if err := handler.choreRepo.SetEligibleAssignees(ctx, created.ID, assignees); err != nil {
-			slog.Error("setting eligible assignees", "error", err)
+	if created.SeriesID != nil {
+		if err := handler.choreService.SetPrerequisites(ctx, *created.SeriesID, chore.Prerequisites, chore.PrerequisiteDelayMinutes); err != nil {
+			slog.Error("setting prerequisites for new chore", "error", err)
 		}
 	}
-
-	assigned, err := handler.choreService.AssignNextUser(ctx, created)
-	if err != nil {
-		slog.Error("assigning chore", "error", err)
-	}
-
-	// Recurring chores (including RecurOnComplete) own their rule in a
-	// chore_series row, which must exist before chores.series_id references it.
-	if created.RecurrenceType != models.RecurrenceNone {
-		seriesID := created.ID
-		assigned.SeriesID = &seriesID
-		if err := handler.choreService.SyncSeriesDefinition(ctx, assigned, assignees); err != nil {
-			slog.Error("creating series definition for new chore", "error", err)
-		} else if err := handler.choreRepo.Update(ctx, assigned); err != nil {
-			slog.Error("setting series_id on new chore", "error", err)
-		} else if !created.RecurOnComplete {
-			if err := handler.choreService.SeedFutureOccurrences(ctx, assigned, services.SeedHorizonFrom(time.Now())); err != nil {
-				slog.Error("seeding future occurrences for new chore", "error", err)
-			}
-		}
+	if err := handler.choreService.SetSeasons(ctx, created, seasons); err != nil {
+		slog.Error("setting seasons for new chore", "error", err)
 	}
 
 	http.Redirect(w, r, "/chores", http.StatusFound)
@@ -321,6 +347,9 @@ func (handler *ChoreHandler) EditForm(w http.ResponseWriter, r *http.Request) {
 			slog.Error("getting series for eligible assignees", "error", err)
 		} else if series != nil {
 			chore.EligibleAssignees = series.EligibleAssignees
+			chore.Prerequisites = series.Prerequisites
+			chore.PrerequisiteDelayMinutes = series.PrerequisiteDelayMinutes
+			chore.Seasons = series.Seasons
 		}
 	}
 	if chore.EligibleAssignees == nil {
@@ -331,16 +360,27 @@ func (handler *ChoreHandler) EditForm(w http.ResponseWriter, r *http.Request) {
 		chore.EligibleAssignees = eligibleAssignees
 	}
 
+	checklist, err := handler.choreService.Checklist(ctx, choreID)
+	if err != nil {
+		slog.Error("getting checklist", "error", err)
+	}
+	chore.Checklist = checklist
+
 	component := pages.ChoreForm(pages.ChoreFormProps{
 		User:       user,
 		Categories: categories,
 		AllUsers:   users,
+		Calendars:  handler.calendarSubscriptions(ctx),
+		Series:     handler.activeSeries(ctx),
 		Chore:      &chore,
 		IsEdit:     true,
 	})
 	component.Render(ctx, w)
 }
 
+// Update saves the chore form. For a recurring chore the scope field picks
+// whether the edit applies to this occurrence only, this and the following
+// occurrences, or the whole series (the default).
 func (handler *ChoreHandler) Update(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	choreID := chi.URLParam(r, "id")
@@ -349,6 +389,11 @@ func (handler *ChoreHandler) Update(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid form", http.StatusBadRequest)
 		return
 	}
+	scope, err := services.ParseEditScope(r.FormValue("scope"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	chore, err := handler.choreRepo.FindByID(ctx, choreID)
 	if err != nil {
@@ -356,19 +401,48 @@ func (handler *ChoreHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	oldRecurrenceType := chore.RecurrenceType
-	oldRecurrenceValue := chore.RecurrenceValue
-	oldRecurrenceEnd := recurrenceEndKey(chore.RecurrenceUntil, chore.RecurrenceCount)
+	if scope == services.EditScopeOccurrence && chore.SeriesID != nil {
+		handler.updateOccurrence(w, r, chore)
+		return
+	}
+	before := chore
 
 	recurrenceType := models.RecurrenceType(r.FormValue("recurrence_type"))
-	recurrenceValue := buildRecurrenceValue(recurrenceType, r)
 
 	chore.Name = r.FormValue("name")
 	chore.Description = r.FormValue("description")
 	chore.RecurrenceType = recurrenceType
-	chore.RecurrenceValue = recurrenceValue
+	chore.RecurrenceValue = buildRecurrenceValue(recurrenceType, r)
+	chore.RecurrenceRule = ""
 	chore.RecurOnComplete = r.FormValue("recur_on_complete") == "on"
+	chore.ChecklistRequired = r.FormValue("checklist_required") == "on"
+	chore.RequiresApproval = r.FormValue("requires_approval") == "on"
 	chore.RecurrenceUntil, chore.RecurrenceCount = parseRecurrenceEnd(r)
+	if err := handler.applyFormRecurrenceSource(&chore, recurrenceType, r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := formAssignmentSettings(&chore, r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := formGroupSettings(&chore, r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := formEscalationSettings(&chore, r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := formPrerequisites(&chore, r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	seasons, err := services.ParseSeasons(r.FormValue("seasons"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	if categoryID := r.FormValue("category_id"); categoryID != "" {
 		chore.CategoryID = &categoryID
@@ -391,59 +465,93 @@ func (handler *ChoreHandler) Update(w http.ResponseWriter, r *http.Request) {
 		chore.DueTime = nil
 	}
 
-	if err := handler.choreRepo.Update(ctx, chore); err != nil {
+	// An empty selection means everyone, so it must clear the pool.
+	assignees := r.Form["assignees"]
+	if assignees == nil {
+		assignees = []string{}
+	}
+
+	if scope == services.EditScopeFollowing {
+		chore, err = handler.choreService.SplitSeries(ctx, before, chore, assignees)
+	} else {
+		err = handler.choreService.UpdateChore(ctx, before, chore, assignees)
+	}
+	if err != nil {
 		slog.Error("updating chore", "error", err)
 		http.Error(w, "Error updating chore", http.StatusInternalServerError)
 		return
 	}
 
-	assignees := r.Form["assignees"]
-	if err := handler.choreRepo.SetEligibleAssignees(ctx, chore.ID, assignees); err != nil {
-		slog.Error("setting eligible assignees", "error", err)
+	if err := handler.choreService.SetChecklist(ctx, chore, formChecklist(r)); err != nil {
+		slog.Error("setting checklist on update", "error", err)
 	}
 
-	// Keep the series definition (rule + eligible pool) in step with the edit so
-	// the change applies to every occurrence, not just this row.
 	if chore.SeriesID != nil {
-		if err := handler.choreService.SyncSeriesDefinition(ctx, chore, assignees); err != nil {
-			slog.Error("syncing series definition on update", "error", err)
+		err := handler.choreService.SetPrerequisites(ctx, *chore.SeriesID, chore.Prerequisites, chore.PrerequisiteDelayMinutes)
+		switch {
+		case errors.Is(err, services.ErrPrerequisiteCycle), errors.Is(err, services.ErrPrerequisiteNotFound):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		case err != nil:
+			slog.Error("setting prerequisites on update", "error", err)
 		}
 	}
-
-	recurrenceChanged := recurrenceType != oldRecurrenceType ||
-		recurrenceValue != oldRecurrenceValue ||
-		recurrenceEndKey(chore.RecurrenceUntil, chore.RecurrenceCount) != oldRecurrenceEnd
-	if recurrenceChanged && chore.SeriesID != nil && !chore.RecurOnComplete {
-		if err := handler.choreRepo.DeleteFuturePendingBySeries(ctx, *chore.SeriesID); err != nil {
-			slog.Error("deleting stale future instances", "error", err)
-		} else if err := handler.choreService.SeedFutureOccurrences(ctx, chore, services.SeedHorizonFrom(time.Now())); err != nil {
-			slog.Error("re-seeding after recurrence change", "error", err)
-		}
+	if err := handler.choreService.SetSeasons(ctx, chore, seasons); err != nil {
+		slog.Error("setting seasons on update", "error", err)
 	}
 
 	http.Redirect(w, r, "/chores", http.StatusFound)
 }
 
+// updateOccurrence saves the form's name, description, due date and time and
+// assignee onto one occurrence, leaving its series as it is.
+func (handler *ChoreHandler) updateOccurrence(w http.ResponseWriter, r *http.Request, chore models.Chore) {
+	edit := services.OccurrenceEdit{
+		Name:        r.FormValue("name"),
+		Description: r.FormValue("description"),
+	}
+	if dueDate, err := time.Parse(DateFormat, r.FormValue("due_date")); err == nil {
+		edit.DueDate = &dueDate
+	}
+	if dueTime := r.FormValue("due_time"); dueTime != "" {
+		edit.DueTime = &dueTime
+	}
+	if assignee := r.FormValue("assigned_to_user_id"); assignee != "" {
+		edit.AssignedToUserID = &assignee
+	}
+
+	_, err := handler.choreService.EditOccurrence(r.Context(), chore.ID, edit)
+	switch {
+	case errors.Is(err, services.ErrChoreNotOpen), errors.Is(err, services.ErrAssigneeNotFound), errors.Is(err, services.ErrGroupChoreAssignee):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case err != nil:
+		slog.Error("updating occurrence", "error", err)
+		http.Error(w, "Error updating chore", http.StatusInternalServerError)
+	default:
+		http.Redirect(w, r, "/chores", http.StatusFound)
+	}
+}
+
+// Delete removes a chore. For a recurring chore the scope field picks this
+// occurrence only, this and the following occurrences, or the whole series
+// (the default).
 func (handler *ChoreHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	choreID := chi.URLParam(r, "id")
 
-	chore, err := handler.choreRepo.FindByID(ctx, choreID)
+	scope, err := services.ParseEditScope(r.FormValue("scope"))
 	if err != nil {
-		http.NotFound(w, r)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	if chore.SeriesID != nil {
-		if err := handler.choreRepo.DeleteFuturePendingBySeries(ctx, *chore.SeriesID); err != nil {
-			slog.Error("deleting future pending siblings", "error", err)
-		}
-		if err := handler.choreService.DeleteSeriesDefinition(ctx, *chore.SeriesID); err != nil {
-			slog.Error("soft-deleting series definition", "error", err)
-		}
+	chore, err := handler.choreRepo.FindByID(ctx, choreID)
+	if err != nil {
+		http.NotFound(w, r)
+		return
 	}
 
-	if err := handler.choreRepo.Delete(ctx, choreID); err != nil {
+	if err := handler.choreService.DeleteChore(ctx, chore, scope); err != nil {
 		slog.Error("deleting chore", "error", err)
 		http.Error(w, "Error deleting chore", http.StatusInternalServerError)
 		return
@@ -474,7 +582,18 @@ func (handler *ChoreHandler) Complete(w http.ResponseWriter, r *http.Request) {
 	user := middleware.GetUser(ctx)
 	choreID := chi.URLParam(r, "id")
 
-	if err := handler.choreService.CompleteChore(ctx, choreID, user.ID); err != nil {
+	// The photo is optional; it is kept for an admin to look at when the
+	// chore requires approval.
+	imageBytes, contentType, ok := readOptionalUploadedImage(w, r, "photo", maxRecipeImageBytes)
+	if !ok {
+		return
+	}
+	var proofImage string
+	if imageBytes != nil {
+		proofImage = encodeDataURI(contentType, imageBytes)
+	}
+
+	if err := handler.choreService.CompleteChoreWithProof(ctx, choreID, user.ID, proofImage); err != nil {
 		slog.Error("completing chore", "error", err, "chore_id", choreID)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
@@ -482,13 +601,11 @@ func (handler *ChoreHandler) Complete(w http.ResponseWriter, r *http.Request) {
 
 	if isHTMXRequest(r) {
 		chore, _ := handler.choreRepo.FindByID(ctx, choreID)
-		users, _ := handler.userRepo.FindAll(ctx)
-		userNameMap := make(map[string]string, len(users))
-		userAvatarMap := make(map[string]string, len(users))
-		for _, u := range users {
-			userNameMap[u.ID] = u.Name
-			userAvatarMap[u.ID] = u.AvatarURL
+		if r.FormValue("view") == "dashboard" {
+			pages.DashboardChoreDone(chore).Render(ctx, w)
+			return
 		}
+		userNameMap, userAvatarMap := handler.userMaps(ctx)
 		component := pages.ChoreRow(chore, user, userNameMap, userAvatarMap)
 		component.Render(ctx, w)
 		return
@@ -497,6 +614,239 @@ func (handler *ChoreHandler) Complete(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/chores", http.StatusFound)
 }
 
+// Uncomplete undoes a completion. HTMX requests get the restored row back:
+// the dashboard's with view=dashboard, otherwise the chore list's.
+func (handler *ChoreHandler) Uncomplete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+	choreID := chi.URLParam(r, "id")
+
+	if err := handler.choreService.UncompleteChore(ctx, user, choreID); err != nil {
+		slog.Error("undoing completion", "error", err, "chore_id", choreID)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if isHTMXRequest(r) {
+		chore, _ := handler.choreRepo.FindByID(ctx, choreID)
+		userNameMap, userAvatarMap := handler.userMaps(ctx)
+		if r.FormValue("view") == "dashboard" {
+			pages.DashboardChoreItem(chore, userNameMap, userAvatarMap).Render(ctx, w)
+			return
+		}
+		component := pages.ChoreRow(chore, user, userNameMap, userAvatarMap)
+		component.Render(ctx, w)
+		return
+	}
+
+	http.Redirect(w, r, "/chores", http.StatusFound)
+}
+
+// userMaps returns every user's name and avatar URL keyed by user ID.
+func (handler *ChoreHandler) userMaps(ctx context.Context) (map[string]string, map[string]string) {
+	users, _ := handler.userRepo.FindAll(ctx)
+	userNameMap := make(map[string]string, len(users))
+	userAvatarMap := make(map[string]string, len(users))
+	for _, u := range users {
+		userNameMap[u.ID] = u.Name
+		userAvatarMap[u.ID] = u.AvatarURL
+	}
+	return userNameMap, userAvatarMap
+}
+
+func (handler *ChoreHandler) Skip(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	choreID := chi.URLParam(r, "id")
+
+	advanceRotation := r.FormValue("keep_turn") == ""
+	if err := handler.choreService.SkipChore(ctx, choreID, advanceRotation); err != nil {
+		slog.Error("skipping chore", "error", err, "chore_id", choreID)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, "/chores", http.StatusFound)
+}
+
+func (handler *ChoreHandler) Snooze(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	choreID := chi.URLParam(r, "id")
+
+	dueDate, err := time.Parse(DateFormat, r.FormValue("due_date"))
+	if err != nil {
+		http.Error(w, "Invalid snooze date", http.StatusBadRequest)
+		return
+	}
+	var dueTime *string
+	if value := r.FormValue("due_time"); value != "" {
+		dueTime = &value
+	}
+
+	if _, err := handler.choreService.SnoozeChore(ctx, choreID, dueDate, dueTime); err != nil {
+		slog.Error("snoozing chore", "error", err, "chore_id", choreID)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, "/chores", http.StatusFound)
+}
+
+// ApproveCompletion accepts a completion waiting for approval.
+func (handler *ChoreHandler) ApproveCompletion(w http.ResponseWriter, r *http.Request) {
+	handler.reviewCompletion(w, r, handler.choreService.ApproveCompletion)
+}
+
+// RejectCompletion sends a completion waiting for approval back to pending.
+func (handler *ChoreHandler) RejectCompletion(w http.ResponseWriter, r *http.Request) {
+	handler.reviewCompletion(w, r, handler.choreService.RejectCompletion)
+}
+
+func (handler *ChoreHandler) reviewCompletion(w http.ResponseWriter, r *http.Request, review func(context.Context, string) error) {
+	ctx := r.Context()
+	choreID := chi.URLParam(r, "id")
+
+	if err := review(ctx, choreID); err != nil {
+		slog.Error("reviewing completion", "error", err, "chore_id", choreID)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, "/chores", http.StatusFound)
+}
+
+// ServeProof serves the photo submitted with a completion.
+func (handler *ChoreHandler) ServeProof(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	choreID := chi.URLParam(r, "id")
+
+	imageData, err := handler.choreService.ProofImage(ctx, choreID)
+	if err != nil || imageData == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	imageBytes, ok := decodeDataURI(imageData)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	mimeType, ok := detectImageContentType(imageBytes)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", mimeType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(imageBytes)
+}
+
+// awaitingApproval lists the completions waiting for approval that the user
+// should see: all of them for admins, only their own for everyone else.
+func (handler *ChoreHandler) awaitingApproval(ctx context.Context, user models.User) []models.Chore {
+	status := models.ChoreStatusAwaitingApproval
+	chores, err := handler.choreRepo.FindAll(ctx, repository.ChoreFilter{Status: &status})
+	if err != nil {
+		slog.Error("finding chores awaiting approval", "error", err)
+		return nil
+	}
+	if user.Role == models.RoleAdmin {
+		return chores
+	}
+	own := make([]models.Chore, 0, len(chores))
+	for _, chore := range chores {
+		if chore.CompletedByUserID != nil && *chore.CompletedByUserID == user.ID {
+			own = append(own, chore)
+		}
+	}
+	return own
+}
+
+// swapPanel loads the user's open swaps and the open chores due in the next
+// two weeks that can be offered or asked for in a new one.
+func (handler *ChoreHandler) swapPanel(ctx context.Context, user models.User) ([]pages.ChoreSwapView, []models.Chore) {
+	if handler.swapService == nil {
+		return nil, nil
+	}
+
+	swaps, err := handler.swapService.PendingSwaps(ctx, user.ID)
+	if err != nil {
+		slog.Error("finding swaps", "error", err)
+	}
+	views := make([]pages.ChoreSwapView, 0, len(swaps))
+	for _, swap := range swaps {
+		view := pages.ChoreSwapView{
+			Swap:     swap,
+			Incoming: swap.ToUserID == user.ID,
+		}
+		if chore, err := handler.choreRepo.FindByID(ctx, swap.ChoreID); err == nil {
+			view.Chore = chore
+		}
+		if swap.RequestedChoreID != nil {
+			if requested, err := handler.choreRepo.FindByID(ctx, *swap.RequestedChoreID); err == nil {
+				view.RequestedChore = &requested
+			}
+		}
+		views = append(views, view)
+	}
+
+	dueBefore := time.Now().AddDate(0, 0, 14)
+	candidates, err := handler.choreRepo.FindAll(ctx, repository.ChoreFilter{
+		Statuses:  []models.ChoreStatus{models.ChoreStatusPending, models.ChoreStatusOverdue},
+		DueBefore: &dueBefore,
+	})
+	if err != nil {
+		slog.Error("finding swap candidates", "error", err)
+	}
+	return views, candidates
+}
+
+func (handler *ChoreHandler) ProposeSwap(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	var requestedChoreID *string
+	if value := r.FormValue("requested_chore_id"); value != "" {
+		requestedChoreID = &value
+	}
+
+	_, err := handler.swapService.ProposeSwap(ctx, user.ID, r.FormValue("chore_id"), r.FormValue("to_user_id"), requestedChoreID)
+	if err != nil {
+		slog.Error("proposing swap", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, "/chores", http.StatusFound)
+}
+
+func (handler *ChoreHandler) AcceptSwap(w http.ResponseWriter, r *http.Request) {
+	handler.answerSwap(w, r, handler.swapService.AcceptSwap)
+}
+
+func (handler *ChoreHandler) DeclineSwap(w http.ResponseWriter, r *http.Request) {
+	handler.answerSwap(w, r, handler.swapService.DeclineSwap)
+}
+
+func (handler *ChoreHandler) CancelSwap(w http.ResponseWriter, r *http.Request) {
+	handler.answerSwap(w, r, handler.swapService.CancelSwap)
+}
+
+func (handler *ChoreHandler) answerSwap(w http.ResponseWriter, r *http.Request, answer swapAnswer) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+	swapID := chi.URLParam(r, "id")
+
+	if err := answer(ctx, swapID, user.ID); err != nil {
+		slog.Error("answering swap", "error", err, "swap_id", swapID)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, "/chores", http.StatusFound)
+}
+
 func (handler *ChoreHandler) Detail(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	choreID := chi.URLParam(r, "id")
@@ -524,7 +874,168 @@ func (handler *ChoreHandler) Detail(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	component := pages.ChoreDetailFragment(chore, assignedToName, assignedToAvatar, categoryName)
+	checklist, err := handler.choreService.Checklist(ctx, choreID)
+	if err != nil {
+		slog.Error("getting checklist", "error", err, "chore_id", choreID)
+	}
+	chore.Checklist = checklist
+
+	escalations, err := handler.choreService.Escalations(ctx, choreID)
+	if err != nil {
+		slog.Error("getting escalations", "error", err, "chore_id", choreID)
+	}
+	chore.Escalations = escalations
+	timer, err := handler.choreService.Timer(ctx, chore)
+	if err != nil {
+		slog.Error("getting chore timer", "error", err, "chore_id", choreID)
+	}
+	userNames, _ := handler.userMaps(ctx)
+
+	component := pages.ChoreDetailFragment(chore, assignedToName, assignedToAvatar, categoryName, userNames, timer)
+	component.Render(ctx, w)
+}
+
+// ToggleChecklistItem ticks or clears a checklist item (form field
+// checked=true|false). HTMX requests get the refreshed checklist back.
+func (handler *ChoreHandler) ToggleChecklistItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+	choreID := chi.URLParam(r, "id")
+
+	checked := r.FormValue("checked") == "true"
+	if err := handler.choreService.TickChecklistItem(ctx, choreID, chi.URLParam(r, "itemID"), user.ID, checked); err != nil {
+		slog.Error("ticking checklist item", "error", err, "chore_id", choreID)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if isHTMXRequest(r) {
+		chore, err := handler.choreRepo.FindByID(ctx, choreID)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		chore.Checklist, _ = handler.choreService.Checklist(ctx, choreID)
+		component := pages.ChoreChecklist(chore)
+		component.Render(ctx, w)
+		return
+	}
+
+	http.Redirect(w, r, "/chores", http.StatusFound)
+}
+
+// TimerPage renders the full-screen timer for an occurrence, with its
+// checklist alongside so it can be worked through while timing.
+func (handler *ChoreHandler) TimerPage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+	choreID := chi.URLParam(r, "id")
+
+	chore, err := handler.choreRepo.FindByID(ctx, choreID)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	checklist, err := handler.choreService.Checklist(ctx, choreID)
+	if err != nil {
+		slog.Error("getting checklist", "error", err, "chore_id", choreID)
+	}
+	chore.Checklist = checklist
+
+	timer, err := handler.choreService.Timer(ctx, chore)
+	if err != nil {
+		slog.Error("getting chore timer", "error", err, "chore_id", choreID)
+	}
+	userNames, _ := handler.userMaps(ctx)
+
+	component := pages.ChoreTimer(pages.ChoreTimerProps{
+		User:      user,
+		Chore:     chore,
+		Timer:     timer,
+		UserNames: userNames,
+	})
+	component.Render(ctx, w)
+}
+
+// TimerAction starts, pauses or stops the timer named by the action URL
+// parameter and returns to the timer page. A start or pause that finds the
+// timer already in that state, as from a double-tap, is not an error.
+func (handler *ChoreHandler) TimerAction(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+	choreID := chi.URLParam(r, "id")
+
+	var err error
+	switch chi.URLParam(r, "action") {
+	case "start":
+		err = handler.choreService.StartTimer(ctx, choreID, user.ID)
+	case "pause":
+		err = handler.choreService.PauseTimer(ctx, choreID, user.ID)
+	case "stop":
+		err = handler.choreService.StopTimer(ctx, choreID)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil && !errors.Is(err, services.ErrTimerRunning) && !errors.Is(err, services.ErrTimerNotRunning) {
+		slog.Error("updating chore timer", "error", err, "chore_id", choreID)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/chores/%s/timer", choreID), http.StatusFound)
+}
+
+// Preview renders the next occurrences the chore form would generate and who
+// each would go to, for the form to show as it is filled in. ?chore_id= on an
+// edit continues that chore's series rotation. A form that cannot be
+// previewed yet gets the reason in place of the list.
+func (handler *ChoreHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+
+	recurrenceType := models.RecurrenceType(r.FormValue("recurrence_type"))
+	draft := models.Chore{
+		RecurrenceType:  recurrenceType,
+		RecurrenceValue: buildRecurrenceValue(recurrenceType, r),
+		RecurOnComplete: r.FormValue("recur_on_complete") == "on",
+	}
+	draft.RecurrenceUntil, draft.RecurrenceCount = parseRecurrenceEnd(r)
+
+	var occurrences []services.PreviewOccurrence
+	err := handler.applyFormRecurrenceSource(&draft, recurrenceType, r)
+	if err == nil {
+		err = formAssignmentSettings(&draft, r)
+	}
+	if err == nil {
+		err = formGroupSettings(&draft, r)
+	}
+	var seasons []models.SeasonWindow
+	if err == nil {
+		seasons, err = services.ParseSeasons(r.FormValue("seasons"))
+	}
+	if err == nil {
+		if dueDate, parseErr := time.Parse(DateFormat, r.FormValue("due_date")); parseErr == nil {
+			draft.DueDate = &dueDate
+		}
+		if choreID := r.URL.Query().Get("chore_id"); choreID != "" {
+			if chore, findErr := handler.choreRepo.FindByID(ctx, choreID); findErr == nil {
+				draft.SeriesID = chore.SeriesID
+			}
+		}
+		occurrences, err = handler.choreService.PreviewSeries(ctx, draft, r.Form["assignees"], seasons, services.DefaultPreviewOccurrences)
+	}
+
+	message := ""
+	if err != nil {
+		message = err.Error()
+	}
+	userNames, _ := handler.userMaps(ctx)
+	component := pages.ChorePreview(occurrences, userNames, message)
 	component.Render(ctx, w)
 }
 
@@ -533,20 +1044,10 @@ type recurrenceConfigJSON struct {
 	Unit       string   `json:"unit,omitempty"`
 	Days       []string `json:"days,omitempty"`
 	DayOfMonth int      `json:"day_of_month,omitempty"`
-}
 
-// recurrenceEndKey renders the end conditions as a comparable string so an edit
-// to either can be detected (to trigger a re-seed).
-func recurrenceEndKey(until *time.Time, count *int) string {
-	key := "until="
-	if until != nil {
-		key += until.Format(DateFormat)
-	}
-	key += ";count="
-	if count != nil {
-		key += strconv.Itoa(*count)
-	}
-	return key
+	SubscriptionID string `json:"subscription_id,omitempty"`
+	Summary        string `json:"summary,omitempty"`
+	LeadHours      int    `json:"lead_hours,omitempty"`
 }
 
 // parseRecurrenceEnd reads the optional recurrence end conditions from the form.
@@ -570,6 +1071,226 @@ func parseRecurrenceEnd(r *http.Request) (*time.Time, *int) {
 	return until, count
 }
 
+// recurrenceTypeRule is the form's "Advanced (RRULE)" choice. It is never
+// stored: the series' recurrence type is derived from the rule's FREQ.
+const recurrenceTypeRule models.RecurrenceType = "rrule"
+
+// applyRecurrenceRule validates an RRULE and stores it on the chore. The rule's
+// COUNT and UNTIL are lifted into the chore's own end conditions (unless those
+// are already set) so the end of a series lives in one place, and the simple
+// recurrence type is derived from FREQ.
+func applyRecurrenceRule(chore *models.Chore, raw string) error {
+	rule, err := services.ParseRRule(raw)
+	if err != nil {
+		return err
+	}
+	if rule.Count > 0 && chore.RecurrenceCount == nil {
+		count := rule.Count
+		chore.RecurrenceCount = &count
+	}
+	if rule.Until != nil && chore.RecurrenceUntil == nil {
+		chore.RecurrenceUntil = rule.Until
+	}
+	rule.Count = 0
+	rule.Until = nil
+
+	chore.RecurrenceType = rule.RecurrenceType()
+	chore.RecurrenceValue = ""
+	chore.RecurrenceRule = rule.String()
+	return nil
+}
+
+// applyCalendarLink points a `calendar` series at an iCal subscription, which
+// must exist. Its occurrences follow the feed, so recur-on-complete does not
+// apply.
+func applyCalendarLink(ctx context.Context, subscriptions repository.ICalSubscriptionRepository, chore *models.Chore, subscriptionID, summary string, leadHours int) error {
+	if subscriptionID == "" {
+		return errors.New("calendar recurrence needs a calendar subscription")
+	}
+	if leadHours < 0 {
+		return errors.New("hours before the event cannot be negative")
+	}
+	if _, err := subscriptions.FindByID(ctx, subscriptionID); errors.Is(err, sql.ErrNoRows) {
+		return errors.New("unknown calendar subscription")
+	} else if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(recurrenceConfigJSON{
+		SubscriptionID: subscriptionID,
+		Summary:        strings.TrimSpace(summary),
+		LeadHours:      leadHours,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding calendar recurrence: %w", err)
+	}
+
+	chore.RecurrenceType = models.RecurrenceCalendar
+	chore.RecurrenceValue = string(data)
+	chore.RecurrenceRule = ""
+	chore.RecurOnComplete = false
+	return nil
+}
+
+// applyAssignmentSettings validates and stores how the chore's series picks
+// assignees. An empty strategy means round-robin; the fixed strategy needs an
+// owner. Effort points below one are raised to one.
+func applyAssignmentSettings(chore *models.Chore, strategy models.AssignmentStrategy, fixedAssigneeID string, effortPoints int) error {
+	switch strategy {
+	case "":
+		strategy = models.AssignmentRoundRobin
+	case models.AssignmentRoundRobin, models.AssignmentLeastLoaded, models.AssignmentEffortWeighted,
+		models.AssignmentRandom, models.AssignmentFixed:
+	default:
+		return fmt.Errorf("unknown assignment strategy %q", strategy)
+	}
+
+	chore.FixedAssigneeUserID = nil
+	if strategy == models.AssignmentFixed {
+		if fixedAssigneeID == "" {
+			return errors.New("a fixed assignment strategy needs an owner")
+		}
+		chore.FixedAssigneeUserID = &fixedAssigneeID
+	}
+
+	if effortPoints < 1 {
+		effortPoints = 1
+	}
+	chore.AssignmentStrategy = strategy
+	chore.EffortPoints = effortPoints
+	return nil
+}
+
+// formAssignmentSettings applies the chore form's assignment fields.
+func formAssignmentSettings(chore *models.Chore, r *http.Request) error {
+	effortPoints, _ := strconv.Atoi(r.FormValue("effort_points"))
+	return applyAssignmentSettings(chore,
+		models.AssignmentStrategy(r.FormValue("assignment_strategy")),
+		r.FormValue("fixed_assignee_id"),
+		effortPoints,
+	)
+}
+
+// applyGroupSettings makes the chore a group chore, or not. quorum is how
+// many participants must do their part, 0 meaning all of them.
+func applyGroupSettings(chore *models.Chore, group bool, quorum int) error {
+	if quorum < 0 {
+		return errors.New("the number of people needed cannot be negative")
+	}
+	chore.GroupChore = group
+	chore.GroupQuorum = 0
+	if group {
+		chore.GroupQuorum = quorum
+	}
+	return nil
+}
+
+// formGroupSettings applies the chore form's group chore fields.
+func formGroupSettings(chore *models.Chore, r *http.Request) error {
+	quorum := 0
+	if quorumStr := r.FormValue("group_quorum"); quorumStr != "" {
+		parsed, err := strconv.Atoi(quorumStr)
+		if err != nil {
+			return errors.New("the number of people needed must be a number")
+		}
+		quorum = parsed
+	}
+	return applyGroupSettings(chore, r.FormValue("group_chore") == "on", quorum)
+}
+
+// applyEscalationSettings sets the chore's escalation policy: remind the
+// assignee after remindHours overdue and hand the chore on after
+// reassignHours, 0 turning either off. A reminder after the chore has already
+// been handed on would never be sent, so it must come first.
+func applyEscalationSettings(chore *models.Chore, remindHours, reassignHours int, notifyAdmins bool) error {
+	if remindHours < 0 || reassignHours < 0 {
+		return errors.New("hours overdue cannot be negative")
+	}
+	if remindHours > 0 && reassignHours > 0 && remindHours >= reassignHours {
+		return errors.New("the reminder must come before the chore is reassigned")
+	}
+	chore.EscalationRemindHours = remindHours
+	chore.EscalationReassignHours = reassignHours
+	chore.EscalationNotifyAdmins = notifyAdmins && (remindHours > 0 || reassignHours > 0)
+	return nil
+}
+
+// formEscalationSettings applies the chore form's escalation fields.
+func formEscalationSettings(chore *models.Chore, r *http.Request) error {
+	hours := make([]int, 2)
+	for i, field := range []string{"escalation_remind_hours", "escalation_reassign_hours"} {
+		if value := r.FormValue(field); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return errors.New("hours overdue must be a number")
+			}
+			hours[i] = parsed
+		}
+	}
+	return applyEscalationSettings(chore, hours[0], hours[1], r.FormValue("escalation_notify_admins") == "on")
+}
+
+// formPrerequisites reads the series the chore waits on and how many minutes
+// after they are done it falls due.
+func formPrerequisites(chore *models.Chore, r *http.Request) error {
+	chore.Prerequisites = r.Form["prerequisites"]
+	chore.PrerequisiteDelayMinutes = 0
+	if delayStr := r.FormValue("prerequisite_delay_minutes"); delayStr != "" {
+		delay, err := strconv.Atoi(delayStr)
+		if err != nil || delay < 0 {
+			return errors.New("minutes after the prerequisite must be a positive number")
+		}
+		chore.PrerequisiteDelayMinutes = delay
+	}
+	return nil
+}
+
+// formChecklist reads the chore form's checklist, one item per line.
+func formChecklist(r *http.Request) []string {
+	return strings.Split(r.FormValue("checklist"), "\n")
+}
+
+// applyFormRecurrenceSource handles the recurrence choices that are not plain
+// structured config: an RRULE or a linked calendar feed.
+func (handler *ChoreHandler) applyFormRecurrenceSource(chore *models.Chore, recurrenceType models.RecurrenceType, r *http.Request) error {
+	switch recurrenceType {
+	case recurrenceTypeRule:
+		return applyRecurrenceRule(chore, r.FormValue("recurrence_rule"))
+	case models.RecurrenceCalendar:
+		leadHours := 0
+		if leadStr := r.FormValue("recurrence_lead_hours"); leadStr != "" {
+			parsed, err := strconv.Atoi(leadStr)
+			if err != nil {
+				return errors.New("hours before the event must be a number")
+			}
+			leadHours = parsed
+		}
+		return applyCalendarLink(r.Context(), handler.icalSubRepo, chore, r.FormValue("recurrence_calendar_id"), r.FormValue("recurrence_calendar_summary"), leadHours)
+	}
+	return nil
+}
+
+// activeSeries lists the series a chore can name as prerequisites.
+func (handler *ChoreHandler) activeSeries(ctx context.Context) []models.ChoreSeries {
+	series, err := handler.choreService.ActiveSeries(ctx)
+	if err != nil {
+		slog.Error("finding chore series", "error", err)
+	}
+	return series
+}
+
+// calendarSubscriptions lists the feeds a `calendar` series can follow.
+func (handler *ChoreHandler) calendarSubscriptions(ctx context.Context) []models.ICalSubscription {
+	if handler.icalSubRepo == nil {
+		return nil
+	}
+	subscriptions, err := handler.icalSubRepo.FindAll(ctx)
+	if err != nil {
+		slog.Error("finding calendar subscriptions", "error", err)
+	}
+	return subscriptions
+}
+
 func buildRecurrenceValue(recurrenceType models.RecurrenceType, r *http.Request) string {
 	interval := 0
 	if intervalStr := r.FormValue("recurrence_interval"); intervalStr != "" {
@@ -15,8 +15,12 @@ import (
 )
 
 type UserStat struct {
+	UserID           string
 	UserName         string
 	UserAvatarURL    string
+	PointsWeek       int
+	PointsMonth      int
+	PointsAllTime    int
 	CompletedWeek    int
 	CompletedMonth   int
 	CompletedAllTime int
@@ -28,6 +32,7 @@ type DashboardHandler struct {
 	icalFetcher    *services.ICalFetcher
 	userRepo       repository.UserRepository
 	assignmentRepo repository.ChoreAssignmentRepository
+	pointsRepo     repository.PointsRepository
 	choreService   *services.ChoreService
 	mealPlanRepo   repository.MealPlanRepository
 	categoryRepo   repository.CategoryRepository
@@ -38,6 +43,7 @@ func NewDashboardHandler(
 	icalFetcher *services.ICalFetcher,
 	userRepo repository.UserRepository,
 	assignmentRepo repository.ChoreAssignmentRepository,
+	pointsRepo repository.PointsRepository,
 	choreService *services.ChoreService,
 	mealPlanRepo repository.MealPlanRepository,
 	categoryRepo repository.CategoryRepository,
@@ -47,6 +53,7 @@ func NewDashboardHandler(
 		icalFetcher:    icalFetcher,
 		userRepo:       userRepo,
 		assignmentRepo: assignmentRepo,
+		pointsRepo:     pointsRepo,
 		choreService:   choreService,
 		mealPlanRepo:   mealPlanRepo,
 		categoryRepo:   categoryRepo,
@@ -56,14 +63,15 @@ func NewDashboardHandler(
 func (handler *DashboardHandler) Dashboard(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	user := middleware.GetUser(ctx)
-	now := time.Now()
+	location := middleware.GetLocation(ctx)
+	now := time.Now().In(location)
 
-	choresDueToday, err := handler.choreRepo.FindDueToday(ctx)
+	choresDueToday, err := handler.choreRepo.FindDueToday(ctx, now)
 	if err != nil {
 		slog.Error("finding chores due today", "error", err)
 	}
 
-	overdueChores, err := handler.choreRepo.FindOverdueChores(ctx)
+	overdueChores, err := handler.choreRepo.FindOverdueChores(ctx, now)
 	if err != nil {
 		slog.Error("finding overdue chores", "error", err)
 	}
@@ -101,8 +109,9 @@ func (handler *DashboardHandler) Dashboard(w http.ResponseWriter, r *http.Reques
 	if len(upcomingEvents) > 7 {
 		upcomingEvents = upcomingEvents[:7]
 	}
+	upcomingEvents = eventsInLocation(upcomingEvents, location)
 
-	startOfToday := now.Truncate(24 * time.Hour)
+	startOfToday := repository.CivilDate(now)
 	sevenDaysOut := startOfToday.AddDate(0, 0, 7)
 	mealsThisWeek, err := handler.mealPlanRepo.FindAll(ctx, repository.MealPlanFilter{
 		DateFrom: startOfToday.Format(DateFormat),
@@ -129,10 +138,11 @@ func (handler *DashboardHandler) Dashboard(w http.ResponseWriter, r *http.Reques
 		userAvatarMap[u.ID] = u.AvatarURL
 	}
 
-	userStats := handler.collectUserStats(ctx, users)
+	userStats := collectUserStats(ctx, users, handler.choreRepo, handler.assignmentRepo, handler.pointsRepo)
 
 	component := pages.Dashboard(pages.DashboardProps{
 		User:               user,
+		Today:              now,
 		ActiveChoreCount:   len(activeChores),
 		OverdueCount:       overdueCount,
 		UpcomingEventCount: len(upcomingEvents),
@@ -163,7 +173,7 @@ func (handler *DashboardHandler) Leaderboard(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	userStats := handler.collectUserStats(ctx, users)
+	userStats := collectUserStats(ctx, users, handler.choreRepo, handler.assignmentRepo, handler.pointsRepo)
 
 	component := pages.LeaderboardTable(pages.LeaderboardProps{
 		UserStats: convertUserStats(userStats, period),
@@ -172,26 +182,42 @@ func (handler *DashboardHandler) Leaderboard(w http.ResponseWriter, r *http.Requ
 	component.Render(ctx, w)
 }
 
-func (handler *DashboardHandler) collectUserStats(ctx context.Context, users []models.User) []UserStat {
+// collectUserStats gathers each user's leaderboard figures: points earned and
+// chores completed over the last week, month and all time, plus their pending
+// chores.
+func collectUserStats(
+	ctx context.Context,
+	users []models.User,
+	choreRepo repository.ChoreRepository,
+	assignmentRepo repository.ChoreAssignmentRepository,
+	pointsRepo repository.PointsRepository,
+) []UserStat {
 	now := time.Now()
 	weekAgo := now.AddDate(0, 0, -7)
 	monthAgo := now.AddDate(0, -1, 0)
 
 	userStats := make([]UserStat, 0, len(users))
 	for _, u := range users {
-		completedWeek, _ := handler.assignmentRepo.CompletedCountByUser(ctx, u.ID, weekAgo)
-		completedMonth, _ := handler.assignmentRepo.CompletedCountByUser(ctx, u.ID, monthAgo)
-		completedAllTime, _ := handler.assignmentRepo.CompletedCountByUser(ctx, u.ID, time.Time{})
+		pointsWeek, _ := pointsRepo.EarnedSince(ctx, u.ID, weekAgo)
+		pointsMonth, _ := pointsRepo.EarnedSince(ctx, u.ID, monthAgo)
+		pointsAllTime, _ := pointsRepo.EarnedSince(ctx, u.ID, time.Time{})
+		completedWeek, _ := assignmentRepo.CompletedCountByUser(ctx, u.ID, weekAgo)
+		completedMonth, _ := assignmentRepo.CompletedCountByUser(ctx, u.ID, monthAgo)
+		completedAllTime, _ := assignmentRepo.CompletedCountByUser(ctx, u.ID, time.Time{})
 		pendingStatus := models.ChoreStatusPending
-		pendingChores, _ := handler.choreRepo.FindAll(ctx, repository.ChoreFilter{
+		pendingChores, _ := choreRepo.FindAll(ctx, repository.ChoreFilter{
 			Status:            &pendingStatus,
 			AssignedToUser:    &u.ID,
 			OnlyNextPerSeries: true,
 		})
 
 		userStats = append(userStats, UserStat{
+			UserID:           u.ID,
 			UserName:         u.Name,
 			UserAvatarURL:    u.AvatarURL,
+			PointsWeek:       pointsWeek,
+			PointsMonth:      pointsMonth,
+			PointsAllTime:    pointsAllTime,
 			CompletedWeek:    completedWeek,
 			CompletedMonth:   completedMonth,
 			CompletedAllTime: completedAllTime,
@@ -201,17 +227,34 @@ func (handler *DashboardHandler) collectUserStats(ctx context.Context, users []m
 	return userStats
 }
 
-func convertUserStats(stats []UserStat, period string) []pages.UserStatProps {
-	sort.Slice(stats, func(i, j int) bool {
-		switch period {
-		case "month":
-			return stats[i].CompletedMonth > stats[j].CompletedMonth
-		case "alltime":
-			return stats[i].CompletedAllTime > stats[j].CompletedAllTime
-		default:
-			return stats[i].CompletedWeek > stats[j].CompletedWeek
+// rankUserStats orders stats by points earned in the period, highest first.
+// Completions break ties so equal points still favour whoever did more.
+func rankUserStats(stats []UserStat, period string) {
+	sort.SliceStable(stats, func(i, j int) bool {
+		pointsI, completedI := stats[i].forPeriod(period)
+		pointsJ, completedJ := stats[j].forPeriod(period)
+		if pointsI != pointsJ {
+			return pointsI > pointsJ
 		}
+		return completedI > completedJ
 	})
+}
+
+// forPeriod returns the points and completions for a leaderboard period
+// ("week", "month" or "alltime"; anything else means week).
+func (stat UserStat) forPeriod(period string) (points, completed int) {
+	switch period {
+	case "month":
+		return stat.PointsMonth, stat.CompletedMonth
+	case "alltime":
+		return stat.PointsAllTime, stat.CompletedAllTime
+	default:
+		return stat.PointsWeek, stat.CompletedWeek
+	}
+}
+
+func convertUserStats(stats []UserStat, period string) []pages.UserStatProps {
+	rankUserStats(stats, period)
 
 	var result []pages.UserStatProps
 	for index, stat := range stats {
@@ -219,6 +262,9 @@ func convertUserStats(stats []UserStat, period string) []pages.UserStatProps {
 			Rank:             index + 1,
 			UserName:         stat.UserName,
 			UserAvatarURL:    stat.UserAvatarURL,
+			PointsWeek:       stat.PointsWeek,
+			PointsMonth:      stat.PointsMonth,
+			PointsAllTime:    stat.PointsAllTime,
 			CompletedWeek:    stat.CompletedWeek,
 			CompletedMonth:   stat.CompletedMonth,
 			CompletedAllTime: stat.CompletedAllTime,
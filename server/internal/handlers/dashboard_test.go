@@ -24,7 +24,7 @@ func setupDashboardHandler(t *testing.T) (*DashboardHandler, models.User, *repos
 	assignmentRepo := repository.NewChoreAssignmentRepository(database)
 	mealPlanRepo := repository.NewMealPlanRepository(database)
 	categoryRepo := repository.NewCategoryRepository(database)
-	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, repository.NewChoreSeriesRepository(database))
+	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, repository.NewChoreSeriesRepository(database), nil, nil, nil, nil, nil, nil, nil, nil)
 	icalFetcher := services.NewICalFetcher(icalSubRepo)
 
 	user, err := userRepo.Create(context.Background(), models.User{
@@ -37,7 +37,7 @@ func setupDashboardHandler(t *testing.T) (*DashboardHandler, models.User, *repos
 		t.Fatalf("creating test user: %v", err)
 	}
 
-	handler := NewDashboardHandler(choreRepo, icalFetcher, userRepo, assignmentRepo, choreService, mealPlanRepo, categoryRepo)
+	handler := NewDashboardHandler(choreRepo, icalFetcher, userRepo, assignmentRepo, repository.NewPointsRepository(database), choreService, mealPlanRepo, categoryRepo)
 	return handler, user, choreRepo
 }
 
@@ -1,9 +1,31 @@
 package handlers
 
-import "net/http"
+import (
+	"net/http"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+)
 
 const DateFormat = "2006-01-02"
 
 func isHTMXRequest(r *http.Request) bool {
 	return r.Header.Get("HX-Request") == "true"
 }
+
+// eventsInLocation converts timed events to location so they are shown and
+// grouped by day in the viewer's timezone. All-day events are dates, not
+// instants, and are left alone.
+func eventsInLocation(events []models.Event, location *time.Location) []models.Event {
+	for index := range events {
+		if events[index].AllDay {
+			continue
+		}
+		events[index].StartTime = events[index].StartTime.In(location)
+		if events[index].EndTime != nil {
+			end := events[index].EndTime.In(location)
+			events[index].EndTime = &end
+		}
+	}
+	return events
+}
@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/bensuskins/family-hub/internal/middleware"
 	"github.com/bensuskins/family-hub/internal/models"
@@ -14,12 +16,13 @@ import (
 )
 
 type ICalSubscriptionsHandler struct {
-	subRepo repository.ICalSubscriptionRepository
-	fetcher *services.ICalFetcher
+	subRepo      repository.ICalSubscriptionRepository
+	fetcher      *services.ICalFetcher
+	choreService *services.ChoreService
 }
 
-func NewICalSubscriptionsHandler(subRepo repository.ICalSubscriptionRepository, fetcher *services.ICalFetcher) *ICalSubscriptionsHandler {
-	return &ICalSubscriptionsHandler{subRepo: subRepo, fetcher: fetcher}
+func NewICalSubscriptionsHandler(subRepo repository.ICalSubscriptionRepository, fetcher *services.ICalFetcher, choreService *services.ChoreService) *ICalSubscriptionsHandler {
+	return &ICalSubscriptionsHandler{subRepo: subRepo, fetcher: fetcher, choreService: choreService}
 }
 
 func (handler *ICalSubscriptionsHandler) List(w http.ResponseWriter, r *http.Request) {
@@ -76,10 +79,27 @@ func (handler *ICalSubscriptionsHandler) Create(w http.ResponseWriter, r *http.R
 	http.Redirect(w, r, "/calendars", http.StatusSeeOther)
 }
 
+// Delete removes a subscription. A calendar that chore series still follow is
+// kept, since those series would stop getting occurrences without notice.
 func (handler *ICalSubscriptionsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	id := chi.URLParam(r, "id")
 
+	linked, err := handler.choreService.CalendarSeries(ctx, id)
+	if err != nil {
+		slog.Error("finding series following ical subscription", "id", id, "error", err)
+		http.Error(w, "Error deleting subscription", http.StatusInternalServerError)
+		return
+	}
+	if len(linked) > 0 {
+		names := make([]string, 0, len(linked))
+		for _, series := range linked {
+			names = append(names, series.Name)
+		}
+		http.Error(w, fmt.Sprintf("These chores follow this calendar: %s. Change their schedule or stop them before deleting it.", strings.Join(names, ", ")), http.StatusConflict)
+		return
+	}
+
 	if err := handler.subRepo.Delete(ctx, id); err != nil {
 		slog.Error("deleting ical subscription", "error", err)
 		http.Error(w, "Error deleting subscription", http.StatusInternalServerError)
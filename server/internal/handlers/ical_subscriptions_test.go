@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestICalSubscriptions_CalendarSeriesNeedAKnownFeed(t *testing.T) {
+	database := testutil.NewTestDatabase(t)
+	choreRepo := repository.NewChoreRepository(database)
+	userRepo := repository.NewUserRepository(database)
+	assignmentRepo := repository.NewChoreAssignmentRepository(database)
+	subRepo := repository.NewICalSubscriptionRepository(database)
+	ctx := t.Context()
+
+	admin, _ := userRepo.Create(ctx, models.User{OIDCSubject: "sub-admin", Email: "admin@example.com", Name: "Admin", Role: models.RoleAdmin})
+	if err := subRepo.Create(ctx, models.ICalSubscription{ID: "school", Name: "School", URL: "https://school.example.com/term.ics", Color: "indigo"}); err != nil {
+		t.Fatalf("creating subscription: %v", err)
+	}
+
+	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, repository.NewChoreSeriesRepository(database), nil, nil, nil, nil, nil, nil, nil, nil)
+	apiHandler := NewAPIHandler(choreRepo, userRepo, nil, assignmentRepo, nil, nil, choreService, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, subRepo, "", "", "")
+	subHandler := NewICalSubscriptionsHandler(subRepo, services.NewICalFetcher(subRepo), choreService)
+
+	router := chi.NewRouter()
+	router.Post("/api/chores", apiHandler.CreateChore)
+	router.Post("/calendars/{id}/delete", subHandler.Delete)
+	post := func(path, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, requestWithUser(request, admin))
+		return recorder
+	}
+
+	chore := `{"name":"Pack the PE kit","recurrenceType":"calendar","calendarSubscriptionId":"%s","assignees":["` + admin.ID + `"]}`
+	if w := post("/api/chores", strings.Replace(chore, "%s", "missing", 1)); w.Code != http.StatusBadRequest {
+		t.Errorf("expected an unknown subscription refused with 400, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := post("/api/chores", strings.Replace(chore, "%s", "school", 1)); w.Code != http.StatusCreated {
+		t.Fatalf("expected the chore created, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w := post("/calendars/school/delete", "")
+	if w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), "Pack the PE kit") {
+		t.Errorf("expected the delete refused naming the chore, got %d: %s", w.Code, w.Body.String())
+	}
+	if _, err := subRepo.FindByID(ctx, "school"); err != nil {
+		t.Errorf("expected the subscription kept, got %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/base64"
+	"errors"
 	"io"
 	"net/http"
 	"strings"
@@ -77,3 +78,20 @@ func readUploadedImage(w http.ResponseWriter, r *http.Request, field string, max
 	}
 	return imageBytes, contentType, true
 }
+
+// readOptionalUploadedImage is readUploadedImage for forms where the image may
+// be left out: a plain form post or a multipart one without the file returns
+// ok=true with no bytes.
+func readOptionalUploadedImage(w http.ResponseWriter, r *http.Request, field string, maxBytes int) (imageBytes []byte, contentType string, ok bool) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		return nil, "", true
+	}
+	if err := r.ParseMultipartForm(int64(maxBytes) + 1024); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return nil, "", false
+	}
+	if _, _, err := r.FormFile(field); errors.Is(err, http.ErrMissingFile) {
+		return nil, "", true
+	}
+	return readUploadedImage(w, r, field, maxBytes)
+}
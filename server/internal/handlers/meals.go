@@ -9,23 +9,25 @@ import (
 	"github.com/bensuskins/family-hub/internal/middleware"
 	"github.com/bensuskins/family-hub/internal/models"
 	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
 	"github.com/bensuskins/family-hub/templates/pages"
 )
 
 type MealHandler struct {
 	mealPlanRepo repository.MealPlanRepository
 	recipeRepo   repository.RecipeRepository
+	webhooks     *services.WebhookService
 }
 
-func NewMealHandler(mealPlanRepo repository.MealPlanRepository, recipeRepo repository.RecipeRepository) *MealHandler {
-	return &MealHandler{mealPlanRepo: mealPlanRepo, recipeRepo: recipeRepo}
+func NewMealHandler(mealPlanRepo repository.MealPlanRepository, recipeRepo repository.RecipeRepository, webhooks *services.WebhookService) *MealHandler {
+	return &MealHandler{mealPlanRepo: mealPlanRepo, recipeRepo: recipeRepo, webhooks: webhooks}
 }
 
 func (handler *MealHandler) Planner(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	user := middleware.GetUser(ctx)
 
-	weekStart := lastFriday(time.Now())
+	weekStart := lastFriday(time.Now().In(middleware.GetLocation(ctx)))
 	if weekStartStr := r.URL.Query().Get("week_start"); weekStartStr != "" {
 		if parsed, err := time.Parse(DateFormat, weekStartStr); err == nil {
 			weekStart = parsed
@@ -104,6 +106,8 @@ func (handler *MealHandler) SaveMeal(w http.ResponseWriter, r *http.Request) {
 	saved, err := handler.mealPlanRepo.FindByDateAndType(ctx, date, mealType)
 	if err != nil {
 		slog.Error("finding saved meal", "error", err)
+	} else {
+		handler.webhooks.Emit(ctx, models.WebhookMealPlanned, saved)
 	}
 
 	pages.MealSlotOOB(date, mealType, &saved).Render(ctx, w)
@@ -1,11 +1,16 @@
 package handlers
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/bensuskins/family-hub/internal/middleware"
+	"github.com/bensuskins/family-hub/internal/models"
 	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
 	"github.com/bensuskins/family-hub/templates/pages"
 	"github.com/go-chi/chi/v5"
 )
@@ -13,11 +18,21 @@ import (
 const maxAvatarBytes = 2 * 1024 * 1024 // 2 MB
 
 type ProfileHandler struct {
-	userRepo repository.UserRepository
+	userRepo      repository.UserRepository
+	choreService  *services.ChoreService
+	notifications *services.NotificationService
+	digests       *services.DigestService
+	webPush       *services.WebPushService
 }
 
-func NewProfileHandler(userRepo repository.UserRepository) *ProfileHandler {
-	return &ProfileHandler{userRepo: userRepo}
+func NewProfileHandler(userRepo repository.UserRepository, choreService *services.ChoreService, notifications *services.NotificationService, digests *services.DigestService, webPush *services.WebPushService) *ProfileHandler {
+	return &ProfileHandler{
+		userRepo:      userRepo,
+		choreService:  choreService,
+		notifications: notifications,
+		digests:       digests,
+		webPush:       webPush,
+	}
 }
 
 func (handler *ProfileHandler) Page(w http.ResponseWriter, r *http.Request) {
@@ -29,13 +44,260 @@ func (handler *ProfileHandler) Page(w http.ResponseWriter, r *http.Request) {
 		slog.Error("finding avatar data", "error", err)
 	}
 
+	today := time.Now().In(middleware.GetLocation(ctx)).Format(DateFormat)
+	filter := repository.UnavailabilityFilter{EndingFrom: &today}
+	if user.Role != models.RoleAdmin {
+		filter.UserID = &user.ID
+	}
+	away, err := handler.choreService.Unavailability(ctx, filter)
+	if err != nil {
+		slog.Error("finding away windows", "error", err)
+	}
+
+	members, err := handler.userRepo.FindAll(ctx)
+	if err != nil {
+		slog.Error("finding users", "error", err)
+	}
+	userNames := make(map[string]string, len(members))
+	for _, member := range members {
+		userNames[member.ID] = member.Name
+	}
+
+	channels, err := handler.notifications.Channels(ctx, user.ID)
+	if err != nil {
+		slog.Error("finding notification channels", "error", err)
+	}
+
+	schedule, err := handler.digests.Schedule(ctx, user.ID)
+	if err != nil {
+		slog.Error("finding digest schedule", "error", err)
+	}
+
 	component := pages.Profile(pages.ProfileProps{
-		User:            user,
-		HasCustomAvatar: avatarData != "",
+		User:                 user,
+		HasCustomAvatar:      avatarData != "",
+		Away:                 away,
+		Members:              members,
+		UserNames:            userNames,
+		NotificationChannels: channels,
+		LatestDeliveries:     handler.notifications.LatestDeliveries(ctx, channels),
+		ChannelKinds:         handler.notifications.ChannelKinds(),
+		DigestSchedule:       schedule,
+		WebPushKey:           handler.webPush.PublicKey(),
+		PushDevices:          handler.webPush.Devices(ctx, user.ID),
 	})
 	component.Render(ctx, w)
 }
 
+// AddAway records an away window for the current user, or for user_id when
+// an admin fills it in on someone's behalf.
+func (handler *ProfileHandler) AddAway(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	_, err := handler.choreService.AddUnavailability(ctx, user, models.Unavailability{
+		UserID:    r.FormValue("user_id"),
+		StartDate: r.FormValue("start_date"),
+		EndDate:   r.FormValue("end_date"),
+		Reason:    r.FormValue("reason"),
+	})
+	if err != nil {
+		slog.Error("adding away window", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, "/profile", http.StatusFound)
+}
+
+func (handler *ProfileHandler) RemoveAway(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	if err := handler.choreService.RemoveUnavailability(ctx, user, chi.URLParam(r, "id")); err != nil {
+		slog.Error("removing away window", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, "/profile", http.StatusFound)
+}
+
+// UpdateTimezone sets or, when blank, clears the current user's timezone
+// override.
+func (handler *ProfileHandler) UpdateTimezone(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+
+	timezone := strings.TrimSpace(r.FormValue("timezone"))
+	if timezone != "" {
+		if _, err := services.ParseTimezone(timezone); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if err := handler.userRepo.UpdateTimezone(ctx, user.ID, timezone); err != nil {
+		slog.Error("updating timezone", "error", err)
+		http.Error(w, "Failed to save timezone", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/profile", http.StatusFound)
+}
+
+// AddChannel sets up a notification channel for the current user. It is
+// sent every event until they pick which.
+func (handler *ProfileHandler) AddChannel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	_, err := handler.notifications.AddChannel(ctx, models.NotificationChannel{
+		UserID: user.ID,
+		Kind:   models.ChannelKind(r.FormValue("kind")),
+		Target: strings.TrimSpace(r.FormValue("target")),
+		Token:  strings.TrimSpace(r.FormValue("token")),
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, "/profile", http.StatusFound)
+}
+
+// UpdateChannel saves which events one of the current user's channels is
+// sent, and its target. A blank token keeps the one it has.
+func (handler *ProfileHandler) UpdateChannel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+
+	channel, err := handler.notifications.Channel(ctx, user.ID, chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Channel not found", http.StatusNotFound)
+		return
+	}
+	channel.Target = strings.TrimSpace(r.FormValue("target"))
+	if token := strings.TrimSpace(r.FormValue("token")); token != "" {
+		channel.Token = token
+	}
+	channel.Events = []models.NotificationEvent{}
+	for _, event := range r.Form["events"] {
+		channel.Events = append(channel.Events, models.NotificationEvent(event))
+	}
+
+	if err := handler.notifications.UpdateChannel(ctx, user.ID, channel); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, "/profile", http.StatusFound)
+}
+
+func (handler *ProfileHandler) RemoveChannel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	if err := handler.notifications.RemoveChannel(ctx, user.ID, chi.URLParam(r, "id")); err != nil {
+		slog.Error("removing notification channel", "error", err)
+		http.Error(w, "Channel not found", http.StatusNotFound)
+		return
+	}
+
+	http.Redirect(w, r, "/profile", http.StatusFound)
+}
+
+// TestChannel queues a test message on one of the current user's channels.
+func (handler *ProfileHandler) TestChannel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	if err := handler.notifications.SendTest(ctx, user.ID, chi.URLParam(r, "id")); err != nil {
+		slog.Error("sending test notification", "error", err)
+		http.Error(w, "Channel not found", http.StatusNotFound)
+		return
+	}
+
+	http.Redirect(w, r, "/profile", http.StatusFound)
+}
+
+// UpdateDigestSchedule sets when the current user is sent their daily and
+// weekly digests; a blank time turns that digest off.
+func (handler *ProfileHandler) UpdateDigestSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+
+	dailyTime := strings.TrimSpace(r.FormValue("daily_time"))
+	weeklyTime := strings.TrimSpace(r.FormValue("weekly_time"))
+	if err := handler.digests.SaveSchedule(ctx, user.ID, dailyTime, weeklyTime); err != nil {
+		if errors.Is(err, services.ErrInvalidDigestTime) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		slog.Error("saving digest schedule", "error", err)
+		http.Error(w, "Failed to save digest times", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/profile", http.StatusFound)
+}
+
+// PreviewDigest shows the current user's digest as it stands now:
+// period=daily (default) or weekly, as format=html (default) or text.
+func (handler *ProfileHandler) PreviewDigest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	period := services.DigestPeriod(r.URL.Query().Get("period"))
+	if period == "" {
+		period = services.DigestDaily
+	}
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "html" && format != "text" {
+		http.Error(w, "format must be html or text", http.StatusBadRequest)
+		return
+	}
+
+	digest, err := handler.digests.Build(ctx, user, period, time.Now())
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidDigestPeriod) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		slog.Error("building digest preview", "error", err)
+		http.Error(w, "Failed to build digest", http.StatusInternalServerError)
+		return
+	}
+	htmlBody, textBody, err := handler.digests.Render(ctx, digest)
+	if err != nil {
+		slog.Error("rendering digest preview", "error", err)
+		http.Error(w, "Failed to render digest", http.StatusInternalServerError)
+		return
+	}
+
+	if format == "text" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte(textBody))
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write([]byte(htmlBody))
+}
+
 func (handler *ProfileHandler) Upload(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	user := middleware.GetUser(ctx)
@@ -13,7 +13,9 @@ import (
 	"github.com/bensuskins/family-hub/internal/middleware"
 	"github.com/bensuskins/family-hub/internal/models"
 	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
 	"github.com/bensuskins/family-hub/internal/testutil"
+	"github.com/bensuskins/family-hub/templates/email"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -30,7 +32,7 @@ func setupProfileHandler(t *testing.T) (*ProfileHandler, models.User, repository
 	if err != nil {
 		t.Fatalf("creating user: %v", err)
 	}
-	return NewProfileHandler(userRepo), user, userRepo
+	return NewProfileHandler(userRepo, nil, nil, nil, nil), user, userRepo
 }
 
 func multipartUpload(t *testing.T, fieldName, fileName string, content []byte) (*bytes.Buffer, string) {
@@ -165,3 +167,40 @@ func TestProfileHandler_Serve_Returns404WhenNoAvatar(t *testing.T) {
 		t.Errorf("expected 404, got %d", w.Code)
 	}
 }
+
+func TestProfileHandler_PreviewDigest(t *testing.T) {
+	database := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(database)
+	user, err := userRepo.Create(context.Background(), models.User{OIDCSubject: "sub-1", Email: "alice@test.com", Name: "Alice", Role: models.RoleMember})
+	if err != nil {
+		t.Fatalf("creating user: %v", err)
+	}
+	digests := services.NewDigestService(repository.NewDigestRepository(database), userRepo, repository.NewChoreRepository(database),
+		repository.NewMealPlanRepository(database), nil, nil, nil, email.RenderDigest, "https://hub.example.com")
+	handler := NewProfileHandler(userRepo, nil, nil, digests, nil)
+
+	preview := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/profile/digest/preview?"+query, nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserContextKey, user))
+		w := httptest.NewRecorder()
+		handler.PreviewDigest(w, req)
+		return w
+	}
+
+	w := preview("period=weekly&format=text")
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/plain") {
+		t.Fatalf("expected a plain text preview, got %d %s", w.Code, w.Header().Get("Content-Type"))
+	}
+	if !strings.HasPrefix(w.Body.String(), "The week ahead: ") {
+		t.Errorf("unexpected weekly preview:\n%s", w.Body.String())
+	}
+
+	w = preview("")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "Good morning, Alice.") || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/html") {
+		t.Errorf("expected the daily digest as HTML, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := preview("period=monthly"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown period, got %d", w.Code)
+	}
+}
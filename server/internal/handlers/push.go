@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/bensuskins/family-hub/internal/middleware"
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/go-chi/chi/v5"
+)
+
+// PushHandler lets a browser turn Web Push notifications on and off for the
+// signed-in user, and the iOS app register its devices for APNs.
+type PushHandler struct {
+	webPush *services.WebPushService
+	apns    *services.APNsService
+}
+
+func NewPushHandler(webPush *services.WebPushService, apns *services.APNsService) *PushHandler {
+	return &PushHandler{webPush: webPush, apns: apns}
+}
+
+// pushSubscriptionAPIBody is a browser's PushSubscription as its toJSON()
+// gives it.
+type pushSubscriptionAPIBody struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256DH string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+// PublicKey returns the VAPID key browsers subscribe with.
+func (handler *PushHandler) PublicKey(w http.ResponseWriter, r *http.Request) {
+	publicKey := handler.webPush.PublicKey()
+	if publicKey == "" {
+		writeJSONError(w, http.StatusServiceUnavailable, services.ErrChannelKindDisabled.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"publicKey": publicKey})
+}
+
+func (handler *PushHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	var body pushSubscriptionAPIBody
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	err := handler.webPush.Subscribe(ctx, models.PushSubscription{
+		UserID:    user.ID,
+		Endpoint:  body.Endpoint,
+		P256DH:    body.Keys.P256DH,
+		Auth:      body.Keys.Auth,
+		UserAgent: r.UserAgent(),
+	})
+	switch {
+	case errors.Is(err, services.ErrInvalidPushSubscription):
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrChannelKindDisabled):
+		writeJSONError(w, http.StatusServiceUnavailable, err.Error())
+	case err != nil:
+		slog.Error("subscribing to push", "user_id", user.ID, "error", err)
+		writeJSONError(w, http.StatusInternalServerError, "failed to subscribe")
+	default:
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+func (handler *PushHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	var body pushSubscriptionAPIBody
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if err := handler.webPush.Unsubscribe(ctx, user.ID, body.Endpoint); err != nil {
+		slog.Error("unsubscribing from push", "user_id", user.ID, "error", err)
+		writeJSONError(w, http.StatusInternalServerError, "failed to unsubscribe")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegisterDevice records the APNs device token the iOS app was given, for the
+// user it signed in as through the token exchange.
+func (handler *PushHandler) RegisterDevice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	var body struct {
+		Token string `json:"token"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	err := handler.apns.Register(ctx, user.ID, body.Token)
+	switch {
+	case errors.Is(err, services.ErrInvalidDeviceToken):
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrChannelKindDisabled):
+		writeJSONError(w, http.StatusServiceUnavailable, err.Error())
+	case err != nil:
+		slog.Error("registering APNs device", "user_id", user.ID, "error", err)
+		writeJSONError(w, http.StatusInternalServerError, "failed to register device")
+	default:
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+func (handler *PushHandler) UnregisterDevice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	if err := handler.apns.Unregister(ctx, user.ID, chi.URLParam(r, "token")); err != nil {
+		slog.Error("unregistering APNs device", "user_id", user.ID, "error", err)
+		writeJSONError(w, http.StatusInternalServerError, "failed to unregister device")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bensuskins/family-hub/internal/middleware"
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestPushHandler_Subscriptions(t *testing.T) {
+	database := testutil.NewTestDatabase(t)
+	ctx := context.Background()
+	userRepo := repository.NewUserRepository(database)
+	pushRepo := repository.NewPushSubscriptionRepository(database)
+	key, err := services.LoadVAPIDKey(ctx, repository.NewSettingsRepository(database))
+	if err != nil {
+		t.Fatalf("LoadVAPIDKey: %v", err)
+	}
+	notificationRepo := repository.NewNotificationRepository(database)
+	deviceRepo := repository.NewAPNsDeviceRepository(database)
+	handler := NewPushHandler(services.NewWebPushService(pushRepo, notificationRepo, key), services.NewAPNsService(deviceRepo, notificationRepo, true))
+	user, _ := userRepo.Create(ctx, models.User{OIDCSubject: "sub-owner", Email: "owner@example.com", Name: "Owner", Role: models.RoleMember})
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		router := chi.NewRouter()
+		router.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), middleware.UserContextKey, user)))
+			})
+		})
+		router.Get("/api/push/vapid-public-key", handler.PublicKey)
+		router.Post("/api/push/subscriptions", handler.Subscribe)
+		router.Delete("/api/push/subscriptions", handler.Unsubscribe)
+		router.Post("/api/push/devices", handler.RegisterDevice)
+		router.Delete("/api/push/devices/{token}", handler.UnregisterDevice)
+
+		request := httptest.NewRequest(method, path, strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	recorder := serve(http.MethodGet, "/api/push/vapid-public-key", "")
+	var publicKey map[string]string
+	json.NewDecoder(recorder.Body).Decode(&publicKey)
+	if recorder.Code != http.StatusOK || publicKey["publicKey"] != services.VAPIDPublicKey(key) {
+		t.Fatalf("unexpected public key response %d %v", recorder.Code, publicKey)
+	}
+
+	browserKey, _ := ecdh.P256().GenerateKey(rand.Reader)
+	subscription := fmt.Sprintf(`{"endpoint":"https://push.example.com/send/abc","keys":{"p256dh":%q,"auth":%q}}`,
+		base64.RawURLEncoding.EncodeToString(browserKey.PublicKey().Bytes()),
+		base64.RawURLEncoding.EncodeToString(make([]byte, 16)),
+	)
+	if recorder := serve(http.MethodPost, "/api/push/subscriptions", subscription); recorder.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := serve(http.MethodPost, "/api/push/subscriptions", `{"endpoint":"https://push.example.com/send/abc","keys":{"p256dh":"nope","auth":"nope"}}`); recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for bad keys, got %d", recorder.Code)
+	}
+	if subscriptions, _ := pushRepo.FindByUser(ctx, user.ID); len(subscriptions) != 1 {
+		t.Fatalf("expected one subscription, got %+v", subscriptions)
+	}
+
+	if recorder := serve(http.MethodDelete, "/api/push/subscriptions", `{"endpoint":"https://push.example.com/send/abc"}`); recorder.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", recorder.Code)
+	}
+	if subscriptions, _ := pushRepo.FindByUser(ctx, user.ID); len(subscriptions) != 0 {
+		t.Errorf("expected the subscription removed, got %+v", subscriptions)
+	}
+
+	deviceToken := strings.Repeat("ab", 32)
+	if recorder := serve(http.MethodPost, "/api/push/devices", `{"token":"`+deviceToken+`"}`); recorder.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := serve(http.MethodPost, "/api/push/devices", `{"token":"xyz"}`); recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a bad token, got %d", recorder.Code)
+	}
+	channels, _ := notificationRepo.FindChannelsByUser(ctx, user.ID)
+	if len(channels) != 2 || !slices.ContainsFunc(channels, func(channel models.NotificationChannel) bool { return channel.Kind == models.ChannelAPNs }) {
+		t.Errorf("expected Web Push and APNs channels, got %+v", channels)
+	}
+	if recorder := serve(http.MethodDelete, "/api/push/devices/"+deviceToken, ""); recorder.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", recorder.Code)
+	}
+	if devices, _ := deviceRepo.FindByUser(ctx, user.ID); len(devices) != 0 {
+		t.Errorf("expected the device removed, got %+v", devices)
+	}
+}
@@ -22,10 +22,11 @@ type RecipeHandler struct {
 	categoryRepo    repository.CategoryRepository
 	mealPlanRepo    repository.MealPlanRepository
 	recipeExtractor *services.RecipeExtractor
+	webhooks        *services.WebhookService
 }
 
-func NewRecipeHandler(recipeRepo repository.RecipeRepository, categoryRepo repository.CategoryRepository, mealPlanRepo repository.MealPlanRepository, recipeExtractor *services.RecipeExtractor) *RecipeHandler {
-	return &RecipeHandler{recipeRepo: recipeRepo, categoryRepo: categoryRepo, mealPlanRepo: mealPlanRepo, recipeExtractor: recipeExtractor}
+func NewRecipeHandler(recipeRepo repository.RecipeRepository, categoryRepo repository.CategoryRepository, mealPlanRepo repository.MealPlanRepository, recipeExtractor *services.RecipeExtractor, webhooks *services.WebhookService) *RecipeHandler {
+	return &RecipeHandler{recipeRepo: recipeRepo, categoryRepo: categoryRepo, mealPlanRepo: mealPlanRepo, recipeExtractor: recipeExtractor, webhooks: webhooks}
 }
 
 func (handler *RecipeHandler) List(w http.ResponseWriter, r *http.Request) {
@@ -144,6 +145,7 @@ func (handler *RecipeHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	handler.saveFormImage(ctx, r, created.ID)
+	handler.webhooks.Emit(ctx, models.WebhookRecipeCreated, created)
 
 	http.Redirect(w, r, fmt.Sprintf("/recipes/%s", created.ID), http.StatusFound)
 }
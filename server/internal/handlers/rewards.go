@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/bensuskins/family-hub/internal/middleware"
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/templates/pages"
+	"github.com/go-chi/chi/v5"
+)
+
+type RewardHandler struct {
+	rewardService *services.RewardService
+	userRepo      repository.UserRepository
+}
+
+func NewRewardHandler(rewardService *services.RewardService, userRepo repository.UserRepository) *RewardHandler {
+	return &RewardHandler{
+		rewardService: rewardService,
+		userRepo:      userRepo,
+	}
+}
+
+func (handler *RewardHandler) Page(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+	isAdmin := user.Role == models.RoleAdmin
+
+	summary, err := handler.rewardService.Summary(ctx, user.ID)
+	if err != nil {
+		slog.Error("loading points summary", "error", err)
+	}
+	ledger, err := handler.rewardService.Ledger(ctx, user.ID, 20)
+	if err != nil {
+		slog.Error("loading points ledger", "error", err)
+	}
+	rewards, err := handler.rewardService.Catalogue(ctx, isAdmin)
+	if err != nil {
+		slog.Error("loading rewards", "error", err)
+	}
+	myRedemptions, err := handler.rewardService.Redemptions(ctx, repository.RedemptionFilter{UserID: &user.ID})
+	if err != nil {
+		slog.Error("loading redemptions", "error", err)
+	}
+
+	var pendingApprovals []models.RewardRedemption
+	if isAdmin {
+		pending := models.RedemptionPending
+		pendingApprovals, err = handler.rewardService.Redemptions(ctx, repository.RedemptionFilter{Status: &pending})
+		if err != nil {
+			slog.Error("loading pending redemptions", "error", err)
+		}
+	}
+
+	// Retired rewards are hidden from members, but their redemptions still
+	// need a name, so look names up across the whole catalogue.
+	allRewards := rewards
+	if !isAdmin {
+		allRewards, _ = handler.rewardService.Catalogue(ctx, true)
+	}
+	rewardNames := make(map[string]string, len(allRewards))
+	for _, reward := range allRewards {
+		rewardNames[reward.ID] = reward.Name
+	}
+
+	users, err := handler.userRepo.FindAll(ctx)
+	if err != nil {
+		slog.Error("finding users", "error", err)
+	}
+	userNames := make(map[string]string, len(users))
+	for _, u := range users {
+		userNames[u.ID] = u.Name
+	}
+
+	component := pages.Rewards(pages.RewardsProps{
+		User:             user,
+		Summary:          summary,
+		Ledger:           ledger,
+		Rewards:          rewards,
+		MyRedemptions:    myRedemptions,
+		PendingApprovals: pendingApprovals,
+		RewardNames:      rewardNames,
+		UserNames:        userNames,
+	})
+	component.Render(ctx, w)
+}
+
+func (handler *RewardHandler) Redeem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	if _, err := handler.rewardService.Redeem(ctx, user.ID, chi.URLParam(r, "id")); err != nil {
+		slog.Error("redeeming reward", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, "/rewards", http.StatusFound)
+}
+
+func (handler *RewardHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	cost, _ := strconv.Atoi(r.FormValue("cost"))
+	_, err := handler.rewardService.CreateReward(ctx, models.Reward{
+		Name:            r.FormValue("name"),
+		Description:     r.FormValue("description"),
+		Cost:            cost,
+		CreatedByUserID: user.ID,
+	})
+	if err != nil {
+		slog.Error("creating reward", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, "/rewards", http.StatusFound)
+}
+
+// SetActive retires or restores a reward (form field active=true|false).
+func (handler *RewardHandler) SetActive(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	reward, err := handler.rewardService.FindReward(ctx, chi.URLParam(r, "id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	reward.Active = r.FormValue("active") == "true"
+	if err := handler.rewardService.UpdateReward(ctx, reward); err != nil {
+		slog.Error("updating reward", "error", err)
+		http.Error(w, "Error updating reward", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/rewards", http.StatusFound)
+}
+
+func (handler *RewardHandler) ApproveRedemption(w http.ResponseWriter, r *http.Request) {
+	handler.answerRedemption(w, r, handler.rewardService.ApproveRedemption)
+}
+
+func (handler *RewardHandler) RejectRedemption(w http.ResponseWriter, r *http.Request) {
+	handler.answerRedemption(w, r, handler.rewardService.RejectRedemption)
+}
+
+func (handler *RewardHandler) answerRedemption(w http.ResponseWriter, r *http.Request, answer redemptionAnswer) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+	redemptionID := chi.URLParam(r, "id")
+
+	if err := answer(ctx, redemptionID, user.ID); err != nil {
+		slog.Error("resolving redemption", "error", err, "redemption_id", redemptionID)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, "/rewards", http.StatusFound)
+}
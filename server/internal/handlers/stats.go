@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/bensuskins/family-hub/internal/middleware"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/templates/pages"
+)
+
+// StatsHandler serves completion analytics as the /stats page and as JSON at
+// /api/stats.
+type StatsHandler struct {
+	statsService *services.StatsService
+}
+
+func NewStatsHandler(statsService *services.StatsService) *StatsHandler {
+	return &StatsHandler{statsService: statsService}
+}
+
+// statsAPIResponse is the JSON shape of GET /api/stats. Daily series line up
+// with Days.
+type statsAPIResponse struct {
+	Timezone    string             `json:"timezone"`
+	Days        []string           `json:"days"`
+	Users       []userStatsAPI     `json:"users"`
+	Categories  []categoryStatsAPI `json:"categories"`
+	MostSkipped []skippedChoreAPI  `json:"mostSkipped"`
+	TimeTaken   []timeTakenAPI     `json:"timeTaken"`
+}
+
+type userStatsAPI struct {
+	UserID             string  `json:"userId"`
+	Name               string  `json:"name"`
+	Daily              []int   `json:"daily"`
+	Total              int     `json:"total"`
+	OnTime             int     `json:"onTime"`
+	Late               int     `json:"late"`
+	OnTimeRate         float64 `json:"onTimeRate"`
+	AverageLateMinutes float64 `json:"averageLateMinutes"`
+	CurrentStreak      int     `json:"currentStreak"`
+	LongestStreak      int     `json:"longestStreak"`
+}
+
+// categoryStatsAPI has a null categoryId for uncategorised chores.
+type categoryStatsAPI struct {
+	CategoryID *string `json:"categoryId"`
+	Name       string  `json:"name"`
+	Daily      []int   `json:"daily"`
+	Total      int     `json:"total"`
+}
+
+type skippedChoreAPI struct {
+	SeriesID *string `json:"seriesId"`
+	Name     string  `json:"name"`
+	Skipped  int     `json:"skipped"`
+}
+
+type timeTakenAPI struct {
+	SeriesID       *string `json:"seriesId"`
+	Name           string  `json:"name"`
+	Occurrences    int     `json:"occurrences"`
+	AverageMinutes float64 `json:"averageMinutes"`
+}
+
+// statsDays reads the days query parameter, defaulting to
+// services.DefaultStatsDays. Range checking is left to the service.
+func statsDays(r *http.Request) (int, error) {
+	value := r.URL.Query().Get("days")
+	if value == "" {
+		return services.DefaultStatsDays, nil
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, services.ErrInvalidStatsRange
+	}
+	return days, nil
+}
+
+func (handler *StatsHandler) Page(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := middleware.GetUser(ctx)
+
+	// The page only links to valid ranges, so fall back quietly on a bad one.
+	days, err := statsDays(r)
+	if err != nil || days < 1 || days > services.MaxStatsDays {
+		days = services.DefaultStatsDays
+	}
+	report, err := handler.statsService.Report(ctx, days)
+	if err != nil {
+		slog.Error("building stats report", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	component := pages.Stats(pages.StatsProps{
+		User:   user,
+		Days:   days,
+		Report: report,
+	})
+	component.Render(ctx, w)
+}
+
+// API returns the stats report as JSON (GET /api/stats?days=N).
+func (handler *StatsHandler) API(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	days, err := statsDays(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	report, err := handler.statsService.Report(ctx, days)
+	if errors.Is(err, services.ErrInvalidStatsRange) {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		slog.Error("building stats report", "error", err)
+		writeJSONError(w, http.StatusInternalServerError, "failed to load stats")
+		return
+	}
+
+	response := statsAPIResponse{
+		Timezone:    report.Timezone,
+		Days:        report.Days,
+		Users:       make([]userStatsAPI, 0, len(report.Users)),
+		Categories:  make([]categoryStatsAPI, 0, len(report.Categories)),
+		MostSkipped: make([]skippedChoreAPI, 0, len(report.MostSkipped)),
+		TimeTaken:   make([]timeTakenAPI, 0, len(report.TimeTaken)),
+	}
+	for _, stats := range report.Users {
+		response.Users = append(response.Users, userStatsAPI{
+			UserID:             stats.User.ID,
+			Name:               stats.User.Name,
+			Daily:              stats.Daily,
+			Total:              stats.Total,
+			OnTime:             stats.OnTime,
+			Late:               stats.Late,
+			OnTimeRate:         stats.OnTimeRate,
+			AverageLateMinutes: stats.AverageLateMinutes,
+			CurrentStreak:      stats.CurrentStreak,
+			LongestStreak:      stats.LongestStreak,
+		})
+	}
+	for _, category := range report.Categories {
+		entry := categoryStatsAPI{Name: "Uncategorised", Daily: category.Daily, Total: category.Total}
+		if category.Category.ID != "" {
+			id := category.Category.ID
+			entry.CategoryID = &id
+			entry.Name = category.Category.Name
+		}
+		response.Categories = append(response.Categories, entry)
+	}
+	for _, skipped := range report.MostSkipped {
+		response.MostSkipped = append(response.MostSkipped, skippedChoreAPI{
+			SeriesID: skipped.SeriesID,
+			Name:     skipped.Name,
+			Skipped:  skipped.Skipped,
+		})
+	}
+	for _, duration := range report.TimeTaken {
+		response.TimeTaken = append(response.TimeTaken, timeTakenAPI{
+			SeriesID:       duration.SeriesID,
+			Name:           duration.Name,
+			Occurrences:    duration.Occurrences,
+			AverageMinutes: duration.AverageMinutes,
+		})
+	}
+	writeJSON(w, http.StatusOK, response)
+}
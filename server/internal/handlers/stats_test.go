@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/middleware"
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestStats_API(t *testing.T) {
+	database := testutil.NewTestDatabase(t)
+	choreRepo := repository.NewChoreRepository(database)
+	userRepo := repository.NewUserRepository(database)
+	categoryRepo := repository.NewCategoryRepository(database)
+	settingsRepo := repository.NewSettingsRepository(database)
+	ctx := context.Background()
+
+	settingsRepo.Set(ctx, repository.SettingsKeyTimezone, "UTC")
+	user, _ := userRepo.Create(ctx, models.User{
+		OIDCSubject: "sub-stats",
+		Email:       "stats@example.com",
+		Name:        "Stats User",
+		Role:        models.RoleMember,
+	})
+
+	now := time.Now().UTC()
+	for _, daysAgo := range []int{0, 1, 2, 5} {
+		done := now.AddDate(0, 0, -daysAgo)
+		due := time.Date(done.Year(), done.Month(), done.Day(), 0, 0, 0, 0, time.UTC)
+		if _, err := choreRepo.Create(ctx, models.Chore{
+			Name:              "Feed cat",
+			CreatedByUserID:   user.ID,
+			DueDate:           &due,
+			Status:            models.ChoreStatusCompleted,
+			CompletedAt:       &done,
+			CompletedByUserID: &user.ID,
+		}); err != nil {
+			t.Fatalf("creating chore: %v", err)
+		}
+	}
+
+	statsService := services.NewStatsService(repository.NewStatsRepository(database), userRepo, categoryRepo, settingsRepo)
+	handler := NewStatsHandler(statsService)
+
+	router := chi.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), middleware.UserContextKey, user)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+	router.Get("/api/stats", handler.API)
+
+	request := httptest.NewRequest(http.MethodGet, "/api/stats?days=7", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var response statsAPIResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if response.Timezone != "UTC" || len(response.Days) != 7 || response.Days[6] != now.Format("2006-01-02") {
+		t.Errorf("unexpected range %s %v", response.Timezone, response.Days)
+	}
+	if len(response.Users) != 1 {
+		t.Fatalf("expected 1 user, got %+v", response.Users)
+	}
+	stats := response.Users[0]
+	if stats.Total != 4 || stats.Daily[6] != 1 || stats.Daily[1] != 1 || stats.Daily[0] != 0 {
+		t.Errorf("unexpected series %+v", stats)
+	}
+	if stats.OnTime != 4 || stats.OnTimeRate != 1 {
+		t.Errorf("expected every completion on time, got %+v", stats)
+	}
+	if stats.CurrentStreak != 3 || stats.LongestStreak != 3 {
+		t.Errorf("expected a 3 day streak, got current %d longest %d", stats.CurrentStreak, stats.LongestStreak)
+	}
+	if len(response.Categories) != 1 || response.Categories[0].CategoryID != nil || response.Categories[0].Total != 4 {
+		t.Errorf("expected everything uncategorised, got %+v", response.Categories)
+	}
+
+	for _, days := range []string{"0", "366", "week"} {
+		request = httptest.NewRequest(http.MethodGet, "/api/stats?days="+days, nil)
+		recorder = httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("days=%s: expected 400, got %d", days, recorder.Code)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/bensuskins/family-hub/internal/middleware"
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/templates/pages"
+	"github.com/go-chi/chi/v5"
+)
+
+// WebhookHandler serves the admin page for outgoing webhooks: registering
+// them and their delivery logs.
+type WebhookHandler struct {
+	webhooks *services.WebhookService
+}
+
+func NewWebhookHandler(webhooks *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhooks: webhooks}
+}
+
+func (handler *WebhookHandler) Page(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	webhooks, err := handler.webhooks.Webhooks(ctx)
+	if err != nil {
+		slog.Error("finding webhooks", "error", err)
+		http.Error(w, "Error loading webhooks", http.StatusInternalServerError)
+		return
+	}
+	deliveries := map[string][]models.WebhookDelivery{}
+	for _, webhook := range webhooks {
+		log, err := handler.webhooks.Deliveries(ctx, webhook.ID)
+		if err != nil {
+			slog.Error("finding webhook deliveries", "webhook_id", webhook.ID, "error", err)
+			continue
+		}
+		deliveries[webhook.ID] = log
+	}
+
+	pages.AdminWebhooks(pages.AdminWebhooksProps{
+		User:       middleware.GetUser(ctx),
+		Webhooks:   webhooks,
+		Deliveries: deliveries,
+	}).Render(ctx, w)
+}
+
+// Create registers a webhook from the form. A blank secret is generated.
+func (handler *WebhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+
+	webhook := webhookFromForm(r)
+	webhook.Active = true
+	if _, err := handler.webhooks.Register(r.Context(), webhook); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/webhooks", http.StatusFound)
+}
+
+// Update saves a webhook from the form. A blank secret keeps the one it has.
+func (handler *WebhookHandler) Update(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+
+	webhook := webhookFromForm(r)
+	webhook.ID = chi.URLParam(r, "id")
+	webhook.Active = r.FormValue("active") != ""
+	if err := handler.webhooks.Update(r.Context(), webhook); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/webhooks", http.StatusFound)
+}
+
+func webhookFromForm(r *http.Request) models.Webhook {
+	webhook := models.Webhook{
+		URL:         strings.TrimSpace(r.FormValue("url")),
+		Description: strings.TrimSpace(r.FormValue("description")),
+		Secret:      strings.TrimSpace(r.FormValue("secret")),
+	}
+	for _, event := range r.Form["events"] {
+		webhook.Events = append(webhook.Events, models.WebhookEvent(event))
+	}
+	return webhook
+}
+
+func (handler *WebhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := handler.webhooks.Remove(r.Context(), chi.URLParam(r, "id")); err != nil {
+		slog.Error("deleting webhook", "error", err)
+		http.Error(w, "Error deleting webhook", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/webhooks", http.StatusFound)
+}
+
+// Ping queues a test delivery to the webhook.
+func (handler *WebhookHandler) Ping(w http.ResponseWriter, r *http.Request) {
+	if err := handler.webhooks.Ping(r.Context(), chi.URLParam(r, "id")); err != nil {
+		http.Error(w, "Webhook not found", http.StatusNotFound)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/webhooks", http.StatusFound)
+}
+
+// Replay queues a logged delivery to be sent again.
+func (handler *WebhookHandler) Replay(w http.ResponseWriter, r *http.Request) {
+	if _, err := handler.webhooks.Replay(r.Context(), chi.URLParam(r, "id")); err != nil {
+		http.Error(w, "Delivery not found", http.StatusNotFound)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/webhooks", http.StatusFound)
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestWebhookHandler_RegisterAndLog(t *testing.T) {
+	database := testutil.NewTestDatabase(t)
+	webhooks := services.NewWebhookService(repository.NewWebhookRepository(database))
+	handler := NewWebhookHandler(webhooks)
+	admin := models.User{ID: "admin", Name: "Admin", Role: models.RoleAdmin}
+
+	router := chi.NewRouter()
+	router.Get("/admin/webhooks", handler.Page)
+	router.Post("/admin/webhooks", handler.Create)
+	router.Post("/admin/webhooks/{id}/ping", handler.Ping)
+	router.Post("/admin/webhooks/deliveries/{id}/replay", handler.Replay)
+	post := func(path string, form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, requestWithUser(req, admin))
+		return w
+	}
+
+	if w := post("/admin/webhooks", url.Values{"url": {"ftp://nas.local/hook"}, "events": {"chore.created"}}); w.Code != http.StatusBadRequest {
+		t.Errorf("expected a non-HTTP URL refused with 400, got %d", w.Code)
+	}
+	w := post("/admin/webhooks", url.Values{
+		"url":         {"http://homeassistant.local:8123/api/webhook/family-hub"},
+		"description": {"Home Assistant"},
+		"events":      {"chore.created", "inventory.low"},
+	})
+	if w.Code != http.StatusFound {
+		t.Fatalf("expected a redirect, got %d: %s", w.Code, w.Body.String())
+	}
+
+	registered, _ := webhooks.Webhooks(t.Context())
+	if len(registered) != 1 || !registered[0].Active || len(registered[0].Events) != 2 {
+		t.Fatalf("expected an active webhook for two events, got %+v", registered)
+	}
+	if w := post("/admin/webhooks/"+registered[0].ID+"/ping", nil); w.Code != http.StatusFound {
+		t.Errorf("expected a redirect after the ping, got %d", w.Code)
+	}
+	if w := post("/admin/webhooks/deliveries/missing/replay", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 replaying an unknown delivery, got %d", w.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/webhooks", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, requestWithUser(req, admin))
+	body := w.Body.String()
+	if w.Code != http.StatusOK || !strings.Contains(body, "homeassistant.local") || !strings.Contains(body, "/replay") {
+		t.Errorf("expected the webhook and its logged ping listed, got %d", w.Code)
+	}
+}
@@ -15,7 +15,10 @@ import (
 
 type contextKey string
 
-const UserContextKey contextKey = "user"
+const (
+	UserContextKey     contextKey = "user"
+	LocationContextKey contextKey = "location"
+)
 
 // RequireUser authenticates the request using either a Bearer API token or a
 // session cookie, populates the user into the request context, and delegates
@@ -107,3 +110,24 @@ func InjectFamilyName(settingsRepo repository.SettingsRepository) func(http.Hand
 		})
 	}
 }
+
+// InjectLocation stores the signed-in user's timezone (their override, else
+// the household's) in the request context. It must run after RequireUser.
+func InjectLocation(settingsRepo repository.SettingsRepository) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			location := services.UserLocation(r.Context(), settingsRepo, GetUser(r.Context()))
+			ctx := context.WithValue(r.Context(), LocationContextKey, location)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetLocation returns the timezone "today" is evaluated in for this request,
+// falling back to services.DefaultLocation when none was injected.
+func GetLocation(ctx context.Context) *time.Location {
+	if location, ok := ctx.Value(LocationContextKey).(*time.Location); ok {
+		return location
+	}
+	return services.DefaultLocation
+}
@@ -15,6 +15,13 @@ const (
 	ChoreStatusPending   ChoreStatus = "pending"
 	ChoreStatusCompleted ChoreStatus = "completed"
 	ChoreStatusOverdue   ChoreStatus = "overdue"
+	ChoreStatusSkipped   ChoreStatus = "skipped"
+	// ChoreStatusAwaitingApproval is a completion an admin has yet to approve
+	// or reject, for series that require approval.
+	ChoreStatusAwaitingApproval ChoreStatus = "awaiting_approval"
+	// ChoreStatusBlocked is an occurrence waiting for a prerequisite chore in
+	// the same cycle to be done. It is neither due nor overdue until then.
+	ChoreStatusBlocked ChoreStatus = "blocked"
 )
 
 type RecurrenceType string
@@ -28,12 +35,32 @@ const (
 	RecurrenceCalendar RecurrenceType = "calendar"
 )
 
+// AssignmentStrategy selects how a series picks the assignee of each new
+// occurrence from its eligible pool.
+type AssignmentStrategy string
+
+const (
+	// AssignmentRoundRobin rotates through the pool from the series' rotation
+	// cursor, passing over users with overdue chores.
+	AssignmentRoundRobin AssignmentStrategy = "round_robin"
+	// AssignmentLeastLoaded picks the user with the fewest open chores.
+	AssignmentLeastLoaded AssignmentStrategy = "least_loaded"
+	// AssignmentEffortWeighted picks the user who completed the fewest effort
+	// points over the last 30 days.
+	AssignmentEffortWeighted AssignmentStrategy = "effort_weighted"
+	// AssignmentRandom picks uniformly at random.
+	AssignmentRandom AssignmentStrategy = "random"
+	// AssignmentFixed always picks the series' fixed assignee.
+	AssignmentFixed AssignmentStrategy = "fixed"
+)
+
 type AssignmentStatus string
 
 const (
 	AssignmentStatusAssigned   AssignmentStatus = "assigned"
 	AssignmentStatusCompleted  AssignmentStatus = "completed"
 	AssignmentStatusReassigned AssignmentStatus = "reassigned"
+	AssignmentStatusSkipped    AssignmentStatus = "skipped"
 )
 
 type User struct {
@@ -46,6 +73,9 @@ type User struct {
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	OnboardedAt *time.Time
+	// Timezone is an IANA zone name overriding the household timezone for
+	// this member, or "" to follow the household.
+	Timezone string
 }
 
 type Category struct {
@@ -68,9 +98,15 @@ type Chore struct {
 
 	DueDate *time.Time
 	DueTime *string
+	// OriginalDueDate is the series slot a snoozed occurrence was generated
+	// for; nil unless the occurrence has been snoozed.
+	OriginalDueDate *time.Time
 
 	RecurrenceType  RecurrenceType
 	RecurrenceValue string
+	// RecurrenceRule is an optional RFC 5545 RRULE (without the "RRULE:"
+	// prefix). When set it takes precedence over RecurrenceValue.
+	RecurrenceRule  string
 	RecurOnComplete bool
 	SeriesID        *string
 
@@ -80,6 +116,50 @@ type Chore struct {
 	RecurrenceUntil *time.Time
 	RecurrenceCount *int
 
+	// Assignment settings, owned by the series like the recurrence rule.
+	AssignmentStrategy  AssignmentStrategy
+	FixedAssigneeUserID *string
+	EffortPoints        int
+
+	// ChecklistRequired blocks completion until every checklist item is
+	// ticked. Checklist is only loaded where an occurrence is shown on its own.
+	ChecklistRequired bool
+	Checklist         []ChecklistItem
+
+	// RequiresApproval holds completions for an admin to approve. HasProof
+	// reports whether a photo was uploaded with the completion.
+	RequiresApproval bool
+	HasProof         bool
+
+	// GroupChore assigns the chore to the whole available pool at once; it
+	// completes when GroupQuorum of them have done their part (0 means all).
+	// Participants are the users holding a part and PartsDone those who have
+	// done theirs; both are empty for other chores.
+	GroupChore   bool
+	GroupQuorum  int
+	Participants []string
+	PartsDone    []string
+
+	// Prerequisites are the series this chore's series waits on, and
+	// PrerequisiteDelayMinutes how long after a prerequisite is done the
+	// occurrence falls due. Like Checklist, only loaded where shown on its own.
+	Prerequisites            []string
+	PrerequisiteDelayMinutes int
+
+	// Escalation policy, owned by the series: remind the assignee once the
+	// chore has been overdue EscalationRemindHours, hand it to the next person
+	// in the rotation after EscalationReassignHours (0 turns either off), and
+	// tell the admins when the last step is taken. Escalations, the steps
+	// taken so far, is only loaded where the occurrence is shown on its own.
+	EscalationRemindHours   int
+	EscalationReassignHours int
+	EscalationNotifyAdmins  bool
+	Escalations             []ChoreEscalation
+
+	// Seasons are the series' active seasons; see ChoreSeries.Seasons. Like
+	// Checklist, only loaded where the chore is shown on its own.
+	Seasons []SeasonWindow
+
 	Status          ChoreStatus
 	CompletedAt     *time.Time
 	CompletedByUserID *string
@@ -103,19 +183,117 @@ type ChoreSeries struct {
 
 	RecurrenceType  RecurrenceType
 	RecurrenceValue string
+	RecurrenceRule  string
 	RecurOnComplete bool
 	RecurrenceUntil *time.Time
 	RecurrenceCount *int
 
+	AssignmentStrategy  AssignmentStrategy
+	FixedAssigneeUserID *string
+	EffortPoints        int
+
+	// Checklist is the ordered template copied onto each new occurrence.
+	ChecklistRequired bool
+	Checklist         []string
+
+	RequiresApproval bool
+
+	// GroupChore and GroupQuorum make each occurrence a group chore; see
+	// Chore.GroupChore.
+	GroupChore  bool
+	GroupQuorum int
+
+	// Prerequisites are the series whose occurrence in the same cycle must be
+	// done before this series' occurrence opens; it then falls due
+	// PrerequisiteDelayMinutes after that completion.
+	Prerequisites            []string
+	PrerequisiteDelayMinutes int
+
+	// Escalation policy for overdue occurrences; see Chore.EscalationRemindHours.
+	EscalationRemindHours   int
+	EscalationReassignHours int
+	EscalationNotifyAdmins  bool
+
+	// Seasons limit the series to stretches of the year; occurrences are only
+	// generated inside them. Empty means all year.
+	Seasons []SeasonWindow
+
 	RotationCursorUserID *string
 	DeletedAt            *time.Time
 
 	EligibleAssignees []string
 
+	// Exceptions are the slots handled outside the rule: occurrences deleted
+	// or edited on their own. Seeding never regenerates them.
+	Exceptions []SeriesException
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+// SeasonWindow is a stretch of the year, from StartMonth/StartDay to
+// EndMonth/EndDay inclusive. A window whose end comes before its start wraps
+// over the new year, as November to February does.
+type SeasonWindow struct {
+	StartMonth time.Month
+	StartDay   int
+	EndMonth   time.Month
+	EndDay     int
+}
+
+// SeriesException marks one slot of a series' schedule as handled by hand.
+// OccurrenceDate is the date the rule generated (a civil date at UTC
+// midnight, like DueDate). ChoreID is the occurrence edited on its own for
+// that slot, or nil when the occurrence was deleted.
+type SeriesException struct {
+	SeriesID       string
+	OccurrenceDate time.Time
+	ChoreID        *string
+	CreatedAt      time.Time
+}
+
+// ChecklistItem is one step of a chore occurrence's checklist, ticked off
+// independently of the other occurrences in its series.
+type ChecklistItem struct {
+	ID              string
+	ChoreID         string
+	Position        int
+	Title           string
+	CheckedAt       *time.Time
+	CheckedByUserID *string
+}
+
+type EscalationKind string
+
+const (
+	EscalationReminded       EscalationKind = "reminded"
+	EscalationReassigned     EscalationKind = "reassigned"
+	EscalationAdminsNotified EscalationKind = "admins_notified"
+)
+
+// ChoreEscalation is one step taken on an overdue occurrence. UserID is who
+// was reminded or handed the chore and FromUserID who it was taken from; both
+// are nil when the admins were told.
+type ChoreEscalation struct {
+	ID         string
+	ChoreID    string
+	Kind       EscalationKind
+	UserID     *string
+	FromUserID *string
+	CreatedAt  time.Time
+}
+
+// ChoreTimeSegment is one stretch of UserID working on an occurrence, from
+// starting or resuming their timer to pausing or stopping it. EndedAt is nil
+// while the timer is running.
+type ChoreTimeSegment struct {
+	ID        string
+	ChoreID   string
+	UserID    string
+	StartedAt time.Time
+	EndedAt   *time.Time
+}
+
 type Event struct {
 	ID              string
 	Title           string
@@ -138,6 +316,96 @@ type ChoreAssignment struct {
 	AssignedAt  time.Time
 	CompletedAt *time.Time
 	Status      AssignmentStatus
+	// HandedOver is set when the assignee came from a swap, cover, escalation
+	// or an edit to the occurrence rather than from the rotation.
+	HandedOver bool
+}
+
+type ChoreSwapStatus string
+
+const (
+	ChoreSwapPending   ChoreSwapStatus = "pending"
+	ChoreSwapAccepted  ChoreSwapStatus = "accepted"
+	ChoreSwapDeclined  ChoreSwapStatus = "declined"
+	ChoreSwapCancelled ChoreSwapStatus = "cancelled"
+)
+
+// ChoreSwap is a proposal from FromUserID to hand their occurrence ChoreID to
+// ToUserID. When RequestedChoreID is set it is a trade: ToUserID's occurrence
+// comes back to FromUserID in return; otherwise it is a one-way handoff.
+type ChoreSwap struct {
+	ID               string
+	ChoreID          string
+	RequestedChoreID *string
+	FromUserID       string
+	ToUserID         string
+	Status           ChoreSwapStatus
+	CreatedAt        time.Time
+	RespondedAt      *time.Time
+}
+
+// Unavailability is a range of days, inclusive, on which UserID is away and
+// should not be handed chores. Dates are YYYY-MM-DD like meal plan dates.
+type Unavailability struct {
+	ID              string
+	UserID          string
+	StartDate       string
+	EndDate         string
+	Reason          string
+	CreatedByUserID string
+	CreatedAt       time.Time
+}
+
+type PointsReason string
+
+const (
+	PointsReasonChore      PointsReason = "chore"
+	PointsReasonRedemption PointsReason = "redemption"
+)
+
+// PointsEntry is one line of a user's points ledger: a credit for a completed
+// chore or a debit for an approved reward redemption. A user's balance is the
+// sum of their entries.
+type PointsEntry struct {
+	ID           string
+	UserID       string
+	Points       int
+	Reason       PointsReason
+	ChoreID      *string
+	RedemptionID *string
+	CreatedAt    time.Time
+}
+
+type Reward struct {
+	ID              string
+	Name            string
+	Description     string
+	Cost            int
+	Active          bool
+	CreatedByUserID string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type RedemptionStatus string
+
+const (
+	RedemptionPending  RedemptionStatus = "pending"
+	RedemptionApproved RedemptionStatus = "approved"
+	RedemptionRejected RedemptionStatus = "rejected"
+)
+
+// RewardRedemption is a member's request to spend Cost points on a reward. The
+// points are only debited once an admin approves it.
+type RewardRedemption struct {
+	ID               string
+	RewardID         string
+	UserID           string
+	Cost             int
+	Status           RedemptionStatus
+	CreatedAt        time.Time
+	ResolvedAt       *time.Time
+	ResolvedByUserID *string
 }
 
 type TokenScope string
@@ -256,3 +524,169 @@ type ICalSubscription struct {
 	LastFetchedAt *time.Time
 	CreatedAt     time.Time
 }
+
+// ChannelKind is how a NotificationChannel delivers.
+type ChannelKind string
+
+const (
+	ChannelWebhook ChannelKind = "webhook"
+	ChannelNtfy    ChannelKind = "ntfy"
+	ChannelEmail   ChannelKind = "email"
+	ChannelGotify  ChannelKind = "gotify"
+	// ChannelWebPush pushes to every browser the user has subscribed; it
+	// has no Target of its own.
+	ChannelWebPush ChannelKind = "webpush"
+	// ChannelAPNs pushes to every iOS device the user has signed in on,
+	// through APNs; it has no Target of its own either.
+	ChannelAPNs ChannelKind = "apns"
+)
+
+// NotificationEvent is what a notification is about.
+type NotificationEvent string
+
+const (
+	NotifyChoreAssigned     NotificationEvent = "chore_assigned"
+	NotifyChoreDueSoon      NotificationEvent = "chore_due_soon"
+	NotifyChoreOverdue      NotificationEvent = "chore_overdue"
+	NotifySwapRequested     NotificationEvent = "swap_requested"
+	NotifyApprovalRequested NotificationEvent = "approval_requested"
+	NotifyLowInventory      NotificationEvent = "low_inventory"
+	NotifyDailyDigest       NotificationEvent = "daily_digest"
+	NotifyWeeklyDigest      NotificationEvent = "weekly_digest"
+	// NotifyTest is a message the user sends themselves to check a channel;
+	// it goes out whatever the channel's Events.
+	NotifyTest NotificationEvent = "test"
+)
+
+// NotificationChannel is somewhere a user is sent notifications. Target is the
+// webhook URL, ntfy topic URL, email address or Gotify server URL (empty for
+// Web Push); Token is
+// the ntfy access token or Gotify application token. Events are the events
+// sent through it.
+type NotificationChannel struct {
+	ID        string
+	UserID    string
+	Kind      ChannelKind
+	Target    string
+	Token     string
+	Events    []NotificationEvent
+	CreatedAt time.Time
+}
+
+// PushSubscription is one browser's Web Push registration: the push service
+// endpoint to post to and the browser's P-256 public key and auth secret
+// (base64url) that payloads are encrypted to.
+type PushSubscription struct {
+	ID        string
+	UserID    string
+	Endpoint  string
+	P256DH    string
+	Auth      string
+	UserAgent string
+	CreatedAt time.Time
+}
+
+// APNsDevice is an iOS device registered for push: the hex APNs device token
+// the app was given.
+type APNsDevice struct {
+	ID        string
+	UserID    string
+	Token     string
+	CreatedAt time.Time
+}
+
+type NotificationStatus string
+
+const (
+	NotificationPending NotificationStatus = "pending"
+	NotificationSent    NotificationStatus = "sent"
+	NotificationFailed  NotificationStatus = "failed"
+)
+
+// Notification is a message queued for delivery through a channel. Link is
+// the page it is about, and HTML an optional richer version of Body for
+// channels that can show it. A pending notification is sent once NextAttemptAt has
+// passed; Attempts and LastError record the failed tries before it.
+type Notification struct {
+	ID            string
+	ChannelID     string
+	Event         NotificationEvent
+	Title         string
+	Body          string
+	Link          string
+	HTML          string
+	DedupeKey     *string
+	Status        NotificationStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
+
+// WebhookEvent is a domain event outgoing webhooks can subscribe to.
+type WebhookEvent string
+
+const (
+	WebhookChoreCreated     WebhookEvent = "chore.created"
+	WebhookChoreAssigned    WebhookEvent = "chore.assigned"
+	WebhookChoreCompleted   WebhookEvent = "chore.completed"
+	WebhookChoreOverdue     WebhookEvent = "chore.overdue"
+	WebhookMealPlanned      WebhookEvent = "meal.planned"
+	WebhookRecipeCreated    WebhookEvent = "recipe.created"
+	WebhookInventoryLow     WebhookEvent = "inventory.low"
+	WebhookInventoryChanged WebhookEvent = "inventory.changed"
+	// WebhookPing is a test delivery an admin sends to check a webhook; it
+	// goes out whatever the webhook's Events.
+	WebhookPing WebhookEvent = "ping"
+)
+
+// Webhook is an endpoint the hub posts domain events to, for home
+// automation. Secret signs each delivery; Events are the events it is posted.
+// An inactive webhook is posted nothing.
+type Webhook struct {
+	ID          string
+	URL         string
+	Description string
+	Secret      string
+	Events      []WebhookEvent
+	Active      bool
+	CreatedAt   time.Time
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+	WebhookDeliverySent    WebhookDeliveryStatus = "sent"
+	WebhookDeliveryFailed  WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event queued for, and then logged against, a
+// webhook. Payload is the JSON body posted. ResponseStatus is the HTTP status
+// of the last attempt, 0 when there was no response.
+type WebhookDelivery struct {
+	ID             string
+	WebhookID      string
+	Event          WebhookEvent
+	Payload        string
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	ResponseStatus int
+	LastError      string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+// DigestSchedule is when a member is sent their digests, as local "HH:MM"
+// times: the daily digest every day at DailyTime and the week-ahead digest on
+// Sundays at WeeklyTime. "" turns a digest off. DailySentOn and WeeklySentOn
+// are the local dates ("2006-01-02") each was last sent.
+type DigestSchedule struct {
+	UserID       string
+	DailyTime    string
+	WeeklyTime   string
+	DailySentOn  string
+	WeeklySentOn string
+}
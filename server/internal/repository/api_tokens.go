@@ -37,12 +37,12 @@ func (repository *SQLiteAPITokenRepository) Create(ctx context.Context, token mo
 	if token.ID == "" {
 		token.ID = uuid.New().String()
 	}
-	token.CreatedAt = time.Now()
+	token.CreatedAt = time.Now().UTC()
 
 	_, err := repository.database.ExecContext(ctx,
 		`INSERT INTO api_tokens (id, name, token_hash, scope, created_by_user_id, expires_at, created_at)
 		VALUES (?, ?, ?, ?, ?, ?, ?)`,
-		token.ID, token.Name, token.TokenHash, token.Scope, token.CreatedByUserID, token.ExpiresAt, token.CreatedAt,
+		token.ID, token.Name, token.TokenHash, token.Scope, token.CreatedByUserID, inUTC(token.ExpiresAt), token.CreatedAt,
 	)
 	if err != nil {
 		return models.APIToken{}, fmt.Errorf("creating api token: %w", err)
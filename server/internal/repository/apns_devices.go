@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/google/uuid"
+)
+
+type APNsDeviceRepository interface {
+	// Save registers a device token for a user, moving it from whoever had
+	// it before.
+	Save(ctx context.Context, device models.APNsDevice) (models.APNsDevice, error)
+	FindByUser(ctx context.Context, userID string) ([]models.APNsDevice, error)
+	// DeleteByToken drops a device token, whoever it belongs to;
+	// DeleteForUser only if it is the user's.
+	DeleteByToken(ctx context.Context, token string) error
+	DeleteForUser(ctx context.Context, userID, token string) error
+}
+
+type SQLiteAPNsDeviceRepository struct {
+	database *sql.DB
+}
+
+func NewAPNsDeviceRepository(database *sql.DB) *SQLiteAPNsDeviceRepository {
+	return &SQLiteAPNsDeviceRepository{database: database}
+}
+
+func (repository *SQLiteAPNsDeviceRepository) Save(ctx context.Context, device models.APNsDevice) (models.APNsDevice, error) {
+	device.ID = uuid.New().String()
+	device.CreatedAt = time.Now().UTC()
+
+	_, err := repository.database.ExecContext(ctx,
+		`INSERT INTO apns_devices (id, user_id, token, created_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (token) DO UPDATE SET user_id = excluded.user_id`,
+		device.ID, device.UserID, device.Token, device.CreatedAt,
+	)
+	if err != nil {
+		return models.APNsDevice{}, fmt.Errorf("saving APNs device: %w", err)
+	}
+	return device, nil
+}
+
+func (repository *SQLiteAPNsDeviceRepository) FindByUser(ctx context.Context, userID string) ([]models.APNsDevice, error) {
+	rows, err := repository.database.QueryContext(ctx,
+		"SELECT id, user_id, token, created_at FROM apns_devices WHERE user_id = ? ORDER BY created_at", userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("finding APNs devices: %w", err)
+	}
+	defer rows.Close()
+
+	var devices []models.APNsDevice
+	for rows.Next() {
+		var device models.APNsDevice
+		if err := rows.Scan(&device.ID, &device.UserID, &device.Token, &device.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning APNs device: %w", err)
+		}
+		devices = append(devices, device)
+	}
+	return devices, rows.Err()
+}
+
+func (repository *SQLiteAPNsDeviceRepository) DeleteByToken(ctx context.Context, token string) error {
+	if _, err := repository.database.ExecContext(ctx, "DELETE FROM apns_devices WHERE token = ?", token); err != nil {
+		return fmt.Errorf("deleting APNs device: %w", err)
+	}
+	return nil
+}
+
+func (repository *SQLiteAPNsDeviceRepository) DeleteForUser(ctx context.Context, userID, token string) error {
+	if _, err := repository.database.ExecContext(ctx,
+		"DELETE FROM apns_devices WHERE user_id = ? AND token = ?", userID, token,
+	); err != nil {
+		return fmt.Errorf("deleting APNs device: %w", err)
+	}
+	return nil
+}
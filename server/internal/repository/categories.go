@@ -61,7 +61,7 @@ func (repository *SQLiteCategoryRepository) Create(ctx context.Context, category
 	if category.ID == "" {
 		category.ID = uuid.New().String()
 	}
-	category.CreatedAt = time.Now()
+	category.CreatedAt = time.Now().UTC()
 
 	_, err := repository.database.ExecContext(ctx,
 		"INSERT INTO categories (id, name, created_by_user_id, created_at) VALUES (?, ?, ?, ?)",
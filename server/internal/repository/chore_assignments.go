@@ -14,8 +14,11 @@ type ChoreAssignmentRepository interface {
 	Create(ctx context.Context, assignment models.ChoreAssignment) (models.ChoreAssignment, error)
 	FindByChoreID(ctx context.Context, choreID string) ([]models.ChoreAssignment, error)
 	MarkCompleted(ctx context.Context, choreID string, userID string) error
+	MarkUncompleted(ctx context.Context, choreID string, userID string) error
 	MarkReassigned(ctx context.Context, choreID string) error
+	MarkSkipped(ctx context.Context, choreID string) error
 	CompletedCountByUser(ctx context.Context, userID string, since time.Time) (int, error)
+	CompletedPointsByUser(ctx context.Context, userID string, since time.Time) (int, error)
 	RecentCompleted(ctx context.Context, limit int) ([]models.ChoreAssignment, error)
 	DeleteCompleted(ctx context.Context) error
 }
@@ -32,16 +35,16 @@ func (repository *SQLiteChoreAssignmentRepository) Create(ctx context.Context, a
 	if assignment.ID == "" {
 		assignment.ID = uuid.New().String()
 	}
-	assignment.AssignedAt = time.Now()
+	assignment.AssignedAt = time.Now().UTC()
 	if assignment.Status == "" {
 		assignment.Status = models.AssignmentStatusAssigned
 	}
 
 	_, err := repository.database.ExecContext(ctx,
-		`INSERT INTO chore_assignments (id, chore_id, user_id, assigned_at, completed_at, status)
-		VALUES (?, ?, ?, ?, ?, ?)`,
+		`INSERT INTO chore_assignments (id, chore_id, user_id, assigned_at, completed_at, status, handed_over)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
 		assignment.ID, assignment.ChoreID, assignment.UserID,
-		assignment.AssignedAt, assignment.CompletedAt, assignment.Status,
+		assignment.AssignedAt, inUTC(assignment.CompletedAt), assignment.Status, assignment.HandedOver,
 	)
 	if err != nil {
 		return models.ChoreAssignment{}, fmt.Errorf("creating chore assignment: %w", err)
@@ -51,7 +54,7 @@ func (repository *SQLiteChoreAssignmentRepository) Create(ctx context.Context, a
 
 func (repository *SQLiteChoreAssignmentRepository) FindByChoreID(ctx context.Context, choreID string) ([]models.ChoreAssignment, error) {
 	rows, err := repository.database.QueryContext(ctx,
-		`SELECT id, chore_id, user_id, assigned_at, completed_at, status
+		`SELECT id, chore_id, user_id, assigned_at, completed_at, status, handed_over
 		FROM chore_assignments WHERE chore_id = ? ORDER BY assigned_at DESC`, choreID,
 	)
 	if err != nil {
@@ -63,7 +66,7 @@ func (repository *SQLiteChoreAssignmentRepository) FindByChoreID(ctx context.Con
 }
 
 func (repository *SQLiteChoreAssignmentRepository) MarkCompleted(ctx context.Context, choreID string, userID string) error {
-	now := time.Now()
+	now := time.Now().UTC()
 	_, err := repository.database.ExecContext(ctx,
 		`UPDATE chore_assignments SET status = ?, completed_at = ?
 		WHERE chore_id = ? AND user_id = ? AND status = 'assigned'`,
@@ -75,6 +78,20 @@ func (repository *SQLiteChoreAssignmentRepository) MarkCompleted(ctx context.Con
 	return nil
 }
 
+// MarkUncompleted reopens the assignment MarkCompleted closed for userID when
+// a completion is undone.
+func (repository *SQLiteChoreAssignmentRepository) MarkUncompleted(ctx context.Context, choreID string, userID string) error {
+	_, err := repository.database.ExecContext(ctx,
+		`UPDATE chore_assignments SET status = ?, completed_at = NULL
+		WHERE chore_id = ? AND user_id = ? AND status = 'completed'`,
+		models.AssignmentStatusAssigned, choreID, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("marking assignment uncompleted: %w", err)
+	}
+	return nil
+}
+
 func (repository *SQLiteChoreAssignmentRepository) MarkReassigned(ctx context.Context, choreID string) error {
 	_, err := repository.database.ExecContext(ctx,
 		`UPDATE chore_assignments SET status = ?
@@ -87,12 +104,52 @@ func (repository *SQLiteChoreAssignmentRepository) MarkReassigned(ctx context.Co
 	return nil
 }
 
+// handOverAssignment closes the chore's open assignment as reassigned and
+// opens one for userID, marked handed over unless it is their turn in the
+// rotation.
+func handOverAssignment(ctx context.Context, database queryExecer, choreID, userID string, handedOver bool, now time.Time) error {
+	if _, err := database.ExecContext(ctx,
+		`UPDATE chore_assignments SET status = ?
+		WHERE chore_id = ? AND status = 'assigned'`,
+		models.AssignmentStatusReassigned, choreID,
+	); err != nil {
+		return fmt.Errorf("marking assignment reassigned: %w", err)
+	}
+
+	if _, err := database.ExecContext(ctx,
+		`INSERT INTO chore_assignments (id, chore_id, user_id, assigned_at, completed_at, status, handed_over)
+		VALUES (?, ?, ?, ?, NULL, ?, ?)`,
+		uuid.New().String(), choreID, userID, now, models.AssignmentStatusAssigned, handedOver,
+	); err != nil {
+		return fmt.Errorf("creating assignment: %w", err)
+	}
+	return nil
+}
+
+// MarkSkipped closes the open assignment of a skipped occurrence. Skipped
+// assignments never count as completions.
+func (repository *SQLiteChoreAssignmentRepository) MarkSkipped(ctx context.Context, choreID string) error {
+	_, err := repository.database.ExecContext(ctx,
+		`UPDATE chore_assignments SET status = ?
+		WHERE chore_id = ? AND status = 'assigned'`,
+		models.AssignmentStatusSkipped, choreID,
+	)
+	if err != nil {
+		return fmt.Errorf("marking assignment skipped: %w", err)
+	}
+	return nil
+}
+
+// CompletedCountByUser counts the user's completed assignments since the given
+// time. Only assignments on completed chores count, so a part done on a group
+// chore that has not completed, or is awaiting approval, is left out.
 func (repository *SQLiteChoreAssignmentRepository) CompletedCountByUser(ctx context.Context, userID string, since time.Time) (int, error) {
 	var count int
 	err := repository.database.QueryRowContext(ctx,
-		`SELECT COUNT(*) FROM chore_assignments
-		WHERE user_id = ? AND status = 'completed' AND completed_at >= ?`,
-		userID, since,
+		`SELECT COUNT(*) FROM chore_assignments ca
+		JOIN chores c ON c.id = ca.chore_id AND c.status = 'completed'
+		WHERE ca.user_id = ? AND ca.status = 'completed' AND ca.completed_at >= ?`,
+		userID, since.UTC(),
 	).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("counting completed assignments: %w", err)
@@ -100,6 +157,26 @@ func (repository *SQLiteChoreAssignmentRepository) CompletedCountByUser(ctx cont
 	return count, nil
 }
 
+// CompletedPointsByUser sums the effort points of the user's completed
+// assignments since the given time, using the series' value for recurring
+// chores and the chore's own for one-offs. Like CompletedCountByUser it only
+// counts assignments on completed chores.
+func (repository *SQLiteChoreAssignmentRepository) CompletedPointsByUser(ctx context.Context, userID string, since time.Time) (int, error) {
+	var points int
+	err := repository.database.QueryRowContext(ctx,
+		`SELECT COALESCE(SUM(COALESCE(cs.effort_points, c.effort_points)), 0)
+		FROM chore_assignments ca
+		JOIN chores c ON c.id = ca.chore_id AND c.status = 'completed'
+		LEFT JOIN chore_series cs ON cs.id = c.series_id
+		WHERE ca.user_id = ? AND ca.status = 'completed' AND ca.completed_at >= ?`,
+		userID, since.UTC(),
+	).Scan(&points)
+	if err != nil {
+		return 0, fmt.Errorf("summing completed effort points: %w", err)
+	}
+	return points, nil
+}
+
 func (repository *SQLiteChoreAssignmentRepository) DeleteCompleted(ctx context.Context) error {
 	_, err := repository.database.ExecContext(ctx,
 		`DELETE FROM chore_assignments WHERE status = 'completed'`)
@@ -111,7 +188,7 @@ func (repository *SQLiteChoreAssignmentRepository) DeleteCompleted(ctx context.C
 
 func (repository *SQLiteChoreAssignmentRepository) RecentCompleted(ctx context.Context, limit int) ([]models.ChoreAssignment, error) {
 	rows, err := repository.database.QueryContext(ctx,
-		`SELECT id, chore_id, user_id, assigned_at, completed_at, status
+		`SELECT id, chore_id, user_id, assigned_at, completed_at, status, handed_over
 		FROM chore_assignments WHERE status = 'completed'
 		ORDER BY completed_at DESC LIMIT ?`, limit,
 	)
@@ -129,7 +206,7 @@ func scanChoreAssignments(rows *sql.Rows) ([]models.ChoreAssignment, error) {
 		var assignment models.ChoreAssignment
 		if err := rows.Scan(
 			&assignment.ID, &assignment.ChoreID, &assignment.UserID,
-			&assignment.AssignedAt, &assignment.CompletedAt, &assignment.Status,
+			&assignment.AssignedAt, &assignment.CompletedAt, &assignment.Status, &assignment.HandedOver,
 		); err != nil {
 			return nil, fmt.Errorf("scanning assignment: %w", err)
 		}
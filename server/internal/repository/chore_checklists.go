@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/google/uuid"
+)
+
+func (repository *SQLiteChoreRepository) GetChecklist(ctx context.Context, choreID string) ([]models.ChecklistItem, error) {
+	rows, err := repository.database.QueryContext(ctx,
+		`SELECT id, chore_id, position, title, checked_at, checked_by_user_id
+		FROM chore_checklist_items WHERE chore_id = ? ORDER BY position`,
+		choreID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("finding checklist: %w", err)
+	}
+	defer rows.Close()
+
+	var items []models.ChecklistItem
+	for rows.Next() {
+		var item models.ChecklistItem
+		if err := rows.Scan(&item.ID, &item.ChoreID, &item.Position, &item.Title, &item.CheckedAt, &item.CheckedByUserID); err != nil {
+			return nil, fmt.Errorf("scanning checklist item: %w", err)
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// SetChecklist replaces the chore's checklist with titles, in order. Items
+// whose title is unchanged keep their tick, so editing the list part-way
+// through a chore does not lose progress.
+func (repository *SQLiteChoreRepository) SetChecklist(ctx context.Context, choreID string, titles []string) error {
+	existing, err := repository.GetChecklist(ctx, choreID)
+	if err != nil {
+		return err
+	}
+	ticks := make(map[string][]models.ChecklistItem, len(existing))
+	for _, item := range existing {
+		ticks[item.Title] = append(ticks[item.Title], item)
+	}
+
+	transaction, err := repository.database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer transaction.Rollback()
+
+	if _, err := transaction.ExecContext(ctx, "DELETE FROM chore_checklist_items WHERE chore_id = ?", choreID); err != nil {
+		return fmt.Errorf("clearing checklist: %w", err)
+	}
+
+	for position, title := range titles {
+		item := models.ChecklistItem{ID: uuid.New().String()}
+		if previous := ticks[title]; len(previous) > 0 {
+			item = previous[0]
+			ticks[title] = previous[1:]
+		}
+		if _, err := transaction.ExecContext(ctx,
+			`INSERT INTO chore_checklist_items (id, chore_id, position, title, checked_at, checked_by_user_id)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			item.ID, choreID, position, title, item.CheckedAt, item.CheckedByUserID,
+		); err != nil {
+			return fmt.Errorf("inserting checklist item: %w", err)
+		}
+	}
+
+	return transaction.Commit()
+}
+
+// SetChecklistItemChecked ticks or clears one item. It returns sql.ErrNoRows
+// when the item does not belong to the chore.
+func (repository *SQLiteChoreRepository) SetChecklistItemChecked(ctx context.Context, choreID, itemID string, userID string, checked bool) error {
+	var checkedAt *time.Time
+	var checkedBy *string
+	if checked {
+		now := time.Now().UTC()
+		checkedAt = &now
+		checkedBy = &userID
+	}
+
+	result, err := repository.database.ExecContext(ctx,
+		"UPDATE chore_checklist_items SET checked_at = ?, checked_by_user_id = ? WHERE id = ? AND chore_id = ?",
+		checkedAt, checkedBy, itemID, choreID,
+	)
+	if err != nil {
+		return fmt.Errorf("updating checklist item: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("updating checklist item: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("updating checklist item: %w", sql.ErrNoRows)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/testutil"
+)
+
+func TestChoreRepository_SetChecklistKeepsTicks(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	repo := repository.NewChoreRepository(db)
+	ctx := context.Background()
+
+	alice := createTestUserNamed(t, userRepo, "alice")
+	chore, err := repo.Create(ctx, models.Chore{Name: "Clean bathroom", CreatedByUserID: alice.ID})
+	if err != nil {
+		t.Fatalf("creating chore: %v", err)
+	}
+
+	if err := repo.SetChecklist(ctx, chore.ID, []string{"Sink", "Bath", "Floor"}); err != nil {
+		t.Fatalf("SetChecklist: %v", err)
+	}
+	items, _ := repo.GetChecklist(ctx, chore.ID)
+	if err := repo.SetChecklistItemChecked(ctx, chore.ID, items[1].ID, alice.ID, true); err != nil {
+		t.Fatalf("SetChecklistItemChecked: %v", err)
+	}
+
+	// Reorder, drop one step and add another: the ticked step keeps its tick.
+	if err := repo.SetChecklist(ctx, chore.ID, []string{"Bath", "Mirror", "Sink"}); err != nil {
+		t.Fatalf("SetChecklist: %v", err)
+	}
+	items, err = repo.GetChecklist(ctx, chore.ID)
+	if err != nil {
+		t.Fatalf("GetChecklist: %v", err)
+	}
+	if len(items) != 3 || items[0].Title != "Bath" || items[1].Title != "Mirror" || items[2].Title != "Sink" {
+		t.Fatalf("unexpected checklist %+v", items)
+	}
+	if items[0].CheckedAt == nil || items[0].CheckedByUserID == nil || *items[0].CheckedByUserID != alice.ID {
+		t.Errorf("expected Bath to stay ticked by alice, got %+v", items[0])
+	}
+	if items[1].CheckedAt != nil || items[2].CheckedAt != nil {
+		t.Errorf("expected the other steps unticked, got %+v", items)
+	}
+
+	if err := repo.SetChecklistItemChecked(ctx, chore.ID, items[0].ID, alice.ID, false); err != nil {
+		t.Fatalf("clearing tick: %v", err)
+	}
+	if items, _ := repo.GetChecklist(ctx, chore.ID); items[0].CheckedAt != nil || items[0].CheckedByUserID != nil {
+		t.Errorf("expected Bath cleared, got %+v", items[0])
+	}
+
+	other, _ := repo.Create(ctx, models.Chore{Name: "Hoover", CreatedByUserID: alice.ID})
+	if err := repo.SetChecklistItemChecked(ctx, other.ID, items[0].ID, alice.ID, true); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows for another chore's item, got %v", err)
+	}
+}
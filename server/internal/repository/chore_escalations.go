@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/google/uuid"
+)
+
+type ChoreEscalationRepository interface {
+	Create(ctx context.Context, escalation models.ChoreEscalation) (models.ChoreEscalation, error)
+	// FindByChore returns the escalation steps taken on an occurrence, oldest
+	// first.
+	FindByChore(ctx context.Context, choreID string) ([]models.ChoreEscalation, error)
+}
+
+type SQLiteChoreEscalationRepository struct {
+	database *sql.DB
+}
+
+func NewChoreEscalationRepository(database *sql.DB) *SQLiteChoreEscalationRepository {
+	return &SQLiteChoreEscalationRepository{database: database}
+}
+
+const choreEscalationColumns = `id, chore_id, kind, user_id, from_user_id, created_at`
+
+func (repository *SQLiteChoreEscalationRepository) Create(ctx context.Context, escalation models.ChoreEscalation) (models.ChoreEscalation, error) {
+	return insertChoreEscalation(ctx, repository.database, escalation)
+}
+
+func insertChoreEscalation(ctx context.Context, database queryExecer, escalation models.ChoreEscalation) (models.ChoreEscalation, error) {
+	escalation.ID = uuid.New().String()
+	escalation.CreatedAt = time.Now().UTC()
+
+	_, err := database.ExecContext(ctx,
+		`INSERT INTO chore_escalations (`+choreEscalationColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		escalation.ID, escalation.ChoreID, escalation.Kind,
+		escalation.UserID, escalation.FromUserID, escalation.CreatedAt,
+	)
+	if err != nil {
+		return models.ChoreEscalation{}, fmt.Errorf("creating chore escalation: %w", err)
+	}
+	return escalation, nil
+}
+
+func (repository *SQLiteChoreEscalationRepository) FindByChore(ctx context.Context, choreID string) ([]models.ChoreEscalation, error) {
+	rows, err := repository.database.QueryContext(ctx,
+		`SELECT `+choreEscalationColumns+` FROM chore_escalations
+		WHERE chore_id = ?
+		ORDER BY created_at, rowid`,
+		choreID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("finding chore escalations: %w", err)
+	}
+	defer rows.Close()
+
+	var escalations []models.ChoreEscalation
+	for rows.Next() {
+		var escalation models.ChoreEscalation
+		if err := rows.Scan(
+			&escalation.ID, &escalation.ChoreID, &escalation.Kind,
+			&escalation.UserID, &escalation.FromUserID, &escalation.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning chore escalation: %w", err)
+		}
+		escalations = append(escalations, escalation)
+	}
+	return escalations, rows.Err()
+}
@@ -13,7 +13,9 @@ import (
 
 const choreSeriesColumns = `id, name, description, created_by_user_id, category_id,
 		due_time,
-		recurrence_type, recurrence_value, recur_on_complete, recurrence_until, recurrence_count,
+		recurrence_type, recurrence_value, recurrence_rule, recur_on_complete, recurrence_until, recurrence_count,
+		assignment_strategy, fixed_assignee_user_id, effort_points, checklist_required, requires_approval, group_chore, group_quorum,
+		prerequisite_delay_minutes, escalation_remind_hours, escalation_reassign_hours, escalation_notify_admins,
 		rotation_cursor_user_id, deleted_at,
 		created_at, updated_at`
 
@@ -21,12 +23,38 @@ type ChoreSeriesRepository interface {
 	// FindByID returns the series, or (nil, nil) when no such series exists, so
 	// callers can fall back to per-occurrence fields during the migration window.
 	FindByID(ctx context.Context, id string) (*models.ChoreSeries, error)
+	FindActive(ctx context.Context) ([]models.ChoreSeries, error)
 	Create(ctx context.Context, series models.ChoreSeries) (models.ChoreSeries, error)
 	Update(ctx context.Context, series models.ChoreSeries) error
 	SetRotationCursor(ctx context.Context, seriesID string, userID string) error
 	MarkDeleted(ctx context.Context, seriesID string) error
 	SetEligibleAssignees(ctx context.Context, seriesID string, userIDs []string) error
 	GetEligibleAssignees(ctx context.Context, seriesID string) ([]string, error)
+	SetChecklist(ctx context.Context, seriesID string, titles []string) error
+	GetChecklist(ctx context.Context, seriesID string) ([]string, error)
+	// AddException records that the slot on day was deleted (choreID nil) or
+	// is held by an occurrence edited on its own, replacing any earlier
+	// exception for the same slot.
+	AddException(ctx context.Context, seriesID string, day time.Time, choreID *string) error
+	GetExceptions(ctx context.Context, seriesID string) ([]models.SeriesException, error)
+	// DeleteExceptionsFrom forgets the exceptions for slots on or after day.
+	DeleteExceptionsFrom(ctx context.Context, seriesID string, day time.Time) error
+	// SetPrerequisites replaces the series' prerequisites and the delay
+	// before an occurrence falls due once they are done.
+	SetPrerequisites(ctx context.Context, seriesID string, prerequisiteIDs []string, delayMinutes int) error
+	// GetPrerequisites returns the series' prerequisites that have not been
+	// deleted.
+	GetPrerequisites(ctx context.Context, seriesID string) ([]string, error)
+	// FindDependents returns the series, not deleted, that list seriesID as a
+	// prerequisite.
+	FindDependents(ctx context.Context, seriesID string) ([]string, error)
+	// CopyPrerequisites gives toSeriesID the prerequisites and delay of
+	// fromSeriesID, and adds it as a prerequisite of every series waiting on
+	// fromSeriesID, in one transaction.
+	CopyPrerequisites(ctx context.Context, fromSeriesID, toSeriesID string) error
+	// SetSeasons replaces the series' active seasons, kept in the given order.
+	SetSeasons(ctx context.Context, seriesID string, seasons []models.SeasonWindow) error
+	GetSeasons(ctx context.Context, seriesID string) ([]models.SeasonWindow, error)
 }
 
 type SQLiteChoreSeriesRepository struct {
@@ -41,13 +69,7 @@ func (repository *SQLiteChoreSeriesRepository) FindByID(ctx context.Context, id
 	var series models.ChoreSeries
 	err := repository.database.QueryRowContext(ctx,
 		fmt.Sprintf("SELECT %s FROM chore_series WHERE id = ?", choreSeriesColumns), id,
-	).Scan(
-		&series.ID, &series.Name, &series.Description, &series.CreatedByUserID, &series.CategoryID,
-		&series.DueTime,
-		&series.RecurrenceType, &series.RecurrenceValue, &series.RecurOnComplete, &series.RecurrenceUntil, &series.RecurrenceCount,
-		&series.RotationCursorUserID, &series.DeletedAt,
-		&series.CreatedAt, &series.UpdatedAt,
-	)
+	).Scan(scanChoreSeriesFields(&series)...)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
@@ -60,31 +82,110 @@ func (repository *SQLiteChoreSeriesRepository) FindByID(ctx context.Context, id
 		return nil, err
 	}
 	series.EligibleAssignees = assignees
+
+	checklist, err := repository.GetChecklist(ctx, series.ID)
+	if err != nil {
+		return nil, err
+	}
+	series.Checklist = checklist
+
+	exceptions, err := repository.GetExceptions(ctx, series.ID)
+	if err != nil {
+		return nil, err
+	}
+	series.Exceptions = exceptions
+
+	prerequisites, err := repository.GetPrerequisites(ctx, series.ID)
+	if err != nil {
+		return nil, err
+	}
+	series.Prerequisites = prerequisites
+
+	seasons, err := repository.GetSeasons(ctx, series.ID)
+	if err != nil {
+		return nil, err
+	}
+	series.Seasons = seasons
 	return &series, nil
 }
 
+// FindActive returns every series that has not been deleted, by name, with
+// its eligible assignees loaded.
+func (repository *SQLiteChoreSeriesRepository) FindActive(ctx context.Context) ([]models.ChoreSeries, error) {
+	rows, err := repository.database.QueryContext(ctx,
+		fmt.Sprintf("SELECT %s FROM chore_series WHERE deleted_at IS NULL ORDER BY name, id", choreSeriesColumns),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("finding active chore series: %w", err)
+	}
+	var all []models.ChoreSeries
+	for rows.Next() {
+		var series models.ChoreSeries
+		if err := rows.Scan(scanChoreSeriesFields(&series)...); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scanning chore series: %w", err)
+		}
+		all = append(all, series)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range all {
+		assignees, err := repository.GetEligibleAssignees(ctx, all[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		all[i].EligibleAssignees = assignees
+	}
+	return all, nil
+}
+
+func scanChoreSeriesFields(series *models.ChoreSeries) []any {
+	return []any{
+		&series.ID, &series.Name, &series.Description, &series.CreatedByUserID, &series.CategoryID,
+		&series.DueTime,
+		&series.RecurrenceType, &series.RecurrenceValue, &series.RecurrenceRule, &series.RecurOnComplete, &series.RecurrenceUntil, &series.RecurrenceCount,
+		&series.AssignmentStrategy, &series.FixedAssigneeUserID, &series.EffortPoints, &series.ChecklistRequired, &series.RequiresApproval, &series.GroupChore, &series.GroupQuorum,
+		&series.PrerequisiteDelayMinutes, &series.EscalationRemindHours, &series.EscalationReassignHours, &series.EscalationNotifyAdmins,
+		&series.RotationCursorUserID, &series.DeletedAt,
+		&series.CreatedAt, &series.UpdatedAt,
+	}
+}
+
 func (repository *SQLiteChoreSeriesRepository) Create(ctx context.Context, series models.ChoreSeries) (models.ChoreSeries, error) {
 	if series.ID == "" {
 		series.ID = uuid.New().String()
 	}
-	now := time.Now()
+	now := time.Now().UTC()
 	series.CreatedAt = now
 	series.UpdatedAt = now
 	if series.RecurrenceType == "" {
 		series.RecurrenceType = models.RecurrenceNone
 	}
+	if series.AssignmentStrategy == "" {
+		series.AssignmentStrategy = models.AssignmentRoundRobin
+	}
+	if series.EffortPoints < 1 {
+		series.EffortPoints = 1
+	}
 
 	_, err := repository.database.ExecContext(ctx,
 		`INSERT INTO chore_series (id, name, description, created_by_user_id, category_id,
 			due_time,
-			recurrence_type, recurrence_value, recur_on_complete, recurrence_until, recurrence_count,
+			recurrence_type, recurrence_value, recurrence_rule, recur_on_complete, recurrence_until, recurrence_count,
+			assignment_strategy, fixed_assignee_user_id, effort_points, checklist_required, requires_approval, group_chore, group_quorum,
+			escalation_remind_hours, escalation_reassign_hours, escalation_notify_admins,
 			rotation_cursor_user_id, deleted_at,
 			created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		series.ID, series.Name, series.Description, series.CreatedByUserID, series.CategoryID,
 		series.DueTime,
-		series.RecurrenceType, series.RecurrenceValue, series.RecurOnComplete, series.RecurrenceUntil, series.RecurrenceCount,
-		series.RotationCursorUserID, series.DeletedAt,
+		series.RecurrenceType, series.RecurrenceValue, series.RecurrenceRule, series.RecurOnComplete, series.RecurrenceUntil, series.RecurrenceCount,
+		series.AssignmentStrategy, series.FixedAssigneeUserID, series.EffortPoints, series.ChecklistRequired, series.RequiresApproval, series.GroupChore, series.GroupQuorum,
+		series.EscalationRemindHours, series.EscalationReassignHours, series.EscalationNotifyAdmins,
+		series.RotationCursorUserID, inUTC(series.DeletedAt),
 		series.CreatedAt, series.UpdatedAt,
 	)
 	if err != nil {
@@ -94,18 +195,28 @@ func (repository *SQLiteChoreSeriesRepository) Create(ctx context.Context, serie
 }
 
 func (repository *SQLiteChoreSeriesRepository) Update(ctx context.Context, series models.ChoreSeries) error {
-	series.UpdatedAt = time.Now()
+	series.UpdatedAt = time.Now().UTC()
+	if series.AssignmentStrategy == "" {
+		series.AssignmentStrategy = models.AssignmentRoundRobin
+	}
+	if series.EffortPoints < 1 {
+		series.EffortPoints = 1
+	}
 	_, err := repository.database.ExecContext(ctx,
 		`UPDATE chore_series SET name = ?, description = ?, category_id = ?,
 			due_time = ?,
-			recurrence_type = ?, recurrence_value = ?, recur_on_complete = ?, recurrence_until = ?, recurrence_count = ?,
+			recurrence_type = ?, recurrence_value = ?, recurrence_rule = ?, recur_on_complete = ?, recurrence_until = ?, recurrence_count = ?,
+			assignment_strategy = ?, fixed_assignee_user_id = ?, effort_points = ?, checklist_required = ?, requires_approval = ?, group_chore = ?, group_quorum = ?,
+			escalation_remind_hours = ?, escalation_reassign_hours = ?, escalation_notify_admins = ?,
 			rotation_cursor_user_id = ?, deleted_at = ?,
 			updated_at = ?
 		WHERE id = ?`,
 		series.Name, series.Description, series.CategoryID,
 		series.DueTime,
-		series.RecurrenceType, series.RecurrenceValue, series.RecurOnComplete, series.RecurrenceUntil, series.RecurrenceCount,
-		series.RotationCursorUserID, series.DeletedAt,
+		series.RecurrenceType, series.RecurrenceValue, series.RecurrenceRule, series.RecurOnComplete, series.RecurrenceUntil, series.RecurrenceCount,
+		series.AssignmentStrategy, series.FixedAssigneeUserID, series.EffortPoints, series.ChecklistRequired, series.RequiresApproval, series.GroupChore, series.GroupQuorum,
+		series.EscalationRemindHours, series.EscalationReassignHours, series.EscalationNotifyAdmins,
+		series.RotationCursorUserID, inUTC(series.DeletedAt),
 		series.UpdatedAt, series.ID,
 	)
 	if err != nil {
@@ -117,7 +228,7 @@ func (repository *SQLiteChoreSeriesRepository) Update(ctx context.Context, serie
 func (repository *SQLiteChoreSeriesRepository) SetRotationCursor(ctx context.Context, seriesID string, userID string) error {
 	_, err := repository.database.ExecContext(ctx,
 		"UPDATE chore_series SET rotation_cursor_user_id = ?, updated_at = ? WHERE id = ?",
-		userID, time.Now(), seriesID,
+		userID, time.Now().UTC(), seriesID,
 	)
 	if err != nil {
 		return fmt.Errorf("setting rotation cursor: %w", err)
@@ -128,7 +239,7 @@ func (repository *SQLiteChoreSeriesRepository) SetRotationCursor(ctx context.Con
 func (repository *SQLiteChoreSeriesRepository) MarkDeleted(ctx context.Context, seriesID string) error {
 	_, err := repository.database.ExecContext(ctx,
 		"UPDATE chore_series SET deleted_at = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL",
-		time.Now(), time.Now(), seriesID,
+		time.Now().UTC(), time.Now().UTC(), seriesID,
 	)
 	if err != nil {
 		return fmt.Errorf("marking series deleted: %w", err)
@@ -179,3 +290,254 @@ func (repository *SQLiteChoreSeriesRepository) GetEligibleAssignees(ctx context.
 	}
 	return userIDs, rows.Err()
 }
+
+func (repository *SQLiteChoreSeriesRepository) SetChecklist(ctx context.Context, seriesID string, titles []string) error {
+	transaction, err := repository.database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer transaction.Rollback()
+
+	if _, err := transaction.ExecContext(ctx, "DELETE FROM chore_series_checklist_items WHERE series_id = ?", seriesID); err != nil {
+		return fmt.Errorf("clearing series checklist: %w", err)
+	}
+
+	for position, title := range titles {
+		if _, err := transaction.ExecContext(ctx,
+			"INSERT INTO chore_series_checklist_items (series_id, position, title) VALUES (?, ?, ?)",
+			seriesID, position, title,
+		); err != nil {
+			return fmt.Errorf("inserting series checklist item: %w", err)
+		}
+	}
+
+	return transaction.Commit()
+}
+
+func (repository *SQLiteChoreSeriesRepository) GetChecklist(ctx context.Context, seriesID string) ([]string, error) {
+	rows, err := repository.database.QueryContext(ctx,
+		"SELECT title FROM chore_series_checklist_items WHERE series_id = ? ORDER BY position",
+		seriesID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("finding series checklist: %w", err)
+	}
+	defer rows.Close()
+
+	var titles []string
+	for rows.Next() {
+		var title string
+		if err := rows.Scan(&title); err != nil {
+			return nil, fmt.Errorf("scanning series checklist item: %w", err)
+		}
+		titles = append(titles, title)
+	}
+	return titles, rows.Err()
+}
+
+// exceptionDateFormat is how a slot is keyed in chore_series_exceptions.
+const exceptionDateFormat = "2006-01-02"
+
+func (repository *SQLiteChoreSeriesRepository) AddException(ctx context.Context, seriesID string, day time.Time, choreID *string) error {
+	return addSeriesException(ctx, repository.database, seriesID, day, choreID)
+}
+
+func addSeriesException(ctx context.Context, database queryExecer, seriesID string, day time.Time, choreID *string) error {
+	_, err := database.ExecContext(ctx,
+		`INSERT INTO chore_series_exceptions (series_id, occurrence_date, chore_id, created_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (series_id, occurrence_date) DO UPDATE SET chore_id = excluded.chore_id`,
+		seriesID, day.Format(exceptionDateFormat), choreID, time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("adding series exception: %w", err)
+	}
+	return nil
+}
+
+func (repository *SQLiteChoreSeriesRepository) GetExceptions(ctx context.Context, seriesID string) ([]models.SeriesException, error) {
+	rows, err := repository.database.QueryContext(ctx,
+		`SELECT series_id, occurrence_date, chore_id, created_at FROM chore_series_exceptions
+		WHERE series_id = ? ORDER BY occurrence_date`,
+		seriesID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("finding series exceptions: %w", err)
+	}
+	defer rows.Close()
+
+	var exceptions []models.SeriesException
+	for rows.Next() {
+		var exception models.SeriesException
+		var day string
+		if err := rows.Scan(&exception.SeriesID, &day, &exception.ChoreID, &exception.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning series exception: %w", err)
+		}
+		exception.OccurrenceDate, err = time.Parse(exceptionDateFormat, day)
+		if err != nil {
+			return nil, fmt.Errorf("parsing series exception date: %w", err)
+		}
+		exceptions = append(exceptions, exception)
+	}
+	return exceptions, rows.Err()
+}
+
+func (repository *SQLiteChoreSeriesRepository) DeleteExceptionsFrom(ctx context.Context, seriesID string, day time.Time) error {
+	_, err := repository.database.ExecContext(ctx,
+		"DELETE FROM chore_series_exceptions WHERE series_id = ? AND occurrence_date >= ?",
+		seriesID, day.Format(exceptionDateFormat),
+	)
+	if err != nil {
+		return fmt.Errorf("deleting series exceptions: %w", err)
+	}
+	return nil
+}
+
+func (repository *SQLiteChoreSeriesRepository) SetPrerequisites(ctx context.Context, seriesID string, prerequisiteIDs []string, delayMinutes int) error {
+	transaction, err := repository.database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer transaction.Rollback()
+
+	if _, err := transaction.ExecContext(ctx, "DELETE FROM chore_series_prerequisites WHERE series_id = ?", seriesID); err != nil {
+		return fmt.Errorf("clearing series prerequisites: %w", err)
+	}
+
+	for _, prerequisiteID := range prerequisiteIDs {
+		if _, err := transaction.ExecContext(ctx,
+			"INSERT INTO chore_series_prerequisites (series_id, prerequisite_series_id) VALUES (?, ?)",
+			seriesID, prerequisiteID,
+		); err != nil {
+			return fmt.Errorf("inserting series prerequisite: %w", err)
+		}
+	}
+
+	if _, err := transaction.ExecContext(ctx,
+		"UPDATE chore_series SET prerequisite_delay_minutes = ?, updated_at = ? WHERE id = ?",
+		delayMinutes, time.Now().UTC(), seriesID,
+	); err != nil {
+		return fmt.Errorf("setting prerequisite delay: %w", err)
+	}
+
+	return transaction.Commit()
+}
+
+func (repository *SQLiteChoreSeriesRepository) GetPrerequisites(ctx context.Context, seriesID string) ([]string, error) {
+	return repository.findSeriesIDs(ctx,
+		`SELECT p.prerequisite_series_id FROM chore_series_prerequisites p
+		JOIN chore_series cs ON cs.id = p.prerequisite_series_id
+		WHERE p.series_id = ? AND cs.deleted_at IS NULL
+		ORDER BY p.prerequisite_series_id`,
+		seriesID,
+	)
+}
+
+func (repository *SQLiteChoreSeriesRepository) FindDependents(ctx context.Context, seriesID string) ([]string, error) {
+	return repository.findSeriesIDs(ctx,
+		`SELECT p.series_id FROM chore_series_prerequisites p
+		JOIN chore_series cs ON cs.id = p.series_id
+		WHERE p.prerequisite_series_id = ? AND cs.deleted_at IS NULL
+		ORDER BY p.series_id`,
+		seriesID,
+	)
+}
+
+func (repository *SQLiteChoreSeriesRepository) CopyPrerequisites(ctx context.Context, fromSeriesID, toSeriesID string) error {
+	transaction, err := repository.database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer transaction.Rollback()
+
+	if _, err := transaction.ExecContext(ctx,
+		`INSERT OR IGNORE INTO chore_series_prerequisites (series_id, prerequisite_series_id)
+		SELECT ?, prerequisite_series_id FROM chore_series_prerequisites WHERE series_id = ?`,
+		toSeriesID, fromSeriesID,
+	); err != nil {
+		return fmt.Errorf("copying series prerequisites: %w", err)
+	}
+
+	if _, err := transaction.ExecContext(ctx,
+		`INSERT OR IGNORE INTO chore_series_prerequisites (series_id, prerequisite_series_id)
+		SELECT series_id, ? FROM chore_series_prerequisites WHERE prerequisite_series_id = ?`,
+		toSeriesID, fromSeriesID,
+	); err != nil {
+		return fmt.Errorf("copying series dependents: %w", err)
+	}
+
+	if _, err := transaction.ExecContext(ctx,
+		`UPDATE chore_series SET prerequisite_delay_minutes =
+			(SELECT prerequisite_delay_minutes FROM chore_series WHERE id = ?), updated_at = ?
+		WHERE id = ?`,
+		fromSeriesID, time.Now().UTC(), toSeriesID,
+	); err != nil {
+		return fmt.Errorf("copying prerequisite delay: %w", err)
+	}
+
+	return transaction.Commit()
+}
+
+func (repository *SQLiteChoreSeriesRepository) findSeriesIDs(ctx context.Context, query string, args ...any) ([]string, error) {
+	rows, err := repository.database.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("finding series prerequisites: %w", err)
+	}
+	defer rows.Close()
+
+	var seriesIDs []string
+	for rows.Next() {
+		var seriesID string
+		if err := rows.Scan(&seriesID); err != nil {
+			return nil, fmt.Errorf("scanning series prerequisite: %w", err)
+		}
+		seriesIDs = append(seriesIDs, seriesID)
+	}
+	return seriesIDs, rows.Err()
+}
+
+func (repository *SQLiteChoreSeriesRepository) SetSeasons(ctx context.Context, seriesID string, seasons []models.SeasonWindow) error {
+	transaction, err := repository.database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer transaction.Rollback()
+
+	if _, err := transaction.ExecContext(ctx, "DELETE FROM chore_series_seasons WHERE series_id = ?", seriesID); err != nil {
+		return fmt.Errorf("clearing series seasons: %w", err)
+	}
+
+	for position, season := range seasons {
+		if _, err := transaction.ExecContext(ctx,
+			`INSERT INTO chore_series_seasons (series_id, position, start_month, start_day, end_month, end_day)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			seriesID, position, int(season.StartMonth), season.StartDay, int(season.EndMonth), season.EndDay,
+		); err != nil {
+			return fmt.Errorf("inserting series season: %w", err)
+		}
+	}
+
+	return transaction.Commit()
+}
+
+func (repository *SQLiteChoreSeriesRepository) GetSeasons(ctx context.Context, seriesID string) ([]models.SeasonWindow, error) {
+	rows, err := repository.database.QueryContext(ctx,
+		`SELECT start_month, start_day, end_month, end_day FROM chore_series_seasons
+		WHERE series_id = ? ORDER BY position`,
+		seriesID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("finding series seasons: %w", err)
+	}
+	defer rows.Close()
+
+	var seasons []models.SeasonWindow
+	for rows.Next() {
+		var season models.SeasonWindow
+		if err := rows.Scan(&season.StartMonth, &season.StartDay, &season.EndMonth, &season.EndDay); err != nil {
+			return nil, fmt.Errorf("scanning series season: %w", err)
+		}
+		seasons = append(seasons, season)
+	}
+	return seasons, rows.Err()
+}
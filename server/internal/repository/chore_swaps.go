@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/google/uuid"
+)
+
+// ErrSwapStale is returned by Accept when one of the swapped chores is no
+// longer open or has changed hands since the swap was proposed.
+var ErrSwapStale = errors.New("swap no longer applies")
+
+type ChoreSwapRepository interface {
+	Create(ctx context.Context, swap models.ChoreSwap) (models.ChoreSwap, error)
+	FindByID(ctx context.Context, id string) (models.ChoreSwap, error)
+	FindPendingByUser(ctx context.Context, userID string) ([]models.ChoreSwap, error)
+	FindPendingByChore(ctx context.Context, choreID string) ([]models.ChoreSwap, error)
+	UpdateStatus(ctx context.Context, id string, status models.ChoreSwapStatus) error
+	Accept(ctx context.Context, swap models.ChoreSwap) error
+}
+
+type SQLiteChoreSwapRepository struct {
+	database *sql.DB
+}
+
+func NewChoreSwapRepository(database *sql.DB) *SQLiteChoreSwapRepository {
+	return &SQLiteChoreSwapRepository{database: database}
+}
+
+const choreSwapColumns = `id, chore_id, requested_chore_id, from_user_id, to_user_id, status, created_at, responded_at`
+
+func (repository *SQLiteChoreSwapRepository) Create(ctx context.Context, swap models.ChoreSwap) (models.ChoreSwap, error) {
+	swap.ID = uuid.New().String()
+	swap.CreatedAt = time.Now().UTC()
+	if swap.Status == "" {
+		swap.Status = models.ChoreSwapPending
+	}
+
+	_, err := repository.database.ExecContext(ctx,
+		`INSERT INTO chore_swaps (`+choreSwapColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		swap.ID, swap.ChoreID, swap.RequestedChoreID, swap.FromUserID, swap.ToUserID,
+		swap.Status, swap.CreatedAt, inUTC(swap.RespondedAt),
+	)
+	if err != nil {
+		return models.ChoreSwap{}, fmt.Errorf("creating chore swap: %w", err)
+	}
+	return swap, nil
+}
+
+func (repository *SQLiteChoreSwapRepository) FindByID(ctx context.Context, id string) (models.ChoreSwap, error) {
+	var swap models.ChoreSwap
+	err := repository.database.QueryRowContext(ctx,
+		`SELECT `+choreSwapColumns+` FROM chore_swaps WHERE id = ?`, id,
+	).Scan(
+		&swap.ID, &swap.ChoreID, &swap.RequestedChoreID, &swap.FromUserID, &swap.ToUserID,
+		&swap.Status, &swap.CreatedAt, &swap.RespondedAt,
+	)
+	if err != nil {
+		return swap, fmt.Errorf("finding chore swap: %w", err)
+	}
+	return swap, nil
+}
+
+// FindPendingByUser returns open swaps the user proposed or has been asked to
+// answer, newest first.
+func (repository *SQLiteChoreSwapRepository) FindPendingByUser(ctx context.Context, userID string) ([]models.ChoreSwap, error) {
+	rows, err := repository.database.QueryContext(ctx,
+		`SELECT `+choreSwapColumns+` FROM chore_swaps
+		WHERE status = 'pending' AND (from_user_id = ? OR to_user_id = ?)
+		ORDER BY created_at DESC`,
+		userID, userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("finding pending swaps by user: %w", err)
+	}
+	defer rows.Close()
+
+	return scanChoreSwaps(rows)
+}
+
+// FindPendingByChore returns open swaps that offer or request the chore.
+func (repository *SQLiteChoreSwapRepository) FindPendingByChore(ctx context.Context, choreID string) ([]models.ChoreSwap, error) {
+	rows, err := repository.database.QueryContext(ctx,
+		`SELECT `+choreSwapColumns+` FROM chore_swaps
+		WHERE status = 'pending' AND (chore_id = ? OR requested_chore_id = ?)
+		ORDER BY created_at DESC`,
+		choreID, choreID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("finding pending swaps by chore: %w", err)
+	}
+	defer rows.Close()
+
+	return scanChoreSwaps(rows)
+}
+
+func (repository *SQLiteChoreSwapRepository) UpdateStatus(ctx context.Context, id string, status models.ChoreSwapStatus) error {
+	_, err := repository.database.ExecContext(ctx,
+		"UPDATE chore_swaps SET status = ?, responded_at = ? WHERE id = ?",
+		status, time.Now().UTC(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("updating chore swap status: %w", err)
+	}
+	return nil
+}
+
+// Accept hands the offered chore to ToUserID and, for a trade, the requested
+// chore to FromUserID in a single transaction. Each chore's open assignment is
+// closed as reassigned and a new one is opened for its new owner. Nothing is
+// written and ErrSwapStale is returned if either chore is no longer open or
+// assigned to the user who held it when the swap was proposed.
+func (repository *SQLiteChoreSwapRepository) Accept(ctx context.Context, swap models.ChoreSwap) error {
+	transaction, err := repository.database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer transaction.Rollback()
+
+	now := time.Now().UTC()
+	if err := handOverChore(ctx, transaction, swap.ChoreID, swap.FromUserID, swap.ToUserID, now); err != nil {
+		return err
+	}
+	if swap.RequestedChoreID != nil {
+		if err := handOverChore(ctx, transaction, *swap.RequestedChoreID, swap.ToUserID, swap.FromUserID, now); err != nil {
+			return err
+		}
+	}
+
+	result, err := transaction.ExecContext(ctx,
+		"UPDATE chore_swaps SET status = ?, responded_at = ? WHERE id = ? AND status = 'pending'",
+		models.ChoreSwapAccepted, now, swap.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("accepting chore swap: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected != 1 {
+		return ErrSwapStale
+	}
+
+	return transaction.Commit()
+}
+
+func handOverChore(ctx context.Context, transaction *sql.Tx, choreID, fromUserID, toUserID string, now time.Time) error {
+	result, err := transaction.ExecContext(ctx,
+		`UPDATE chores SET assigned_to_user_id = ?, updated_at = ?
+		WHERE id = ? AND assigned_to_user_id = ? AND status IN ('pending', 'overdue')`,
+		toUserID, now, choreID, fromUserID,
+	)
+	if err != nil {
+		return fmt.Errorf("reassigning swapped chore: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected != 1 {
+		return ErrSwapStale
+	}
+	return handOverAssignment(ctx, transaction, choreID, toUserID, true, now)
+}
+
+func scanChoreSwaps(rows *sql.Rows) ([]models.ChoreSwap, error) {
+	var swaps []models.ChoreSwap
+	for rows.Next() {
+		var swap models.ChoreSwap
+		if err := rows.Scan(
+			&swap.ID, &swap.ChoreID, &swap.RequestedChoreID, &swap.FromUserID, &swap.ToUserID,
+			&swap.Status, &swap.CreatedAt, &swap.RespondedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning chore swap: %w", err)
+		}
+		swaps = append(swaps, swap)
+	}
+	return swaps, rows.Err()
+}
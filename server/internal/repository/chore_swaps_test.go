@@ -0,0 +1,118 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/testutil"
+)
+
+func TestChoreSwapRepository_AcceptTrade(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	assignmentRepo := repository.NewChoreAssignmentRepository(db)
+	swapRepo := repository.NewChoreSwapRepository(db)
+	ctx := context.Background()
+
+	alice := createTestUserNamed(t, userRepo, "alice")
+	bob := createTestUserNamed(t, userRepo, "bob")
+
+	dishes := createAssignedChore(t, choreRepo, assignmentRepo, "Dishes", alice.ID)
+	bins := createAssignedChore(t, choreRepo, assignmentRepo, "Bins", bob.ID)
+
+	swap, err := swapRepo.Create(ctx, models.ChoreSwap{
+		ChoreID:          dishes.ID,
+		RequestedChoreID: &bins.ID,
+		FromUserID:       alice.ID,
+		ToUserID:         bob.ID,
+	})
+	if err != nil {
+		t.Fatalf("creating swap: %v", err)
+	}
+
+	if err := swapRepo.Accept(ctx, swap); err != nil {
+		t.Fatalf("accepting swap: %v", err)
+	}
+
+	dishes, _ = choreRepo.FindByID(ctx, dishes.ID)
+	bins, _ = choreRepo.FindByID(ctx, bins.ID)
+	if *dishes.AssignedToUserID != bob.ID || *bins.AssignedToUserID != alice.ID {
+		t.Errorf("chores not swapped: dishes=%s bins=%s", *dishes.AssignedToUserID, *bins.AssignedToUserID)
+	}
+
+	history, _ := assignmentRepo.FindByChoreID(ctx, dishes.ID)
+	statuses := map[models.AssignmentStatus]string{}
+	for _, assignment := range history {
+		statuses[assignment.Status] = assignment.UserID
+	}
+	if statuses[models.AssignmentStatusReassigned] != alice.ID || statuses[models.AssignmentStatusAssigned] != bob.ID {
+		t.Errorf("expected reassigned history for alice and open assignment for bob, got %v", statuses)
+	}
+
+	accepted, _ := swapRepo.FindByID(ctx, swap.ID)
+	if accepted.Status != models.ChoreSwapAccepted || accepted.RespondedAt == nil {
+		t.Errorf("expected accepted swap with response time, got %s", accepted.Status)
+	}
+}
+
+func TestChoreSwapRepository_AcceptStaleChangesNothing(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	assignmentRepo := repository.NewChoreAssignmentRepository(db)
+	swapRepo := repository.NewChoreSwapRepository(db)
+	ctx := context.Background()
+
+	alice := createTestUserNamed(t, userRepo, "alice")
+	bob := createTestUserNamed(t, userRepo, "bob")
+
+	dishes := createAssignedChore(t, choreRepo, assignmentRepo, "Dishes", alice.ID)
+	bins := createAssignedChore(t, choreRepo, assignmentRepo, "Bins", bob.ID)
+
+	swap, _ := swapRepo.Create(ctx, models.ChoreSwap{
+		ChoreID:          dishes.ID,
+		RequestedChoreID: &bins.ID,
+		FromUserID:       alice.ID,
+		ToUserID:         bob.ID,
+	})
+
+	bins.Status = models.ChoreStatusCompleted
+	if err := choreRepo.Update(ctx, bins); err != nil {
+		t.Fatalf("completing bins: %v", err)
+	}
+
+	if err := swapRepo.Accept(ctx, swap); !errors.Is(err, repository.ErrSwapStale) {
+		t.Fatalf("expected ErrSwapStale, got %v", err)
+	}
+
+	dishes, _ = choreRepo.FindByID(ctx, dishes.ID)
+	if *dishes.AssignedToUserID != alice.ID {
+		t.Error("a stale swap must not move the offered chore")
+	}
+	history, _ := assignmentRepo.FindByChoreID(ctx, dishes.ID)
+	if len(history) != 1 || history[0].Status != models.AssignmentStatusAssigned {
+		t.Errorf("a stale swap must not touch assignment history, got %+v", history)
+	}
+}
+
+func createAssignedChore(t *testing.T, choreRepo *repository.SQLiteChoreRepository, assignmentRepo *repository.SQLiteChoreAssignmentRepository, name, userID string) models.Chore {
+	t.Helper()
+	ctx := context.Background()
+	chore, err := choreRepo.Create(ctx, models.Chore{
+		Name:             name,
+		CreatedByUserID:  userID,
+		AssignedToUserID: &userID,
+		Status:           models.ChoreStatusPending,
+	})
+	if err != nil {
+		t.Fatalf("creating chore %s: %v", name, err)
+	}
+	if _, err := assignmentRepo.Create(ctx, models.ChoreAssignment{ChoreID: chore.ID, UserID: userID}); err != nil {
+		t.Fatalf("creating assignment for %s: %v", name, err)
+	}
+	return chore
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/google/uuid"
+)
+
+type ChoreTimeSegmentRepository interface {
+	// Start opens a running segment for the user on the chore, failing if
+	// they already have one.
+	Start(ctx context.Context, choreID, userID string, at time.Time) (models.ChoreTimeSegment, error)
+	// EndRunning closes the chore's running segments at at, only userID's
+	// when it is set, and reports how many it closed.
+	EndRunning(ctx context.Context, choreID string, userID *string, at time.Time) (int, error)
+	// FindByChore returns the chore's segments in the order they started.
+	FindByChore(ctx context.Context, choreID string) ([]models.ChoreTimeSegment, error)
+	// SeriesAverage is the average time timed on the series' completed
+	// occurrences, over the occurrences with any time recorded.
+	SeriesAverage(ctx context.Context, seriesID string) (time.Duration, int, error)
+}
+
+type SQLiteChoreTimeSegmentRepository struct {
+	database *sql.DB
+}
+
+func NewChoreTimeSegmentRepository(database *sql.DB) *SQLiteChoreTimeSegmentRepository {
+	return &SQLiteChoreTimeSegmentRepository{database: database}
+}
+
+const choreTimeSegmentColumns = `id, chore_id, user_id, started_at, ended_at`
+
+// segmentSeconds totals a set of closed segments in seconds. Segments are
+// stored in UTC, so the leading date and time are all SQLite needs.
+const segmentSeconds = `SUM(julianday(substr(s.ended_at, 1, 19)) - julianday(substr(s.started_at, 1, 19))) * 86400`
+
+func (repository *SQLiteChoreTimeSegmentRepository) Start(ctx context.Context, choreID, userID string, at time.Time) (models.ChoreTimeSegment, error) {
+	segment := models.ChoreTimeSegment{
+		ID:        uuid.New().String(),
+		ChoreID:   choreID,
+		UserID:    userID,
+		StartedAt: at.UTC(),
+	}
+	_, err := repository.database.ExecContext(ctx,
+		`INSERT INTO chore_time_segments (`+choreTimeSegmentColumns+`) VALUES (?, ?, ?, ?, NULL)`,
+		segment.ID, segment.ChoreID, segment.UserID, segment.StartedAt,
+	)
+	if err != nil {
+		return models.ChoreTimeSegment{}, fmt.Errorf("starting time segment: %w", err)
+	}
+	return segment, nil
+}
+
+func (repository *SQLiteChoreTimeSegmentRepository) EndRunning(ctx context.Context, choreID string, userID *string, at time.Time) (int, error) {
+	query := `UPDATE chore_time_segments SET ended_at = ? WHERE chore_id = ? AND ended_at IS NULL`
+	args := []any{at.UTC(), choreID}
+	if userID != nil {
+		query += ` AND user_id = ?`
+		args = append(args, *userID)
+	}
+	result, err := repository.database.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("ending time segments: %w", err)
+	}
+	ended, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("counting ended time segments: %w", err)
+	}
+	return int(ended), nil
+}
+
+func (repository *SQLiteChoreTimeSegmentRepository) FindByChore(ctx context.Context, choreID string) ([]models.ChoreTimeSegment, error) {
+	rows, err := repository.database.QueryContext(ctx,
+		`SELECT `+choreTimeSegmentColumns+` FROM chore_time_segments
+		WHERE chore_id = ?
+		ORDER BY started_at, rowid`,
+		choreID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("finding time segments: %w", err)
+	}
+	defer rows.Close()
+
+	var segments []models.ChoreTimeSegment
+	for rows.Next() {
+		var segment models.ChoreTimeSegment
+		if err := rows.Scan(&segment.ID, &segment.ChoreID, &segment.UserID, &segment.StartedAt, &segment.EndedAt); err != nil {
+			return nil, fmt.Errorf("scanning time segment: %w", err)
+		}
+		segments = append(segments, segment)
+	}
+	return segments, rows.Err()
+}
+
+func (repository *SQLiteChoreTimeSegmentRepository) SeriesAverage(ctx context.Context, seriesID string) (time.Duration, int, error) {
+	var samples int
+	var seconds float64
+	err := repository.database.QueryRowContext(ctx,
+		`SELECT COUNT(*), COALESCE(AVG(total), 0) FROM (
+			SELECT `+segmentSeconds+` AS total
+			FROM chore_time_segments s
+			JOIN chores c ON c.id = s.chore_id
+			WHERE c.series_id = ? AND c.status = 'completed' AND s.ended_at IS NOT NULL
+			GROUP BY s.chore_id
+		)`,
+		seriesID,
+	).Scan(&samples, &seconds)
+	if err != nil {
+		return 0, 0, fmt.Errorf("averaging series duration: %w", err)
+	}
+	return time.Duration(seconds * float64(time.Second)).Round(time.Second), samples, nil
+}
@@ -22,6 +22,7 @@ type ChoreFilter struct {
 	RecurrenceTypes    []models.RecurrenceType
 	AssignedToUser     *string
 	CategoryID         *string
+	SeriesID           *string
 	DueBefore          *time.Time
 	DueAfter           *time.Time
 	OrderBy            string
@@ -35,16 +36,30 @@ type ChoreRepository interface {
 	Create(ctx context.Context, chore models.Chore) (models.Chore, error)
 	Update(ctx context.Context, chore models.Chore) error
 	Delete(ctx context.Context, id string) error
-	FindOverdueChores(ctx context.Context) ([]models.Chore, error)
+	FindOverdueChores(ctx context.Context, now time.Time) ([]models.Chore, error)
 	MarkOverdue(ctx context.Context, choreID string) error
-	FindDueToday(ctx context.Context) ([]models.Chore, error)
+	FindDueToday(ctx context.Context, now time.Time) ([]models.Chore, error)
 	CountByStatusAndUser(ctx context.Context, status models.ChoreStatus, userID string) (int, error)
 	SetEligibleAssignees(ctx context.Context, choreID string, userIDs []string) error
 	GetEligibleAssignees(ctx context.Context, choreID string) ([]string, error)
 	DeleteFuturePendingBySeries(ctx context.Context, seriesID string) error
+	DeleteUpcomingBySeries(ctx context.Context, seriesID string, from time.Time, keepEdited bool) error
 	FindLastFuturePendingInSeries(ctx context.Context, seriesID string) (*models.Chore, error)
+	// FindLatestInSeriesOnOrBefore returns the series' occurrence, of any
+	// status, with the latest slot on or before day, or nil if there is none.
+	FindLatestInSeriesOnOrBefore(ctx context.Context, seriesID string, day time.Time) (*models.Chore, error)
 	CountBySeries(ctx context.Context, seriesID string) (int, error)
 	DeleteCompletedByName(ctx context.Context, name string) error
+	GetChecklist(ctx context.Context, choreID string) ([]models.ChecklistItem, error)
+	SetChecklist(ctx context.Context, choreID string, titles []string) error
+	SetChecklistItemChecked(ctx context.Context, choreID, itemID string, userID string, checked bool) error
+	FindProofImage(ctx context.Context, choreID string) (string, error)
+	UpdateProofImage(ctx context.Context, choreID string, imageData string) error
+	UpdateOccurrence(ctx context.Context, chore models.Chore, reassigned bool, exception *models.SeriesException) error
+	// HandOver gives the chore to its AssignedToUserID in one transaction: the
+	// previous assignment is closed as reassigned, a new one opened, the chore
+	// row saved and, when given, the escalation that moved it recorded.
+	HandOver(ctx context.Context, chore models.Chore, handedOver bool, escalation *models.ChoreEscalation) error
 }
 
 type SQLiteChoreRepository struct {
@@ -57,20 +72,26 @@ func NewChoreRepository(database *sql.DB) *SQLiteChoreRepository {
 
 func (repository *SQLiteChoreRepository) FindByID(ctx context.Context, id string) (models.Chore, error) {
 	var chore models.Chore
+	var participants, partsDone string
 	err := repository.database.QueryRowContext(ctx,
 		fmt.Sprintf("SELECT %s %s WHERE c.id = ?", choreSelectColumns, choreJoin), id,
 	).Scan(
 		&chore.ID, &chore.Name, &chore.Description, &chore.CreatedByUserID, &chore.CategoryID,
 		&chore.AssignedToUserID, &chore.LastAssignedIndex,
-		&chore.DueDate, &chore.DueTime,
-		&chore.RecurrenceType, &chore.RecurrenceValue, &chore.RecurOnComplete, &chore.SeriesID,
+		&chore.DueDate, &chore.DueTime, &chore.OriginalDueDate,
+		&chore.RecurrenceType, &chore.RecurrenceValue, &chore.RecurrenceRule, &chore.RecurOnComplete, &chore.SeriesID,
 		&chore.RecurrenceUntil, &chore.RecurrenceCount,
+		&chore.AssignmentStrategy, &chore.FixedAssigneeUserID, &chore.EffortPoints, &chore.ChecklistRequired,
+		&chore.RequiresApproval, &chore.HasProof,
+		&chore.GroupChore, &chore.GroupQuorum, &participants, &partsDone,
+		&chore.EscalationRemindHours, &chore.EscalationReassignHours, &chore.EscalationNotifyAdmins,
 		&chore.Status, &chore.CompletedAt, &chore.CompletedByUserID,
 		&chore.CreatedAt, &chore.UpdatedAt,
 	)
 	if err != nil {
 		return models.Chore{}, fmt.Errorf("finding chore by id: %w", err)
 	}
+	chore.Participants, chore.PartsDone = splitUserIDs(participants), splitUserIDs(partsDone)
 	return chore, nil
 }
 
@@ -82,19 +103,40 @@ func (repository *SQLiteChoreRepository) FindByID(ctx context.Context, id string
 const choreSelectColumns = `c.id AS id, c.name AS name, c.description AS description,
 		c.created_by_user_id AS created_by_user_id, c.category_id AS category_id,
 		c.assigned_to_user_id AS assigned_to_user_id, c.last_assigned_index AS last_assigned_index,
-		c.due_date AS due_date, c.due_time AS due_time,
+		c.due_date AS due_date, c.due_time AS due_time, c.original_due_date AS original_due_date,
 		COALESCE(cs.recurrence_type, 'none') AS recurrence_type,
 		COALESCE(cs.recurrence_value, '') AS recurrence_value,
+		COALESCE(cs.recurrence_rule, '') AS recurrence_rule,
 		COALESCE(cs.recur_on_complete, 0) AS recur_on_complete,
 		c.series_id AS series_id,
 		cs.recurrence_until AS recurrence_until, cs.recurrence_count AS recurrence_count,
+		COALESCE(cs.assignment_strategy, 'round_robin') AS assignment_strategy,
+		cs.fixed_assignee_user_id AS fixed_assignee_user_id,
+		COALESCE(cs.effort_points, c.effort_points) AS effort_points,
+		COALESCE(cs.checklist_required, c.checklist_required) AS checklist_required,
+		COALESCE(cs.requires_approval, c.requires_approval) AS requires_approval,
+		CASE WHEN c.proof_image_data != '' THEN 1 ELSE 0 END AS has_proof,
+		COALESCE(cs.group_chore, c.group_chore) AS group_chore,
+		COALESCE(cs.group_quorum, c.group_quorum) AS group_quorum,
+		CASE WHEN COALESCE(cs.group_chore, c.group_chore) THEN COALESCE((SELECT GROUP_CONCAT(ca.user_id) FROM chore_assignments ca
+			WHERE ca.chore_id = c.id AND ca.status IN ('assigned', 'completed')), '') ELSE '' END AS participants,
+		CASE WHEN COALESCE(cs.group_chore, c.group_chore) THEN COALESCE((SELECT GROUP_CONCAT(ca.user_id) FROM chore_assignments ca
+			WHERE ca.chore_id = c.id AND ca.status = 'completed'), '') ELSE '' END AS parts_done,
+		COALESCE(cs.escalation_remind_hours, 0) AS escalation_remind_hours,
+		COALESCE(cs.escalation_reassign_hours, 0) AS escalation_reassign_hours,
+		COALESCE(cs.escalation_notify_admins, 0) AS escalation_notify_admins,
 		c.status AS status, c.completed_at AS completed_at, c.completed_by_user_id AS completed_by_user_id,
 		c.created_at AS created_at, c.updated_at AS updated_at`
 
 const choreColumnNames = `id, name, description, created_by_user_id, category_id,
-		assigned_to_user_id, last_assigned_index, due_date, due_time,
-		recurrence_type, recurrence_value, recur_on_complete, series_id,
-		recurrence_until, recurrence_count, status, completed_at, completed_by_user_id,
+		assigned_to_user_id, last_assigned_index, due_date, due_time, original_due_date,
+		recurrence_type, recurrence_value, recurrence_rule, recur_on_complete, series_id,
+		recurrence_until, recurrence_count,
+		assignment_strategy, fixed_assignee_user_id, effort_points, checklist_required,
+		requires_approval, has_proof,
+		group_chore, group_quorum, participants, parts_done,
+		escalation_remind_hours, escalation_reassign_hours, escalation_notify_admins,
+		status, completed_at, completed_by_user_id,
 		created_at, updated_at`
 
 const choreJoin = `FROM chores c LEFT JOIN chore_series cs ON cs.id = c.series_id`
@@ -124,13 +166,18 @@ func (repository *SQLiteChoreRepository) FindAll(ctx context.Context, filter Cho
 		where += " AND COALESCE(cs.recurrence_type, 'none') IN (" + strings.Join(placeholders, ",") + ")"
 	}
 	if filter.AssignedToUser != nil {
-		where += " AND c.assigned_to_user_id = ?"
-		args = append(args, *filter.AssignedToUser)
+		where += ` AND (c.assigned_to_user_id = ? OR EXISTS (SELECT 1 FROM chore_assignments ca
+			WHERE ca.chore_id = c.id AND ca.user_id = ? AND ca.status = 'assigned' AND COALESCE(cs.group_chore, c.group_chore)))`
+		args = append(args, *filter.AssignedToUser, *filter.AssignedToUser)
 	}
 	if filter.CategoryID != nil {
 		where += " AND c.category_id = ?"
 		args = append(args, *filter.CategoryID)
 	}
+	if filter.SeriesID != nil {
+		where += " AND c.series_id = ?"
+		args = append(args, *filter.SeriesID)
+	}
 	if filter.DueBefore != nil {
 		where += " AND c.due_date <= ?"
 		args = append(args, *filter.DueBefore)
@@ -177,7 +224,7 @@ func (repository *SQLiteChoreRepository) Create(ctx context.Context, chore model
 	if chore.ID == "" {
 		chore.ID = uuid.New().String()
 	}
-	now := time.Now()
+	now := time.Now().UTC()
 	chore.CreatedAt = now
 	chore.UpdatedAt = now
 	if chore.Status == "" {
@@ -186,6 +233,9 @@ func (repository *SQLiteChoreRepository) Create(ctx context.Context, chore model
 	if chore.RecurrenceType == "" {
 		chore.RecurrenceType = models.RecurrenceNone
 	}
+	if chore.EffortPoints < 1 {
+		chore.EffortPoints = 1
+	}
 
 	// The recurrence rule lives in chore_series (migration 016); only occurrence
 	// state is stored here. series_id has a foreign key, so the series row must
@@ -193,14 +243,16 @@ func (repository *SQLiteChoreRepository) Create(ctx context.Context, chore model
 	_, err := repository.database.ExecContext(ctx,
 		`INSERT INTO chores (id, name, description, created_by_user_id, category_id,
 			assigned_to_user_id, last_assigned_index,
-			due_date, due_time, series_id,
+			due_date, due_time, original_due_date, series_id, effort_points, checklist_required, requires_approval,
+			group_chore, group_quorum,
 			status, completed_at, completed_by_user_id,
 			created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		chore.ID, chore.Name, chore.Description, chore.CreatedByUserID, chore.CategoryID,
 		chore.AssignedToUserID, chore.LastAssignedIndex,
-		chore.DueDate, chore.DueTime, chore.SeriesID,
-		chore.Status, chore.CompletedAt, chore.CompletedByUserID,
+		chore.DueDate, chore.DueTime, chore.OriginalDueDate, chore.SeriesID, chore.EffortPoints, chore.ChecklistRequired, chore.RequiresApproval,
+		chore.GroupChore, chore.GroupQuorum,
+		chore.Status, inUTC(chore.CompletedAt), chore.CompletedByUserID,
 		chore.CreatedAt, chore.UpdatedAt,
 	)
 	if err != nil {
@@ -210,18 +262,77 @@ func (repository *SQLiteChoreRepository) Create(ctx context.Context, chore model
 }
 
 func (repository *SQLiteChoreRepository) Update(ctx context.Context, chore models.Chore) error {
-	chore.UpdatedAt = time.Now()
-	_, err := repository.database.ExecContext(ctx,
+	return updateChore(ctx, repository.database, chore)
+}
+
+// UpdateOccurrence saves an occurrence edited on its own in one transaction:
+// the chore row, a new open assignment when reassigned is set (closing the
+// previous one as reassigned), and the series exception, when given, that
+// keeps its slot from being generated again.
+func (repository *SQLiteChoreRepository) UpdateOccurrence(ctx context.Context, chore models.Chore, reassigned bool, exception *models.SeriesException) error {
+	transaction, err := repository.database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer transaction.Rollback()
+
+	if err := updateChore(ctx, transaction, chore); err != nil {
+		return err
+	}
+	if reassigned && chore.AssignedToUserID != nil {
+		if err := handOverAssignment(ctx, transaction, chore.ID, *chore.AssignedToUserID, true, time.Now().UTC()); err != nil {
+			return err
+		}
+	}
+	if exception != nil {
+		if err := addSeriesException(ctx, transaction, exception.SeriesID, exception.OccurrenceDate, exception.ChoreID); err != nil {
+			return err
+		}
+	}
+
+	return transaction.Commit()
+}
+
+func (repository *SQLiteChoreRepository) HandOver(ctx context.Context, chore models.Chore, handedOver bool, escalation *models.ChoreEscalation) error {
+	transaction, err := repository.database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer transaction.Rollback()
+
+	if err := handOverAssignment(ctx, transaction, chore.ID, *chore.AssignedToUserID, handedOver, time.Now().UTC()); err != nil {
+		return err
+	}
+	if err := updateChore(ctx, transaction, chore); err != nil {
+		return err
+	}
+	if escalation != nil {
+		if _, err := insertChoreEscalation(ctx, transaction, *escalation); err != nil {
+			return err
+		}
+	}
+
+	return transaction.Commit()
+}
+
+func updateChore(ctx context.Context, database queryExecer, chore models.Chore) error {
+	chore.UpdatedAt = time.Now().UTC()
+	if chore.EffortPoints < 1 {
+		chore.EffortPoints = 1
+	}
+	_, err := database.ExecContext(ctx,
 		`UPDATE chores SET name = ?, description = ?, category_id = ?,
 			assigned_to_user_id = ?, last_assigned_index = ?,
-			due_date = ?, due_time = ?, series_id = ?,
+			due_date = ?, due_time = ?, original_due_date = ?, series_id = ?, effort_points = ?, checklist_required = ?, requires_approval = ?,
+			group_chore = ?, group_quorum = ?,
 			status = ?, completed_at = ?, completed_by_user_id = ?,
 			updated_at = ?
 		WHERE id = ?`,
 		chore.Name, chore.Description, chore.CategoryID,
 		chore.AssignedToUserID, chore.LastAssignedIndex,
-		chore.DueDate, chore.DueTime, chore.SeriesID,
-		chore.Status, chore.CompletedAt, chore.CompletedByUserID,
+		chore.DueDate, chore.DueTime, chore.OriginalDueDate, chore.SeriesID, chore.EffortPoints, chore.ChecklistRequired, chore.RequiresApproval,
+		chore.GroupChore, chore.GroupQuorum,
+		chore.Status, inUTC(chore.CompletedAt), chore.CompletedByUserID,
 		chore.UpdatedAt, chore.ID,
 	)
 	if err != nil {
@@ -238,9 +349,10 @@ func (repository *SQLiteChoreRepository) Delete(ctx context.Context, id string)
 	return nil
 }
 
-func (repository *SQLiteChoreRepository) FindOverdueChores(ctx context.Context) ([]models.Chore, error) {
-	now := time.Now()
-	endOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).Add(24 * time.Hour)
+// FindOverdueChores returns open chores that are overdue at now, which should
+// be in the household timezone: "today" is now's calendar date there.
+func (repository *SQLiteChoreRepository) FindOverdueChores(ctx context.Context, now time.Time) ([]models.Chore, error) {
+	endOfToday := CivilDate(now).AddDate(0, 0, 1)
 
 	rows, err := repository.database.QueryContext(ctx,
 		fmt.Sprintf(`SELECT %s %s
@@ -273,7 +385,7 @@ func (repository *SQLiteChoreRepository) FindOverdueChores(ctx context.Context)
 func (repository *SQLiteChoreRepository) MarkOverdue(ctx context.Context, choreID string) error {
 	_, err := repository.database.ExecContext(ctx,
 		"UPDATE chores SET status = 'overdue', updated_at = ? WHERE id = ? AND status = 'pending'",
-		time.Now(), choreID,
+		time.Now().UTC(), choreID,
 	)
 	if err != nil {
 		return fmt.Errorf("marking chore overdue: %w", err)
@@ -281,9 +393,11 @@ func (repository *SQLiteChoreRepository) MarkOverdue(ctx context.Context, choreI
 	return nil
 }
 
-func (repository *SQLiteChoreRepository) FindDueToday(ctx context.Context) ([]models.Chore, error) {
-	today := time.Now().Truncate(24 * time.Hour)
-	tomorrow := today.Add(24 * time.Hour)
+// FindDueToday returns pending chores due on now's calendar date in now's
+// location.
+func (repository *SQLiteChoreRepository) FindDueToday(ctx context.Context, now time.Time) ([]models.Chore, error) {
+	today := CivilDate(now)
+	tomorrow := today.AddDate(0, 0, 1)
 
 	rows, err := repository.database.QueryContext(ctx,
 		fmt.Sprintf(`SELECT %s %s
@@ -357,7 +471,7 @@ func (repository *SQLiteChoreRepository) GetEligibleAssignees(ctx context.Contex
 
 func (repository *SQLiteChoreRepository) DeleteFuturePendingBySeries(ctx context.Context, seriesID string) error {
 	_, err := repository.database.ExecContext(ctx,
-		`DELETE FROM chores WHERE series_id = ? AND status = 'pending' AND due_date > CURRENT_TIMESTAMP`,
+		`DELETE FROM chores WHERE series_id = ? AND status IN ('pending', 'blocked') AND due_date > CURRENT_TIMESTAMP`,
 		seriesID,
 	)
 	if err != nil {
@@ -366,11 +480,31 @@ func (repository *SQLiteChoreRepository) DeleteFuturePendingBySeries(ctx context
 	return nil
 }
 
+// DeleteUpcomingBySeries deletes the series' pending occurrences whose slot
+// falls on or after from. With keepEdited, occurrences edited on their own
+// (those holding a series exception) are left in place.
+func (repository *SQLiteChoreRepository) DeleteUpcomingBySeries(ctx context.Context, seriesID string, from time.Time, keepEdited bool) error {
+	query := `DELETE FROM chores WHERE series_id = ? AND status IN ('pending', 'blocked')
+		AND COALESCE(original_due_date, due_date) >= ?`
+	if keepEdited {
+		query += ` AND id NOT IN (SELECT chore_id FROM chore_series_exceptions WHERE chore_id IS NOT NULL)`
+	}
+	if _, err := repository.database.ExecContext(ctx, query, seriesID, from); err != nil {
+		return fmt.Errorf("deleting upcoming by series: %w", err)
+	}
+	return nil
+}
+
+// FindLastFuturePendingInSeries returns the series' latest upcoming
+// occurrence by slot, ignoring occurrences edited on their own: seeding
+// resumes from the rule's own occurrences and skips the edited slots.
 func (repository *SQLiteChoreRepository) FindLastFuturePendingInSeries(ctx context.Context, seriesID string) (*models.Chore, error) {
 	rows, err := repository.database.QueryContext(ctx,
 		fmt.Sprintf(`SELECT %s %s
-		WHERE c.series_id = ? AND c.status = 'pending' AND c.due_date > CURRENT_TIMESTAMP
-		ORDER BY c.due_date DESC
+		WHERE c.series_id = ? AND c.status IN ('pending', 'blocked')
+			AND COALESCE(c.original_due_date, c.due_date) > CURRENT_TIMESTAMP
+			AND NOT EXISTS (SELECT 1 FROM chore_series_exceptions e WHERE e.chore_id = c.id)
+		ORDER BY COALESCE(c.original_due_date, c.due_date) DESC
 		LIMIT 1`, choreSelectColumns, choreJoin),
 		seriesID,
 	)
@@ -389,6 +523,29 @@ func (repository *SQLiteChoreRepository) FindLastFuturePendingInSeries(ctx conte
 	return &chores[0], nil
 }
 
+func (repository *SQLiteChoreRepository) FindLatestInSeriesOnOrBefore(ctx context.Context, seriesID string, day time.Time) (*models.Chore, error) {
+	rows, err := repository.database.QueryContext(ctx,
+		fmt.Sprintf(`SELECT %s %s
+		WHERE c.series_id = ? AND COALESCE(c.original_due_date, c.due_date) <= ?
+		ORDER BY COALESCE(c.original_due_date, c.due_date) DESC
+		LIMIT 1`, choreSelectColumns, choreJoin),
+		seriesID, day,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("finding latest in series: %w", err)
+	}
+	defer rows.Close()
+
+	chores, err := scanChores(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(chores) == 0 {
+		return nil, nil
+	}
+	return &chores[0], nil
+}
+
 // CountBySeries returns the total number of chore rows (any status) belonging to
 // the series. Used to enforce a recurrence occurrence cap.
 func (repository *SQLiteChoreRepository) CountBySeries(ctx context.Context, seriesID string) (int, error) {
@@ -413,13 +570,32 @@ func (repository *SQLiteChoreRepository) DeleteCompletedByName(ctx context.Conte
 	return nil
 }
 
+// CivilDate returns t's calendar date in t's location, encoded the way due
+// dates are stored: midnight UTC.
+func CivilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// inUTC returns an optional instant in UTC, the zone every stored instant is
+// written in.
+func inUTC(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+// IsOverdue reports whether the chore is overdue at now. The due date is a
+// calendar date and the due time a wall-clock time, both read in now's
+// location, so pass now in the household timezone.
 func IsOverdue(chore models.Chore, now time.Time) bool {
 	if chore.DueDate == nil {
 		return false
 	}
 
-	startOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	dueDay := time.Date(chore.DueDate.Year(), chore.DueDate.Month(), chore.DueDate.Day(), 0, 0, 0, 0, now.Location())
+	startOfToday := CivilDate(now)
+	dueDay := CivilDate(*chore.DueDate)
 
 	if dueDay.Before(startOfToday) {
 		return true
@@ -437,22 +613,61 @@ func IsOverdue(chore models.Chore, now time.Time) bool {
 	return false
 }
 
+// FindProofImage returns the data URI of the photo uploaded with the chore's
+// completion, or "" when there is none.
+func (repository *SQLiteChoreRepository) FindProofImage(ctx context.Context, choreID string) (string, error) {
+	var imageData string
+	err := repository.database.QueryRowContext(ctx,
+		`SELECT proof_image_data FROM chores WHERE id = ?`, choreID,
+	).Scan(&imageData)
+	if err != nil {
+		return "", fmt.Errorf("finding proof image: %w", err)
+	}
+	return imageData, nil
+}
+
+// UpdateProofImage stores the completion photo; an empty imageData clears it.
+func (repository *SQLiteChoreRepository) UpdateProofImage(ctx context.Context, choreID string, imageData string) error {
+	_, err := repository.database.ExecContext(ctx,
+		`UPDATE chores SET proof_image_data = ?, updated_at = ? WHERE id = ?`,
+		imageData, time.Now().UTC(), choreID,
+	)
+	if err != nil {
+		return fmt.Errorf("updating proof image: %w", err)
+	}
+	return nil
+}
+
 func scanChores(rows *sql.Rows) ([]models.Chore, error) {
 	var chores []models.Chore
 	for rows.Next() {
 		var chore models.Chore
+		var participants, partsDone string
 		if err := rows.Scan(
 			&chore.ID, &chore.Name, &chore.Description, &chore.CreatedByUserID, &chore.CategoryID,
 			&chore.AssignedToUserID, &chore.LastAssignedIndex,
-			&chore.DueDate, &chore.DueTime,
-			&chore.RecurrenceType, &chore.RecurrenceValue, &chore.RecurOnComplete, &chore.SeriesID,
+			&chore.DueDate, &chore.DueTime, &chore.OriginalDueDate,
+			&chore.RecurrenceType, &chore.RecurrenceValue, &chore.RecurrenceRule, &chore.RecurOnComplete, &chore.SeriesID,
 			&chore.RecurrenceUntil, &chore.RecurrenceCount,
+			&chore.AssignmentStrategy, &chore.FixedAssigneeUserID, &chore.EffortPoints, &chore.ChecklistRequired,
+			&chore.RequiresApproval, &chore.HasProof,
+			&chore.GroupChore, &chore.GroupQuorum, &participants, &partsDone,
+			&chore.EscalationRemindHours, &chore.EscalationReassignHours, &chore.EscalationNotifyAdmins,
 			&chore.Status, &chore.CompletedAt, &chore.CompletedByUserID,
 			&chore.CreatedAt, &chore.UpdatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("scanning chore: %w", err)
 		}
+		chore.Participants, chore.PartsDone = splitUserIDs(participants), splitUserIDs(partsDone)
 		chores = append(chores, chore)
 	}
 	return chores, rows.Err()
 }
+
+// splitUserIDs splits a GROUP_CONCAT of user ids; "" gives nil.
+func splitUserIDs(concatenated string) []string {
+	if concatenated == "" {
+		return nil
+	}
+	return strings.Split(concatenated, ",")
+}
@@ -3,6 +3,8 @@ package repository_test
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -67,6 +69,160 @@ func TestIsOverdue(t *testing.T) {
 	}
 }
 
+func TestIsOverdue_HouseholdTimezoneAcrossDST(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("loading zone: %v", err)
+	}
+	date := func(year int, month time.Month, day int) *time.Time {
+		return timePtr(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+	}
+
+	tests := []struct {
+		name  string
+		now   time.Time
+		chore models.Chore
+		want  bool
+	}{
+		{
+			// Clocks went forward at 01:00 UTC: 08:30 UTC is 09:30 BST.
+			name:  "spring forward, due time passed in BST",
+			now:   time.Date(2026, 3, 29, 8, 30, 0, 0, time.UTC),
+			chore: models.Chore{DueDate: date(2026, 3, 29), DueTime: stringPtr("09:00")},
+			want:  true,
+		},
+		{
+			name:  "spring forward, due time still ahead in BST",
+			now:   time.Date(2026, 3, 29, 8, 30, 0, 0, time.UTC),
+			chore: models.Chore{DueDate: date(2026, 3, 29), DueTime: stringPtr("10:00")},
+			want:  false,
+		},
+		{
+			// 23:30 UTC on the 14th is already the 15th in London.
+			name:  "summer night, yesterday's chore",
+			now:   time.Date(2026, 6, 14, 23, 30, 0, 0, time.UTC),
+			chore: models.Chore{DueDate: date(2026, 6, 14)},
+			want:  true,
+		},
+		{
+			name:  "summer night, today's chore",
+			now:   time.Date(2026, 6, 14, 23, 30, 0, 0, time.UTC),
+			chore: models.Chore{DueDate: date(2026, 6, 15)},
+			want:  false,
+		},
+		{
+			// Clocks went back at 01:00 UTC: 09:30 UTC is 09:30 GMT.
+			name:  "fall back, due time ahead in GMT",
+			now:   time.Date(2026, 10, 25, 9, 30, 0, 0, time.UTC),
+			chore: models.Chore{DueDate: date(2026, 10, 25), DueTime: stringPtr("10:00")},
+			want:  false,
+		},
+		{
+			name:  "fall back, due time passed in GMT",
+			now:   time.Date(2026, 10, 25, 9, 30, 0, 0, time.UTC),
+			chore: models.Chore{DueDate: date(2026, 10, 25), DueTime: stringPtr("09:15")},
+			want:  true,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := repository.IsOverdue(testCase.chore, testCase.now.In(london))
+			if got != testCase.want {
+				t.Errorf("IsOverdue() = %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestChoreRepository_DueTodayInHouseholdTimezone(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	ctx := context.Background()
+	user := createTestUser(t, userRepo)
+
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("loading zone: %v", err)
+	}
+	for _, day := range []int{14, 15} {
+		due := time.Date(2026, 6, day, 0, 0, 0, 0, time.UTC)
+		if _, err := choreRepo.Create(ctx, models.Chore{
+			Name:            fmt.Sprintf("Due %d June", day),
+			CreatedByUserID: user.ID,
+			DueDate:         &due,
+			Status:          models.ChoreStatusPending,
+		}); err != nil {
+			t.Fatalf("creating chore: %v", err)
+		}
+	}
+
+	// 00:30 BST on the 15th, still the 14th in UTC.
+	now := time.Date(2026, 6, 14, 23, 30, 0, 0, time.UTC).In(london)
+
+	dueToday, err := choreRepo.FindDueToday(ctx, now)
+	if err != nil {
+		t.Fatalf("FindDueToday: %v", err)
+	}
+	if len(dueToday) != 1 || dueToday[0].Name != "Due 15 June" {
+		t.Errorf("expected only the 15 June chore due today, got %+v", dueToday)
+	}
+
+	overdue, err := choreRepo.FindOverdueChores(ctx, now)
+	if err != nil {
+		t.Fatalf("FindOverdueChores: %v", err)
+	}
+	if len(overdue) != 1 || overdue[0].Name != "Due 14 June" {
+		t.Errorf("expected only the 14 June chore overdue, got %+v", overdue)
+	}
+}
+
+func TestChoreRepository_StoresInstantsInUTC(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	assignmentRepo := repository.NewChoreAssignmentRepository(db)
+	ctx := context.Background()
+	user := createTestUser(t, userRepo)
+
+	auckland, err := time.LoadLocation("Pacific/Auckland")
+	if err != nil {
+		t.Fatalf("loading zone: %v", err)
+	}
+	completedAt := time.Now().In(auckland)
+	chore, err := choreRepo.Create(ctx, models.Chore{
+		Name:              "Water the plants",
+		CreatedByUserID:   user.ID,
+		Status:            models.ChoreStatusCompleted,
+		CompletedAt:       &completedAt,
+		CompletedByUserID: &user.ID,
+	})
+	if err != nil {
+		t.Fatalf("creating chore: %v", err)
+	}
+	var stored string
+	if err := db.QueryRow(`SELECT completed_at || '' FROM chores WHERE id = ?`, chore.ID).Scan(&stored); err != nil {
+		t.Fatalf("reading completed_at: %v", err)
+	}
+	if !strings.Contains(stored, "+0000") {
+		t.Errorf("expected completed_at stored in UTC, got %q", stored)
+	}
+
+	// Stored instants compare as text, so a bound in another zone must be
+	// written in UTC as well to match.
+	if _, err := assignmentRepo.Create(ctx, models.ChoreAssignment{ChoreID: chore.ID, UserID: user.ID}); err != nil {
+		t.Fatalf("creating assignment: %v", err)
+	}
+	if err := assignmentRepo.MarkCompleted(ctx, chore.ID, user.ID); err != nil {
+		t.Fatalf("MarkCompleted: %v", err)
+	}
+	count, err := assignmentRepo.CompletedCountByUser(ctx, user.ID, time.Now().Add(-time.Hour).In(auckland))
+	if err != nil || count != 1 {
+		t.Errorf("expected the completion counted since an hour ago, got %d (%v)", count, err)
+	}
+}
+
 func createTestUser(t *testing.T, repo *repository.SQLiteUserRepository) models.User {
 	t.Helper()
 	user, err := repo.Create(context.Background(), models.User{
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/bensuskins/family-hub/internal/models"
+)
+
+// Default digest times for members who have not chosen their own.
+const (
+	DefaultDailyDigestTime  = "07:00"
+	DefaultWeeklyDigestTime = "18:00"
+)
+
+type DigestRepository interface {
+	// FindSchedule returns the member's digest schedule, or the defaults when
+	// they have never changed it.
+	FindSchedule(ctx context.Context, userID string) (models.DigestSchedule, error)
+	// SaveTimes saves the member's DailyTime and WeeklyTime.
+	SaveTimes(ctx context.Context, schedule models.DigestSchedule) error
+	// MarkDailySent and MarkWeeklySent record the local date a digest went out.
+	MarkDailySent(ctx context.Context, userID, date string) error
+	MarkWeeklySent(ctx context.Context, userID, date string) error
+}
+
+type SQLiteDigestRepository struct {
+	database *sql.DB
+}
+
+func NewDigestRepository(database *sql.DB) *SQLiteDigestRepository {
+	return &SQLiteDigestRepository{database: database}
+}
+
+func (repository *SQLiteDigestRepository) FindSchedule(ctx context.Context, userID string) (models.DigestSchedule, error) {
+	schedule := models.DigestSchedule{UserID: userID}
+	err := repository.database.QueryRowContext(ctx,
+		`SELECT daily_time, weekly_time, daily_sent_on, weekly_sent_on FROM digest_schedules WHERE user_id = ?`, userID,
+	).Scan(&schedule.DailyTime, &schedule.WeeklyTime, &schedule.DailySentOn, &schedule.WeeklySentOn)
+	if errors.Is(err, sql.ErrNoRows) {
+		schedule.DailyTime = DefaultDailyDigestTime
+		schedule.WeeklyTime = DefaultWeeklyDigestTime
+		return schedule, nil
+	}
+	if err != nil {
+		return models.DigestSchedule{}, fmt.Errorf("finding digest schedule: %w", err)
+	}
+	return schedule, nil
+}
+
+func (repository *SQLiteDigestRepository) SaveTimes(ctx context.Context, schedule models.DigestSchedule) error {
+	_, err := repository.database.ExecContext(ctx,
+		`INSERT INTO digest_schedules (user_id, daily_time, weekly_time) VALUES (?, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET
+			daily_time = excluded.daily_time,
+			weekly_time = excluded.weekly_time`,
+		schedule.UserID, schedule.DailyTime, schedule.WeeklyTime,
+	)
+	if err != nil {
+		return fmt.Errorf("saving digest schedule: %w", err)
+	}
+	return nil
+}
+
+func (repository *SQLiteDigestRepository) MarkDailySent(ctx context.Context, userID, date string) error {
+	_, err := repository.database.ExecContext(ctx,
+		`INSERT INTO digest_schedules (user_id, daily_sent_on) VALUES (?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET daily_sent_on = excluded.daily_sent_on`,
+		userID, date,
+	)
+	if err != nil {
+		return fmt.Errorf("marking daily digest sent: %w", err)
+	}
+	return nil
+}
+
+func (repository *SQLiteDigestRepository) MarkWeeklySent(ctx context.Context, userID, date string) error {
+	_, err := repository.database.ExecContext(ctx,
+		`INSERT INTO digest_schedules (user_id, weekly_sent_on) VALUES (?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET weekly_sent_on = excluded.weekly_sent_on`,
+		userID, date,
+	)
+	if err != nil {
+		return fmt.Errorf("marking weekly digest sent: %w", err)
+	}
+	return nil
+}
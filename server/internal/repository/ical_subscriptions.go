@@ -69,7 +69,7 @@ func (repository *SQLiteICalSubscriptionRepository) Create(ctx context.Context,
 	}
 	_, err := repository.database.ExecContext(ctx,
 		`INSERT INTO ical_subscriptions (id, name, url, color, created_at) VALUES (?, ?, ?, ?, ?)`,
-		sub.ID, sub.Name, sub.URL, sub.Color, time.Now(),
+		sub.ID, sub.Name, sub.URL, sub.Color, time.Now().UTC(),
 	)
 	if err != nil {
 		return fmt.Errorf("inserting ical subscription: %w", err)
@@ -91,7 +91,7 @@ func (repository *SQLiteICalSubscriptionRepository) UpdateColor(ctx context.Cont
 func (repository *SQLiteICalSubscriptionRepository) UpdateCache(ctx context.Context, id string, data string, fetchedAt time.Time) error {
 	_, err := repository.database.ExecContext(ctx,
 		`UPDATE ical_subscriptions SET cached_data = ?, last_fetched_at = ? WHERE id = ?`,
-		data, fetchedAt, id,
+		data, fetchedAt.UTC(), id,
 	)
 	if err != nil {
 		return fmt.Errorf("updating ical subscription cache: %w", err)
@@ -120,7 +120,7 @@ func (repository *SQLiteInventoryRepository) CreateArea(ctx context.Context, are
 	if area.Tint == "" {
 		area.Tint = "blue"
 	}
-	now := time.Now()
+	now := time.Now().UTC()
 	area.CreatedAt = now
 	area.UpdatedAt = now
 
@@ -139,7 +139,7 @@ func (repository *SQLiteInventoryRepository) CreateArea(ctx context.Context, are
 }
 
 func (repository *SQLiteInventoryRepository) UpdateArea(ctx context.Context, area models.InventoryArea) error {
-	area.UpdatedAt = time.Now()
+	area.UpdatedAt = time.Now().UTC()
 	_, err := repository.database.ExecContext(ctx,
 		`UPDATE inventory_areas SET name = ?, icon = ?, tint = ?, updated_at = ? WHERE id = ?`,
 		area.Name, area.Icon, area.Tint, area.UpdatedAt, area.ID,
@@ -178,7 +178,7 @@ func (repository *SQLiteInventoryRepository) CreateItem(ctx context.Context, ite
 		item.ID = uuid.New().String()
 	}
 	item = normalizeItem(item)
-	now := time.Now()
+	now := time.Now().UTC()
 	item.CreatedAt = now
 	item.UpdatedAt = now
 
@@ -196,7 +196,7 @@ func (repository *SQLiteInventoryRepository) CreateItem(ctx context.Context, ite
 
 func (repository *SQLiteInventoryRepository) UpdateItem(ctx context.Context, item models.InventoryItem) error {
 	item = normalizeItem(item)
-	item.UpdatedAt = time.Now()
+	item.UpdatedAt = time.Now().UTC()
 	_, err := repository.database.ExecContext(ctx,
 		`UPDATE inventory_items SET name = ?, tracking_mode = ?, quantity = ?, level = ?, unit = ?, low_at = ?, updated_at = ? WHERE id = ?`,
 		item.Name, item.TrackingMode, item.Quantity, item.Level, item.Unit, item.LowAt, item.UpdatedAt, item.ID,
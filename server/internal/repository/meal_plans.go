@@ -77,7 +77,7 @@ func (repository *SQLiteMealPlanRepository) FindByDate(ctx context.Context, date
 }
 
 func (repository *SQLiteMealPlanRepository) Upsert(ctx context.Context, meal models.MealPlan) error {
-	now := time.Now()
+	now := time.Now().UTC()
 	_, err := repository.database.ExecContext(ctx,
 		`INSERT INTO meal_plans (date, meal_type, recipe_id, name, notes, created_by_user_id, created_at, updated_at)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
@@ -108,7 +108,7 @@ func (repository *SQLiteMealPlanRepository) Delete(ctx context.Context, date str
 func (repository *SQLiteMealPlanRepository) ClearRecipeID(ctx context.Context, recipeID string) error {
 	_, err := repository.database.ExecContext(ctx,
 		"UPDATE meal_plans SET recipe_id = NULL, updated_at = ? WHERE recipe_id = ?",
-		time.Now(), recipeID,
+		time.Now().UTC(), recipeID,
 	)
 	if err != nil {
 		return fmt.Errorf("clearing recipe id from meal plans: %w", err)
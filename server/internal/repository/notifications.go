@@ -0,0 +1,289 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/google/uuid"
+)
+
+type NotificationRepository interface {
+	CreateChannel(ctx context.Context, channel models.NotificationChannel) (models.NotificationChannel, error)
+	// UpdateChannel saves a channel's target, token and events.
+	UpdateChannel(ctx context.Context, channel models.NotificationChannel) error
+	DeleteChannel(ctx context.Context, id string) error
+	FindChannelByID(ctx context.Context, id string) (models.NotificationChannel, error)
+	FindChannelsByUser(ctx context.Context, userID string) ([]models.NotificationChannel, error)
+	// Enqueue queues a notification to go out at its NextAttemptAt. One whose
+	// DedupeKey has already been queued on the channel is dropped.
+	Enqueue(ctx context.Context, notification models.Notification) error
+	// FindDue returns up to limit pending notifications whose next attempt is
+	// due by now, oldest first.
+	FindDue(ctx context.Context, now time.Time, limit int) ([]models.Notification, error)
+	// FindByChannel returns the channel's most recent notifications, newest
+	// first.
+	FindByChannel(ctx context.Context, channelID string, limit int) ([]models.Notification, error)
+	MarkSent(ctx context.Context, id string, sentAt time.Time) error
+	// RecordFailure saves a failed attempt: the notification's Attempts,
+	// Status, NextAttemptAt and LastError.
+	RecordFailure(ctx context.Context, notification models.Notification) error
+	// DeleteFinishedBefore drops sent and failed notifications created before
+	// the given time.
+	DeleteFinishedBefore(ctx context.Context, before time.Time) error
+}
+
+type SQLiteNotificationRepository struct {
+	database *sql.DB
+}
+
+func NewNotificationRepository(database *sql.DB) *SQLiteNotificationRepository {
+	return &SQLiteNotificationRepository{database: database}
+}
+
+const notificationChannelColumns = `id, user_id, kind, target, token, created_at`
+
+const notificationColumns = `id, channel_id, event, title, body, link, html, dedupe_key, status, attempts, next_attempt_at, last_error, created_at, sent_at`
+
+func (repository *SQLiteNotificationRepository) CreateChannel(ctx context.Context, channel models.NotificationChannel) (models.NotificationChannel, error) {
+	channel.ID = uuid.New().String()
+	channel.CreatedAt = time.Now().UTC()
+
+	transaction, err := repository.database.BeginTx(ctx, nil)
+	if err != nil {
+		return models.NotificationChannel{}, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer transaction.Rollback()
+
+	if _, err := transaction.ExecContext(ctx,
+		`INSERT INTO notification_channels (`+notificationChannelColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		channel.ID, channel.UserID, channel.Kind, channel.Target, channel.Token, channel.CreatedAt,
+	); err != nil {
+		return models.NotificationChannel{}, fmt.Errorf("creating notification channel: %w", err)
+	}
+	if err := insertChannelEvents(ctx, transaction, channel); err != nil {
+		return models.NotificationChannel{}, err
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return models.NotificationChannel{}, fmt.Errorf("committing notification channel: %w", err)
+	}
+	return channel, nil
+}
+
+func (repository *SQLiteNotificationRepository) UpdateChannel(ctx context.Context, channel models.NotificationChannel) error {
+	transaction, err := repository.database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer transaction.Rollback()
+
+	if _, err := transaction.ExecContext(ctx,
+		`UPDATE notification_channels SET target = ?, token = ? WHERE id = ?`,
+		channel.Target, channel.Token, channel.ID,
+	); err != nil {
+		return fmt.Errorf("updating notification channel: %w", err)
+	}
+	if _, err := transaction.ExecContext(ctx, "DELETE FROM notification_channel_events WHERE channel_id = ?", channel.ID); err != nil {
+		return fmt.Errorf("clearing notification channel events: %w", err)
+	}
+	if err := insertChannelEvents(ctx, transaction, channel); err != nil {
+		return err
+	}
+
+	return transaction.Commit()
+}
+
+func insertChannelEvents(ctx context.Context, transaction *sql.Tx, channel models.NotificationChannel) error {
+	for _, event := range channel.Events {
+		if _, err := transaction.ExecContext(ctx,
+			`INSERT OR IGNORE INTO notification_channel_events (channel_id, event) VALUES (?, ?)`,
+			channel.ID, event,
+		); err != nil {
+			return fmt.Errorf("inserting notification channel event: %w", err)
+		}
+	}
+	return nil
+}
+
+func (repository *SQLiteNotificationRepository) DeleteChannel(ctx context.Context, id string) error {
+	_, err := repository.database.ExecContext(ctx, "DELETE FROM notification_channels WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("deleting notification channel: %w", err)
+	}
+	return nil
+}
+
+func (repository *SQLiteNotificationRepository) FindChannelByID(ctx context.Context, id string) (models.NotificationChannel, error) {
+	var channel models.NotificationChannel
+	err := repository.database.QueryRowContext(ctx,
+		`SELECT `+notificationChannelColumns+` FROM notification_channels WHERE id = ?`, id,
+	).Scan(&channel.ID, &channel.UserID, &channel.Kind, &channel.Target, &channel.Token, &channel.CreatedAt)
+	if err != nil {
+		return models.NotificationChannel{}, fmt.Errorf("finding notification channel: %w", err)
+	}
+
+	channel.Events, err = repository.findChannelEvents(ctx, channel.ID)
+	if err != nil {
+		return models.NotificationChannel{}, err
+	}
+	return channel, nil
+}
+
+func (repository *SQLiteNotificationRepository) FindChannelsByUser(ctx context.Context, userID string) ([]models.NotificationChannel, error) {
+	rows, err := repository.database.QueryContext(ctx,
+		`SELECT `+notificationChannelColumns+` FROM notification_channels
+		WHERE user_id = ?
+		ORDER BY created_at, rowid`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("finding notification channels: %w", err)
+	}
+	defer rows.Close()
+
+	var channels []models.NotificationChannel
+	for rows.Next() {
+		var channel models.NotificationChannel
+		if err := rows.Scan(&channel.ID, &channel.UserID, &channel.Kind, &channel.Target, &channel.Token, &channel.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning notification channel: %w", err)
+		}
+		channels = append(channels, channel)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for i := range channels {
+		channels[i].Events, err = repository.findChannelEvents(ctx, channels[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return channels, nil
+}
+
+func (repository *SQLiteNotificationRepository) findChannelEvents(ctx context.Context, channelID string) ([]models.NotificationEvent, error) {
+	rows, err := repository.database.QueryContext(ctx,
+		`SELECT event FROM notification_channel_events WHERE channel_id = ? ORDER BY event`,
+		channelID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("finding notification channel events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []models.NotificationEvent
+	for rows.Next() {
+		var event models.NotificationEvent
+		if err := rows.Scan(&event); err != nil {
+			return nil, fmt.Errorf("scanning notification channel event: %w", err)
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func (repository *SQLiteNotificationRepository) Enqueue(ctx context.Context, notification models.Notification) error {
+	notification.ID = uuid.New().String()
+	notification.CreatedAt = time.Now().UTC()
+	if notification.Status == "" {
+		notification.Status = models.NotificationPending
+	}
+	if notification.NextAttemptAt.IsZero() {
+		notification.NextAttemptAt = notification.CreatedAt
+	}
+
+	_, err := repository.database.ExecContext(ctx,
+		`INSERT OR IGNORE INTO notifications (`+notificationColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		notification.ID, notification.ChannelID, notification.Event,
+		notification.Title, notification.Body, notification.Link, notification.HTML, notification.DedupeKey,
+		notification.Status, notification.Attempts, notification.NextAttemptAt.UTC(),
+		notification.LastError, notification.CreatedAt.UTC(), inUTC(notification.SentAt),
+	)
+	if err != nil {
+		return fmt.Errorf("queueing notification: %w", err)
+	}
+	return nil
+}
+
+func (repository *SQLiteNotificationRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]models.Notification, error) {
+	return repository.findNotifications(ctx,
+		`SELECT `+notificationColumns+` FROM notifications
+		WHERE status = 'pending' AND next_attempt_at <= ?
+		ORDER BY next_attempt_at, rowid
+		LIMIT ?`,
+		now.UTC(), limit,
+	)
+}
+
+func (repository *SQLiteNotificationRepository) FindByChannel(ctx context.Context, channelID string, limit int) ([]models.Notification, error) {
+	return repository.findNotifications(ctx,
+		`SELECT `+notificationColumns+` FROM notifications
+		WHERE channel_id = ?
+		ORDER BY created_at DESC, rowid DESC
+		LIMIT ?`,
+		channelID, limit,
+	)
+}
+
+func (repository *SQLiteNotificationRepository) findNotifications(ctx context.Context, query string, args ...any) ([]models.Notification, error) {
+	rows, err := repository.database.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("finding notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []models.Notification
+	for rows.Next() {
+		var notification models.Notification
+		if err := rows.Scan(
+			&notification.ID, &notification.ChannelID, &notification.Event,
+			&notification.Title, &notification.Body, &notification.Link, &notification.HTML, &notification.DedupeKey,
+			&notification.Status, &notification.Attempts, &notification.NextAttemptAt,
+			&notification.LastError, &notification.CreatedAt, &notification.SentAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning notification: %w", err)
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, rows.Err()
+}
+
+func (repository *SQLiteNotificationRepository) MarkSent(ctx context.Context, id string, sentAt time.Time) error {
+	_, err := repository.database.ExecContext(ctx,
+		`UPDATE notifications SET status = 'sent', attempts = attempts + 1, last_error = '', sent_at = ? WHERE id = ?`,
+		sentAt.UTC(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("marking notification sent: %w", err)
+	}
+	return nil
+}
+
+func (repository *SQLiteNotificationRepository) RecordFailure(ctx context.Context, notification models.Notification) error {
+	_, err := repository.database.ExecContext(ctx,
+		`UPDATE notifications SET status = ?, attempts = ?, next_attempt_at = ?, last_error = ? WHERE id = ?`,
+		notification.Status, notification.Attempts, notification.NextAttemptAt.UTC(), notification.LastError, notification.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("recording notification failure: %w", err)
+	}
+	return nil
+}
+
+func (repository *SQLiteNotificationRepository) DeleteFinishedBefore(ctx context.Context, before time.Time) error {
+	_, err := repository.database.ExecContext(ctx,
+		`DELETE FROM notifications WHERE status IN ('sent', 'failed') AND created_at < ?`,
+		before.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("deleting finished notifications: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/google/uuid"
+)
+
+type PointsRepository interface {
+	Create(ctx context.Context, entry models.PointsEntry) (models.PointsEntry, error)
+	Balance(ctx context.Context, userID string) (int, error)
+	EarnedSince(ctx context.Context, userID string, since time.Time) (int, error)
+	FindByUser(ctx context.Context, userID string, limit int) ([]models.PointsEntry, error)
+	DeleteChoreCredits(ctx context.Context, choreID string) error
+}
+
+type SQLitePointsRepository struct {
+	database *sql.DB
+}
+
+func NewPointsRepository(database *sql.DB) *SQLitePointsRepository {
+	return &SQLitePointsRepository{database: database}
+}
+
+const pointsColumns = `id, user_id, points, reason, chore_id, redemption_id, created_at`
+
+func (repository *SQLitePointsRepository) Create(ctx context.Context, entry models.PointsEntry) (models.PointsEntry, error) {
+	entry.ID = uuid.New().String()
+	entry.CreatedAt = time.Now().UTC()
+
+	if err := insertPointsEntry(ctx, repository.database, entry); err != nil {
+		return models.PointsEntry{}, err
+	}
+	return entry, nil
+}
+
+// Balance is the user's spendable points: everything earned minus approved
+// redemptions.
+func (repository *SQLitePointsRepository) Balance(ctx context.Context, userID string) (int, error) {
+	return pointsBalance(ctx, repository.database, userID)
+}
+
+// EarnedSince sums the points the user earned from chores since the given time.
+// Redemptions are ignored so spending never lowers a leaderboard position.
+func (repository *SQLitePointsRepository) EarnedSince(ctx context.Context, userID string, since time.Time) (int, error) {
+	var points int
+	err := repository.database.QueryRowContext(ctx,
+		`SELECT COALESCE(SUM(points), 0) FROM points_ledger
+		WHERE user_id = ? AND reason = 'chore' AND created_at >= ?`,
+		userID, since.UTC(),
+	).Scan(&points)
+	if err != nil {
+		return 0, fmt.Errorf("summing earned points: %w", err)
+	}
+	return points, nil
+}
+
+// FindByUser returns the user's most recent ledger entries, newest first.
+func (repository *SQLitePointsRepository) FindByUser(ctx context.Context, userID string, limit int) ([]models.PointsEntry, error) {
+	rows, err := repository.database.QueryContext(ctx,
+		`SELECT `+pointsColumns+` FROM points_ledger
+		WHERE user_id = ?
+		ORDER BY created_at DESC
+		LIMIT ?`,
+		userID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("finding points ledger: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.PointsEntry
+	for rows.Next() {
+		var entry models.PointsEntry
+		if err := rows.Scan(
+			&entry.ID, &entry.UserID, &entry.Points, &entry.Reason,
+			&entry.ChoreID, &entry.RedemptionID, &entry.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning points entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// DeleteChoreCredits removes the points earned for completing a chore, for
+// when the completion is undone.
+func (repository *SQLitePointsRepository) DeleteChoreCredits(ctx context.Context, choreID string) error {
+	_, err := repository.database.ExecContext(ctx,
+		`DELETE FROM points_ledger WHERE chore_id = ? AND reason = 'chore'`, choreID,
+	)
+	if err != nil {
+		return fmt.Errorf("deleting chore credits: %w", err)
+	}
+	return nil
+}
+
+// queryExecer is satisfied by both *sql.DB and *sql.Tx so ledger writes can
+// join a caller's transaction.
+type queryExecer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func insertPointsEntry(ctx context.Context, database queryExecer, entry models.PointsEntry) error {
+	_, err := database.ExecContext(ctx,
+		`INSERT INTO points_ledger (`+pointsColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		entry.ID, entry.UserID, entry.Points, entry.Reason,
+		entry.ChoreID, entry.RedemptionID, entry.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("creating points entry: %w", err)
+	}
+	return nil
+}
+
+func pointsBalance(ctx context.Context, database queryExecer, userID string) (int, error) {
+	var balance int
+	err := database.QueryRowContext(ctx,
+		"SELECT COALESCE(SUM(points), 0) FROM points_ledger WHERE user_id = ?", userID,
+	).Scan(&balance)
+	if err != nil {
+		return 0, fmt.Errorf("summing points balance: %w", err)
+	}
+	return balance, nil
+}
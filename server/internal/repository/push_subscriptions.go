@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/google/uuid"
+)
+
+type PushSubscriptionRepository interface {
+	// Save stores a subscription, replacing any earlier one for the same
+	// endpoint.
+	Save(ctx context.Context, subscription models.PushSubscription) (models.PushSubscription, error)
+	FindByUser(ctx context.Context, userID string) ([]models.PushSubscription, error)
+	// DeleteByEndpoint drops the subscription for an endpoint, whoever it
+	// belongs to; DeleteForUser only if it is the user's.
+	DeleteByEndpoint(ctx context.Context, endpoint string) error
+	DeleteForUser(ctx context.Context, userID, endpoint string) error
+}
+
+type SQLitePushSubscriptionRepository struct {
+	database *sql.DB
+}
+
+func NewPushSubscriptionRepository(database *sql.DB) *SQLitePushSubscriptionRepository {
+	return &SQLitePushSubscriptionRepository{database: database}
+}
+
+func (repository *SQLitePushSubscriptionRepository) Save(ctx context.Context, subscription models.PushSubscription) (models.PushSubscription, error) {
+	subscription.ID = uuid.New().String()
+	subscription.CreatedAt = time.Now().UTC()
+
+	_, err := repository.database.ExecContext(ctx,
+		`INSERT INTO push_subscriptions (id, user_id, endpoint, p256dh, auth, user_agent, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (endpoint) DO UPDATE SET
+			user_id = excluded.user_id,
+			p256dh = excluded.p256dh,
+			auth = excluded.auth,
+			user_agent = excluded.user_agent`,
+		subscription.ID, subscription.UserID, subscription.Endpoint,
+		subscription.P256DH, subscription.Auth, subscription.UserAgent, subscription.CreatedAt,
+	)
+	if err != nil {
+		return models.PushSubscription{}, fmt.Errorf("saving push subscription: %w", err)
+	}
+	return subscription, nil
+}
+
+func (repository *SQLitePushSubscriptionRepository) FindByUser(ctx context.Context, userID string) ([]models.PushSubscription, error) {
+	rows, err := repository.database.QueryContext(ctx,
+		`SELECT id, user_id, endpoint, p256dh, auth, user_agent, created_at
+		FROM push_subscriptions WHERE user_id = ? ORDER BY created_at`, userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("finding push subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subscriptions []models.PushSubscription
+	for rows.Next() {
+		var subscription models.PushSubscription
+		if err := rows.Scan(
+			&subscription.ID, &subscription.UserID, &subscription.Endpoint,
+			&subscription.P256DH, &subscription.Auth, &subscription.UserAgent, &subscription.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning push subscription: %w", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, rows.Err()
+}
+
+func (repository *SQLitePushSubscriptionRepository) DeleteByEndpoint(ctx context.Context, endpoint string) error {
+	if _, err := repository.database.ExecContext(ctx, "DELETE FROM push_subscriptions WHERE endpoint = ?", endpoint); err != nil {
+		return fmt.Errorf("deleting push subscription: %w", err)
+	}
+	return nil
+}
+
+func (repository *SQLitePushSubscriptionRepository) DeleteForUser(ctx context.Context, userID, endpoint string) error {
+	if _, err := repository.database.ExecContext(ctx,
+		"DELETE FROM push_subscriptions WHERE user_id = ? AND endpoint = ?", userID, endpoint,
+	); err != nil {
+		return fmt.Errorf("deleting push subscription: %w", err)
+	}
+	return nil
+}
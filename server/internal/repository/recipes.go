@@ -119,7 +119,7 @@ func (repository *SQLiteRecipeRepository) Create(ctx context.Context, recipe mod
 	if recipe.ID == "" {
 		recipe.ID = uuid.New().String()
 	}
-	now := time.Now()
+	now := time.Now().UTC()
 	recipe.CreatedAt = now
 	recipe.UpdatedAt = now
 
@@ -144,7 +144,7 @@ func (repository *SQLiteRecipeRepository) Create(ctx context.Context, recipe mod
 }
 
 func (repository *SQLiteRecipeRepository) Update(ctx context.Context, recipe models.Recipe) error {
-	recipe.UpdatedAt = time.Now()
+	recipe.UpdatedAt = time.Now().UTC()
 
 	ingredientsJSON, stepsJSON, mealTypeStr, err := marshalRecipeFields(recipe)
 	if err != nil {
@@ -187,7 +187,7 @@ func (repository *SQLiteRecipeRepository) FindImageData(ctx context.Context, id
 func (repository *SQLiteRecipeRepository) UpdateImage(ctx context.Context, id string, imageData string) error {
 	_, err := repository.database.ExecContext(ctx,
 		`UPDATE recipes SET image_data = ?, updated_at = ? WHERE id = ?`,
-		imageData, time.Now(), id,
+		imageData, time.Now().UTC(), id,
 	)
 	if err != nil {
 		return fmt.Errorf("updating recipe image: %w", err)
@@ -198,7 +198,7 @@ func (repository *SQLiteRecipeRepository) UpdateImage(ctx context.Context, id st
 func (repository *SQLiteRecipeRepository) ClearImage(ctx context.Context, id string) error {
 	_, err := repository.database.ExecContext(ctx,
 		`UPDATE recipes SET image_data = '', updated_at = ? WHERE id = ?`,
-		time.Now(), id,
+		time.Now().UTC(), id,
 	)
 	if err != nil {
 		return fmt.Errorf("clearing recipe image: %w", err)
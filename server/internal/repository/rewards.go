@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInsufficientPoints is returned by ApproveRedemption when the member's
+	// balance no longer covers the reward.
+	ErrInsufficientPoints = errors.New("not enough points")
+	// ErrRedemptionResolved is returned when a redemption has already been
+	// approved or rejected.
+	ErrRedemptionResolved = errors.New("redemption has already been resolved")
+)
+
+type RedemptionFilter struct {
+	UserID *string
+	Status *models.RedemptionStatus
+}
+
+type RewardRepository interface {
+	FindAll(ctx context.Context, includeInactive bool) ([]models.Reward, error)
+	FindByID(ctx context.Context, id string) (models.Reward, error)
+	Create(ctx context.Context, reward models.Reward) (models.Reward, error)
+	Update(ctx context.Context, reward models.Reward) error
+
+	CreateRedemption(ctx context.Context, redemption models.RewardRedemption) (models.RewardRedemption, error)
+	FindRedemptionByID(ctx context.Context, id string) (models.RewardRedemption, error)
+	FindRedemptions(ctx context.Context, filter RedemptionFilter) ([]models.RewardRedemption, error)
+	PendingCost(ctx context.Context, userID string) (int, error)
+	ApproveRedemption(ctx context.Context, redemption models.RewardRedemption, resolvedByUserID string) error
+	RejectRedemption(ctx context.Context, id string, resolvedByUserID string) error
+}
+
+type SQLiteRewardRepository struct {
+	database *sql.DB
+}
+
+func NewRewardRepository(database *sql.DB) *SQLiteRewardRepository {
+	return &SQLiteRewardRepository{database: database}
+}
+
+const rewardColumns = `id, name, description, cost, active, created_by_user_id, created_at, updated_at`
+
+const redemptionColumns = `id, reward_id, user_id, cost, status, created_at, resolved_at, resolved_by_user_id`
+
+func (repository *SQLiteRewardRepository) FindAll(ctx context.Context, includeInactive bool) ([]models.Reward, error) {
+	query := `SELECT ` + rewardColumns + ` FROM rewards`
+	if !includeInactive {
+		query += ` WHERE active = 1`
+	}
+	query += ` ORDER BY cost, name`
+
+	rows, err := repository.database.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("finding rewards: %w", err)
+	}
+	defer rows.Close()
+
+	var rewards []models.Reward
+	for rows.Next() {
+		var reward models.Reward
+		if err := rows.Scan(
+			&reward.ID, &reward.Name, &reward.Description, &reward.Cost, &reward.Active,
+			&reward.CreatedByUserID, &reward.CreatedAt, &reward.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning reward: %w", err)
+		}
+		rewards = append(rewards, reward)
+	}
+	return rewards, rows.Err()
+}
+
+func (repository *SQLiteRewardRepository) FindByID(ctx context.Context, id string) (models.Reward, error) {
+	var reward models.Reward
+	err := repository.database.QueryRowContext(ctx,
+		`SELECT `+rewardColumns+` FROM rewards WHERE id = ?`, id,
+	).Scan(
+		&reward.ID, &reward.Name, &reward.Description, &reward.Cost, &reward.Active,
+		&reward.CreatedByUserID, &reward.CreatedAt, &reward.UpdatedAt,
+	)
+	if err != nil {
+		return reward, fmt.Errorf("finding reward: %w", err)
+	}
+	return reward, nil
+}
+
+func (repository *SQLiteRewardRepository) Create(ctx context.Context, reward models.Reward) (models.Reward, error) {
+	reward.ID = uuid.New().String()
+	now := time.Now().UTC()
+	reward.CreatedAt = now
+	reward.UpdatedAt = now
+
+	_, err := repository.database.ExecContext(ctx,
+		`INSERT INTO rewards (`+rewardColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		reward.ID, reward.Name, reward.Description, reward.Cost, reward.Active,
+		reward.CreatedByUserID, reward.CreatedAt, reward.UpdatedAt,
+	)
+	if err != nil {
+		return models.Reward{}, fmt.Errorf("creating reward: %w", err)
+	}
+	return reward, nil
+}
+
+func (repository *SQLiteRewardRepository) Update(ctx context.Context, reward models.Reward) error {
+	reward.UpdatedAt = time.Now().UTC()
+	_, err := repository.database.ExecContext(ctx,
+		`UPDATE rewards SET name = ?, description = ?, cost = ?, active = ?, updated_at = ?
+		WHERE id = ?`,
+		reward.Name, reward.Description, reward.Cost, reward.Active, reward.UpdatedAt, reward.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("updating reward: %w", err)
+	}
+	return nil
+}
+
+func (repository *SQLiteRewardRepository) CreateRedemption(ctx context.Context, redemption models.RewardRedemption) (models.RewardRedemption, error) {
+	redemption.ID = uuid.New().String()
+	redemption.CreatedAt = time.Now().UTC()
+	if redemption.Status == "" {
+		redemption.Status = models.RedemptionPending
+	}
+
+	_, err := repository.database.ExecContext(ctx,
+		`INSERT INTO reward_redemptions (`+redemptionColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		redemption.ID, redemption.RewardID, redemption.UserID, redemption.Cost, redemption.Status,
+		redemption.CreatedAt, inUTC(redemption.ResolvedAt), redemption.ResolvedByUserID,
+	)
+	if err != nil {
+		return models.RewardRedemption{}, fmt.Errorf("creating redemption: %w", err)
+	}
+	return redemption, nil
+}
+
+func (repository *SQLiteRewardRepository) FindRedemptionByID(ctx context.Context, id string) (models.RewardRedemption, error) {
+	var redemption models.RewardRedemption
+	err := repository.database.QueryRowContext(ctx,
+		`SELECT `+redemptionColumns+` FROM reward_redemptions WHERE id = ?`, id,
+	).Scan(
+		&redemption.ID, &redemption.RewardID, &redemption.UserID, &redemption.Cost, &redemption.Status,
+		&redemption.CreatedAt, &redemption.ResolvedAt, &redemption.ResolvedByUserID,
+	)
+	if err != nil {
+		return redemption, fmt.Errorf("finding redemption: %w", err)
+	}
+	return redemption, nil
+}
+
+// FindRedemptions returns redemptions matching the filter, newest first.
+func (repository *SQLiteRewardRepository) FindRedemptions(ctx context.Context, filter RedemptionFilter) ([]models.RewardRedemption, error) {
+	where := "WHERE 1=1"
+	var args []interface{}
+	if filter.UserID != nil {
+		where += " AND user_id = ?"
+		args = append(args, *filter.UserID)
+	}
+	if filter.Status != nil {
+		where += " AND status = ?"
+		args = append(args, *filter.Status)
+	}
+
+	rows, err := repository.database.QueryContext(ctx,
+		`SELECT `+redemptionColumns+` FROM reward_redemptions `+where+` ORDER BY created_at DESC`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("finding redemptions: %w", err)
+	}
+	defer rows.Close()
+
+	var redemptions []models.RewardRedemption
+	for rows.Next() {
+		var redemption models.RewardRedemption
+		if err := rows.Scan(
+			&redemption.ID, &redemption.RewardID, &redemption.UserID, &redemption.Cost, &redemption.Status,
+			&redemption.CreatedAt, &redemption.ResolvedAt, &redemption.ResolvedByUserID,
+		); err != nil {
+			return nil, fmt.Errorf("scanning redemption: %w", err)
+		}
+		redemptions = append(redemptions, redemption)
+	}
+	return redemptions, rows.Err()
+}
+
+// PendingCost sums the cost of the user's redemptions still awaiting approval,
+// i.e. points that are spoken for but not yet debited.
+func (repository *SQLiteRewardRepository) PendingCost(ctx context.Context, userID string) (int, error) {
+	var cost int
+	err := repository.database.QueryRowContext(ctx,
+		`SELECT COALESCE(SUM(cost), 0) FROM reward_redemptions
+		WHERE user_id = ? AND status = 'pending'`,
+		userID,
+	).Scan(&cost)
+	if err != nil {
+		return 0, fmt.Errorf("summing pending redemptions: %w", err)
+	}
+	return cost, nil
+}
+
+// ApproveRedemption marks the redemption approved and debits its cost from the
+// member's ledger in one transaction. Nothing is written and
+// ErrInsufficientPoints returned if the balance no longer covers the cost.
+func (repository *SQLiteRewardRepository) ApproveRedemption(ctx context.Context, redemption models.RewardRedemption, resolvedByUserID string) error {
+	transaction, err := repository.database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer transaction.Rollback()
+
+	now := time.Now().UTC()
+	if err := resolveRedemption(ctx, transaction, redemption.ID, models.RedemptionApproved, resolvedByUserID, now); err != nil {
+		return err
+	}
+
+	balance, err := pointsBalance(ctx, transaction, redemption.UserID)
+	if err != nil {
+		return err
+	}
+	if balance < redemption.Cost {
+		return ErrInsufficientPoints
+	}
+
+	if err := insertPointsEntry(ctx, transaction, models.PointsEntry{
+		ID:           uuid.New().String(),
+		UserID:       redemption.UserID,
+		Points:       -redemption.Cost,
+		Reason:       models.PointsReasonRedemption,
+		RedemptionID: &redemption.ID,
+		CreatedAt:    now,
+	}); err != nil {
+		return err
+	}
+
+	return transaction.Commit()
+}
+
+func (repository *SQLiteRewardRepository) RejectRedemption(ctx context.Context, id string, resolvedByUserID string) error {
+	return resolveRedemption(ctx, repository.database, id, models.RedemptionRejected, resolvedByUserID, time.Now().UTC())
+}
+
+func resolveRedemption(ctx context.Context, database queryExecer, id string, status models.RedemptionStatus, resolvedByUserID string, now time.Time) error {
+	result, err := database.ExecContext(ctx,
+		`UPDATE reward_redemptions SET status = ?, resolved_at = ?, resolved_by_user_id = ?
+		WHERE id = ? AND status = 'pending'`,
+		status, now, resolvedByUserID, id,
+	)
+	if err != nil {
+		return fmt.Errorf("resolving redemption: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected != 1 {
+		return ErrRedemptionResolved
+	}
+	return nil
+}
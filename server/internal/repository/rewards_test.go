@@ -0,0 +1,87 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/testutil"
+)
+
+func TestRewardRepository_ApproveDebitsLedger(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	pointsRepo := repository.NewPointsRepository(db)
+	rewardRepo := repository.NewRewardRepository(db)
+	ctx := context.Background()
+
+	parent := createTestUserNamed(t, userRepo, "parent")
+	kid := createTestUserNamed(t, userRepo, "kid")
+
+	if _, err := pointsRepo.Create(ctx, models.PointsEntry{UserID: kid.ID, Points: 10, Reason: models.PointsReasonChore}); err != nil {
+		t.Fatalf("crediting points: %v", err)
+	}
+	reward, err := rewardRepo.Create(ctx, models.Reward{Name: "Movie night", Cost: 8, Active: true, CreatedByUserID: parent.ID})
+	if err != nil {
+		t.Fatalf("creating reward: %v", err)
+	}
+	redemption, err := rewardRepo.CreateRedemption(ctx, models.RewardRedemption{RewardID: reward.ID, UserID: kid.ID, Cost: reward.Cost})
+	if err != nil {
+		t.Fatalf("creating redemption: %v", err)
+	}
+
+	if pending, _ := rewardRepo.PendingCost(ctx, kid.ID); pending != 8 {
+		t.Errorf("pending cost: got %d, want 8", pending)
+	}
+
+	if err := rewardRepo.ApproveRedemption(ctx, redemption, parent.ID); err != nil {
+		t.Fatalf("approving redemption: %v", err)
+	}
+	if balance, _ := pointsRepo.Balance(ctx, kid.ID); balance != 2 {
+		t.Errorf("balance after approval: got %d, want 2", balance)
+	}
+	if pending, _ := rewardRepo.PendingCost(ctx, kid.ID); pending != 0 {
+		t.Errorf("pending cost after approval: got %d, want 0", pending)
+	}
+
+	approved, _ := rewardRepo.FindRedemptionByID(ctx, redemption.ID)
+	if approved.Status != models.RedemptionApproved || approved.ResolvedByUserID == nil || *approved.ResolvedByUserID != parent.ID {
+		t.Errorf("expected approval by parent, got %s", approved.Status)
+	}
+
+	if err := rewardRepo.ApproveRedemption(ctx, redemption, parent.ID); !errors.Is(err, repository.ErrRedemptionResolved) {
+		t.Errorf("approving twice: got %v, want ErrRedemptionResolved", err)
+	}
+	if balance, _ := pointsRepo.Balance(ctx, kid.ID); balance != 2 {
+		t.Errorf("second approval must not debit again, balance %d", balance)
+	}
+}
+
+func TestRewardRepository_ApproveInsufficientChangesNothing(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	pointsRepo := repository.NewPointsRepository(db)
+	rewardRepo := repository.NewRewardRepository(db)
+	ctx := context.Background()
+
+	parent := createTestUserNamed(t, userRepo, "parent")
+	kid := createTestUserNamed(t, userRepo, "kid")
+
+	pointsRepo.Create(ctx, models.PointsEntry{UserID: kid.ID, Points: 3, Reason: models.PointsReasonChore})
+	reward, _ := rewardRepo.Create(ctx, models.Reward{Name: "Ice cream", Cost: 5, Active: true, CreatedByUserID: parent.ID})
+	redemption, _ := rewardRepo.CreateRedemption(ctx, models.RewardRedemption{RewardID: reward.ID, UserID: kid.ID, Cost: reward.Cost})
+
+	if err := rewardRepo.ApproveRedemption(ctx, redemption, parent.ID); !errors.Is(err, repository.ErrInsufficientPoints) {
+		t.Fatalf("got %v, want ErrInsufficientPoints", err)
+	}
+
+	unchanged, _ := rewardRepo.FindRedemptionByID(ctx, redemption.ID)
+	if unchanged.Status != models.RedemptionPending {
+		t.Errorf("redemption should stay pending, got %s", unchanged.Status)
+	}
+	if balance, _ := pointsRepo.Balance(ctx, kid.ID); balance != 3 {
+		t.Errorf("balance: got %d, want 3", balance)
+	}
+}
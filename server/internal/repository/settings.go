@@ -6,11 +6,24 @@ import (
 	"fmt"
 )
 
-const SettingsKeyFamilyName = "family_name"
+const (
+	SettingsKeyFamilyName = "family_name"
+	// SettingsKeyAllowancePerPoint is the money one point is worth, as a
+	// decimal string such as "0.10". Unset or empty disables the allowance.
+	SettingsKeyAllowancePerPoint = "allowance_per_point"
+	// SettingsKeyTimezone is the household's IANA zone name, such as
+	// "Europe/London". Day boundaries and due times are evaluated in it.
+	SettingsKeyTimezone = "timezone"
+	// SettingsKeyVAPIDPrivateKey is the server's Web Push (VAPID) P-256
+	// private key, base64url encoded. It is generated on first start.
+	SettingsKeyVAPIDPrivateKey = "vapid_private_key"
+)
 
 type SettingsRepository interface {
 	Get(ctx context.Context, key string) (string, error)
 	Set(ctx context.Context, key string, value string) error
+	// SetIfAbsent stores the value only when the key has none yet.
+	SetIfAbsent(ctx context.Context, key string, value string) error
 }
 
 type SQLiteSettingsRepository struct {
@@ -42,3 +55,13 @@ func (repository *SQLiteSettingsRepository) Set(ctx context.Context, key string,
 	}
 	return nil
 }
+
+func (repository *SQLiteSettingsRepository) SetIfAbsent(ctx context.Context, key string, value string) error {
+	_, err := repository.database.ExecContext(ctx,
+		"INSERT OR IGNORE INTO settings (key, value) VALUES (?, ?)", key, value,
+	)
+	if err != nil {
+		return fmt.Errorf("setting %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DailyCompletions is how many chores UserID completed in CategoryID (nil for
+// uncategorised chores) on Day, a YYYY-MM-DD date in the report's timezone.
+type DailyCompletions struct {
+	UserID     string
+	CategoryID *string
+	Day        string
+	Count      int
+}
+
+// Punctuality summarises a user's completions of dated chores. A completion is
+// late once the chore's due time has passed, or the end of its due day when it
+// has no due time. AverageLateMinutes covers late completions only.
+type Punctuality struct {
+	UserID             string
+	OnTime             int
+	Late               int
+	AverageLateMinutes float64
+}
+
+// CompletionRun is an unbroken run of days on which UserID completed at least
+// one chore, from FirstDay to LastDay inclusive.
+type CompletionRun struct {
+	UserID   string
+	FirstDay string
+	LastDay  string
+	Days     int
+}
+
+// SkippedChore counts the skipped occurrences of one chore, or of one series
+// when SeriesID is set.
+type SkippedChore struct {
+	SeriesID *string
+	Name     string
+	Skipped  int
+}
+
+// SeriesDuration is the average time timed on a chore's completed
+// occurrences, or on a series' when SeriesID is set. Time is person-time: two
+// members timing the same ten minutes count twenty.
+type SeriesDuration struct {
+	SeriesID       *string
+	Name           string
+	Occurrences    int
+	AverageMinutes float64
+}
+
+type StatsRepository interface {
+	DailyCompletions(ctx context.Context, from, to time.Time, location *time.Location) ([]DailyCompletions, error)
+	Punctuality(ctx context.Context, from, to time.Time, location *time.Location) ([]Punctuality, error)
+	CompletionRuns(ctx context.Context, from, to time.Time, location *time.Location) ([]CompletionRun, error)
+	MostSkipped(ctx context.Context, fromDay, toDay string, limit int) ([]SkippedChore, error)
+	SeriesDurations(ctx context.Context, from, to time.Time) ([]SeriesDuration, error)
+}
+
+type SQLiteStatsRepository struct {
+	database *sql.DB
+}
+
+func NewStatsRepository(database *sql.DB) *SQLiteStatsRepository {
+	return &SQLiteStatsRepository{database: database}
+}
+
+// sqliteTimestamp is the prefix of a stored instant that SQLite's date
+// functions understand. Instants are stored in UTC.
+const sqliteTimestamp = "2006-01-02 15:04:05"
+
+// completionsCTE selects completions between from and to with their time
+// shifted into the report's timezone (local_done). A chore is credited to
+// whoever completed it; a group chore to each participant who did their part,
+// when they did it, as the leaderboard counts them. The zone CTE lists each
+// stretch of that range with a single UTC offset, so completions either side
+// of a DST change land on the right local day.
+func completionsCTE(from, to time.Time, location *time.Location) (string, []any) {
+	var values []string
+	var args []any
+	for start := from.UTC(); start.Before(to); {
+		_, offset := start.In(location).Zone()
+		_, next := start.In(location).ZoneBounds()
+		end := to.UTC()
+		if !next.IsZero() && next.Before(end) {
+			end = next.UTC()
+		}
+		values = append(values, "(?, ?, ?)")
+		args = append(args, start.Format(sqliteTimestamp), end.Format(sqliteTimestamp), fmt.Sprintf("%+d seconds", offset))
+		start = end
+	}
+	if len(values) == 0 {
+		values = append(values, "(?, ?, ?)")
+		args = append(args, from.UTC().Format(sqliteTimestamp), from.UTC().Format(sqliteTimestamp), "+0 seconds")
+	}
+
+	return `WITH zone(starts, ends, shift) AS (VALUES ` + strings.Join(values, ", ") + `),
+	parts AS (
+		SELECT c.id AS chore_id, c.completed_by_user_id AS user_id, c.category_id AS category_id,
+			c.due_date AS due_date, c.due_time AS due_time, c.completed_at AS completed_at
+		FROM chores c
+		WHERE c.status = 'completed' AND c.completed_by_user_id IS NOT NULL AND NOT c.group_chore
+		UNION ALL
+		SELECT c.id, a.user_id, c.category_id, c.due_date, c.due_time, a.completed_at
+		FROM chores c
+		JOIN chore_assignments a ON a.chore_id = c.id AND a.status = 'completed'
+		WHERE c.status = 'completed' AND c.group_chore
+	),
+	done AS (
+		SELECT p.chore_id, p.user_id, p.category_id, p.due_date, p.due_time,
+			datetime(substr(p.completed_at, 1, 19), z.shift) AS local_done
+		FROM parts p
+		JOIN zone z ON substr(p.completed_at, 1, 19) >= z.starts AND substr(p.completed_at, 1, 19) < z.ends
+	)`, args
+}
+
+// DailyCompletions counts completions per user, category and local day.
+func (repository *SQLiteStatsRepository) DailyCompletions(ctx context.Context, from, to time.Time, location *time.Location) ([]DailyCompletions, error) {
+	cte, args := completionsCTE(from, to, location)
+	rows, err := repository.database.QueryContext(ctx,
+		cte+`
+		SELECT user_id, category_id, date(local_done) AS day, COUNT(*)
+		FROM done
+		GROUP BY user_id, category_id, day
+		ORDER BY day`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("counting daily completions: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []DailyCompletions
+	for rows.Next() {
+		var count DailyCompletions
+		if err := rows.Scan(&count.UserID, &count.CategoryID, &count.Day, &count.Count); err != nil {
+			return nil, fmt.Errorf("scanning daily completions: %w", err)
+		}
+		counts = append(counts, count)
+	}
+	return counts, rows.Err()
+}
+
+// Punctuality compares each completion of a dated chore with its deadline,
+// read as a wall-clock time in the report's timezone.
+func (repository *SQLiteStatsRepository) Punctuality(ctx context.Context, from, to time.Time, location *time.Location) ([]Punctuality, error) {
+	cte, args := completionsCTE(from, to, location)
+	rows, err := repository.database.QueryContext(ctx,
+		cte+`,
+	deadlines AS (
+		SELECT user_id, local_done,
+			substr(due_date, 1, 10) || ' ' || COALESCE(due_time || ':00', '23:59:59') AS deadline
+		FROM done
+		WHERE due_date IS NOT NULL
+	)
+		SELECT user_id,
+			SUM(local_done <= deadline),
+			SUM(local_done > deadline),
+			COALESCE(AVG(CASE WHEN local_done > deadline
+				THEN (julianday(local_done) - julianday(deadline)) * 1440 END), 0)
+		FROM deadlines
+		GROUP BY user_id`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("measuring punctuality: %w", err)
+	}
+	defer rows.Close()
+
+	var results []Punctuality
+	for rows.Next() {
+		var result Punctuality
+		if err := rows.Scan(&result.UserID, &result.OnTime, &result.Late, &result.AverageLateMinutes); err != nil {
+			return nil, fmt.Errorf("scanning punctuality: %w", err)
+		}
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
+
+// CompletionRuns groups each user's completion days into unbroken runs: days
+// minus their rank is constant within a run ("gaps and islands").
+func (repository *SQLiteStatsRepository) CompletionRuns(ctx context.Context, from, to time.Time, location *time.Location) ([]CompletionRun, error) {
+	cte, args := completionsCTE(from, to, location)
+	rows, err := repository.database.QueryContext(ctx,
+		cte+`,
+	days AS (
+		SELECT DISTINCT user_id, date(local_done) AS day FROM done
+	),
+	islands AS (
+		SELECT user_id, day,
+			julianday(day) - ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY day) AS island
+		FROM days
+	)
+		SELECT user_id, MIN(day), MAX(day), COUNT(*)
+		FROM islands
+		GROUP BY user_id, island
+		ORDER BY user_id, MIN(day)`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("finding completion runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []CompletionRun
+	for rows.Next() {
+		var run CompletionRun
+		if err := rows.Scan(&run.UserID, &run.FirstDay, &run.LastDay, &run.Days); err != nil {
+			return nil, fmt.Errorf("scanning completion run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+// MostSkipped ranks chores by skipped occurrences due between fromDay and
+// toDay (YYYY-MM-DD, inclusive). Occurrences of a series count together.
+func (repository *SQLiteStatsRepository) MostSkipped(ctx context.Context, fromDay, toDay string, limit int) ([]SkippedChore, error) {
+	rows, err := repository.database.QueryContext(ctx,
+		`SELECT c.series_id, COALESCE(cs.name, c.name), COUNT(*) AS skipped
+		FROM chores c
+		LEFT JOIN chore_series cs ON cs.id = c.series_id
+		WHERE c.status = 'skipped'
+			AND substr(COALESCE(c.due_date, c.updated_at), 1, 10) BETWEEN ? AND ?
+		GROUP BY COALESCE(c.series_id, c.id)
+		ORDER BY skipped DESC, COALESCE(cs.name, c.name)
+		LIMIT ?`,
+		fromDay, toDay, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("finding most skipped chores: %w", err)
+	}
+	defer rows.Close()
+
+	var skipped []SkippedChore
+	for rows.Next() {
+		var chore SkippedChore
+		if err := rows.Scan(&chore.SeriesID, &chore.Name, &chore.Skipped); err != nil {
+			return nil, fmt.Errorf("scanning skipped chore: %w", err)
+		}
+		skipped = append(skipped, chore)
+	}
+	return skipped, rows.Err()
+}
+
+// SeriesDurations averages the time timed on occurrences completed between
+// from and to, per series, longest first. Occurrences nobody timed are left
+// out.
+func (repository *SQLiteStatsRepository) SeriesDurations(ctx context.Context, from, to time.Time) ([]SeriesDuration, error) {
+	rows, err := repository.database.QueryContext(ctx,
+		`SELECT c.series_id, COALESCE(cs.name, c.name), COUNT(*), AVG(t.total) / 60 AS average
+		FROM (
+			SELECT s.chore_id, `+segmentSeconds+` AS total
+			FROM chore_time_segments s
+			WHERE s.ended_at IS NOT NULL
+			GROUP BY s.chore_id
+		) t
+		JOIN chores c ON c.id = t.chore_id
+		LEFT JOIN chore_series cs ON cs.id = c.series_id
+		WHERE c.status = 'completed'
+			AND substr(c.completed_at, 1, 19) >= ? AND substr(c.completed_at, 1, 19) < ?
+		GROUP BY COALESCE(c.series_id, c.id)
+		ORDER BY average DESC, COALESCE(cs.name, c.name)`,
+		from.UTC().Format(sqliteTimestamp), to.UTC().Format(sqliteTimestamp),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("averaging chore durations: %w", err)
+	}
+	defer rows.Close()
+
+	var durations []SeriesDuration
+	for rows.Next() {
+		var duration SeriesDuration
+		if err := rows.Scan(&duration.SeriesID, &duration.Name, &duration.Occurrences, &duration.AverageMinutes); err != nil {
+			return nil, fmt.Errorf("scanning chore duration: %w", err)
+		}
+		durations = append(durations, duration)
+	}
+	return durations, rows.Err()
+}
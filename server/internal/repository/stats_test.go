@@ -0,0 +1,235 @@
+package repository_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/testutil"
+)
+
+func TestStatsRepository_CompletionsAcrossDST(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	categoryRepo := repository.NewCategoryRepository(db)
+	repo := repository.NewStatsRepository(db)
+	ctx := context.Background()
+
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("loading zone: %v", err)
+	}
+	alice := createTestUserNamed(t, userRepo, "alice")
+	bob := createTestUserNamed(t, userRepo, "bob")
+	cleaning, _ := categoryRepo.Create(ctx, models.Category{Name: "Cleaning", CreatedByUserID: alice.ID})
+
+	complete := func(user models.User, categoryID *string, due time.Time, dueTime *string, done time.Time) {
+		t.Helper()
+		_, err := choreRepo.Create(ctx, models.Chore{
+			Name:              "Chore",
+			CreatedByUserID:   user.ID,
+			CategoryID:        categoryID,
+			DueDate:           &due,
+			DueTime:           dueTime,
+			Status:            models.ChoreStatusCompleted,
+			CompletedAt:       &done,
+			CompletedByUserID: &user.ID,
+		})
+		if err != nil {
+			t.Fatalf("creating chore: %v", err)
+		}
+	}
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	// London clocks go forward at 01:00 UTC on 29 March.
+	complete(alice, nil, day(27), nil, time.Date(2026, 3, 27, 10, 0, 0, 0, time.UTC))
+	complete(alice, nil, day(28), stringPtr("23:00"), time.Date(2026, 3, 28, 23, 30, 0, 0, time.UTC))
+	complete(alice, nil, day(30), nil, time.Date(2026, 3, 29, 23, 30, 0, 0, time.UTC))
+	complete(alice, nil, day(31), nil, time.Date(2026, 3, 31, 23, 30, 0, 0, time.UTC))
+	complete(bob, &cleaning.ID, day(29), stringPtr("12:30"), time.Date(2026, 3, 29, 12, 0, 0, 0, time.UTC))
+
+	from := time.Date(2026, 3, 27, 0, 0, 0, 0, london)
+	to := time.Date(2026, 4, 1, 0, 0, 0, 0, london)
+
+	counts, err := repo.DailyCompletions(ctx, from, to, london)
+	if err != nil {
+		t.Fatalf("DailyCompletions: %v", err)
+	}
+	got := map[string]string{}
+	for _, count := range counts {
+		if count.Count != 1 {
+			t.Errorf("expected one completion per row, got %+v", count)
+		}
+		label := count.UserID
+		if count.CategoryID != nil {
+			label += "/" + *count.CategoryID
+		}
+		got[count.Day] = label
+	}
+	want := map[string]string{
+		"2026-03-27": alice.ID,
+		"2026-03-28": alice.ID,
+		"2026-03-29": bob.ID + "/" + cleaning.ID,
+		// 23:30 UTC on the 29th is 00:30 BST on the 30th.
+		"2026-03-30": alice.ID,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected days %v, got %v", want, got)
+	}
+	for day, label := range want {
+		if got[day] != label {
+			t.Errorf("%s: expected %s, got %s", day, label, got[day])
+		}
+	}
+
+	punctuality, err := repo.Punctuality(ctx, from, to, london)
+	if err != nil {
+		t.Fatalf("Punctuality: %v", err)
+	}
+	byUser := map[string]repository.Punctuality{}
+	for _, result := range punctuality {
+		byUser[result.UserID] = result
+	}
+	if result := byUser[alice.ID]; result.OnTime != 2 || result.Late != 1 || math.Abs(result.AverageLateMinutes-30) > 0.01 {
+		t.Errorf("alice: expected 2 on time and 1 late by 30m, got %+v", result)
+	}
+	// Due 12:30 and done at 13:00 BST.
+	if result := byUser[bob.ID]; result.OnTime != 0 || result.Late != 1 || math.Abs(result.AverageLateMinutes-30) > 0.01 {
+		t.Errorf("bob: expected 1 late by 30m, got %+v", result)
+	}
+
+	runs, err := repo.CompletionRuns(ctx, from, to, london)
+	if err != nil {
+		t.Fatalf("CompletionRuns: %v", err)
+	}
+	var aliceRuns []repository.CompletionRun
+	for _, run := range runs {
+		if run.UserID == alice.ID {
+			aliceRuns = append(aliceRuns, run)
+		}
+	}
+	if len(aliceRuns) != 2 ||
+		aliceRuns[0].FirstDay != "2026-03-27" || aliceRuns[0].LastDay != "2026-03-28" || aliceRuns[0].Days != 2 ||
+		aliceRuns[1].FirstDay != "2026-03-30" || aliceRuns[1].Days != 1 {
+		t.Errorf("unexpected runs for alice: %+v", aliceRuns)
+	}
+}
+
+func TestStatsRepository_MostSkippedGroupsSeries(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	seriesRepo := repository.NewChoreSeriesRepository(db)
+	repo := repository.NewStatsRepository(db)
+	ctx := context.Background()
+
+	alice := createTestUserNamed(t, userRepo, "alice")
+	series, err := seriesRepo.Create(ctx, models.ChoreSeries{
+		Name:            "Bins",
+		CreatedByUserID: alice.ID,
+		RecurrenceType:  models.RecurrenceWeekly,
+		RecurrenceValue: `{"interval":1}`,
+	})
+	if err != nil {
+		t.Fatalf("creating series: %v", err)
+	}
+
+	skip := func(name string, seriesID *string, day int) {
+		t.Helper()
+		due := time.Date(2026, 5, day, 0, 0, 0, 0, time.UTC)
+		if _, err := choreRepo.Create(ctx, models.Chore{
+			Name:            name,
+			CreatedByUserID: alice.ID,
+			SeriesID:        seriesID,
+			DueDate:         &due,
+			Status:          models.ChoreStatusSkipped,
+		}); err != nil {
+			t.Fatalf("creating chore: %v", err)
+		}
+	}
+	skip("Bins", &series.ID, 4)
+	skip("Bins", &series.ID, 11)
+	skip("Hoover", nil, 6)
+	skip("Windows", nil, 20)
+
+	skipped, err := repo.MostSkipped(ctx, "2026-05-01", "2026-05-15", 5)
+	if err != nil {
+		t.Fatalf("MostSkipped: %v", err)
+	}
+	if len(skipped) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", skipped)
+	}
+	if skipped[0].Name != "Bins" || skipped[0].Skipped != 2 || skipped[0].SeriesID == nil || *skipped[0].SeriesID != series.ID {
+		t.Errorf("expected Bins skipped twice first, got %+v", skipped[0])
+	}
+	if skipped[1].Name != "Hoover" || skipped[1].Skipped != 1 || skipped[1].SeriesID != nil {
+		t.Errorf("expected Hoover skipped once, got %+v", skipped[1])
+	}
+}
+
+func TestStatsRepository_CreditsEveryGroupParticipant(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	assignmentRepo := repository.NewChoreAssignmentRepository(db)
+	repo := repository.NewStatsRepository(db)
+	ctx := context.Background()
+
+	alice := createTestUserNamed(t, userRepo, "alice")
+	bob := createTestUserNamed(t, userRepo, "bob")
+	due := time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC)
+	aliceDone := time.Date(2026, 6, 1, 18, 0, 0, 0, time.UTC)
+	bobDone := time.Date(2026, 6, 3, 9, 0, 0, 0, time.UTC)
+	chore, err := choreRepo.Create(ctx, models.Chore{
+		Name:              "Clear the garden",
+		CreatedByUserID:   alice.ID,
+		DueDate:           &due,
+		GroupChore:        true,
+		Status:            models.ChoreStatusCompleted,
+		CompletedAt:       &bobDone,
+		CompletedByUserID: &bob.ID,
+	})
+	if err != nil {
+		t.Fatalf("creating chore: %v", err)
+	}
+	for user, done := range map[string]time.Time{alice.ID: aliceDone, bob.ID: bobDone} {
+		if _, err := assignmentRepo.Create(ctx, models.ChoreAssignment{
+			ChoreID:     chore.ID,
+			UserID:      user,
+			CompletedAt: &done,
+			Status:      models.AssignmentStatusCompleted,
+		}); err != nil {
+			t.Fatalf("creating assignment: %v", err)
+		}
+	}
+
+	from := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 6, 8, 0, 0, 0, 0, time.UTC)
+	counts, err := repo.DailyCompletions(ctx, from, to, time.UTC)
+	if err != nil {
+		t.Fatalf("DailyCompletions: %v", err)
+	}
+	got := map[string]string{}
+	for _, count := range counts {
+		got[count.UserID] = count.Day
+	}
+	if len(got) != 2 || got[alice.ID] != "2026-06-01" || got[bob.ID] != "2026-06-03" {
+		t.Errorf("expected each participant credited on the day of their part, got %v", got)
+	}
+
+	punctuality, err := repo.Punctuality(ctx, from, to, time.UTC)
+	if err != nil {
+		t.Fatalf("Punctuality: %v", err)
+	}
+	byUser := map[string]repository.Punctuality{}
+	for _, result := range punctuality {
+		byUser[result.UserID] = result
+	}
+	if byUser[alice.ID].OnTime != 1 || byUser[bob.ID].Late != 1 {
+		t.Errorf("expected alice on time and bob late, got %+v", punctuality)
+	}
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/google/uuid"
+)
+
+// UnavailabilityFilter narrows away windows. EndingFrom (YYYY-MM-DD) drops
+// windows that finished before that day.
+type UnavailabilityFilter struct {
+	UserID     *string
+	EndingFrom *string
+}
+
+type UnavailabilityRepository interface {
+	Create(ctx context.Context, window models.Unavailability) (models.Unavailability, error)
+	FindByID(ctx context.Context, id string) (models.Unavailability, error)
+	FindAll(ctx context.Context, filter UnavailabilityFilter) ([]models.Unavailability, error)
+	FindOverlapping(ctx context.Context, from, to string) ([]models.Unavailability, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type SQLiteUnavailabilityRepository struct {
+	database *sql.DB
+}
+
+func NewUnavailabilityRepository(database *sql.DB) *SQLiteUnavailabilityRepository {
+	return &SQLiteUnavailabilityRepository{database: database}
+}
+
+const unavailabilityColumns = `id, user_id, start_date, end_date, reason, created_by_user_id, created_at`
+
+func (repository *SQLiteUnavailabilityRepository) Create(ctx context.Context, window models.Unavailability) (models.Unavailability, error) {
+	window.ID = uuid.New().String()
+	window.CreatedAt = time.Now().UTC()
+
+	_, err := repository.database.ExecContext(ctx,
+		`INSERT INTO user_unavailability (`+unavailabilityColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		window.ID, window.UserID, window.StartDate, window.EndDate, window.Reason,
+		window.CreatedByUserID, window.CreatedAt,
+	)
+	if err != nil {
+		return models.Unavailability{}, fmt.Errorf("creating unavailability: %w", err)
+	}
+	return window, nil
+}
+
+func (repository *SQLiteUnavailabilityRepository) FindByID(ctx context.Context, id string) (models.Unavailability, error) {
+	var window models.Unavailability
+	err := repository.database.QueryRowContext(ctx,
+		`SELECT `+unavailabilityColumns+` FROM user_unavailability WHERE id = ?`, id,
+	).Scan(
+		&window.ID, &window.UserID, &window.StartDate, &window.EndDate, &window.Reason,
+		&window.CreatedByUserID, &window.CreatedAt,
+	)
+	if err != nil {
+		return window, fmt.Errorf("finding unavailability: %w", err)
+	}
+	return window, nil
+}
+
+// FindAll returns windows matching the filter, soonest first.
+func (repository *SQLiteUnavailabilityRepository) FindAll(ctx context.Context, filter UnavailabilityFilter) ([]models.Unavailability, error) {
+	where := "WHERE 1=1"
+	var args []interface{}
+	if filter.UserID != nil {
+		where += " AND user_id = ?"
+		args = append(args, *filter.UserID)
+	}
+	if filter.EndingFrom != nil {
+		where += " AND end_date >= ?"
+		args = append(args, *filter.EndingFrom)
+	}
+	return repository.query(ctx, where+" ORDER BY start_date, end_date", args...)
+}
+
+// FindOverlapping returns every window that shares at least one day with the
+// inclusive range from..to.
+func (repository *SQLiteUnavailabilityRepository) FindOverlapping(ctx context.Context, from, to string) ([]models.Unavailability, error) {
+	return repository.query(ctx, "WHERE start_date <= ? AND end_date >= ? ORDER BY start_date", to, from)
+}
+
+func (repository *SQLiteUnavailabilityRepository) Delete(ctx context.Context, id string) error {
+	_, err := repository.database.ExecContext(ctx, `DELETE FROM user_unavailability WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("deleting unavailability: %w", err)
+	}
+	return nil
+}
+
+func (repository *SQLiteUnavailabilityRepository) query(ctx context.Context, clause string, args ...interface{}) ([]models.Unavailability, error) {
+	rows, err := repository.database.QueryContext(ctx,
+		`SELECT `+unavailabilityColumns+` FROM user_unavailability `+clause, args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("finding unavailability: %w", err)
+	}
+	defer rows.Close()
+
+	var windows []models.Unavailability
+	for rows.Next() {
+		var window models.Unavailability
+		if err := rows.Scan(
+			&window.ID, &window.UserID, &window.StartDate, &window.EndDate, &window.Reason,
+			&window.CreatedByUserID, &window.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning unavailability: %w", err)
+		}
+		windows = append(windows, window)
+	}
+	return windows, rows.Err()
+}
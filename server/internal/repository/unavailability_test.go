@@ -0,0 +1,62 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/testutil"
+)
+
+func TestUnavailabilityRepository_FindOverlapping(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	repo := repository.NewUnavailabilityRepository(db)
+	ctx := context.Background()
+
+	alice := createTestUserNamed(t, userRepo, "alice")
+	bob := createTestUserNamed(t, userRepo, "bob")
+
+	camp, err := repo.Create(ctx, models.Unavailability{UserID: alice.ID, StartDate: "2030-07-01", EndDate: "2030-07-07", CreatedByUserID: alice.ID})
+	if err != nil {
+		t.Fatalf("creating window: %v", err)
+	}
+	repo.Create(ctx, models.Unavailability{UserID: bob.ID, StartDate: "2030-07-07", EndDate: "2030-07-07", CreatedByUserID: bob.ID})
+
+	tests := []struct {
+		name     string
+		from, to string
+		want     int
+	}{
+		{name: "before both", from: "2030-06-30", to: "2030-06-30", want: 0},
+		{name: "first day is inclusive", from: "2030-07-01", to: "2030-07-01", want: 1},
+		{name: "shared last day", from: "2030-07-07", to: "2030-07-07", want: 2},
+		{name: "range spanning both", from: "2030-06-01", to: "2030-08-01", want: 2},
+		{name: "after both", from: "2030-07-08", to: "2030-07-09", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			windows, err := repo.FindOverlapping(ctx, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("FindOverlapping: %v", err)
+			}
+			if len(windows) != tt.want {
+				t.Errorf("got %d windows, want %d", len(windows), tt.want)
+			}
+		})
+	}
+
+	from := "2030-07-02"
+	mine, _ := repo.FindAll(ctx, repository.UnavailabilityFilter{UserID: &alice.ID, EndingFrom: &from})
+	if len(mine) != 1 || mine[0].ID != camp.ID {
+		t.Errorf("expected alice's camp window, got %+v", mine)
+	}
+
+	if err := repo.Delete(ctx, camp.ID); err != nil {
+		t.Fatalf("deleting window: %v", err)
+	}
+	if windows, _ := repo.FindOverlapping(ctx, "2030-07-01", "2030-07-06"); len(windows) != 0 {
+		t.Errorf("expected no windows after delete, got %d", len(windows))
+	}
+}
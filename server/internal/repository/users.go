@@ -22,6 +22,7 @@ type UserRepository interface {
 	ClearAvatar(ctx context.Context, userID string) error
 	Count(ctx context.Context) (int, error)
 	MarkOnboarded(ctx context.Context, id string) error
+	UpdateTimezone(ctx context.Context, id string, timezone string) error
 }
 
 type SQLiteUserRepository struct {
@@ -32,7 +33,7 @@ func NewUserRepository(database *sql.DB) *SQLiteUserRepository {
 	return &SQLiteUserRepository{database: database}
 }
 
-const userColumns = "id, oidc_subject, email, name, avatar_url, role, created_at, updated_at, onboarded_at"
+const userColumns = "id, oidc_subject, email, name, avatar_url, role, created_at, updated_at, onboarded_at, timezone"
 
 func (repository *SQLiteUserRepository) FindByID(ctx context.Context, id string) (models.User, error) {
 	var user models.User
@@ -80,7 +81,7 @@ func scanUserFields(user *models.User) []any {
 	return []any{
 		&user.ID, &user.OIDCSubject, &user.Email, &user.Name,
 		&user.AvatarURL, &user.Role, &user.CreatedAt, &user.UpdatedAt,
-		&user.OnboardedAt,
+		&user.OnboardedAt, &user.Timezone,
 	}
 }
 
@@ -88,7 +89,7 @@ func (repository *SQLiteUserRepository) Create(ctx context.Context, user models.
 	if user.ID == "" {
 		user.ID = uuid.New().String()
 	}
-	now := time.Now()
+	now := time.Now().UTC()
 	user.CreatedAt = now
 	user.UpdatedAt = now
 
@@ -105,7 +106,7 @@ func (repository *SQLiteUserRepository) Create(ctx context.Context, user models.
 func (repository *SQLiteUserRepository) UpdateRole(ctx context.Context, id string, role models.Role) error {
 	_, err := repository.database.ExecContext(ctx,
 		"UPDATE users SET role = ?, updated_at = ? WHERE id = ?",
-		role, time.Now(), id,
+		role, time.Now().UTC(), id,
 	)
 	if err != nil {
 		return fmt.Errorf("updating user role: %w", err)
@@ -116,7 +117,7 @@ func (repository *SQLiteUserRepository) UpdateRole(ctx context.Context, id strin
 func (repository *SQLiteUserRepository) UpdateProfile(ctx context.Context, id string, name string, email string, avatarURL string) error {
 	_, err := repository.database.ExecContext(ctx,
 		"UPDATE users SET name = ?, email = ?, avatar_url = ?, updated_at = ? WHERE id = ?",
-		name, email, avatarURL, time.Now(), id,
+		name, email, avatarURL, time.Now().UTC(), id,
 	)
 	if err != nil {
 		return fmt.Errorf("updating user profile: %w", err)
@@ -139,7 +140,7 @@ func (repository *SQLiteUserRepository) UpdateAvatar(ctx context.Context, userID
 	avatarURL := "/avatar/" + userID
 	_, err := repository.database.ExecContext(ctx,
 		"UPDATE users SET avatar_data = ?, avatar_url = ?, updated_at = ? WHERE id = ?",
-		dataURI, avatarURL, time.Now(), userID,
+		dataURI, avatarURL, time.Now().UTC(), userID,
 	)
 	if err != nil {
 		return fmt.Errorf("updating avatar: %w", err)
@@ -150,7 +151,7 @@ func (repository *SQLiteUserRepository) UpdateAvatar(ctx context.Context, userID
 func (repository *SQLiteUserRepository) ClearAvatar(ctx context.Context, userID string) error {
 	_, err := repository.database.ExecContext(ctx,
 		"UPDATE users SET avatar_data = '', avatar_url = '', updated_at = ? WHERE id = ?",
-		time.Now(), userID,
+		time.Now().UTC(), userID,
 	)
 	if err != nil {
 		return fmt.Errorf("clearing avatar: %w", err)
@@ -159,7 +160,7 @@ func (repository *SQLiteUserRepository) ClearAvatar(ctx context.Context, userID
 }
 
 func (repository *SQLiteUserRepository) MarkOnboarded(ctx context.Context, id string) error {
-	now := time.Now()
+	now := time.Now().UTC()
 	_, err := repository.database.ExecContext(ctx,
 		"UPDATE users SET onboarded_at = COALESCE(onboarded_at, ?), updated_at = ? WHERE id = ?",
 		now, now, id,
@@ -170,6 +171,18 @@ func (repository *SQLiteUserRepository) MarkOnboarded(ctx context.Context, id st
 	return nil
 }
 
+// UpdateTimezone sets the member's timezone override; "" clears it.
+func (repository *SQLiteUserRepository) UpdateTimezone(ctx context.Context, id string, timezone string) error {
+	_, err := repository.database.ExecContext(ctx,
+		"UPDATE users SET timezone = ?, updated_at = ? WHERE id = ?",
+		timezone, time.Now().UTC(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("updating user timezone: %w", err)
+	}
+	return nil
+}
+
 func (repository *SQLiteUserRepository) Count(ctx context.Context) (int, error) {
 	var count int
 	err := repository.database.QueryRowContext(ctx, "SELECT COUNT(*) FROM users").Scan(&count)
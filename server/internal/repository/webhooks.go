@@ -0,0 +1,317 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/google/uuid"
+)
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook models.Webhook) (models.Webhook, error)
+	// Update saves a webhook's URL, description, secret, active flag and
+	// events.
+	Update(ctx context.Context, webhook models.Webhook) error
+	Delete(ctx context.Context, id string) error
+	FindByID(ctx context.Context, id string) (models.Webhook, error)
+	FindAll(ctx context.Context) ([]models.Webhook, error)
+	// FindActiveByEvent returns the active webhooks subscribed to the event.
+	FindActiveByEvent(ctx context.Context, event models.WebhookEvent) ([]models.Webhook, error)
+
+	// EnqueueDelivery queues a delivery to go out at its NextAttemptAt.
+	EnqueueDelivery(ctx context.Context, delivery models.WebhookDelivery) (models.WebhookDelivery, error)
+	FindDeliveryByID(ctx context.Context, id string) (models.WebhookDelivery, error)
+	// FindDueDeliveries returns up to limit pending deliveries whose next
+	// attempt is due by now, oldest first.
+	FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error)
+	// FindDeliveriesByWebhook returns the webhook's most recent deliveries,
+	// newest first.
+	FindDeliveriesByWebhook(ctx context.Context, webhookID string, limit int) ([]models.WebhookDelivery, error)
+	MarkDelivered(ctx context.Context, id string, responseStatus int, deliveredAt time.Time) error
+	// RecordDeliveryFailure saves a failed attempt: the delivery's Attempts,
+	// Status, NextAttemptAt, ResponseStatus and LastError.
+	RecordDeliveryFailure(ctx context.Context, delivery models.WebhookDelivery) error
+	// DeleteDeliveriesFinishedBefore drops sent and failed deliveries created
+	// before the given time.
+	DeleteDeliveriesFinishedBefore(ctx context.Context, before time.Time) error
+}
+
+type SQLiteWebhookRepository struct {
+	database *sql.DB
+}
+
+func NewWebhookRepository(database *sql.DB) *SQLiteWebhookRepository {
+	return &SQLiteWebhookRepository{database: database}
+}
+
+const webhookColumns = `id, url, description, secret, active, created_at`
+
+const webhookDeliveryColumns = `id, webhook_id, event, payload, status, attempts, next_attempt_at, response_status, last_error, created_at, delivered_at`
+
+func (repository *SQLiteWebhookRepository) Create(ctx context.Context, webhook models.Webhook) (models.Webhook, error) {
+	webhook.ID = uuid.New().String()
+	webhook.CreatedAt = time.Now().UTC()
+
+	transaction, err := repository.database.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Webhook{}, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer transaction.Rollback()
+
+	if _, err := transaction.ExecContext(ctx,
+		`INSERT INTO webhooks (`+webhookColumns+`) VALUES (?, ?, ?, ?, ?, ?)`,
+		webhook.ID, webhook.URL, webhook.Description, webhook.Secret, webhook.Active, webhook.CreatedAt,
+	); err != nil {
+		return models.Webhook{}, fmt.Errorf("creating webhook: %w", err)
+	}
+	if err := insertWebhookEvents(ctx, transaction, webhook); err != nil {
+		return models.Webhook{}, err
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return models.Webhook{}, fmt.Errorf("committing webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+func (repository *SQLiteWebhookRepository) Update(ctx context.Context, webhook models.Webhook) error {
+	transaction, err := repository.database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer transaction.Rollback()
+
+	if _, err := transaction.ExecContext(ctx,
+		`UPDATE webhooks SET url = ?, description = ?, secret = ?, active = ? WHERE id = ?`,
+		webhook.URL, webhook.Description, webhook.Secret, webhook.Active, webhook.ID,
+	); err != nil {
+		return fmt.Errorf("updating webhook: %w", err)
+	}
+	if _, err := transaction.ExecContext(ctx, "DELETE FROM webhook_events WHERE webhook_id = ?", webhook.ID); err != nil {
+		return fmt.Errorf("clearing webhook events: %w", err)
+	}
+	if err := insertWebhookEvents(ctx, transaction, webhook); err != nil {
+		return err
+	}
+
+	return transaction.Commit()
+}
+
+func insertWebhookEvents(ctx context.Context, transaction *sql.Tx, webhook models.Webhook) error {
+	for _, event := range webhook.Events {
+		if _, err := transaction.ExecContext(ctx,
+			`INSERT OR IGNORE INTO webhook_events (webhook_id, event) VALUES (?, ?)`,
+			webhook.ID, event,
+		); err != nil {
+			return fmt.Errorf("inserting webhook event: %w", err)
+		}
+	}
+	return nil
+}
+
+func (repository *SQLiteWebhookRepository) Delete(ctx context.Context, id string) error {
+	_, err := repository.database.ExecContext(ctx, "DELETE FROM webhooks WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("deleting webhook: %w", err)
+	}
+	return nil
+}
+
+func (repository *SQLiteWebhookRepository) FindByID(ctx context.Context, id string) (models.Webhook, error) {
+	var webhook models.Webhook
+	err := repository.database.QueryRowContext(ctx,
+		`SELECT `+webhookColumns+` FROM webhooks WHERE id = ?`, id,
+	).Scan(&webhook.ID, &webhook.URL, &webhook.Description, &webhook.Secret, &webhook.Active, &webhook.CreatedAt)
+	if err != nil {
+		return models.Webhook{}, fmt.Errorf("finding webhook: %w", err)
+	}
+
+	webhook.Events, err = repository.findWebhookEvents(ctx, webhook.ID)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+	return webhook, nil
+}
+
+func (repository *SQLiteWebhookRepository) FindAll(ctx context.Context) ([]models.Webhook, error) {
+	return repository.findWebhooks(ctx,
+		`SELECT `+webhookColumns+` FROM webhooks ORDER BY created_at, rowid`,
+	)
+}
+
+func (repository *SQLiteWebhookRepository) FindActiveByEvent(ctx context.Context, event models.WebhookEvent) ([]models.Webhook, error) {
+	return repository.findWebhooks(ctx,
+		`SELECT `+webhookColumns+` FROM webhooks
+		WHERE active = 1 AND id IN (SELECT webhook_id FROM webhook_events WHERE event = ?)
+		ORDER BY created_at, rowid`,
+		event,
+	)
+}
+
+func (repository *SQLiteWebhookRepository) findWebhooks(ctx context.Context, query string, args ...any) ([]models.Webhook, error) {
+	rows, err := repository.database.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("finding webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []models.Webhook
+	for rows.Next() {
+		var webhook models.Webhook
+		if err := rows.Scan(&webhook.ID, &webhook.URL, &webhook.Description, &webhook.Secret, &webhook.Active, &webhook.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning webhook: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for i := range webhooks {
+		webhooks[i].Events, err = repository.findWebhookEvents(ctx, webhooks[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return webhooks, nil
+}
+
+func (repository *SQLiteWebhookRepository) findWebhookEvents(ctx context.Context, webhookID string) ([]models.WebhookEvent, error) {
+	rows, err := repository.database.QueryContext(ctx,
+		`SELECT event FROM webhook_events WHERE webhook_id = ? ORDER BY event`,
+		webhookID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("finding webhook events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []models.WebhookEvent
+	for rows.Next() {
+		var event models.WebhookEvent
+		if err := rows.Scan(&event); err != nil {
+			return nil, fmt.Errorf("scanning webhook event: %w", err)
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func (repository *SQLiteWebhookRepository) EnqueueDelivery(ctx context.Context, delivery models.WebhookDelivery) (models.WebhookDelivery, error) {
+	delivery.ID = uuid.New().String()
+	delivery.CreatedAt = time.Now().UTC()
+	if delivery.Status == "" {
+		delivery.Status = models.WebhookDeliveryPending
+	}
+	if delivery.NextAttemptAt.IsZero() {
+		delivery.NextAttemptAt = delivery.CreatedAt
+	}
+
+	_, err := repository.database.ExecContext(ctx,
+		`INSERT INTO webhook_deliveries (`+webhookDeliveryColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		delivery.ID, delivery.WebhookID, delivery.Event, delivery.Payload,
+		delivery.Status, delivery.Attempts, delivery.NextAttemptAt.UTC(),
+		delivery.ResponseStatus, delivery.LastError, delivery.CreatedAt.UTC(), inUTC(delivery.DeliveredAt),
+	)
+	if err != nil {
+		return models.WebhookDelivery{}, fmt.Errorf("queueing webhook delivery: %w", err)
+	}
+	return delivery, nil
+}
+
+func (repository *SQLiteWebhookRepository) FindDeliveryByID(ctx context.Context, id string) (models.WebhookDelivery, error) {
+	deliveries, err := repository.findDeliveries(ctx,
+		`SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries WHERE id = ?`, id,
+	)
+	if err != nil {
+		return models.WebhookDelivery{}, err
+	}
+	if len(deliveries) == 0 {
+		return models.WebhookDelivery{}, fmt.Errorf("finding webhook delivery: %w", sql.ErrNoRows)
+	}
+	return deliveries[0], nil
+}
+
+func (repository *SQLiteWebhookRepository) FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	return repository.findDeliveries(ctx,
+		`SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries
+		WHERE status = 'pending' AND next_attempt_at <= ?
+		ORDER BY next_attempt_at, rowid
+		LIMIT ?`,
+		now.UTC(), limit,
+	)
+}
+
+func (repository *SQLiteWebhookRepository) FindDeliveriesByWebhook(ctx context.Context, webhookID string, limit int) ([]models.WebhookDelivery, error) {
+	return repository.findDeliveries(ctx,
+		`SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries
+		WHERE webhook_id = ?
+		ORDER BY created_at DESC, rowid DESC
+		LIMIT ?`,
+		webhookID, limit,
+	)
+}
+
+func (repository *SQLiteWebhookRepository) findDeliveries(ctx context.Context, query string, args ...any) ([]models.WebhookDelivery, error) {
+	rows, err := repository.database.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("finding webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []models.WebhookDelivery
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		if err := rows.Scan(
+			&delivery.ID, &delivery.WebhookID, &delivery.Event, &delivery.Payload,
+			&delivery.Status, &delivery.Attempts, &delivery.NextAttemptAt,
+			&delivery.ResponseStatus, &delivery.LastError, &delivery.CreatedAt, &delivery.DeliveredAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+func (repository *SQLiteWebhookRepository) MarkDelivered(ctx context.Context, id string, responseStatus int, deliveredAt time.Time) error {
+	_, err := repository.database.ExecContext(ctx,
+		`UPDATE webhook_deliveries
+		SET status = 'sent', attempts = attempts + 1, response_status = ?, last_error = '', delivered_at = ?
+		WHERE id = ?`,
+		responseStatus, deliveredAt.UTC(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("marking webhook delivery sent: %w", err)
+	}
+	return nil
+}
+
+func (repository *SQLiteWebhookRepository) RecordDeliveryFailure(ctx context.Context, delivery models.WebhookDelivery) error {
+	_, err := repository.database.ExecContext(ctx,
+		`UPDATE webhook_deliveries
+		SET status = ?, attempts = ?, next_attempt_at = ?, response_status = ?, last_error = ?
+		WHERE id = ?`,
+		delivery.Status, delivery.Attempts, delivery.NextAttemptAt.UTC(), delivery.ResponseStatus, delivery.LastError, delivery.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("recording webhook delivery failure: %w", err)
+	}
+	return nil
+}
+
+func (repository *SQLiteWebhookRepository) DeleteDeliveriesFinishedBefore(ctx context.Context, before time.Time) error {
+	_, err := repository.database.ExecContext(ctx,
+		`DELETE FROM webhook_deliveries WHERE status IN ('sent', 'failed') AND created_at < ?`,
+		before.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("deleting finished webhook deliveries: %w", err)
+	}
+	return nil
+}
@@ -1,7 +1,9 @@
 package server
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"time"
@@ -11,17 +13,27 @@ import (
 	"github.com/bensuskins/family-hub/internal/middleware"
 	"github.com/bensuskins/family-hub/internal/repository"
 	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/templates/email"
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/httprate"
 )
 
+// Server serves the web app and API and runs the background workers, all
+// sharing one set of services.
 type Server struct {
 	router *chi.Mux
 	config config.Config
+
+	choreService        *services.ChoreService
+	notificationService *services.NotificationService
+	webhookService      *services.WebhookService
+	digestService       *services.DigestService
 }
 
-func New(database *sql.DB, cfg config.Config, authService *services.AuthService) *Server {
+// New wires the repositories, services and handlers. It fails when the VAPID
+// key cannot be loaded or created, or APNs is configured but cannot be set up.
+func New(database *sql.DB, cfg config.Config, authService *services.AuthService) (*Server, error) {
 	userRepo := repository.NewUserRepository(database)
 	categoryRepo := repository.NewCategoryRepository(database)
 	choreRepo := repository.NewChoreRepository(database)
@@ -33,23 +45,58 @@ func New(database *sql.DB, cfg config.Config, authService *services.AuthService)
 	mealPlanRepo := repository.NewMealPlanRepository(database)
 	inventoryRepo := repository.NewInventoryRepository(database)
 	icalSubRepo := repository.NewICalSubscriptionRepository(database)
+	swapRepo := repository.NewChoreSwapRepository(database)
+	pointsRepo := repository.NewPointsRepository(database)
+	rewardRepo := repository.NewRewardRepository(database)
+	unavailabilityRepo := repository.NewUnavailabilityRepository(database)
+	statsRepo := repository.NewStatsRepository(database)
+	escalationRepo := repository.NewChoreEscalationRepository(database)
+	timeSegmentRepo := repository.NewChoreTimeSegmentRepository(database)
+	notificationRepo := repository.NewNotificationRepository(database)
+	digestRepo := repository.NewDigestRepository(database)
+	pushRepo := repository.NewPushSubscriptionRepository(database)
+	apnsDeviceRepo := repository.NewAPNsDeviceRepository(database)
+	webhookRepo := repository.NewWebhookRepository(database)
+
+	vapidKey, err := services.LoadVAPIDKey(context.Background(), settingsRepo)
+	if err != nil {
+		return nil, fmt.Errorf("loading VAPID key: %w", err)
+	}
+	apnsSender, err := services.NewAPNsSender(cfg, apnsDeviceRepo, choreRepo, userRepo, settingsRepo)
+	if err != nil {
+		return nil, fmt.Errorf("setting up APNs: %w", err)
+	}
 
-	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, seriesRepo)
 	icalFetcher := services.NewICalFetcher(icalSubRepo)
+	notificationService := services.NewNotificationService(notificationRepo, userRepo, services.NotificationSenders(cfg, pushRepo, vapidKey, apnsSender), cfg.BaseURL)
+	webhookService := services.NewWebhookService(webhookRepo)
+	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, seriesRepo, pointsRepo, unavailabilityRepo, icalFetcher, settingsRepo, escalationRepo, timeSegmentRepo, notificationService, webhookService)
 	recipeExtractor := services.NewRecipeExtractor()
+	swapService := services.NewChoreSwapService(swapRepo, choreRepo, userRepo, notificationService, choreService)
+	rewardService := services.NewRewardService(rewardRepo, pointsRepo, settingsRepo, notificationService)
+	statsService := services.NewStatsService(statsRepo, userRepo, categoryRepo, settingsRepo)
+	choreImportService := services.NewChoreImportService(choreService, seriesRepo, userRepo, categoryRepo)
+	webPushService := services.NewWebPushService(pushRepo, notificationRepo, vapidKey)
+	apnsService := services.NewAPNsService(apnsDeviceRepo, notificationRepo, apnsSender != nil)
+	digestService := services.NewDigestService(digestRepo, userRepo, choreRepo, mealPlanRepo, settingsRepo, icalFetcher, notificationService, email.RenderDigest, cfg.BaseURL)
 
 	authHandler := handlers.NewAuthHandler(authService)
-	dashboardHandler := handlers.NewDashboardHandler(choreRepo, icalFetcher, userRepo, assignmentRepo, choreService, mealPlanRepo, categoryRepo)
-	choreHandler := handlers.NewChoreHandler(choreRepo, categoryRepo, userRepo, choreService)
+	dashboardHandler := handlers.NewDashboardHandler(choreRepo, icalFetcher, userRepo, assignmentRepo, pointsRepo, choreService, mealPlanRepo, categoryRepo)
+	choreHandler := handlers.NewChoreHandler(choreRepo, categoryRepo, userRepo, choreService, icalSubRepo, swapService)
 	categoryHandler := handlers.NewCategoryHandler(categoryRepo)
 	calendarHandler := handlers.NewCalendarHandler(choreRepo, icalFetcher, userRepo, mealPlanRepo)
 	adminHandler := handlers.NewAdminHandler(userRepo, tokenRepo, settingsRepo, categoryRepo)
-	apiHandler := handlers.NewAPIHandler(choreRepo, userRepo, categoryRepo, assignmentRepo, tokenRepo, settingsRepo, choreService, mealPlanRepo, recipeRepo, inventoryRepo, icalFetcher, recipeExtractor, cfg.OIDCUserInfoURL, cfg.OIDCClientID, cfg.OIDCIssuer)
-	recipeHandler := handlers.NewRecipeHandler(recipeRepo, categoryRepo, mealPlanRepo, recipeExtractor)
-	mealHandler := handlers.NewMealHandler(mealPlanRepo, recipeRepo)
-	icalSubHandler := handlers.NewICalSubscriptionsHandler(icalSubRepo, icalFetcher)
-	profileHandler := handlers.NewProfileHandler(userRepo)
+	apiHandler := handlers.NewAPIHandler(choreRepo, userRepo, categoryRepo, assignmentRepo, tokenRepo, settingsRepo, choreService, mealPlanRepo, recipeRepo, inventoryRepo, icalFetcher, recipeExtractor, swapService, pointsRepo, rewardService, notificationService, webhookService, icalSubRepo, cfg.OIDCUserInfoURL, cfg.OIDCClientID, cfg.OIDCIssuer)
+	recipeHandler := handlers.NewRecipeHandler(recipeRepo, categoryRepo, mealPlanRepo, recipeExtractor, webhookService)
+	mealHandler := handlers.NewMealHandler(mealPlanRepo, recipeRepo, webhookService)
+	icalSubHandler := handlers.NewICalSubscriptionsHandler(icalSubRepo, icalFetcher, choreService)
+	profileHandler := handlers.NewProfileHandler(userRepo, choreService, notificationService, digestService, webPushService)
+	pushHandler := handlers.NewPushHandler(webPushService, apnsService)
 	backupHandler := handlers.NewBackupHandler(database, cfg.DatabasePath)
+	rewardHandler := handlers.NewRewardHandler(rewardService, userRepo)
+	statsHandler := handlers.NewStatsHandler(statsService)
+	choreImportHandler := handlers.NewChoreImportHandler(choreImportService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
 
 	router := chi.NewRouter()
 
@@ -62,6 +109,12 @@ func New(database *sql.DB, cfg config.Config, authService *services.AuthService)
 
 	router.Handle("/static/*", http.StripPrefix("/static/", http.FileServer(http.Dir("static"))))
 
+	// The service worker is served from the root so its scope covers the app.
+	router.Get("/sw.js", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-cache")
+		http.ServeFile(w, r, "static/js/sw.js")
+	})
+
 	router.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("ok"))
@@ -84,6 +137,7 @@ func New(database *sql.DB, cfg config.Config, authService *services.AuthService)
 	// Unified authenticated surface — session cookie OR Bearer API token.
 	router.Group(func(r chi.Router) {
 		r.Use(middleware.RequireUser(authService, tokenRepo, userRepo))
+		r.Use(middleware.InjectLocation(settingsRepo))
 
 		r.Get("/", dashboardHandler.Dashboard)
 		r.Get("/leaderboard", dashboardHandler.Leaderboard)
@@ -91,17 +145,43 @@ func New(database *sql.DB, cfg config.Config, authService *services.AuthService)
 		r.Get("/profile", profileHandler.Page)
 		r.Post("/profile/avatar", profileHandler.Upload)
 		r.Post("/profile/avatar/delete", profileHandler.Remove)
+		r.Post("/profile/timezone", profileHandler.UpdateTimezone)
+		r.Post("/profile/away", profileHandler.AddAway)
+		r.Post("/profile/away/{id}/delete", profileHandler.RemoveAway)
+		r.Post("/profile/notifications", profileHandler.AddChannel)
+		r.Post("/profile/notifications/{id}", profileHandler.UpdateChannel)
+		r.Post("/profile/notifications/{id}/delete", profileHandler.RemoveChannel)
+		r.Post("/profile/notifications/{id}/test", profileHandler.TestChannel)
+		r.Post("/profile/digest", profileHandler.UpdateDigestSchedule)
+		r.Get("/profile/digest/preview", profileHandler.PreviewDigest)
 		r.Get("/avatar/{userID}", profileHandler.Serve)
 
 		r.Get("/chores", choreHandler.List)
 		r.Get("/chores/{id}/detail", choreHandler.Detail)
 		r.Post("/chores/{id}/complete", choreHandler.Complete)
+		r.Post("/chores/{id}/uncomplete", choreHandler.Uncomplete)
+		r.Post("/chores/{id}/skip", choreHandler.Skip)
+		r.Post("/chores/{id}/snooze", choreHandler.Snooze)
+		r.Post("/chores/{id}/checklist/{itemID}", choreHandler.ToggleChecklistItem)
+		r.Get("/chores/{id}/timer", choreHandler.TimerPage)
+		r.Post("/chores/{id}/timer/{action}", choreHandler.TimerAction)
+		r.Get("/chores/{id}/proof", choreHandler.ServeProof)
 		r.Get("/chores/new", choreHandler.CreateForm)
+		r.Post("/chores/preview", choreHandler.Preview)
 		r.Post("/chores", choreHandler.Create)
 		r.Get("/chores/{id}/edit", choreHandler.EditForm)
 		r.Post("/chores/{id}", choreHandler.Update)
 		r.Post("/chores/{id}/delete", choreHandler.Delete)
 		r.Post("/chores/history/delete", choreHandler.DeleteHistory)
+		r.Post("/chores/swaps", choreHandler.ProposeSwap)
+		r.Post("/chores/swaps/{id}/accept", choreHandler.AcceptSwap)
+		r.Post("/chores/swaps/{id}/decline", choreHandler.DeclineSwap)
+		r.Post("/chores/swaps/{id}/cancel", choreHandler.CancelSwap)
+
+		r.Get("/rewards", rewardHandler.Page)
+		r.Post("/rewards/{id}/redeem", rewardHandler.Redeem)
+
+		r.Get("/stats", statsHandler.Page)
 
 		r.Get("/calendars", icalSubHandler.List)
 
@@ -135,17 +215,53 @@ func New(database *sql.DB, cfg config.Config, authService *services.AuthService)
 		r.Get("/api/me", apiHandler.Me)
 		r.Post("/api/profile/avatar", apiHandler.UploadAvatar)
 		r.Delete("/api/profile/avatar", apiHandler.DeleteAvatar)
+		r.Put("/api/profile/timezone", apiHandler.UpdateTimezone)
+		r.Get("/api/unavailability", apiHandler.ListUnavailability)
+		r.Post("/api/unavailability", apiHandler.CreateUnavailability)
+		r.Delete("/api/unavailability/{id}", apiHandler.DeleteUnavailability)
+		r.Get("/api/notifications/channels", apiHandler.ListNotificationChannels)
+		r.Post("/api/notifications/channels", apiHandler.CreateNotificationChannel)
+		r.Put("/api/notifications/channels/{id}", apiHandler.UpdateNotificationChannel)
+		r.Delete("/api/notifications/channels/{id}", apiHandler.DeleteNotificationChannel)
+		r.Post("/api/notifications/channels/{id}/test", apiHandler.TestNotificationChannel)
+		r.Get("/api/push/vapid-public-key", pushHandler.PublicKey)
+		r.Post("/api/push/subscriptions", pushHandler.Subscribe)
+		r.Delete("/api/push/subscriptions", pushHandler.Unsubscribe)
+		r.Post("/api/push/devices", pushHandler.RegisterDevice)
+		r.Delete("/api/push/devices/{token}", pushHandler.UnregisterDevice)
 		r.Get("/api/settings", apiHandler.GetSettings)
 		r.Get("/api/chores", apiHandler.ListChores)
 		r.Post("/api/chores", apiHandler.CreateChore)
+		r.Post("/api/chores/preview", apiHandler.PreviewChore)
 		r.Get("/api/chores/{id}", apiHandler.GetChore)
 		r.Put("/api/chores/{id}", apiHandler.UpdateChore)
 		r.Delete("/api/chores/{id}", apiHandler.DeleteChore)
 		r.Post("/api/chores/{id}/complete", apiHandler.CompleteChore)
+		r.Post("/api/chores/{id}/uncomplete", apiHandler.UncompleteChore)
+		r.Post("/api/chores/{id}/skip", apiHandler.SkipChore)
+		r.Post("/api/chores/{id}/snooze", apiHandler.SnoozeChore)
+		r.Get("/api/chores/{id}/checklist", apiHandler.GetChoreChecklist)
+		r.Put("/api/chores/{id}/checklist/{itemId}", apiHandler.SetChoreChecklistItem)
+		r.Get("/api/chores/{id}/timer", apiHandler.GetChoreTimer)
+		r.Post("/api/chores/{id}/timer/start", apiHandler.StartChoreTimer)
+		r.Post("/api/chores/{id}/timer/pause", apiHandler.PauseChoreTimer)
+		r.Post("/api/chores/{id}/timer/stop", apiHandler.StopChoreTimer)
+		r.Get("/api/chores/{id}/proof", choreHandler.ServeProof)
+		r.Get("/api/swaps", apiHandler.ListSwaps)
+		r.Post("/api/swaps", apiHandler.ProposeSwap)
+		r.Post("/api/swaps/{id}/accept", apiHandler.AcceptSwap)
+		r.Post("/api/swaps/{id}/decline", apiHandler.DeclineSwap)
+		r.Post("/api/swaps/{id}/cancel", apiHandler.CancelSwap)
+		r.Get("/api/points", apiHandler.GetPoints)
+		r.Get("/api/rewards", apiHandler.ListRewards)
+		r.Post("/api/rewards/{id}/redeem", apiHandler.RedeemReward)
+		r.Get("/api/redemptions", apiHandler.ListRedemptions)
 		r.Get("/api/users", apiHandler.ListUsers)
 		r.Get("/api/users/{id}", apiHandler.GetUser)
 		r.Get("/api/categories", apiHandler.ListCategories)
 		r.Get("/api/dashboard", apiHandler.DashboardStats)
+		r.Get("/api/stats", statsHandler.API)
+		r.Get("/api/chores/library", choreImportHandler.Library)
 		r.Get("/api/meals", apiHandler.ListMeals)
 		r.Post("/api/meals", apiHandler.SaveMeal)
 		r.Delete("/api/meals", apiHandler.DeleteMeal)
@@ -187,6 +303,26 @@ func New(database *sql.DB, cfg config.Config, authService *services.AuthService)
 			r.Post("/admin/settings", adminHandler.UpdateSettings)
 			r.Post("/admin/tokens", adminHandler.CreateToken)
 
+			r.Post("/rewards", rewardHandler.Create)
+			r.Post("/rewards/{id}/active", rewardHandler.SetActive)
+			r.Post("/rewards/redemptions/{id}/approve", rewardHandler.ApproveRedemption)
+			r.Post("/rewards/redemptions/{id}/reject", rewardHandler.RejectRedemption)
+
+			r.Post("/chores/{id}/approve", choreHandler.ApproveCompletion)
+			r.Post("/chores/{id}/reject", choreHandler.RejectCompletion)
+
+			r.Get("/admin/chores/import", choreImportHandler.Page)
+			r.Post("/admin/chores/import", choreImportHandler.Upload)
+			r.Post("/admin/chores/library", choreImportHandler.ImportLibrary)
+			r.Get("/admin/chores/export", choreImportHandler.Export)
+
+			r.Get("/admin/webhooks", webhookHandler.Page)
+			r.Post("/admin/webhooks", webhookHandler.Create)
+			r.Post("/admin/webhooks/{id}", webhookHandler.Update)
+			r.Post("/admin/webhooks/{id}/delete", webhookHandler.Delete)
+			r.Post("/admin/webhooks/{id}/ping", webhookHandler.Ping)
+			r.Post("/admin/webhooks/deliveries/{id}/replay", webhookHandler.Replay)
+
 			r.Get("/admin/backup", backupHandler.Backup)
 			r.Post("/admin/restore", backupHandler.Restore)
 
@@ -196,6 +332,14 @@ func New(database *sql.DB, cfg config.Config, authService *services.AuthService)
 			r.Post("/api/categories", apiHandler.CreateCategory)
 			r.Put("/api/categories/{id}", apiHandler.UpdateCategory)
 			r.Delete("/api/categories/{id}", apiHandler.DeleteCategory)
+			r.Post("/api/rewards", apiHandler.CreateReward)
+			r.Put("/api/rewards/{id}", apiHandler.UpdateReward)
+			r.Post("/api/redemptions/{id}/approve", apiHandler.ApproveRedemption)
+			r.Post("/api/redemptions/{id}/reject", apiHandler.RejectRedemption)
+			r.Post("/api/chores/{id}/approve", apiHandler.ApproveChoreCompletion)
+			r.Post("/api/chores/{id}/reject", apiHandler.RejectChoreCompletion)
+			r.Get("/api/chores/export", choreImportHandler.ExportAPI)
+			r.Post("/api/chores/import", choreImportHandler.ImportAPI)
 			r.Get("/api/tokens", apiHandler.ListTokens)
 			r.Post("/api/tokens", apiHandler.CreateToken)
 			r.Delete("/api/tokens/{id}", apiHandler.DeleteToken)
@@ -203,17 +347,29 @@ func New(database *sql.DB, cfg config.Config, authService *services.AuthService)
 	})
 
 	// Recurring-series materialization (including backfill of legacy chores that
-	// predate series_id) is handled by the periodic top-up started in main.
+	// predate series_id) is handled by the periodic top-up started in Start.
 
 	server := &Server{
 		router: router,
 		config: cfg,
+
+		choreService:        choreService,
+		notificationService: notificationService,
+		webhookService:      webhookService,
+		digestService:       digestService,
 	}
 
-	return server
+	return server, nil
 }
 
+// Start runs the background workers and serves HTTP until the listener fails.
 func (server *Server) Start() error {
+	go runOverdueChecker(server.choreService)
+	go runSeriesTopUp(server.choreService)
+	go runNotificationDelivery(server.notificationService)
+	go runWebhookDelivery(server.webhookService)
+	go runDigests(server.digestService)
+
 	address := ":" + server.config.Port
 	slog.Info("starting server", "address", address)
 	return http.ListenAndServe(address, server.router)
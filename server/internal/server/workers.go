@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/services"
+)
+
+func runOverdueChecker(choreService *services.ChoreService) {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		ctx := context.Background()
+		if err := choreService.UpdateOverdueChores(ctx); err != nil {
+			slog.Error("updating overdue chores", "error", err)
+		}
+		if err := choreService.EscalateOverdueChores(ctx); err != nil {
+			slog.Error("escalating overdue chores", "error", err)
+		}
+		if err := choreService.RemindDueSoon(ctx); err != nil {
+			slog.Error("reminding of chores due soon", "error", err)
+		}
+		<-ticker.C
+	}
+}
+
+// runSeriesTopUp keeps the bounded materialization window full for every active
+// recurring series so a never-completed chore does not run out of future
+// occurrences. Runs once on startup, then every 6 hours.
+func runSeriesTopUp(choreService *services.ChoreService) {
+	ticker := time.NewTicker(6 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		ctx := context.Background()
+		if err := choreService.TopUpAllSeries(ctx, services.SeedHorizonFrom(time.Now())); err != nil {
+			slog.Error("topping up recurring series", "error", err)
+		}
+		<-ticker.C
+	}
+}
+
+// runNotificationDelivery sends queued notifications, including retries of
+// failed deliveries, every 30 seconds.
+func runNotificationDelivery(notificationService *services.NotificationService) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		ctx := context.Background()
+		if err := notificationService.DeliverPending(ctx); err != nil {
+			slog.Error("delivering notifications", "error", err)
+		}
+		<-ticker.C
+	}
+}
+
+// runWebhookDelivery posts queued webhook deliveries, including retries of
+// failed ones, every 15 seconds.
+func runWebhookDelivery(webhookService *services.WebhookService) {
+	ticker := time.NewTicker(15 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		ctx := context.Background()
+		if err := webhookService.DeliverPending(ctx); err != nil {
+			slog.Error("delivering webhooks", "error", err)
+		}
+		<-ticker.C
+	}
+}
+
+// runDigests sends each member's digests as they fall due, checking every
+// minute.
+func runDigests(digestService *services.DigestService) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		ctx := context.Background()
+		if err := digestService.SendDue(ctx, time.Now()); err != nil {
+			slog.Error("sending digests", "error", err)
+		}
+		<-ticker.C
+	}
+}
@@ -0,0 +1,321 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/config"
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+)
+
+var (
+	ErrInvalidDeviceToken = errors.New("invalid APNs device token")
+	errNoAPNsDevices      = errors.New("no iOS devices are signed in")
+)
+
+// apnsTokenLifetime is how long a provider token is reused. APNs accepts one
+// for an hour but refuses a new one more often than every 20 minutes.
+const apnsTokenLifetime = 50 * time.Minute
+
+// apnsGoneReasons are the APNs error reasons meaning a device token will
+// never work again, so the device is removed.
+var apnsGoneReasons = []string{"BadDeviceToken", "DeviceTokenNotForTopic", "Unregistered"}
+
+// APNsService keeps track of the iOS devices each member is signed in on.
+type APNsService struct {
+	deviceRepo       repository.APNsDeviceRepository
+	notificationRepo repository.NotificationRepository
+	enabled          bool
+}
+
+// NewAPNsService returns the device registry; while enabled is false (no
+// APNs provider is configured) devices cannot register.
+func NewAPNsService(
+	deviceRepo repository.APNsDeviceRepository,
+	notificationRepo repository.NotificationRepository,
+	enabled bool,
+) *APNsService {
+	return &APNsService{
+		deviceRepo:       deviceRepo,
+		notificationRepo: notificationRepo,
+		enabled:          enabled,
+	}
+}
+
+// Register records a device token for the user and, the first time, sets up
+// their APNs channel, sent PushEvents.
+func (service *APNsService) Register(ctx context.Context, userID, token string) error {
+	if !service.enabled {
+		return ErrChannelKindDisabled
+	}
+	token = strings.ToLower(strings.TrimSpace(token))
+	if raw, err := hex.DecodeString(token); err != nil || len(raw) < 32 || len(raw) > 100 {
+		return ErrInvalidDeviceToken
+	}
+	if _, err := service.deviceRepo.Save(ctx, models.APNsDevice{UserID: userID, Token: token}); err != nil {
+		return err
+	}
+	return ensurePushChannel(ctx, service.notificationRepo, userID, models.ChannelAPNs)
+}
+
+// Unregister forgets one of the user's devices, as when they sign out of the
+// app on it.
+func (service *APNsService) Unregister(ctx context.Context, userID, token string) error {
+	return service.deviceRepo.DeleteForUser(ctx, userID, strings.ToLower(strings.TrimSpace(token)))
+}
+
+// apnsPayload is the notification's JSON for APNs: the standard aps
+// dictionary plus the event and link the app opens.
+type apnsPayload struct {
+	APS   apnsAPS                  `json:"aps"`
+	Event models.NotificationEvent `json:"event"`
+	Link  string                   `json:"link,omitempty"`
+}
+
+type apnsAPS struct {
+	Alert    apnsAlert `json:"alert"`
+	Badge    int       `json:"badge"`
+	Sound    string    `json:"sound"`
+	ThreadID string    `json:"thread-id"`
+}
+
+type apnsAlert struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// APNsSender pushes each notification to every iOS device its channel's user
+// is signed in on, over HTTP/2 with token-based (.p8) authentication. The app
+// icon's badge is set to the user's outstanding chores. A device APNs reports
+// unregistered or invalid is removed.
+type APNsSender struct {
+	Client  *http.Client
+	Devices repository.APNsDeviceRepository
+	// Chores, Users and Settings count the badge.
+	Chores   repository.ChoreRepository
+	Users    repository.UserRepository
+	Settings repository.SettingsRepository
+	Key      *ecdsa.PrivateKey
+	KeyID    string
+	TeamID   string
+	Topic    string
+	URL      string
+
+	mutex       sync.Mutex
+	token       string
+	tokenIssued time.Time
+}
+
+// NewAPNsSender returns the APNs provider the configuration describes, or nil
+// when APNs is not configured.
+func NewAPNsSender(
+	cfg config.Config,
+	devices repository.APNsDeviceRepository,
+	chores repository.ChoreRepository,
+	users repository.UserRepository,
+	settings repository.SettingsRepository,
+) (*APNsSender, error) {
+	if cfg.APNSKeyPath == "" {
+		return nil, nil
+	}
+	key, err := loadAPNsKey(cfg.APNSKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	return &APNsSender{
+		Client: &http.Client{
+			Timeout:   notificationSendTimeout,
+			Transport: &http.Transport{ForceAttemptHTTP2: true},
+		},
+		Devices:  devices,
+		Chores:   chores,
+		Users:    users,
+		Settings: settings,
+		Key:      key,
+		KeyID:    cfg.APNSKeyID,
+		TeamID:   cfg.APNSTeamID,
+		Topic:    cfg.APNSTopic,
+		URL:      strings.TrimSuffix(cfg.APNSURL, "/"),
+	}, nil
+}
+
+// loadAPNsKey reads the PKCS #8 P-256 key of an App Store Connect .p8 file.
+func loadAPNsKey(path string) (*ecdsa.PrivateKey, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading APNs key: %w", err)
+	}
+	block, _ := pem.Decode(contents)
+	if block == nil {
+		return nil, fmt.Errorf("reading APNs key: %s is not PEM", path)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing APNs key: %w", err)
+	}
+	key, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("parsing APNs key: %s is not an EC key", path)
+	}
+	return key, nil
+}
+
+func (sender *APNsSender) Send(ctx context.Context, channel models.NotificationChannel, notification models.Notification) error {
+	devices, err := sender.Devices.FindByUser(ctx, channel.UserID)
+	if err != nil {
+		return err
+	}
+	if len(devices) == 0 {
+		return errNoAPNsDevices
+	}
+
+	badge, err := sender.badge(ctx, channel.UserID, time.Now())
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(apnsPayload{
+		APS: apnsAPS{
+			Alert:    apnsAlert{Title: notification.Title, Body: pushText(notification)},
+			Badge:    badge,
+			Sound:    "default",
+			ThreadID: string(notification.Event),
+		},
+		Event: notification.Event,
+		Link:  notification.Link,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding APNs payload: %w", err)
+	}
+
+	// As with Web Push, one delivered device is a success, so a retry does
+	// not repeat the notification on the others.
+	var failures []error
+	delivered := false
+	for _, device := range devices {
+		gone, err := sender.push(ctx, device, notification, payload)
+		switch {
+		case gone:
+			if err := sender.Devices.DeleteByToken(ctx, device.Token); err != nil {
+				slog.Error("removing unregistered APNs device", "device_id", device.ID, "error", err)
+			}
+		case err != nil:
+			failures = append(failures, err)
+		default:
+			delivered = true
+		}
+	}
+	if delivered {
+		return nil
+	}
+	if len(failures) > 0 {
+		return errors.Join(failures...)
+	}
+	return errNoAPNsDevices
+}
+
+// push sends the payload to one device, reporting whether APNs says the
+// device token is no longer valid.
+func (sender *APNsSender) push(ctx context.Context, device models.APNsDevice, notification models.Notification, payload []byte) (bool, error) {
+	token, err := sender.providerToken(time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, sender.URL+"/3/device/"+device.Token, bytes.NewReader(payload))
+	if err != nil {
+		return false, fmt.Errorf("building APNs request: %w", err)
+	}
+	request.Header.Set("Authorization", "bearer "+token)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("apns-topic", sender.Topic)
+	request.Header.Set("apns-push-type", "alert")
+	request.Header.Set("apns-priority", "10")
+	request.Header.Set("apns-expiration", strconv.FormatInt(time.Now().Add(pushTTL).Unix(), 10))
+	// A retried notification replaces rather than repeats itself.
+	request.Header.Set("apns-collapse-id", notification.ID)
+
+	response, err := sender.Client.Do(request)
+	if err != nil {
+		return false, fmt.Errorf("pushing to APNs: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusOK {
+		io.Copy(io.Discard, io.LimitReader(response.Body, 64*1024))
+		return false, nil
+	}
+
+	var failure struct {
+		Reason string `json:"reason"`
+	}
+	json.NewDecoder(io.LimitReader(response.Body, 64*1024)).Decode(&failure)
+	if response.StatusCode == http.StatusGone || slices.Contains(apnsGoneReasons, failure.Reason) {
+		return true, nil
+	}
+	if failure.Reason == "ExpiredProviderToken" {
+		sender.mutex.Lock()
+		sender.token = ""
+		sender.mutex.Unlock()
+	}
+	return false, fmt.Errorf("APNs responded %s: %s", response.Status, failure.Reason)
+}
+
+// providerToken is the JWT APNs authenticates the provider by, signed anew
+// once apnsTokenLifetime has passed.
+func (sender *APNsSender) providerToken(now time.Time) (string, error) {
+	sender.mutex.Lock()
+	defer sender.mutex.Unlock()
+	if sender.token != "" && now.Sub(sender.tokenIssued) < apnsTokenLifetime {
+		return sender.token, nil
+	}
+	token, err := signES256JWT(sender.Key,
+		map[string]string{"alg": "ES256", "kid": sender.KeyID},
+		map[string]any{"iss": sender.TeamID, "iat": now.Unix()},
+	)
+	if err != nil {
+		return "", fmt.Errorf("signing APNs provider token: %w", err)
+	}
+	sender.token, sender.tokenIssued = token, now
+	return token, nil
+}
+
+// badge is the count the app icon shows: the user's chores due today, in
+// their own timezone, or before that still to do.
+func (sender *APNsSender) badge(ctx context.Context, userID string, now time.Time) (int, error) {
+	user, err := sender.Users.FindByID(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("finding user: %w", err)
+	}
+	today := repository.CivilDate(now.In(UserLocation(ctx, sender.Settings, user)))
+	chores, err := sender.Chores.FindAll(ctx, repository.ChoreFilter{
+		AssignedToUser: &userID,
+		Statuses:       []models.ChoreStatus{models.ChoreStatusPending, models.ChoreStatusOverdue},
+		DueBefore:      &today,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("finding chores: %w", err)
+	}
+	count := 0
+	for _, chore := range chores {
+		if chore.DueDate != nil && slices.Contains(outstandingUsers(chore), userID) {
+			count++
+		}
+	}
+	return count, nil
+}
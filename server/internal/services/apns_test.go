@@ -0,0 +1,166 @@
+package services_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/config"
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+)
+
+const (
+	phoneToken  = "a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90"
+	tabletToken = "0f1e2d3c4b5a69788796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f0"
+)
+
+type apnsFixture struct {
+	mock          *testutil.MockAPNs
+	apns          *services.APNsService
+	notifications *services.NotificationService
+	deviceRepo    *repository.SQLiteAPNsDeviceRepository
+	choreRepo     *repository.SQLiteChoreRepository
+	user          models.User
+}
+
+// setupAPNs points an APNs provider, configured from a .p8 key file as in
+// production, at a mock APNs.
+func setupAPNs(t *testing.T) apnsFixture {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+	keyPath := filepath.Join(t.TempDir(), "AuthKey_ABC123DEFG.p8")
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("writing key: %v", err)
+	}
+	mock := testutil.NewMockAPNs(t, &key.PublicKey)
+
+	db := testutil.NewTestDatabase(t)
+	deviceRepo := repository.NewAPNsDeviceRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	sender, err := services.NewAPNsSender(config.Config{
+		APNSKeyPath: keyPath,
+		APNSKeyID:   "ABC123DEFG",
+		APNSTeamID:  "TEAM123456",
+		APNSTopic:   "com.example.FamilyHub",
+		APNSURL:     mock.URL(),
+	}, deviceRepo, choreRepo, userRepo, nil)
+	if err != nil {
+		t.Fatalf("NewAPNsSender: %v", err)
+	}
+	sender.Client = mock.Client()
+
+	notifications := services.NewNotificationService(notificationRepo, userRepo, services.NotificationSenders(config.Config{}, nil, nil, sender), "https://hub.example.com")
+	return apnsFixture{
+		mock:          mock,
+		apns:          services.NewAPNsService(deviceRepo, notificationRepo, true),
+		notifications: notifications,
+		deviceRepo:    deviceRepo,
+		choreRepo:     choreRepo,
+		user:          createUsers(t, userRepo, 1)[0],
+	}
+}
+
+func TestAPNsSender_PushesWithBadge(t *testing.T) {
+	fixture := setupAPNs(t)
+	ctx := context.Background()
+	date := func(days int) *time.Time {
+		due := repository.CivilDate(time.Now().In(services.DefaultLocation)).AddDate(0, 0, days)
+		return &due
+	}
+	for _, chore := range []models.Chore{
+		{Name: "Feed the cat", DueDate: date(0), Status: models.ChoreStatusPending},
+		{Name: "Put the bins out", DueDate: date(-2), Status: models.ChoreStatusOverdue},
+		{Name: "Mow the lawn", DueDate: date(3), Status: models.ChoreStatusPending},
+		{Name: "Hoover the stairs", DueDate: date(0), Status: models.ChoreStatusCompleted},
+	} {
+		chore.AssignedToUserID = &fixture.user.ID
+		chore.CreatedByUserID = fixture.user.ID
+		if _, err := fixture.choreRepo.Create(ctx, chore); err != nil {
+			t.Fatalf("creating chore: %v", err)
+		}
+	}
+
+	if err := fixture.apns.Register(ctx, fixture.user.ID, "not-a-token"); err != services.ErrInvalidDeviceToken {
+		t.Errorf("expected ErrInvalidDeviceToken, got %v", err)
+	}
+	if err := fixture.apns.Register(ctx, fixture.user.ID, strings.ToUpper(phoneToken)); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	channels, _ := fixture.notifications.Channels(ctx, fixture.user.ID)
+	if len(channels) != 1 || channels[0].Kind != models.ChannelAPNs {
+		t.Fatalf("expected an APNs channel, got %+v", channels)
+	}
+
+	for _, title := range []string{"You have a new chore", "Feed the cat is due soon"} {
+		fixture.notifications.Notify(ctx, []string{fixture.user.ID}, models.Notification{Event: models.NotifyChoreAssigned, Title: title, Link: "/chores"})
+	}
+	if err := fixture.notifications.DeliverPending(ctx); err != nil {
+		t.Fatalf("DeliverPending: %v", err)
+	}
+
+	pushes := fixture.mock.Pushes()
+	if len(pushes) != 2 {
+		t.Fatalf("expected two pushes, got %d", len(pushes))
+	}
+	push := pushes[0]
+	if push.DeviceToken != phoneToken || push.Topic != "com.example.FamilyHub" || push.PushType != "alert" {
+		t.Errorf("unexpected push %+v", push)
+	}
+	if push.KeyID != "ABC123DEFG" || push.TeamID != "TEAM123456" {
+		t.Errorf("unexpected provider token key %q and team %q", push.KeyID, push.TeamID)
+	}
+	if pushes[1].ProviderToken != push.ProviderToken {
+		t.Error("expected the provider token to be reused")
+	}
+
+	aps, _ := push.Payload["aps"].(map[string]any)
+	alert, _ := aps["alert"].(map[string]any)
+	if alert["title"] == nil || push.Payload["link"] != "https://hub.example.com/chores" {
+		t.Errorf("unexpected payload %v", push.Payload)
+	}
+	if aps["badge"] != float64(2) {
+		t.Errorf("expected a badge of the chores due today or overdue, got %v", aps["badge"])
+	}
+}
+
+func TestAPNsSender_RemovesUnregisteredDevices(t *testing.T) {
+	fixture := setupAPNs(t)
+	ctx := context.Background()
+	for _, token := range []string{phoneToken, tabletToken} {
+		if err := fixture.apns.Register(ctx, fixture.user.ID, token); err != nil {
+			t.Fatalf("Register: %v", err)
+		}
+	}
+	fixture.mock.Reject(tabletToken, http.StatusGone, "Unregistered")
+
+	fixture.notifications.Notify(ctx, []string{fixture.user.ID}, models.Notification{Event: models.NotifyChoreOverdue, Title: "Put the bins out is overdue"})
+	if err := fixture.notifications.DeliverPending(ctx); err != nil {
+		t.Fatalf("DeliverPending: %v", err)
+	}
+
+	if pushes := fixture.mock.Pushes(); len(pushes) != 1 || pushes[0].DeviceToken != phoneToken {
+		t.Errorf("expected the phone pushed, got %+v", pushes)
+	}
+	devices, _ := fixture.deviceRepo.FindByUser(ctx, fixture.user.ID)
+	if len(devices) != 1 || devices[0].Token != phoneToken {
+		t.Errorf("expected only the unregistered tablet removed, got %+v", devices)
+	}
+}
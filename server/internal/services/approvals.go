@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bensuskins/family-hub/internal/models"
+)
+
+// needsApproval reports whether a completion by userID has to wait for an
+// admin. Admins' own completions are accepted straight away.
+func (service *ChoreService) needsApproval(ctx context.Context, chore models.Chore, userID string) (bool, error) {
+	if !chore.RequiresApproval {
+		return false, nil
+	}
+	user, err := service.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("finding user: %w", err)
+	}
+	return user.Role != models.RoleAdmin, nil
+}
+
+// requestApproval tells the admins that userID's completion is waiting for
+// them.
+func (service *ChoreService) requestApproval(ctx context.Context, chore models.Chore, userID string) {
+	service.notifications.NotifyAdmins(ctx, userID, models.Notification{
+		Event: models.NotifyApprovalRequested,
+		Title: "Approval needed: " + chore.Name,
+		Body:  service.notifications.userName(ctx, userID) + " marked it done.",
+		Link:  "/chores",
+	})
+}
+
+// ApproveCompletion accepts a completion waiting for approval. The completer
+// is credited and the series moves on exactly as if the chore had been
+// completed without approval, dated from when it was submitted.
+func (service *ChoreService) ApproveCompletion(ctx context.Context, choreID string) error {
+	chore, err := service.choreRepo.FindByID(ctx, choreID)
+	if err != nil {
+		return fmt.Errorf("finding chore: %w", err)
+	}
+	if chore.Status != models.ChoreStatusAwaitingApproval {
+		return ErrChoreNotAwaitingApproval
+	}
+
+	chore.Status = models.ChoreStatusCompleted
+	if err := service.choreRepo.Update(ctx, chore); err != nil {
+		return fmt.Errorf("updating chore: %w", err)
+	}
+	return service.finishCompletion(ctx, chore)
+}
+
+// RejectCompletion sends a completion waiting for approval back to pending
+// for the same assignee and discards its photo. Every part of a group chore
+// has to be done again.
+func (service *ChoreService) RejectCompletion(ctx context.Context, choreID string) error {
+	chore, err := service.choreRepo.FindByID(ctx, choreID)
+	if err != nil {
+		return fmt.Errorf("finding chore: %w", err)
+	}
+	if chore.Status != models.ChoreStatusAwaitingApproval {
+		return ErrChoreNotAwaitingApproval
+	}
+
+	chore.Status = models.ChoreStatusPending
+	chore.CompletedAt = nil
+	chore.CompletedByUserID = nil
+	if err := service.choreRepo.Update(ctx, chore); err != nil {
+		return fmt.Errorf("updating chore: %w", err)
+	}
+	if err := service.choreRepo.UpdateProofImage(ctx, chore.ID, ""); err != nil {
+		return fmt.Errorf("clearing proof: %w", err)
+	}
+	if chore.GroupChore {
+		return service.reopenParts(ctx, chore.ID, chore.PartsDone)
+	}
+	return nil
+}
+
+// ProofImage returns the photo submitted with a completion as a data URI, or
+// "" when there is none.
+func (service *ChoreService) ProofImage(ctx context.Context, choreID string) (string, error) {
+	imageData, err := service.choreRepo.FindProofImage(ctx, choreID)
+	if err != nil {
+		return "", fmt.Errorf("finding proof: %w", err)
+	}
+	return imageData, nil
+}
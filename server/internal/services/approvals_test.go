@@ -0,0 +1,122 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+)
+
+func TestChoreService_ApprovalHoldsCompletionUntilApproved(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	seriesRepo := repository.NewChoreSeriesRepository(db)
+	pointsRepo := repository.NewPointsRepository(db)
+	service := services.NewChoreService(choreRepo, repository.NewChoreAssignmentRepository(db), userRepo, seriesRepo, pointsRepo, nil, nil, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 1)
+
+	due := time.Now().AddDate(0, 0, 1).Truncate(24 * time.Hour)
+	chore := newRecurringChore(t, choreRepo, seriesRepo,
+		models.ChoreSeries{
+			RecurrenceType:   models.RecurrenceDaily,
+			RecurrenceValue:  `{"interval":1}`,
+			RecurOnComplete:  true,
+			EffortPoints:     3,
+			RequiresApproval: true,
+		},
+		models.Chore{
+			Name:             "Make bed",
+			CreatedByUserID:  users[0].ID,
+			AssignedToUserID: &users[0].ID,
+			DueDate:          &due,
+			Status:           models.ChoreStatusPending,
+		})
+
+	if err := service.CompleteChoreWithProof(ctx, chore.ID, users[0].ID, "data:image/png;base64,AAAA"); err != nil {
+		t.Fatalf("CompleteChoreWithProof: %v", err)
+	}
+	held, _ := choreRepo.FindByID(ctx, chore.ID)
+	if held.Status != models.ChoreStatusAwaitingApproval || !held.HasProof {
+		t.Fatalf("expected awaiting_approval with proof, got %s (proof %v)", held.Status, held.HasProof)
+	}
+	if balance, _ := pointsRepo.Balance(ctx, users[0].ID); balance != 0 {
+		t.Errorf("expected no points before approval, got %d", balance)
+	}
+	pendingStatus := models.ChoreStatusPending
+	if next, _ := choreRepo.FindAll(ctx, repository.ChoreFilter{SeriesID: &chore.ID, Status: &pendingStatus}); len(next) != 0 {
+		t.Errorf("expected no next occurrence before approval, got %d", len(next))
+	}
+	if err := service.CompleteChore(ctx, chore.ID, users[0].ID); !errors.Is(err, services.ErrChoreAwaitingApproval) {
+		t.Errorf("expected ErrChoreAwaitingApproval, got %v", err)
+	}
+
+	if err := service.ApproveCompletion(ctx, chore.ID); err != nil {
+		t.Fatalf("ApproveCompletion: %v", err)
+	}
+	approved, _ := choreRepo.FindByID(ctx, chore.ID)
+	if approved.Status != models.ChoreStatusCompleted {
+		t.Errorf("expected completed, got %s", approved.Status)
+	}
+	if balance, _ := pointsRepo.Balance(ctx, users[0].ID); balance != 3 {
+		t.Errorf("expected 3 points after approval, got %d", balance)
+	}
+	if next, _ := choreRepo.FindAll(ctx, repository.ChoreFilter{SeriesID: &chore.ID, Status: &pendingStatus}); len(next) != 1 {
+		t.Errorf("expected the next occurrence after approval, got %d", len(next))
+	}
+	if err := service.ApproveCompletion(ctx, chore.ID); !errors.Is(err, services.ErrChoreNotAwaitingApproval) {
+		t.Errorf("expected ErrChoreNotAwaitingApproval, got %v", err)
+	}
+}
+
+func TestChoreService_RejectReturnsChoreToAssignee(t *testing.T) {
+	service, choreRepo, _, userRepo := setupChoreService(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 2)
+
+	chore, err := choreRepo.Create(ctx, models.Chore{
+		Name:             "Tidy toys",
+		CreatedByUserID:  users[0].ID,
+		AssignedToUserID: &users[0].ID,
+		Status:           models.ChoreStatusPending,
+		RequiresApproval: true,
+	})
+	if err != nil {
+		t.Fatalf("creating chore: %v", err)
+	}
+
+	if err := service.CompleteChoreWithProof(ctx, chore.ID, users[1].ID, "data:image/png;base64,AAAA"); err != nil {
+		t.Fatalf("CompleteChoreWithProof: %v", err)
+	}
+	if err := service.RejectCompletion(ctx, chore.ID); err != nil {
+		t.Fatalf("RejectCompletion: %v", err)
+	}
+
+	rejected, _ := choreRepo.FindByID(ctx, chore.ID)
+	if rejected.Status != models.ChoreStatusPending || rejected.CompletedAt != nil || rejected.CompletedByUserID != nil {
+		t.Errorf("expected a clean pending chore, got %+v", rejected)
+	}
+	if rejected.AssignedToUserID == nil || *rejected.AssignedToUserID != users[0].ID {
+		t.Errorf("expected the chore to stay with %s", users[0].Name)
+	}
+	if proof, _ := service.ProofImage(ctx, chore.ID); proof != "" {
+		t.Errorf("expected the proof to be discarded, got %q", proof)
+	}
+
+	// An admin's own completion needs nobody's approval.
+	if err := userRepo.UpdateRole(ctx, users[1].ID, models.RoleAdmin); err != nil {
+		t.Fatalf("promoting user: %v", err)
+	}
+	if err := service.CompleteChore(ctx, chore.ID, users[1].ID); err != nil {
+		t.Fatalf("CompleteChore: %v", err)
+	}
+	if completed, _ := choreRepo.FindByID(ctx, chore.ID); completed.Status != models.ChoreStatusCompleted {
+		t.Errorf("expected an admin completion to be accepted, got %s", completed.Status)
+	}
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+)
+
+// effortWindow is how far back the effort-weighted strategy looks when
+// totalling each candidate's completed effort points.
+const effortWindow = 30 * 24 * time.Hour
+
+// chooseAssignee returns the index of the candidate that should take the
+// chore under its series' assignment strategy. start is the round-robin
+// position (the candidate after the rotation cursor); strategies that rank
+// candidates break ties in rotation order from there, so equal standings still
+// rotate fairly.
+func (service *ChoreService) chooseAssignee(ctx context.Context, chore models.Chore, candidates []models.User, start int) (int, error) {
+	switch chore.AssignmentStrategy {
+	case models.AssignmentFixed:
+		if chore.FixedAssigneeUserID != nil {
+			for i, user := range candidates {
+				if user.ID == *chore.FixedAssigneeUserID {
+					return i, nil
+				}
+			}
+		}
+		// The owner has left the pool; keep the chore moving by rotation.
+		return service.roundRobin(ctx, candidates, start)
+	case models.AssignmentLeastLoaded:
+		return lowestScore(candidates, start, func(userID string) (int, error) {
+			return service.openChoreCount(ctx, userID)
+		})
+	case models.AssignmentEffortWeighted:
+		since := time.Now().Add(-effortWindow)
+		return lowestScore(candidates, start, func(userID string) (int, error) {
+			points, err := service.assignmentRepo.CompletedPointsByUser(ctx, userID, since)
+			if err != nil {
+				return 0, fmt.Errorf("totalling effort points: %w", err)
+			}
+			return points, nil
+		})
+	case models.AssignmentRandom:
+		return rand.IntN(len(candidates)), nil
+	default:
+		return service.roundRobin(ctx, candidates, start)
+	}
+}
+
+// roundRobin walks the candidates from start and takes the first one without
+// overdue chores. If everyone is overdue the rotation still advances to start
+// rather than always landing on the same person.
+func (service *ChoreService) roundRobin(ctx context.Context, candidates []models.User, start int) (int, error) {
+	for attempts := 0; attempts < len(candidates); attempts++ {
+		candidateIndex := (start + attempts) % len(candidates)
+
+		overdueCount, err := service.choreRepo.CountByStatusAndUser(ctx, models.ChoreStatusOverdue, candidates[candidateIndex].ID)
+		if err != nil {
+			return start, fmt.Errorf("checking overdue chores: %w", err)
+		}
+
+		if overdueCount == 0 {
+			return candidateIndex, nil
+		}
+	}
+	return start, nil
+}
+
+// openChoreCount is a user's current load: their pending and overdue chores.
+func (service *ChoreService) openChoreCount(ctx context.Context, userID string) (int, error) {
+	total := 0
+	for _, status := range []models.ChoreStatus{models.ChoreStatusPending, models.ChoreStatusOverdue} {
+		count, err := service.choreRepo.CountByStatusAndUser(ctx, status, userID)
+		if err != nil {
+			return 0, fmt.Errorf("counting open chores: %w", err)
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// lowestScore returns the index of the candidate with the lowest score,
+// visiting candidates in rotation order from start so the earliest one wins a
+// tie.
+func lowestScore(candidates []models.User, start int, score func(userID string) (int, error)) (int, error) {
+	best, bestScore := start, 0
+	for attempts := 0; attempts < len(candidates); attempts++ {
+		candidateIndex := (start + attempts) % len(candidates)
+		candidateScore, err := score(candidates[candidateIndex].ID)
+		if err != nil {
+			return start, err
+		}
+		if attempts == 0 || candidateScore < bestScore {
+			best, bestScore = candidateIndex, candidateScore
+		}
+	}
+	return best, nil
+}
@@ -0,0 +1,207 @@
+package services_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+)
+
+// giveOpenChores assigns count pending chores to the user.
+func giveOpenChores(t *testing.T, choreRepo *repository.SQLiteChoreRepository, user models.User, count int) {
+	t.Helper()
+	for i := 0; i < count; i++ {
+		if _, err := choreRepo.Create(context.Background(), models.Chore{
+			Name:             "Open chore",
+			CreatedByUserID:  user.ID,
+			AssignedToUserID: &user.ID,
+			Status:           models.ChoreStatusPending,
+		}); err != nil {
+			t.Fatalf("creating open chore: %v", err)
+		}
+	}
+}
+
+func TestChoreService_AssignNextUser_RoundRobinFollowsCursor(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 3)
+	// Rotation order is by user ID.
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	chore := newRecurringChore(t, choreRepo, seriesRepo,
+		models.ChoreSeries{
+			RecurrenceType:       models.RecurrenceDaily,
+			RecurrenceValue:      `{"interval":1}`,
+			AssignmentStrategy:   models.AssignmentRoundRobin,
+			RotationCursorUserID: &users[0].ID,
+		},
+		models.Chore{Name: "Rotating", CreatedByUserID: users[0].ID, LastAssignedIndex: -1})
+
+	assigned, err := service.AssignNextUser(ctx, chore)
+	if err != nil {
+		t.Fatalf("assigning: %v", err)
+	}
+	if *assigned.AssignedToUserID != users[1].ID {
+		t.Errorf("expected the user after the cursor, got %s", *assigned.AssignedToUserID)
+	}
+}
+
+func TestChoreService_AssignNextUser_LeastLoaded(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 3)
+
+	giveOpenChores(t, choreRepo, users[0], 2)
+	giveOpenChores(t, choreRepo, users[2], 1)
+
+	chore := newRecurringChore(t, choreRepo, seriesRepo,
+		models.ChoreSeries{
+			RecurrenceType:     models.RecurrenceDaily,
+			RecurrenceValue:    `{"interval":1}`,
+			AssignmentStrategy: models.AssignmentLeastLoaded,
+		},
+		models.Chore{Name: "Balanced", CreatedByUserID: users[0].ID, LastAssignedIndex: -1})
+
+	assigned, err := service.AssignNextUser(ctx, chore)
+	if err != nil {
+		t.Fatalf("assigning: %v", err)
+	}
+	if *assigned.AssignedToUserID != users[1].ID {
+		t.Errorf("expected the user with no open chores (Bob), got %s", *assigned.AssignedToUserID)
+	}
+}
+
+func TestChoreService_AssignNextUser_EffortWeighted(t *testing.T) {
+	service, choreRepo, assignmentRepo, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 3)
+
+	// Alice finished a heavy chore, Bob a light one; Charlie has done nothing
+	// but has the most open chores, so load alone would not pick him.
+	heavy := newRecurringChore(t, choreRepo, seriesRepo,
+		models.ChoreSeries{RecurrenceType: models.RecurrenceNone, EffortPoints: 5},
+		models.Chore{Name: "Heavy", CreatedByUserID: users[0].ID, Status: models.ChoreStatusCompleted})
+	light, _ := choreRepo.Create(ctx, models.Chore{Name: "Light", CreatedByUserID: users[1].ID, Status: models.ChoreStatusCompleted})
+	for _, done := range []struct {
+		choreID string
+		userID  string
+	}{{heavy.ID, users[0].ID}, {light.ID, users[1].ID}} {
+		if _, err := assignmentRepo.Create(ctx, models.ChoreAssignment{ChoreID: done.choreID, UserID: done.userID}); err != nil {
+			t.Fatalf("creating assignment: %v", err)
+		}
+		if err := assignmentRepo.MarkCompleted(ctx, done.choreID, done.userID); err != nil {
+			t.Fatalf("completing assignment: %v", err)
+		}
+	}
+	giveOpenChores(t, choreRepo, users[2], 2)
+
+	chore := newRecurringChore(t, choreRepo, seriesRepo,
+		models.ChoreSeries{
+			RecurrenceType:     models.RecurrenceDaily,
+			RecurrenceValue:    `{"interval":1}`,
+			AssignmentStrategy: models.AssignmentEffortWeighted,
+		},
+		models.Chore{Name: "Weighted", CreatedByUserID: users[0].ID, LastAssignedIndex: -1})
+
+	assigned, err := service.AssignNextUser(ctx, chore)
+	if err != nil {
+		t.Fatalf("assigning: %v", err)
+	}
+	if *assigned.AssignedToUserID != users[2].ID {
+		t.Errorf("expected the user with the fewest effort points (Charlie), got %s", *assigned.AssignedToUserID)
+	}
+
+	points, err := assignmentRepo.CompletedPointsByUser(ctx, users[0].ID, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("totalling points: %v", err)
+	}
+	if points != 5 {
+		t.Errorf("expected Alice to have 5 points, got %d", points)
+	}
+}
+
+func TestChoreService_AssignNextUser_Random(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 3)
+
+	chore := newRecurringChore(t, choreRepo, seriesRepo,
+		models.ChoreSeries{
+			RecurrenceType:     models.RecurrenceDaily,
+			RecurrenceValue:    `{"interval":1}`,
+			AssignmentStrategy: models.AssignmentRandom,
+		},
+		models.Chore{Name: "Lottery", CreatedByUserID: users[0].ID, LastAssignedIndex: -1})
+	seriesRepo.SetEligibleAssignees(ctx, *chore.SeriesID, []string{users[0].ID, users[1].ID})
+
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		assigned, err := service.AssignNextUser(ctx, chore)
+		if err != nil {
+			t.Fatalf("assigning: %v", err)
+		}
+		if *assigned.AssignedToUserID == users[2].ID {
+			t.Fatal("random assignment picked a user outside the eligible pool")
+		}
+		seen[*assigned.AssignedToUserID] = true
+		chore = assigned
+	}
+	if len(seen) != 2 {
+		t.Errorf("expected both eligible users to be drawn, got %d", len(seen))
+	}
+}
+
+func TestChoreService_AssignNextUser_FixedOwner(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 3)
+
+	chore := newRecurringChore(t, choreRepo, seriesRepo,
+		models.ChoreSeries{
+			RecurrenceType:       models.RecurrenceDaily,
+			RecurrenceValue:      `{"interval":1}`,
+			AssignmentStrategy:   models.AssignmentFixed,
+			FixedAssigneeUserID:  &users[2].ID,
+			RotationCursorUserID: &users[0].ID,
+		},
+		models.Chore{Name: "Charlie's job", CreatedByUserID: users[0].ID, LastAssignedIndex: -1})
+
+	for i := 0; i < 3; i++ {
+		assigned, err := service.AssignNextUser(ctx, chore)
+		if err != nil {
+			t.Fatalf("assigning: %v", err)
+		}
+		if *assigned.AssignedToUserID != users[2].ID {
+			t.Fatalf("expected the fixed owner (Charlie), got %s", *assigned.AssignedToUserID)
+		}
+		chore = assigned
+	}
+}
+
+func TestChoreService_AssignNextUser_FixedOwnerOutsidePool(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 3)
+
+	chore := newRecurringChore(t, choreRepo, seriesRepo,
+		models.ChoreSeries{
+			RecurrenceType:      models.RecurrenceDaily,
+			RecurrenceValue:     `{"interval":1}`,
+			AssignmentStrategy:  models.AssignmentFixed,
+			FixedAssigneeUserID: &users[2].ID,
+		},
+		models.Chore{Name: "Orphaned", CreatedByUserID: users[0].ID, LastAssignedIndex: -1})
+	seriesRepo.SetEligibleAssignees(ctx, *chore.SeriesID, []string{users[0].ID, users[1].ID})
+
+	assigned, err := service.AssignNextUser(ctx, chore)
+	if err != nil {
+		t.Fatalf("assigning: %v", err)
+	}
+	if *assigned.AssignedToUserID == users[2].ID {
+		t.Error("owner outside the eligible pool should not be assigned")
+	}
+}
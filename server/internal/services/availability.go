@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+)
+
+// dayFormat is the YYYY-MM-DD layout away windows are stored in.
+const dayFormat = "2006-01-02"
+
+var (
+	ErrUnavailabilityInvalid   = errors.New("away window needs a start date on or before its end date")
+	ErrUnavailabilityForbidden = errors.New("only admins can manage other members' away windows")
+)
+
+// occurrenceDay is the day availability is checked against: the chore's due
+// date, or today in the household timezone for chores without one.
+func (service *ChoreService) occurrenceDay(ctx context.Context, chore models.Chore) string {
+	if chore.DueDate != nil {
+		return chore.DueDate.Format(dayFormat)
+	}
+	return service.now(ctx).Format(dayFormat)
+}
+
+// awayUserIDs returns the users with an away window covering day.
+func (service *ChoreService) awayUserIDs(ctx context.Context, day string) (map[string]bool, error) {
+	away := map[string]bool{}
+	if service.unavailabilityRepo == nil {
+		return away, nil
+	}
+	windows, err := service.unavailabilityRepo.FindOverlapping(ctx, day, day)
+	if err != nil {
+		return nil, fmt.Errorf("finding away windows: %w", err)
+	}
+	for _, window := range windows {
+		away[window.UserID] = true
+	}
+	return away, nil
+}
+
+// availableOn drops candidates who are away on day. The returned start is the
+// position of the first remaining candidate at or after start in rotation
+// order, so the rotation passes over an absent member and reaches them as
+// normal once they are back. If everyone is away the pool is returned
+// unchanged so the chore still gets an owner.
+func (service *ChoreService) availableOn(ctx context.Context, candidates []models.User, start int, day string) ([]models.User, int, error) {
+	away, err := service.awayUserIDs(ctx, day)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(away) == 0 {
+		return candidates, start, nil
+	}
+
+	startID := ""
+	for attempts := 0; attempts < len(candidates); attempts++ {
+		if candidate := candidates[(start+attempts)%len(candidates)]; !away[candidate.ID] {
+			startID = candidate.ID
+			break
+		}
+	}
+	if startID == "" {
+		return candidates, start, nil
+	}
+
+	var available []models.User
+	availableStart := 0
+	for _, candidate := range candidates {
+		if away[candidate.ID] {
+			continue
+		}
+		if candidate.ID == startID {
+			availableStart = len(available)
+		}
+		available = append(available, candidate)
+	}
+	return available, availableStart, nil
+}
+
+// coverUnavailable hands on a series' upcoming occurrences whose assignee is
+// away on the due date, as happens when a window is added after the
+// occurrences were seeded. Each one goes to whoever the strategy picks from
+// the members who are around, without moving the series' rotation cursor.
+func (service *ChoreService) coverUnavailable(ctx context.Context, seriesID *string, series *models.ChoreSeries) error {
+	if service.unavailabilityRepo == nil || seriesID == nil {
+		return nil
+	}
+
+	pendingStatus := models.ChoreStatusPending
+	today := service.now(ctx).Format(dayFormat)
+	pending, err := service.choreRepo.FindAll(ctx, repository.ChoreFilter{
+		SeriesID: seriesID,
+		Status:   &pendingStatus,
+	})
+	if err != nil {
+		return fmt.Errorf("finding pending occurrences: %w", err)
+	}
+
+	for _, occurrence := range pending {
+		if occurrence.AssignedToUserID == nil || occurrence.DueDate == nil {
+			continue
+		}
+		day := service.occurrenceDay(ctx, occurrence)
+		if day < today {
+			continue
+		}
+		away, err := service.awayUserIDs(ctx, day)
+		if err != nil {
+			return err
+		}
+		if !away[*occurrence.AssignedToUserID] {
+			continue
+		}
+
+		cover, found, err := service.pickCover(ctx, occurrence, series, day)
+		if err != nil {
+			return err
+		}
+		if !found {
+			// Everyone is away that day; leave it where it is.
+			continue
+		}
+		if _, err := service.reassign(ctx, occurrence, cover); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pickCover chooses who takes an occurrence over from its assignee on day:
+// whoever the assignment strategy picks from the rest of the pool who are
+// around, continuing the rotation from the assignee. It reports false when
+// nobody else is available. Both away-window cover and the reassign
+// escalation go through it.
+func (service *ChoreService) pickCover(ctx context.Context, occurrence models.Chore, series *models.ChoreSeries, day string) (string, bool, error) {
+	current := *occurrence.AssignedToUserID
+	candidates, err := service.findCandidates(ctx, occurrence.ID, series)
+	if err != nil {
+		return "", false, err
+	}
+	start := rotationStart(candidates, &current, occurrence.LastAssignedIndex)
+	var others []models.User
+	othersStart := 0
+	for i, candidate := range candidates {
+		if candidate.ID == current {
+			continue
+		}
+		if i == start {
+			othersStart = len(others)
+		}
+		others = append(others, candidate)
+	}
+	if len(others) == 0 {
+		return "", false, nil
+	}
+
+	away, err := service.awayUserIDs(ctx, day)
+	if err != nil {
+		return "", false, err
+	}
+	available, availableStart, err := service.availableOn(ctx, others, othersStart, day)
+	if err != nil {
+		return "", false, err
+	}
+	chosen, err := service.chooseAssignee(ctx, applySeriesRule(occurrence, series), available, availableStart)
+	if err != nil {
+		return "", false, err
+	}
+	if away[available[chosen].ID] {
+		return "", false, nil
+	}
+	return available[chosen].ID, true, nil
+}
+
+// Unavailability lists away windows matching the filter, soonest first.
+func (service *ChoreService) Unavailability(ctx context.Context, filter repository.UnavailabilityFilter) ([]models.Unavailability, error) {
+	if service.unavailabilityRepo == nil {
+		return nil, nil
+	}
+	return service.unavailabilityRepo.FindAll(ctx, filter)
+}
+
+// AddUnavailability records an away window. Members may only add their own;
+// admins may add one for anyone. Upcoming occurrences that fall inside the
+// window are handed to someone else straight away rather than at the next
+// top-up.
+func (service *ChoreService) AddUnavailability(ctx context.Context, actor models.User, window models.Unavailability) (models.Unavailability, error) {
+	if window.UserID == "" {
+		window.UserID = actor.ID
+	}
+	if window.UserID != actor.ID && actor.Role != models.RoleAdmin {
+		return models.Unavailability{}, ErrUnavailabilityForbidden
+	}
+	if _, err := service.userRepo.FindByID(ctx, window.UserID); err != nil {
+		return models.Unavailability{}, fmt.Errorf("finding user: %w", err)
+	}
+
+	start, startErr := time.Parse(dayFormat, window.StartDate)
+	end, endErr := time.Parse(dayFormat, window.EndDate)
+	if startErr != nil || endErr != nil || end.Before(start) {
+		return models.Unavailability{}, ErrUnavailabilityInvalid
+	}
+	window.Reason = strings.TrimSpace(window.Reason)
+	window.CreatedByUserID = actor.ID
+
+	created, err := service.unavailabilityRepo.Create(ctx, window)
+	if err != nil {
+		return models.Unavailability{}, err
+	}
+
+	if err := service.TopUpAllSeries(ctx, SeedHorizonFrom(time.Now())); err != nil {
+		return created, fmt.Errorf("reassigning around away window: %w", err)
+	}
+	return created, nil
+}
+
+// RemoveUnavailability deletes an away window. Chores already handed on stay
+// with their new owners.
+func (service *ChoreService) RemoveUnavailability(ctx context.Context, actor models.User, windowID string) error {
+	window, err := service.unavailabilityRepo.FindByID(ctx, windowID)
+	if err != nil {
+		return err
+	}
+	if window.UserID != actor.ID && actor.Role != models.RoleAdmin {
+		return ErrUnavailabilityForbidden
+	}
+	return service.unavailabilityRepo.Delete(ctx, windowID)
+}
@@ -0,0 +1,160 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+)
+
+// seedDailyRotation creates a round-robin daily series for users (sorted by
+// ID, the rotation order) whose anchor is due tomorrow and assigned to the
+// first user. It returns the anchor and the first due date.
+func seedDailyRotation(t *testing.T, service *services.ChoreService, choreRepo *repository.SQLiteChoreRepository, seriesRepo *repository.SQLiteChoreSeriesRepository, users []models.User) (models.Chore, time.Time) {
+	t.Helper()
+	now := time.Now()
+	first := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	chore := newRecurringChore(t, choreRepo, seriesRepo,
+		models.ChoreSeries{
+			RecurrenceType:       models.RecurrenceDaily,
+			RecurrenceValue:      `{"interval":1}`,
+			AssignmentStrategy:   models.AssignmentRoundRobin,
+			RotationCursorUserID: &users[0].ID,
+		},
+		models.Chore{
+			Name:             "Feed the cat",
+			CreatedByUserID:  users[0].ID,
+			AssignedToUserID: &users[0].ID,
+			DueDate:          &first,
+			Status:           models.ChoreStatusPending,
+		})
+	return chore, first
+}
+
+// assigneesByDay maps each pending occurrence's due date to its assignee.
+func assigneesByDay(t *testing.T, choreRepo *repository.SQLiteChoreRepository, seriesID string) map[string]string {
+	t.Helper()
+	pendingStatus := models.ChoreStatusPending
+	occurrences, err := choreRepo.FindAll(context.Background(), repository.ChoreFilter{SeriesID: &seriesID, Status: &pendingStatus})
+	if err != nil {
+		t.Fatalf("finding occurrences: %v", err)
+	}
+	byDay := map[string]string{}
+	for _, occurrence := range occurrences {
+		byDay[occurrence.DueDate.Format("2006-01-02")] = *occurrence.AssignedToUserID
+	}
+	return byDay
+}
+
+func TestChoreService_SeedSkipsAwayUser(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 3)
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	chore, first := seedDailyRotation(t, service, choreRepo, seriesRepo, users)
+	day := func(offset int) string { return first.AddDate(0, 0, offset).Format("2006-01-02") }
+
+	if _, err := service.AddUnavailability(ctx, users[1], models.Unavailability{
+		StartDate: day(1), EndDate: day(1), Reason: "Camp",
+	}); err != nil {
+		t.Fatalf("AddUnavailability: %v", err)
+	}
+
+	if err := service.SeedFutureOccurrences(ctx, chore, first.AddDate(0, 0, 6)); err != nil {
+		t.Fatalf("SeedFutureOccurrences: %v", err)
+	}
+
+	// The second user is passed over while away and picks up their usual
+	// place in the rotation once back.
+	byDay := assigneesByDay(t, choreRepo, *chore.SeriesID)
+	expected := []string{users[0].ID, users[2].ID, users[0].ID, users[1].ID, users[2].ID, users[0].ID}
+	for offset, userID := range expected {
+		if byDay[day(offset)] != userID {
+			t.Errorf("day %d: got %s, want %s", offset, byDay[day(offset)], userID)
+		}
+	}
+}
+
+func TestChoreService_AddUnavailabilityReassignsSeeded(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 3)
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	admin := users[0]
+	admin.Role = models.RoleAdmin
+
+	chore, first := seedDailyRotation(t, service, choreRepo, seriesRepo, users)
+	day := func(offset int) string { return first.AddDate(0, 0, offset).Format("2006-01-02") }
+
+	// Seeded to the horizon, so the top-up that follows the window adds
+	// nothing that would move the cursor on its own.
+	if err := service.SeedFutureOccurrences(ctx, chore, services.SeedHorizonFrom(time.Now())); err != nil {
+		t.Fatalf("SeedFutureOccurrences: %v", err)
+	}
+	before := assigneesByDay(t, choreRepo, *chore.SeriesID)
+	if before[day(4)] != users[1].ID {
+		t.Fatalf("expected the second user on day 4 before the window, got %s", before[day(4)])
+	}
+	series, _ := seriesRepo.FindByID(ctx, *chore.SeriesID)
+	cursor := *series.RotationCursorUserID
+
+	// An admin books the second user away over day 4 after it was seeded.
+	if _, err := service.AddUnavailability(ctx, admin, models.Unavailability{
+		UserID: users[1].ID, StartDate: day(3), EndDate: day(4),
+	}); err != nil {
+		t.Fatalf("AddUnavailability: %v", err)
+	}
+
+	after := assigneesByDay(t, choreRepo, *chore.SeriesID)
+	if after[day(4)] == users[1].ID {
+		t.Errorf("day 4 should have been handed on, still with the away user")
+	}
+	for offset := 0; offset < 6; offset++ {
+		if offset != 4 && after[day(offset)] != before[day(offset)] {
+			t.Errorf("day %d changed hands: %s -> %s", offset, before[day(offset)], after[day(offset)])
+		}
+	}
+
+	series, _ = seriesRepo.FindByID(ctx, *chore.SeriesID)
+	if *series.RotationCursorUserID != cursor {
+		t.Errorf("covering an occurrence must not move the rotation cursor")
+	}
+}
+
+func TestChoreService_AddUnavailabilityValidation(t *testing.T) {
+	service, _, _, userRepo, _ := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 2)
+
+	_, err := service.AddUnavailability(ctx, users[0], models.Unavailability{
+		UserID: users[1].ID, StartDate: "2030-07-01", EndDate: "2030-07-10",
+	})
+	if !errors.Is(err, services.ErrUnavailabilityForbidden) {
+		t.Errorf("member adding for someone else: got %v, want ErrUnavailabilityForbidden", err)
+	}
+
+	_, err = service.AddUnavailability(ctx, users[0], models.Unavailability{StartDate: "2030-07-10", EndDate: "2030-07-01"})
+	if !errors.Is(err, services.ErrUnavailabilityInvalid) {
+		t.Errorf("end before start: got %v, want ErrUnavailabilityInvalid", err)
+	}
+
+	window, err := service.AddUnavailability(ctx, users[0], models.Unavailability{StartDate: "2030-07-01", EndDate: "2030-07-10"})
+	if err != nil {
+		t.Fatalf("AddUnavailability: %v", err)
+	}
+	if window.UserID != users[0].ID {
+		t.Errorf("window should default to the caller, got %s", window.UserID)
+	}
+	if err := service.RemoveUnavailability(ctx, users[1], window.ID); !errors.Is(err, services.ErrUnavailabilityForbidden) {
+		t.Errorf("removing someone else's window: got %v, want ErrUnavailabilityForbidden", err)
+	}
+	if err := service.RemoveUnavailability(ctx, users[0], window.ID); err != nil {
+		t.Errorf("RemoveUnavailability: %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+)
+
+// CalendarEventSource supplies the events of a single iCal subscription. It is
+// satisfied by ICalFetcher and drives `calendar` recurrence.
+type CalendarEventSource interface {
+	FetchSubscriptionEvents(ctx context.Context, subscriptionID string, start, end time.Time) ([]models.Event, error)
+}
+
+// seedCalendarOccurrences materializes one occurrence per matching event of the
+// series' linked subscription, due LeadHours before the event, up to `until`.
+// Like SeedFutureOccurrences it resumes after the latest future pending
+// occurrence, so repeated top-ups are idempotent. A new calendar series starts
+// as an undated anchor; the first matching event dates it instead of adding a
+// row.
+func (service *ChoreService) seedCalendarOccurrences(ctx context.Context, chore models.Chore, series *models.ChoreSeries, until time.Time) error {
+	if service.calendarEvents == nil {
+		return nil
+	}
+
+	config, err := parseConfig(chore.RecurrenceValue)
+	if err != nil {
+		return fmt.Errorf("parsing recurrence config: %w", err)
+	}
+	if config.SubscriptionID == "" {
+		return nil
+	}
+
+	if err := service.ensureSeriesID(ctx, &chore); err != nil {
+		return fmt.Errorf("setting series_id: %w", err)
+	}
+
+	if chore.RecurrenceUntil != nil && chore.RecurrenceUntil.Before(until) {
+		until = *chore.RecurrenceUntil
+	}
+
+	location := service.Location(ctx)
+	now := time.Now()
+	lead := time.Duration(config.LeadHours) * time.Hour
+	events, err := service.calendarEvents.FetchSubscriptionEvents(ctx, config.SubscriptionID, now, until.Add(lead))
+	if err != nil {
+		return fmt.Errorf("fetching calendar events: %w", err)
+	}
+
+	cursor := now
+	previous := chore
+	lastFuture, err := service.choreRepo.FindLastFuturePendingInSeries(ctx, *chore.SeriesID)
+	if err != nil {
+		return fmt.Errorf("finding last future pending: %w", err)
+	}
+	if lastFuture != nil {
+		cursor = choreDueAt(*lastFuture, location)
+		previous = *lastFuture
+	}
+
+	existing := 0
+	if chore.RecurrenceCount != nil {
+		existing, err = service.choreRepo.CountBySeries(ctx, *chore.SeriesID)
+		if err != nil {
+			return fmt.Errorf("counting series occurrences: %w", err)
+		}
+		existing += deletedSlots(series)
+	}
+
+	for _, dueAt := range calendarDueTimes(events, config.Summary, lead, location) {
+		if !dueAt.After(cursor) || !dueAt.Before(until) {
+			continue
+		}
+		dueDate, dueTime := splitDueAt(dueAt, location)
+		cursor = dueAt
+		if exceptedSlot(series, dueDate) {
+			continue
+		}
+
+		if chore.DueDate == nil && chore.Status != models.ChoreStatusCompleted && previous.ID == chore.ID {
+			chore.DueDate = &dueDate
+			chore.DueTime = &dueTime
+			if err := service.choreRepo.Update(ctx, chore); err != nil {
+				return fmt.Errorf("dating calendar anchor: %w", err)
+			}
+			previous = chore
+			continue
+		}
+
+		if chore.RecurrenceCount != nil && existing >= *chore.RecurrenceCount {
+			break
+		}
+
+		occurrence := newChoreFromTemplate(chore, &dueDate, previous.LastAssignedIndex)
+		occurrence.DueTime = &dueTime
+		created, err := service.choreRepo.Create(ctx, occurrence)
+		if err != nil {
+			return fmt.Errorf("creating calendar chore instance: %w", err)
+		}
+
+		if series == nil {
+			if err := service.copyEligibleAssignees(ctx, chore.ID, created.ID); err != nil {
+				return err
+			}
+		}
+		if err := service.copyChecklist(ctx, series, chore.ID, created.ID); err != nil {
+			return err
+		}
+
+		assigned, err := service.assignNextUser(ctx, created, previous.AssignedToUserID)
+		if err != nil {
+			return fmt.Errorf("assigning calendar chore: %w", err)
+		}
+		previous = assigned
+		existing++
+	}
+
+	return nil
+}
+
+// calendarDueTimes returns the sorted, de-duplicated due instants for events
+// whose summary contains summary (case-insensitive; empty matches every
+// event). All-day events start at midnight in location on their date.
+func calendarDueTimes(events []models.Event, summary string, lead time.Duration, location *time.Location) []time.Time {
+	summary = strings.ToLower(strings.TrimSpace(summary))
+
+	var dueTimes []time.Time
+	for _, event := range events {
+		if summary != "" && !strings.Contains(strings.ToLower(event.Title), summary) {
+			continue
+		}
+		start := event.StartTime
+		if event.AllDay {
+			start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, location)
+		}
+		dueTimes = append(dueTimes, start.Add(-lead))
+	}
+
+	sort.Slice(dueTimes, func(i, j int) bool { return dueTimes[i].Before(dueTimes[j]) })
+
+	var unique []time.Time
+	for _, dueAt := range dueTimes {
+		if len(unique) == 0 || !unique[len(unique)-1].Equal(dueAt) {
+			unique = append(unique, dueAt)
+		}
+	}
+	return unique
+}
+
+// splitDueAt converts an instant into the date + "HH:MM" pair chores store,
+// using the same date-only encoding as the chore form. The wall-clock time is
+// read in location, the household timezone.
+func splitDueAt(dueAt time.Time, location *time.Location) (time.Time, string) {
+	local := dueAt.In(location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC), local.Format("15:04")
+}
+
+// choreDueAt is the inverse of splitDueAt: the chore's series slot at its due
+// time (midnight when unset) in location.
+func choreDueAt(chore models.Chore, location *time.Location) time.Time {
+	if chore.DueDate == nil {
+		return time.Time{}
+	}
+	dueDate := seriesSlot(chore)
+	hour, minute := 0, 0
+	if chore.DueTime != nil {
+		if parsed, err := time.Parse("15:04", *chore.DueTime); err == nil {
+			hour, minute = parsed.Hour(), parsed.Minute()
+		}
+	}
+	return time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), hour, minute, 0, 0, location)
+}
+
+// CalendarSeries lists the active series whose occurrences follow the given
+// iCal subscription.
+func (service *ChoreService) CalendarSeries(ctx context.Context, subscriptionID string) ([]models.ChoreSeries, error) {
+	active, err := service.ActiveSeries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var linked []models.ChoreSeries
+	for _, series := range active {
+		if series.RecurrenceType != models.RecurrenceCalendar {
+			continue
+		}
+		config, err := parseConfig(series.RecurrenceValue)
+		if err != nil {
+			return nil, fmt.Errorf("parsing recurrence config of series %s: %w", series.ID, err)
+		}
+		if config.SubscriptionID == subscriptionID {
+			linked = append(linked, series)
+		}
+	}
+	return linked, nil
+}
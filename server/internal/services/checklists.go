@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+)
+
+var ErrChecklistIncomplete = errors.New("every checklist item must be ticked before the chore can be completed")
+
+// Checklist returns the occurrence's checklist items in order.
+func (service *ChoreService) Checklist(ctx context.Context, choreID string) ([]models.ChecklistItem, error) {
+	return service.choreRepo.GetChecklist(ctx, choreID)
+}
+
+// SetChecklist replaces a chore's checklist. For a series the list becomes the
+// template for future occurrences and is applied to every open occurrence
+// already seeded; completed ones keep the list they were done against. Blank
+// lines are dropped and items already ticked stay ticked.
+func (service *ChoreService) SetChecklist(ctx context.Context, chore models.Chore, titles []string) error {
+	var cleaned []string
+	for _, title := range titles {
+		if title = strings.TrimSpace(title); title != "" {
+			cleaned = append(cleaned, title)
+		}
+	}
+
+	var targets []models.Chore
+	if choreIsOpen(chore) {
+		targets = append(targets, chore)
+	}
+
+	if series := service.loadSeries(ctx, chore.SeriesID); series != nil {
+		if err := service.seriesRepo.SetChecklist(ctx, series.ID, cleaned); err != nil {
+			return fmt.Errorf("setting series checklist: %w", err)
+		}
+		occurrences, err := service.choreRepo.FindAll(ctx, repository.ChoreFilter{
+			SeriesID: &series.ID,
+			Statuses: []models.ChoreStatus{models.ChoreStatusPending, models.ChoreStatusOverdue},
+		})
+		if err != nil {
+			return fmt.Errorf("finding open occurrences: %w", err)
+		}
+		for _, occurrence := range occurrences {
+			if occurrence.ID != chore.ID {
+				targets = append(targets, occurrence)
+			}
+		}
+	}
+
+	for _, target := range targets {
+		if err := service.choreRepo.SetChecklist(ctx, target.ID, cleaned); err != nil {
+			return fmt.Errorf("setting checklist: %w", err)
+		}
+	}
+	return nil
+}
+
+// TickChecklistItem ticks or clears one item on an open occurrence.
+func (service *ChoreService) TickChecklistItem(ctx context.Context, choreID, itemID, userID string, checked bool) error {
+	chore, err := service.choreRepo.FindByID(ctx, choreID)
+	if err != nil {
+		return fmt.Errorf("finding chore: %w", err)
+	}
+	if !choreIsOpen(chore) {
+		return ErrChoreNotOpen
+	}
+	return service.choreRepo.SetChecklistItemChecked(ctx, choreID, itemID, userID, checked)
+}
+
+// requireChecklistDone returns ErrChecklistIncomplete while any item on the
+// occurrence is unticked.
+func (service *ChoreService) requireChecklistDone(ctx context.Context, choreID string) error {
+	items, err := service.choreRepo.GetChecklist(ctx, choreID)
+	if err != nil {
+		return fmt.Errorf("loading checklist: %w", err)
+	}
+	for _, item := range items {
+		if item.CheckedAt == nil {
+			return ErrChecklistIncomplete
+		}
+	}
+	return nil
+}
+
+// copyChecklist gives a newly created occurrence its unticked checklist: the
+// series template when there is one, otherwise the items on the occurrence it
+// was generated from.
+func (service *ChoreService) copyChecklist(ctx context.Context, series *models.ChoreSeries, sourceChoreID, targetChoreID string) error {
+	var titles []string
+	if series != nil {
+		titles = series.Checklist
+	} else {
+		items, err := service.choreRepo.GetChecklist(ctx, sourceChoreID)
+		if err != nil {
+			return fmt.Errorf("getting checklist for recurrence: %w", err)
+		}
+		for _, item := range items {
+			titles = append(titles, item.Title)
+		}
+	}
+	if len(titles) == 0 {
+		return nil
+	}
+	if err := service.choreRepo.SetChecklist(ctx, targetChoreID, titles); err != nil {
+		return fmt.Errorf("copying checklist: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+)
+
+func TestChoreService_ChecklistCopiedOntoEachOccurrence(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 2)
+
+	chore, first := seedDailyRotation(t, service, choreRepo, seriesRepo, users)
+	if err := service.SetChecklist(ctx, chore, []string{"Fill bowl", " ", "Fresh water"}); err != nil {
+		t.Fatalf("SetChecklist: %v", err)
+	}
+
+	anchorItems, _ := service.Checklist(ctx, chore.ID)
+	if err := service.TickChecklistItem(ctx, chore.ID, anchorItems[0].ID, users[0].ID, true); err != nil {
+		t.Fatalf("TickChecklistItem: %v", err)
+	}
+
+	if err := service.SeedFutureOccurrences(ctx, chore, first.AddDate(0, 0, 3)); err != nil {
+		t.Fatalf("SeedFutureOccurrences: %v", err)
+	}
+
+	pendingStatus := models.ChoreStatusPending
+	occurrences, err := choreRepo.FindAll(ctx, repository.ChoreFilter{SeriesID: chore.SeriesID, Status: &pendingStatus})
+	if err != nil {
+		t.Fatalf("finding occurrences: %v", err)
+	}
+	if len(occurrences) != 3 {
+		t.Fatalf("expected 3 occurrences, got %d", len(occurrences))
+	}
+	for _, occurrence := range occurrences {
+		items, err := service.Checklist(ctx, occurrence.ID)
+		if err != nil {
+			t.Fatalf("Checklist: %v", err)
+		}
+		if len(items) != 2 || items[0].Title != "Fill bowl" || items[1].Title != "Fresh water" {
+			t.Fatalf("occurrence %s: unexpected checklist %+v", occurrence.ID, items)
+		}
+		// Ticks belong to one occurrence only.
+		if occurrence.ID != chore.ID && items[0].CheckedAt != nil {
+			t.Errorf("occurrence %s: tick leaked from the anchor", occurrence.ID)
+		}
+	}
+}
+
+func TestChoreService_CompleteRequiresChecklist(t *testing.T) {
+	service, choreRepo, _, userRepo := setupChoreService(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 1)
+
+	chore, err := choreRepo.Create(ctx, models.Chore{
+		Name:              "Pack school bag",
+		CreatedByUserID:   users[0].ID,
+		AssignedToUserID:  &users[0].ID,
+		Status:            models.ChoreStatusPending,
+		ChecklistRequired: true,
+	})
+	if err != nil {
+		t.Fatalf("creating chore: %v", err)
+	}
+	if err := service.SetChecklist(ctx, chore, []string{"Lunch", "Homework"}); err != nil {
+		t.Fatalf("SetChecklist: %v", err)
+	}
+	items, _ := service.Checklist(ctx, chore.ID)
+	service.TickChecklistItem(ctx, chore.ID, items[0].ID, users[0].ID, true)
+
+	if err := service.CompleteChore(ctx, chore.ID, users[0].ID); !errors.Is(err, services.ErrChecklistIncomplete) {
+		t.Fatalf("expected ErrChecklistIncomplete, got %v", err)
+	}
+
+	service.TickChecklistItem(ctx, chore.ID, items[1].ID, users[0].ID, true)
+	if err := service.CompleteChore(ctx, chore.ID, users[0].ID); err != nil {
+		t.Fatalf("CompleteChore: %v", err)
+	}
+
+	// A completed occurrence's checklist is a record and can no longer change.
+	if err := service.TickChecklistItem(ctx, chore.ID, items[0].ID, users[0].ID, false); !errors.Is(err, services.ErrChoreNotOpen) {
+		t.Errorf("expected ErrChoreNotOpen, got %v", err)
+	}
+}
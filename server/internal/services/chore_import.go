@@ -0,0 +1,501 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+)
+
+var (
+	ErrImportInvalid       = errors.New("some rows are invalid; nothing was imported")
+	ErrImportEmpty         = errors.New("no chores to import")
+	ErrUnknownImportFormat = errors.New("format must be json or csv")
+	ErrUnknownLibraryChore = errors.New("unknown starter chore")
+)
+
+// ChoreTemplate is one chore series in the import/export file format. The
+// category is matched by name and created if missing; assignees are member
+// emails. RecurrenceRule is an RRULE such as "FREQ=WEEKLY;BYDAY=SA" and may be
+// empty for a one-off chore. DueTime is "HH:MM" or empty.
+type ChoreTemplate struct {
+	Name           string   `json:"name"`
+	Description    string   `json:"description,omitempty"`
+	Category       string   `json:"category,omitempty"`
+	RecurrenceRule string   `json:"recurrenceRule,omitempty"`
+	DueTime        string   `json:"dueTime,omitempty"`
+	Assignees      []string `json:"assignees,omitempty"`
+	Points         int      `json:"points,omitempty"`
+}
+
+// ImportRow is the verdict on one template. Row counts from 1 in file order.
+// NewCategory names a category the import will create. Created is set once
+// Import has created the row's chore.
+type ImportRow struct {
+	Row         int
+	Name        string
+	NewCategory string
+	Errors      []string
+	Created     bool
+}
+
+// ImportReport covers every row of an import, valid or not. Created counts
+// the rows Import got through, which is fewer than all of them only when the
+// import failed partway.
+type ImportReport struct {
+	Rows    []ImportRow
+	Invalid int
+	Created int
+}
+
+// ChoreImportService moves chore series in and out as JSON or CSV and serves
+// the starter library. An import is validated as a whole first, so a file with
+// any bad row changes nothing. A valid file is then created row by row and is
+// not rolled back: if a row fails, the rows before it stay imported.
+type ChoreImportService struct {
+	choreService *ChoreService
+	seriesRepo   repository.ChoreSeriesRepository
+	userRepo     repository.UserRepository
+	categoryRepo repository.CategoryRepository
+}
+
+func NewChoreImportService(
+	choreService *ChoreService,
+	seriesRepo repository.ChoreSeriesRepository,
+	userRepo repository.UserRepository,
+	categoryRepo repository.CategoryRepository,
+) *ChoreImportService {
+	return &ChoreImportService{
+		choreService: choreService,
+		seriesRepo:   seriesRepo,
+		userRepo:     userRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+// Export lists every active series as a template. Series that follow a
+// calendar feed have no rule to export and are left out.
+func (service *ChoreImportService) Export(ctx context.Context) ([]ChoreTemplate, error) {
+	all, err := service.seriesRepo.FindActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	users, err := service.userRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := service.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	emails := make(map[string]string, len(users))
+	for _, user := range users {
+		emails[user.ID] = user.Email
+	}
+	categoryNames := make(map[string]string, len(categories))
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+	}
+
+	templates := []ChoreTemplate{}
+	for _, series := range all {
+		if series.RecurrenceType == models.RecurrenceCalendar {
+			continue
+		}
+		rule, err := seriesRule(series)
+		if err != nil {
+			return nil, fmt.Errorf("exporting series %s: %w", series.ID, err)
+		}
+		template := ChoreTemplate{
+			Name:        series.Name,
+			Description: series.Description,
+			Points:      series.EffortPoints,
+		}
+		if rule != nil {
+			template.RecurrenceRule = rule.String()
+		}
+		if series.CategoryID != nil {
+			template.Category = categoryNames[*series.CategoryID]
+		}
+		if series.DueTime != nil {
+			template.DueTime = *series.DueTime
+		}
+		for _, userID := range series.EligibleAssignees {
+			if email := emails[userID]; email != "" {
+				template.Assignees = append(template.Assignees, email)
+			}
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+// seriesRule expresses a series' schedule as an RRULE, with its end conditions
+// folded back in. Simple schedules are translated; nil means no recurrence.
+func seriesRule(series models.ChoreSeries) (*RRule, error) {
+	var rule *RRule
+	if series.RecurrenceRule != "" {
+		parsed, err := ParseRRule(series.RecurrenceRule)
+		if err != nil {
+			return nil, err
+		}
+		rule = parsed
+	} else {
+		config, err := parseConfig(series.RecurrenceValue)
+		if err != nil {
+			return nil, err
+		}
+		rule = simpleRule(series.RecurrenceType, config)
+	}
+	if rule == nil {
+		return nil, nil
+	}
+	if series.RecurrenceCount != nil {
+		rule.Count = *series.RecurrenceCount
+	}
+	if series.RecurrenceUntil != nil {
+		rule.Until = series.RecurrenceUntil
+	}
+	return rule, nil
+}
+
+// simpleRule translates a structured recurrence into the equivalent RRULE.
+// Weekly rules start their weeks on Sunday, as findNextWeekday does.
+func simpleRule(recurrenceType models.RecurrenceType, config RecurrenceConfig) *RRule {
+	rule := &RRule{Interval: intervalOrDefault(config.Interval), WeekStart: time.Monday}
+	switch recurrenceType {
+	case models.RecurrenceDaily:
+		rule.Freq = FrequencyDaily
+	case models.RecurrenceWeekly:
+		rule.Freq = FrequencyWeekly
+		rule.WeekStart = time.Sunday
+		for _, day := range config.Days {
+			for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+				if strings.EqualFold(weekday.String(), day) {
+					rule.ByDay = append(rule.ByDay, RRuleWeekday{Weekday: weekday})
+				}
+			}
+		}
+	case models.RecurrenceMonthly:
+		rule.Freq = FrequencyMonthly
+	case models.RecurrenceCustom:
+		switch config.Unit {
+		case "weeks":
+			rule.Freq = FrequencyWeekly
+		case "months":
+			rule.Freq = FrequencyMonthly
+		default:
+			rule.Freq = FrequencyDaily
+		}
+	default:
+		return nil
+	}
+	if rule.Freq == FrequencyMonthly && config.DayOfMonth > 0 {
+		rule.ByMonthDay = []int{config.DayOfMonth}
+	}
+	return rule
+}
+
+// importPlan is a validated template ready to create.
+type importPlan struct {
+	chore       models.Chore
+	category    string
+	assigneeIDs []string
+}
+
+// Preview validates templates without changing anything.
+func (service *ChoreImportService) Preview(ctx context.Context, templates []ChoreTemplate) (ImportReport, error) {
+	report, _, err := service.plan(ctx, templates)
+	return report, err
+}
+
+// Import creates a series (or one-off chore) for every template, creating
+// missing categories on the way. When any row is invalid nothing is created
+// and the report is returned with ErrImportInvalid. When creating a row fails
+// the import stops there, keeping the chores and categories already created,
+// and the report marks which rows were created.
+func (service *ChoreImportService) Import(ctx context.Context, creatorID string, templates []ChoreTemplate) (ImportReport, error) {
+	report, plans, err := service.plan(ctx, templates)
+	if err != nil {
+		return report, err
+	}
+	if report.Invalid > 0 {
+		return report, ErrImportInvalid
+	}
+
+	categories, err := service.categoryIDs(ctx)
+	if err != nil {
+		return report, err
+	}
+	for i, plan := range plans {
+		chore := plan.chore
+		chore.CreatedByUserID = creatorID
+		if plan.category != "" {
+			key := strings.ToLower(plan.category)
+			categoryID, ok := categories[key]
+			if !ok {
+				created, err := service.categoryRepo.Create(ctx, models.Category{Name: plan.category, CreatedByUserID: creatorID})
+				if err != nil {
+					return report, fmt.Errorf("creating category %q: %w", plan.category, err)
+				}
+				categoryID = created.ID
+				categories[key] = categoryID
+			}
+			chore.CategoryID = &categoryID
+		}
+		if _, err := service.choreService.CreateChore(ctx, chore, plan.assigneeIDs, nil); err != nil {
+			return report, fmt.Errorf("importing %q: %w", chore.Name, err)
+		}
+		report.Rows[i].Created = true
+		report.Created++
+	}
+	return report, nil
+}
+
+func (service *ChoreImportService) plan(ctx context.Context, templates []ChoreTemplate) (ImportReport, []importPlan, error) {
+	if len(templates) == 0 {
+		return ImportReport{}, nil, ErrImportEmpty
+	}
+	users, err := service.userRepo.FindAll(ctx)
+	if err != nil {
+		return ImportReport{}, nil, err
+	}
+	userIDs := make(map[string]string, len(users))
+	for _, user := range users {
+		userIDs[strings.ToLower(user.Email)] = user.ID
+	}
+	categories, err := service.categoryIDs(ctx)
+	if err != nil {
+		return ImportReport{}, nil, err
+	}
+
+	now := service.choreService.now(ctx)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	newCategories := map[string]bool{}
+
+	var report ImportReport
+	plans := make([]importPlan, 0, len(templates))
+	for i, template := range templates {
+		row := ImportRow{Row: i + 1, Name: strings.TrimSpace(template.Name)}
+		plan := importPlan{category: strings.TrimSpace(template.Category)}
+		plan.chore = models.Chore{
+			Name:               row.Name,
+			Description:        strings.TrimSpace(template.Description),
+			RecurrenceType:     models.RecurrenceNone,
+			AssignmentStrategy: models.AssignmentRoundRobin,
+			EffortPoints:       template.Points,
+			Status:             models.ChoreStatusPending,
+		}
+
+		if row.Name == "" {
+			row.Errors = append(row.Errors, "name is required")
+		}
+		if template.Points < 0 {
+			row.Errors = append(row.Errors, "points must be a positive whole number")
+		}
+		if plan.chore.EffortPoints < 1 {
+			plan.chore.EffortPoints = 1
+		}
+		if dueTime := strings.TrimSpace(template.DueTime); dueTime != "" {
+			if _, err := time.Parse("15:04", dueTime); err != nil {
+				row.Errors = append(row.Errors, fmt.Sprintf("due time %q must be HH:MM", dueTime))
+			} else {
+				plan.chore.DueTime = &dueTime
+			}
+		}
+		if raw := strings.TrimSpace(template.RecurrenceRule); raw != "" {
+			if err := applyImportedRule(&plan.chore, raw, today); err != nil {
+				row.Errors = append(row.Errors, err.Error())
+			}
+		}
+		for _, email := range template.Assignees {
+			email = strings.TrimSpace(email)
+			if email == "" {
+				continue
+			}
+			userID, ok := userIDs[strings.ToLower(email)]
+			if !ok {
+				row.Errors = append(row.Errors, fmt.Sprintf("no member with email %s", email))
+				continue
+			}
+			plan.assigneeIDs = append(plan.assigneeIDs, userID)
+		}
+		if plan.category != "" {
+			key := strings.ToLower(plan.category)
+			if _, ok := categories[key]; !ok && !newCategories[key] {
+				newCategories[key] = true
+				row.NewCategory = plan.category
+			}
+		}
+
+		if len(row.Errors) > 0 {
+			report.Invalid++
+		}
+		report.Rows = append(report.Rows, row)
+		plans = append(plans, plan)
+	}
+	return report, plans, nil
+}
+
+// applyImportedRule sets the chore's schedule from an RRULE. As with the chore
+// form, COUNT and UNTIL become the series' end conditions. The first
+// occurrence is the first day from today that the rule allows.
+func applyImportedRule(chore *models.Chore, raw string, today time.Time) error {
+	rule, err := ParseRRule(raw)
+	if err != nil {
+		return err
+	}
+	if rule.Count > 0 {
+		count := rule.Count
+		chore.RecurrenceCount = &count
+	}
+	chore.RecurrenceUntil = rule.Until
+
+	first := today
+	if len(rule.ByDay)+len(rule.ByMonthDay)+len(rule.ByYearDay)+len(rule.ByWeekNo)+len(rule.ByMonth)+len(rule.BySetPos) > 0 {
+		next, ok := rule.Next(today.AddDate(0, 0, -1))
+		if !ok {
+			return fmt.Errorf("%w: no occurrences from today", ErrInvalidRecurrenceRule)
+		}
+		first = next
+	}
+	rule.Count = 0
+	rule.Until = nil
+
+	chore.RecurrenceType = rule.RecurrenceType()
+	chore.RecurrenceRule = rule.String()
+	chore.DueDate = &first
+	return nil
+}
+
+// categoryIDs maps lower-cased category names to ids.
+func (service *ChoreImportService) categoryIDs(ctx context.Context) (map[string]string, error) {
+	categories, err := service.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]string, len(categories))
+	for _, category := range categories {
+		ids[strings.ToLower(category.Name)] = category.ID
+	}
+	return ids, nil
+}
+
+// choreTemplateColumns is the CSV header. Assignees are separated by
+// semicolons within their cell.
+var choreTemplateColumns = []string{"name", "description", "category", "recurrence_rule", "due_time", "assignees", "points"}
+
+// DecodeChoreTemplates reads templates as "json" (an array of objects) or
+// "csv" (with a header row naming choreTemplateColumns in any order).
+func DecodeChoreTemplates(format string, reader io.Reader) ([]ChoreTemplate, error) {
+	switch format {
+	case "json":
+		var templates []ChoreTemplate
+		if err := json.NewDecoder(reader).Decode(&templates); err != nil {
+			return nil, fmt.Errorf("reading JSON: %w", err)
+		}
+		return templates, nil
+	case "csv":
+		return decodeChoreTemplatesCSV(reader)
+	default:
+		return nil, ErrUnknownImportFormat
+	}
+}
+
+func decodeChoreTemplatesCSV(reader io.Reader) ([]ChoreTemplate, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, errors.New("CSV needs a name column")
+	}
+	cell := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var templates []ChoreTemplate
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading CSV: %w", err)
+		}
+		template := ChoreTemplate{
+			Name:           cell(record, "name"),
+			Description:    cell(record, "description"),
+			Category:       cell(record, "category"),
+			RecurrenceRule: cell(record, "recurrence_rule"),
+			DueTime:        cell(record, "due_time"),
+		}
+		for _, email := range strings.Split(cell(record, "assignees"), ";") {
+			if email = strings.TrimSpace(email); email != "" {
+				template.Assignees = append(template.Assignees, email)
+			}
+		}
+		// Unreadable points become -1 so validation reports the row.
+		if points := cell(record, "points"); points != "" {
+			template.Points, err = strconv.Atoi(points)
+			if err != nil {
+				template.Points = -1
+			}
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+// EncodeChoreTemplates writes templates in the same formats
+// DecodeChoreTemplates reads.
+func EncodeChoreTemplates(format string, writer io.Writer, templates []ChoreTemplate) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(templates)
+	case "csv":
+		csvWriter := csv.NewWriter(writer)
+		if err := csvWriter.Write(choreTemplateColumns); err != nil {
+			return err
+		}
+		for _, template := range templates {
+			if err := csvWriter.Write([]string{
+				template.Name,
+				template.Description,
+				template.Category,
+				template.RecurrenceRule,
+				template.DueTime,
+				strings.Join(template.Assignees, ";"),
+				strconv.Itoa(template.Points),
+			}); err != nil {
+				return err
+			}
+		}
+		csvWriter.Flush()
+		return csvWriter.Error()
+	default:
+		return ErrUnknownImportFormat
+	}
+}
@@ -0,0 +1,183 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+)
+
+func setupChoreImportService(t *testing.T) (*services.ChoreImportService, *repository.SQLiteChoreRepository, *repository.SQLiteUserRepository, *repository.SQLiteCategoryRepository, *sql.DB) {
+	t.Helper()
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	seriesRepo := repository.NewChoreSeriesRepository(db)
+	categoryRepo := repository.NewCategoryRepository(db)
+	choreService := services.NewChoreService(choreRepo, repository.NewChoreAssignmentRepository(db), userRepo, seriesRepo, repository.NewPointsRepository(db), repository.NewUnavailabilityRepository(db), nil, nil, nil, nil, nil, nil)
+	return services.NewChoreImportService(choreService, seriesRepo, userRepo, categoryRepo), choreRepo, userRepo, categoryRepo, db
+}
+
+func TestChoreImport_RoundTrip(t *testing.T) {
+	service, choreRepo, userRepo, categoryRepo, _ := setupChoreImportService(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 2)
+
+	input := `name,description,category,recurrence_rule,due_time,assignees,points
+Take the bins out,,Household,FREQ=WEEKLY;BYDAY=MO,19:00,alice@test.com;BOB@test.com,2
+Clean the oven,Racks too,Kitchen,FREQ=MONTHLY;BYDAY=1SA,,,4
+Fix the shelf,,,,,,
+`
+	templates, err := services.DecodeChoreTemplates("csv", strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("decoding: %v", err)
+	}
+	report, err := service.Import(ctx, users[0].ID, templates)
+	if err != nil {
+		t.Fatalf("Import: %v (%+v)", err, report)
+	}
+	if report.Invalid != 0 || report.Rows[0].NewCategory != "Household" || report.Rows[2].NewCategory != "" {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	categories, _ := categoryRepo.FindAll(ctx)
+	if len(categories) != 2 {
+		t.Errorf("expected 2 new categories, got %d", len(categories))
+	}
+	chores, _ := choreRepo.FindAll(ctx, repository.ChoreFilter{})
+	oneOffs := 0
+	for _, chore := range chores {
+		if chore.SeriesID == nil {
+			oneOffs++
+		}
+	}
+	if oneOffs != 1 {
+		t.Errorf("expected one one-off chore, got %d", oneOffs)
+	}
+
+	exported, err := service.Export(ctx)
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if len(exported) != 2 {
+		t.Fatalf("expected the two series exported, got %+v", exported)
+	}
+	bins := exported[1]
+	if bins.Name != "Take the bins out" || bins.Category != "Household" || bins.DueTime != "19:00" || bins.Points != 2 {
+		t.Errorf("unexpected export %+v", bins)
+	}
+	if !strings.Contains(bins.RecurrenceRule, "FREQ=WEEKLY") || !strings.Contains(bins.RecurrenceRule, "BYDAY=MO") {
+		t.Errorf("unexpected rule %q", bins.RecurrenceRule)
+	}
+	if len(bins.Assignees) != 2 {
+		t.Errorf("expected both assignees exported, got %v", bins.Assignees)
+	}
+
+	// An export must import cleanly into the same household.
+	var buffer bytes.Buffer
+	if err := services.EncodeChoreTemplates("csv", &buffer, exported); err != nil {
+		t.Fatalf("encoding: %v", err)
+	}
+	again, err := services.DecodeChoreTemplates("csv", &buffer)
+	if err != nil {
+		t.Fatalf("decoding export: %v", err)
+	}
+	report, err = service.Preview(ctx, again)
+	if err != nil || report.Invalid != 0 {
+		t.Errorf("expected the export to validate, got %v %+v", err, report)
+	}
+}
+
+func TestChoreImport_InvalidRowsImportNothing(t *testing.T) {
+	service, choreRepo, userRepo, _, _ := setupChoreImportService(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 1)
+
+	templates := []services.ChoreTemplate{
+		{Name: "Good", RecurrenceRule: "FREQ=DAILY", Points: 1},
+		{Name: "", RecurrenceRule: "FREQ=DAILY"},
+		{Name: "Bad rule", RecurrenceRule: "FREQ=SOMETIMES"},
+		{Name: "Bad time", DueTime: "7pm"},
+		{Name: "Stranger", Assignees: []string{"nobody@test.com"}},
+	}
+	report, err := service.Import(ctx, users[0].ID, templates)
+	if !errors.Is(err, services.ErrImportInvalid) {
+		t.Fatalf("expected ErrImportInvalid, got %v", err)
+	}
+	if report.Invalid != 4 || len(report.Rows[0].Errors) != 0 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	for _, row := range report.Rows[1:] {
+		if len(row.Errors) == 0 {
+			t.Errorf("row %d: expected an error", row.Row)
+		}
+	}
+
+	chores, _ := choreRepo.FindAll(ctx, repository.ChoreFilter{})
+	if len(chores) != 0 {
+		t.Errorf("expected nothing imported, got %d chores", len(chores))
+	}
+
+	if _, err := service.Import(ctx, users[0].ID, nil); !errors.Is(err, services.ErrImportEmpty) {
+		t.Errorf("expected ErrImportEmpty, got %v", err)
+	}
+}
+
+func TestChoreImport_FailurePartwayReportsCreatedRows(t *testing.T) {
+	service, choreRepo, userRepo, categoryRepo, db := setupChoreImportService(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 1)
+
+	// The database refuses the second chore, after the first has been created.
+	if _, err := db.Exec(`CREATE TRIGGER refuse_chore BEFORE INSERT ON chores
+		WHEN NEW.name = 'Refused' BEGIN SELECT RAISE(ABORT, 'refused'); END`); err != nil {
+		t.Fatalf("creating trigger: %v", err)
+	}
+	templates := []services.ChoreTemplate{
+		{Name: "Sweep the porch", Category: "Garden"},
+		{Name: "Refused", Category: "Garage"},
+		{Name: "Never reached"},
+	}
+	report, err := service.Import(ctx, users[0].ID, templates)
+	if err == nil {
+		t.Fatal("expected the import to fail at the second row")
+	}
+	if report.Created != 1 || !report.Rows[0].Created || report.Rows[1].Created || report.Rows[2].Created {
+		t.Errorf("expected only the first row reported created, got %+v", report)
+	}
+
+	chores, _ := choreRepo.FindAll(ctx, repository.ChoreFilter{})
+	if len(chores) != 1 || chores[0].Name != "Sweep the porch" {
+		t.Errorf("expected the first chore to stay imported, got %+v", chores)
+	}
+	if categories, _ := categoryRepo.FindAll(ctx); len(categories) != 2 {
+		t.Errorf("expected both categories created before the failure, got %d", len(categories))
+	}
+}
+
+func TestChoreImport_LibraryIsValid(t *testing.T) {
+	service, _, _, _, _ := setupChoreImportService(t)
+
+	var keys []string
+	for _, chore := range services.Library() {
+		keys = append(keys, chore.Key)
+	}
+	templates, err := services.LibraryTemplates(keys)
+	if err != nil {
+		t.Fatalf("LibraryTemplates: %v", err)
+	}
+	report, err := service.Preview(context.Background(), templates)
+	if err != nil || report.Invalid != 0 {
+		t.Errorf("expected every starter chore to validate, got %v %+v", err, report)
+	}
+
+	if _, err := services.LibraryTemplates([]string{"juggling"}); !errors.Is(err, services.ErrUnknownLibraryChore) {
+		t.Errorf("expected ErrUnknownLibraryChore, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package services
+
+import "fmt"
+
+// LibraryChore is a starter chore an admin can add in one click. Key is stable
+// across releases so clients can refer to entries.
+type LibraryChore struct {
+	Key string
+	ChoreTemplate
+}
+
+// choreLibrary is the built-in starter set, grouped by category. Points are a
+// rough effort guide: 1 for a few minutes' work, up to 4 for a big job.
+var choreLibrary = []LibraryChore{
+	{"dishes", ChoreTemplate{Name: "Wash the dishes", Category: "Kitchen", RecurrenceRule: "FREQ=DAILY", DueTime: "20:00", Points: 2}},
+	{"dishwasher", ChoreTemplate{Name: "Empty the dishwasher", Category: "Kitchen", RecurrenceRule: "FREQ=DAILY", DueTime: "08:00", Points: 1}},
+	{"wipe-counters", ChoreTemplate{Name: "Wipe kitchen counters", Category: "Kitchen", RecurrenceRule: "FREQ=DAILY", Points: 1}},
+	{"fridge", ChoreTemplate{Name: "Clear out the fridge", Description: "Bin anything out of date and wipe the shelves.", Category: "Kitchen", RecurrenceRule: "FREQ=WEEKLY;BYDAY=SU", Points: 2}},
+	{"oven", ChoreTemplate{Name: "Clean the oven", Category: "Kitchen", RecurrenceRule: "FREQ=MONTHLY;BYDAY=1SA", Points: 4}},
+
+	{"bins", ChoreTemplate{Name: "Take the bins out", Category: "Household", RecurrenceRule: "FREQ=WEEKLY;BYDAY=MO", DueTime: "19:00", Points: 1}},
+	{"recycling", ChoreTemplate{Name: "Sort the recycling", Category: "Household", RecurrenceRule: "FREQ=WEEKLY;BYDAY=SU", Points: 1}},
+	{"tidy-living-room", ChoreTemplate{Name: "Tidy the living room", Category: "Household", RecurrenceRule: "FREQ=DAILY", DueTime: "19:30", Points: 1}},
+	{"make-bed", ChoreTemplate{Name: "Make your bed", Category: "Household", RecurrenceRule: "FREQ=DAILY", DueTime: "09:00", Points: 1}},
+
+	{"hoover", ChoreTemplate{Name: "Hoover downstairs", Category: "Cleaning", RecurrenceRule: "FREQ=WEEKLY;BYDAY=SA", Points: 3}},
+	{"mop", ChoreTemplate{Name: "Mop the floors", Category: "Cleaning", RecurrenceRule: "FREQ=WEEKLY;BYDAY=SA", Points: 3}},
+	{"dust", ChoreTemplate{Name: "Dust surfaces", Category: "Cleaning", RecurrenceRule: "FREQ=WEEKLY;BYDAY=WE", Points: 2}},
+	{"bathroom", ChoreTemplate{Name: "Clean the bathroom", Description: "Sink, toilet, bath or shower, mirror and floor.", Category: "Cleaning", RecurrenceRule: "FREQ=WEEKLY;BYDAY=SU", Points: 4}},
+	{"windows", ChoreTemplate{Name: "Clean the windows", Category: "Cleaning", RecurrenceRule: "FREQ=MONTHLY;BYDAY=-1SU", Points: 4}},
+
+	{"laundry", ChoreTemplate{Name: "Put a wash on", Category: "Laundry", RecurrenceRule: "FREQ=WEEKLY;BYDAY=MO,TH", Points: 2}},
+	{"fold-laundry", ChoreTemplate{Name: "Fold and put away laundry", Category: "Laundry", RecurrenceRule: "FREQ=WEEKLY;BYDAY=TU,FR", Points: 2}},
+	{"bedding", ChoreTemplate{Name: "Change the bedding", Category: "Laundry", RecurrenceRule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=SU", Points: 3}},
+
+	{"feed-pets", ChoreTemplate{Name: "Feed the pets", Category: "Pets", RecurrenceRule: "FREQ=DAILY", DueTime: "07:30", Points: 1}},
+	{"walk-dog", ChoreTemplate{Name: "Walk the dog", Category: "Pets", RecurrenceRule: "FREQ=DAILY", DueTime: "17:30", Points: 2}},
+	{"litter", ChoreTemplate{Name: "Clean the litter tray", Category: "Pets", RecurrenceRule: "FREQ=DAILY", Points: 1}},
+
+	{"water-plants", ChoreTemplate{Name: "Water the plants", Category: "Garden", RecurrenceRule: "FREQ=WEEKLY;BYDAY=WE,SA", Points: 1}},
+	{"mow-lawn", ChoreTemplate{Name: "Mow the lawn", Category: "Garden", RecurrenceRule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=SA;BYMONTH=4,5,6,7,8,9", Points: 4}},
+	{"weed", ChoreTemplate{Name: "Weed the garden", Category: "Garden", RecurrenceRule: "FREQ=MONTHLY;BYDAY=2SA", Points: 3}},
+}
+
+// Library lists the starter chores.
+func Library() []LibraryChore {
+	return choreLibrary
+}
+
+// LibraryTemplates returns the templates for the given library keys, in the
+// order asked for.
+func LibraryTemplates(keys []string) ([]ChoreTemplate, error) {
+	byKey := make(map[string]ChoreTemplate, len(choreLibrary))
+	for _, chore := range choreLibrary {
+		byKey[chore.Key] = chore.ChoreTemplate
+	}
+	templates := make([]ChoreTemplate, 0, len(keys))
+	for _, key := range keys {
+		template, ok := byKey[key]
+		if !ok {
+			return nil, fmt.Errorf("%w %q", ErrUnknownLibraryChore, key)
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+)
+
+const (
+	// dueSoonLead is how long before its due time a chore is reminded of.
+	dueSoonLead = time.Hour
+	// untimedReminderHour is when, on its due day, a chore without a due
+	// time is reminded of.
+	untimedReminderHour = 8
+)
+
+// notifyAssigned tells whoever has just been given the chore: its assignee
+// or each participant of a group chore. Webhooks are sent chore.assigned.
+func (service *ChoreService) notifyAssigned(ctx context.Context, chore models.Chore) {
+	userIDs := holders(chore)
+	if len(userIDs) == 0 {
+		return
+	}
+	service.notifications.Notify(ctx, userIDs, models.Notification{
+		Event: models.NotifyChoreAssigned,
+		Title: "New chore: " + chore.Name,
+		Body:  dueLabel(chore),
+		Link:  "/chores",
+	})
+	service.webhooks.Emit(ctx, models.WebhookChoreAssigned, chore)
+}
+
+// RemindDueSoon reminds whoever still has a pending chore to do as it comes
+// due: dueSoonLead before its due time or, without one, from
+// untimedReminderHour on its due day. Each occurrence is reminded of once
+// per due date and time, so snoozing it brings a fresh reminder.
+func (service *ChoreService) RemindDueSoon(ctx context.Context) error {
+	if service.notifications == nil {
+		return nil
+	}
+
+	now := service.now(ctx)
+	location := now.Location()
+	today := repository.CivilDate(now)
+	pendingStatus := models.ChoreStatusPending
+	pending, err := service.choreRepo.FindAll(ctx, repository.ChoreFilter{Status: &pendingStatus, DueBefore: &today})
+	if err != nil {
+		return fmt.Errorf("finding pending chores: %w", err)
+	}
+
+	for _, chore := range pending {
+		if chore.DueDate == nil {
+			continue
+		}
+		remindAt := reminderTime(chore, location)
+		if now.Before(remindAt) || !now.Before(overdueSince(chore, location)) {
+			continue
+		}
+		dedupeKey := fmt.Sprintf("due_soon:%s:%s", chore.ID, remindAt.UTC().Format(time.RFC3339))
+		service.notifications.Notify(ctx, outstandingUsers(chore), models.Notification{
+			Event:     models.NotifyChoreDueSoon,
+			Title:     "Due soon: " + chore.Name,
+			Body:      dueLabel(chore),
+			Link:      "/chores",
+			DedupeKey: &dedupeKey,
+		})
+	}
+	return nil
+}
+
+// notifyOverdue tells whoever still has the chore to do that it has fallen
+// overdue, and webhooks are sent chore.overdue.
+func (service *ChoreService) notifyOverdue(ctx context.Context, chore models.Chore) {
+	dedupeKey := "overdue:" + chore.ID
+	service.notifications.Notify(ctx, outstandingUsers(chore), models.Notification{
+		Event:     models.NotifyChoreOverdue,
+		Title:     "Overdue: " + chore.Name,
+		Body:      dueLabel(chore),
+		Link:      "/chores",
+		DedupeKey: &dedupeKey,
+	})
+	service.webhooks.Emit(ctx, models.WebhookChoreOverdue, chore)
+}
+
+// reminderTime is when a pending chore is reminded of, in location.
+func reminderTime(chore models.Chore, location *time.Location) time.Time {
+	dueDate := *chore.DueDate
+	if chore.DueTime != nil {
+		if parsed, err := time.Parse("15:04", *chore.DueTime); err == nil {
+			due := time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), parsed.Hour(), parsed.Minute(), 0, 0, location)
+			return due.Add(-dueSoonLead)
+		}
+	}
+	return time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), untimedReminderHour, 0, 0, 0, location)
+}
+
+// holders is everyone the chore is assigned to.
+func holders(chore models.Chore) []string {
+	if chore.AssignedToUserID != nil {
+		return []string{*chore.AssignedToUserID}
+	}
+	return chore.Participants
+}
+
+// outstandingUsers is whoever still has the chore to do: its assignee, or
+// each participant of a group chore whose part is outstanding.
+func outstandingUsers(chore models.Chore) []string {
+	var outstanding []string
+	if chore.AssignedToUserID != nil {
+		outstanding = append(outstanding, *chore.AssignedToUserID)
+	}
+	for _, userID := range chore.Participants {
+		if !slices.Contains(chore.PartsDone, userID) {
+			outstanding = append(outstanding, userID)
+		}
+	}
+	return outstanding
+}
+
+// dueLabel says when a chore is due, for a notification.
+func dueLabel(chore models.Chore) string {
+	if chore.DueDate == nil {
+		return "No due date."
+	}
+	label := "Due " + chore.DueDate.Format("Mon 2 Jan")
+	if chore.DueTime != nil {
+		label += " at " + *chore.DueTime
+	}
+	return label + "."
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+)
+
+var (
+	ErrSwapNotAssignee = errors.New("chore is not assigned to you")
+	ErrSwapInvalid     = errors.New("invalid swap request")
+	ErrSwapNotPending  = errors.New("swap has already been answered")
+	ErrSwapForbidden   = errors.New("swap belongs to another user")
+)
+
+// ChoreSwapService runs the trade/handoff workflow between family members. A
+// swap is proposed by the offered chore's assignee and only changes any
+// assignment once the other user accepts it. Chores that change hands are
+// announced by the chore service, as any other assignment is.
+type ChoreSwapService struct {
+	swapRepo      repository.ChoreSwapRepository
+	choreRepo     repository.ChoreRepository
+	userRepo      repository.UserRepository
+	notifications *NotificationService
+	chores        *ChoreService
+}
+
+func NewChoreSwapService(
+	swapRepo repository.ChoreSwapRepository,
+	choreRepo repository.ChoreRepository,
+	userRepo repository.UserRepository,
+	notifications *NotificationService,
+	chores *ChoreService,
+) *ChoreSwapService {
+	return &ChoreSwapService{
+		swapRepo:      swapRepo,
+		choreRepo:     choreRepo,
+		userRepo:      userRepo,
+		notifications: notifications,
+		chores:        chores,
+	}
+}
+
+// ProposeSwap offers fromUserID's open chore to toUserID. With a
+// requestedChoreID it proposes a trade for one of toUserID's open chores;
+// without one it is a one-way handoff.
+func (service *ChoreSwapService) ProposeSwap(ctx context.Context, fromUserID, choreID, toUserID string, requestedChoreID *string) (models.ChoreSwap, error) {
+	chore, err := service.choreRepo.FindByID(ctx, choreID)
+	if err != nil {
+		return models.ChoreSwap{}, fmt.Errorf("finding chore: %w", err)
+	}
+	if !choreIsOpen(chore) {
+		return models.ChoreSwap{}, ErrChoreNotOpen
+	}
+	if chore.AssignedToUserID == nil || *chore.AssignedToUserID != fromUserID {
+		return models.ChoreSwap{}, ErrSwapNotAssignee
+	}
+
+	if toUserID == "" || toUserID == fromUserID {
+		return models.ChoreSwap{}, ErrSwapInvalid
+	}
+	if _, err := service.userRepo.FindByID(ctx, toUserID); err != nil {
+		return models.ChoreSwap{}, ErrSwapInvalid
+	}
+
+	offer := "hand you " + chore.Name
+	if requestedChoreID != nil {
+		if *requestedChoreID == choreID {
+			return models.ChoreSwap{}, ErrSwapInvalid
+		}
+		requested, err := service.choreRepo.FindByID(ctx, *requestedChoreID)
+		if err != nil || !choreIsOpen(requested) ||
+			requested.AssignedToUserID == nil || *requested.AssignedToUserID != toUserID {
+			return models.ChoreSwap{}, ErrSwapInvalid
+		}
+		offer = "trade " + chore.Name + " for your " + requested.Name
+	}
+
+	swap, err := service.swapRepo.Create(ctx, models.ChoreSwap{
+		ChoreID:          choreID,
+		RequestedChoreID: requestedChoreID,
+		FromUserID:       fromUserID,
+		ToUserID:         toUserID,
+	})
+	if err != nil {
+		return models.ChoreSwap{}, err
+	}
+
+	service.notifications.Notify(ctx, []string{toUserID}, models.Notification{
+		Event: models.NotifySwapRequested,
+		Title: "Swap request: " + chore.Name,
+		Body:  service.notifications.userName(ctx, fromUserID) + " wants to " + offer + ".",
+		Link:  "/chores",
+	})
+	return swap, nil
+}
+
+// AcceptSwap applies a swap on behalf of its recipient. Both chores change
+// hands atomically, and each new holder is told about theirs; other open
+// swaps involving either chore are cancelled because they no longer describe
+// who holds what. A swap whose chores have moved on in the meantime is
+// cancelled and repository.ErrSwapStale returned.
+func (service *ChoreSwapService) AcceptSwap(ctx context.Context, swapID, userID string) error {
+	swap, err := service.respondableSwap(ctx, swapID)
+	if err != nil {
+		return err
+	}
+	if swap.ToUserID != userID {
+		return ErrSwapForbidden
+	}
+
+	if err := service.swapRepo.Accept(ctx, swap); err != nil {
+		if errors.Is(err, repository.ErrSwapStale) {
+			if err := service.swapRepo.UpdateStatus(ctx, swap.ID, models.ChoreSwapCancelled); err != nil {
+				return fmt.Errorf("cancelling stale swap: %w", err)
+			}
+		}
+		return err
+	}
+
+	choreIDs := []string{swap.ChoreID}
+	if swap.RequestedChoreID != nil {
+		choreIDs = append(choreIDs, *swap.RequestedChoreID)
+	}
+	for _, choreID := range choreIDs {
+		chore, err := service.choreRepo.FindByID(ctx, choreID)
+		if err != nil {
+			return fmt.Errorf("finding swapped chore: %w", err)
+		}
+		service.chores.notifyAssigned(ctx, chore)
+
+		others, err := service.swapRepo.FindPendingByChore(ctx, choreID)
+		if err != nil {
+			return fmt.Errorf("finding overlapping swaps: %w", err)
+		}
+		for _, other := range others {
+			if err := service.swapRepo.UpdateStatus(ctx, other.ID, models.ChoreSwapCancelled); err != nil {
+				return fmt.Errorf("cancelling overlapping swap: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// DeclineSwap rejects a swap on behalf of its recipient.
+func (service *ChoreSwapService) DeclineSwap(ctx context.Context, swapID, userID string) error {
+	swap, err := service.respondableSwap(ctx, swapID)
+	if err != nil {
+		return err
+	}
+	if swap.ToUserID != userID {
+		return ErrSwapForbidden
+	}
+	return service.swapRepo.UpdateStatus(ctx, swap.ID, models.ChoreSwapDeclined)
+}
+
+// CancelSwap withdraws a swap on behalf of the user who proposed it.
+func (service *ChoreSwapService) CancelSwap(ctx context.Context, swapID, userID string) error {
+	swap, err := service.respondableSwap(ctx, swapID)
+	if err != nil {
+		return err
+	}
+	if swap.FromUserID != userID {
+		return ErrSwapForbidden
+	}
+	return service.swapRepo.UpdateStatus(ctx, swap.ID, models.ChoreSwapCancelled)
+}
+
+// PendingSwaps returns the open swaps the user proposed or has to answer.
+func (service *ChoreSwapService) PendingSwaps(ctx context.Context, userID string) ([]models.ChoreSwap, error) {
+	return service.swapRepo.FindPendingByUser(ctx, userID)
+}
+
+func (service *ChoreSwapService) respondableSwap(ctx context.Context, swapID string) (models.ChoreSwap, error) {
+	swap, err := service.swapRepo.FindByID(ctx, swapID)
+	if err != nil {
+		return swap, fmt.Errorf("finding swap: %w", err)
+	}
+	if swap.Status != models.ChoreSwapPending {
+		return swap, ErrSwapNotPending
+	}
+	return swap, nil
+}
+
+func choreIsOpen(chore models.Chore) bool {
+	return chore.Status == models.ChoreStatusPending || chore.Status == models.ChoreStatusOverdue
+}
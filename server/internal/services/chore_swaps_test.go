@@ -0,0 +1,170 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+)
+
+func setupChoreSwapService(t *testing.T) (
+	*services.ChoreSwapService,
+	*repository.SQLiteChoreRepository,
+	*repository.SQLiteChoreSwapRepository,
+	[]models.User,
+) {
+	t.Helper()
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	swapRepo := repository.NewChoreSwapRepository(db)
+	users := createUsers(t, userRepo, 3)
+	chores := services.NewChoreService(choreRepo, repository.NewChoreAssignmentRepository(db), userRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	return services.NewChoreSwapService(swapRepo, choreRepo, userRepo, nil, chores), choreRepo, swapRepo, users
+}
+
+func createOpenChore(t *testing.T, choreRepo *repository.SQLiteChoreRepository, name, userID string) models.Chore {
+	t.Helper()
+	chore, err := choreRepo.Create(context.Background(), models.Chore{
+		Name:             name,
+		CreatedByUserID:  userID,
+		AssignedToUserID: &userID,
+		Status:           models.ChoreStatusPending,
+	})
+	if err != nil {
+		t.Fatalf("creating chore %s: %v", name, err)
+	}
+	return chore
+}
+
+func TestChoreSwapService_HandoffAccepted(t *testing.T) {
+	service, choreRepo, _, users := setupChoreSwapService(t)
+	ctx := context.Background()
+	alice, bob := users[0], users[1]
+
+	dishes := createOpenChore(t, choreRepo, "Dishes", alice.ID)
+
+	swap, err := service.ProposeSwap(ctx, alice.ID, dishes.ID, bob.ID, nil)
+	if err != nil {
+		t.Fatalf("ProposeSwap: %v", err)
+	}
+
+	if err := service.AcceptSwap(ctx, swap.ID, alice.ID); !errors.Is(err, services.ErrSwapForbidden) {
+		t.Errorf("proposer accepting own swap: got %v, want ErrSwapForbidden", err)
+	}
+	if err := service.AcceptSwap(ctx, swap.ID, bob.ID); err != nil {
+		t.Fatalf("AcceptSwap: %v", err)
+	}
+
+	updated, _ := choreRepo.FindByID(ctx, dishes.ID)
+	if *updated.AssignedToUserID != bob.ID {
+		t.Errorf("expected dishes handed to bob, got %s", *updated.AssignedToUserID)
+	}
+	if err := service.DeclineSwap(ctx, swap.ID, bob.ID); !errors.Is(err, services.ErrSwapNotPending) {
+		t.Errorf("answering twice: got %v, want ErrSwapNotPending", err)
+	}
+}
+
+func TestChoreSwapService_AcceptCancelsOverlappingSwaps(t *testing.T) {
+	service, choreRepo, swapRepo, users := setupChoreSwapService(t)
+	ctx := context.Background()
+	alice, bob, charlie := users[0], users[1], users[2]
+
+	dishes := createOpenChore(t, choreRepo, "Dishes", alice.ID)
+	bins := createOpenChore(t, choreRepo, "Bins", bob.ID)
+
+	trade, err := service.ProposeSwap(ctx, alice.ID, dishes.ID, bob.ID, &bins.ID)
+	if err != nil {
+		t.Fatalf("ProposeSwap trade: %v", err)
+	}
+	handoff, err := service.ProposeSwap(ctx, alice.ID, dishes.ID, charlie.ID, nil)
+	if err != nil {
+		t.Fatalf("ProposeSwap handoff: %v", err)
+	}
+
+	if err := service.AcceptSwap(ctx, trade.ID, bob.ID); err != nil {
+		t.Fatalf("AcceptSwap: %v", err)
+	}
+
+	dishes, _ = choreRepo.FindByID(ctx, dishes.ID)
+	bins, _ = choreRepo.FindByID(ctx, bins.ID)
+	if *dishes.AssignedToUserID != bob.ID || *bins.AssignedToUserID != alice.ID {
+		t.Errorf("trade not applied: dishes=%s bins=%s", *dishes.AssignedToUserID, *bins.AssignedToUserID)
+	}
+
+	cancelled, _ := swapRepo.FindByID(ctx, handoff.ID)
+	if cancelled.Status != models.ChoreSwapCancelled {
+		t.Errorf("expected overlapping handoff cancelled, got %s", cancelled.Status)
+	}
+}
+
+func TestChoreSwapService_ProposeValidation(t *testing.T) {
+	service, choreRepo, _, users := setupChoreSwapService(t)
+	ctx := context.Background()
+	alice, bob, charlie := users[0], users[1], users[2]
+
+	dishes := createOpenChore(t, choreRepo, "Dishes", alice.ID)
+	bins := createOpenChore(t, choreRepo, "Bins", bob.ID)
+
+	if _, err := service.ProposeSwap(ctx, bob.ID, dishes.ID, charlie.ID, nil); !errors.Is(err, services.ErrSwapNotAssignee) {
+		t.Errorf("offering someone else's chore: got %v, want ErrSwapNotAssignee", err)
+	}
+	if _, err := service.ProposeSwap(ctx, alice.ID, dishes.ID, alice.ID, nil); !errors.Is(err, services.ErrSwapInvalid) {
+		t.Errorf("swapping with yourself: got %v, want ErrSwapInvalid", err)
+	}
+	if _, err := service.ProposeSwap(ctx, alice.ID, dishes.ID, charlie.ID, &bins.ID); !errors.Is(err, services.ErrSwapInvalid) {
+		t.Errorf("requesting a chore the recipient does not hold: got %v, want ErrSwapInvalid", err)
+	}
+}
+
+func TestChoreSwapService_AcceptAnnouncesNewHolders(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	webhookRepo := repository.NewWebhookRepository(db)
+	webhooks := services.NewWebhookService(webhookRepo)
+	chores := services.NewChoreService(choreRepo, repository.NewChoreAssignmentRepository(db), userRepo, nil, nil, nil, nil, nil, nil, nil, nil, webhooks)
+	service := services.NewChoreSwapService(repository.NewChoreSwapRepository(db), choreRepo, userRepo, nil, chores)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 2)
+	alice, bob := users[0], users[1]
+
+	webhook, err := webhooks.Register(ctx, models.Webhook{
+		URL:    "http://homeassistant.local/hook",
+		Events: []models.WebhookEvent{models.WebhookChoreAssigned},
+		Active: true,
+	})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	dishes := createOpenChore(t, choreRepo, "Dishes", alice.ID)
+	bins := createOpenChore(t, choreRepo, "Bins", bob.ID)
+	swap, err := service.ProposeSwap(ctx, alice.ID, dishes.ID, bob.ID, &bins.ID)
+	if err != nil {
+		t.Fatalf("ProposeSwap: %v", err)
+	}
+	if err := service.AcceptSwap(ctx, swap.ID, bob.ID); err != nil {
+		t.Fatalf("AcceptSwap: %v", err)
+	}
+
+	deliveries, _ := webhookRepo.FindDeliveriesByWebhook(ctx, webhook.ID, 10)
+	holders := map[string]string{}
+	for _, delivery := range deliveries {
+		var payload struct {
+			Data models.Chore `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(delivery.Payload), &payload); err != nil {
+			t.Fatalf("decoding payload: %v", err)
+		}
+		holders[payload.Data.ID] = *payload.Data.AssignedToUserID
+	}
+	if len(holders) != 2 || holders[dishes.ID] != bob.ID || holders[bins.ID] != alice.ID {
+		t.Errorf("expected chore.assigned for both traded chores to their new holders, got %v", holders)
+	}
+}
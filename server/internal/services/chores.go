@@ -13,8 +13,14 @@ import (
 )
 
 var (
-	ErrUserHasOverdueChores = errors.New("user has overdue chores")
-	ErrChoreAlreadyComplete = errors.New("chore is already completed")
+	ErrUserHasOverdueChores     = errors.New("user has overdue chores")
+	ErrChoreAlreadyComplete     = errors.New("chore is already completed")
+	ErrChoreNotOpen             = errors.New("chore is not pending or overdue")
+	ErrSnoozeInPast             = errors.New("snooze date is in the past")
+	ErrChoreAwaitingApproval    = errors.New("chore is already awaiting approval")
+	ErrChoreNotAwaitingApproval = errors.New("chore is not awaiting approval")
+	ErrChoreNotCompleted        = errors.New("chore is not completed")
+	ErrUndoForbidden            = errors.New("only admins can undo someone else's completion")
 )
 
 // SeedHorizon is how far ahead fixed-schedule recurring chores are materialized.
@@ -26,10 +32,18 @@ const SeedHorizon = 75 * 24 * time.Hour
 func SeedHorizonFrom(now time.Time) time.Time { return now.Add(SeedHorizon) }
 
 type ChoreService struct {
-	choreRepo      repository.ChoreRepository
-	assignmentRepo repository.ChoreAssignmentRepository
-	userRepo       repository.UserRepository
-	seriesRepo     repository.ChoreSeriesRepository
+	choreRepo          repository.ChoreRepository
+	assignmentRepo     repository.ChoreAssignmentRepository
+	userRepo           repository.UserRepository
+	seriesRepo         repository.ChoreSeriesRepository
+	pointsRepo         repository.PointsRepository
+	unavailabilityRepo repository.UnavailabilityRepository
+	calendarEvents     CalendarEventSource
+	settingsRepo       repository.SettingsRepository
+	escalationRepo     repository.ChoreEscalationRepository
+	timeSegmentRepo    repository.ChoreTimeSegmentRepository
+	notifications      *NotificationService
+	webhooks           *WebhookService
 }
 
 func NewChoreService(
@@ -37,27 +51,55 @@ func NewChoreService(
 	assignmentRepo repository.ChoreAssignmentRepository,
 	userRepo repository.UserRepository,
 	seriesRepo repository.ChoreSeriesRepository,
+	pointsRepo repository.PointsRepository,
+	unavailabilityRepo repository.UnavailabilityRepository,
+	calendarEvents CalendarEventSource,
+	settingsRepo repository.SettingsRepository,
+	escalationRepo repository.ChoreEscalationRepository,
+	timeSegmentRepo repository.ChoreTimeSegmentRepository,
+	notifications *NotificationService,
+	webhooks *WebhookService,
 ) *ChoreService {
 	return &ChoreService{
-		choreRepo:      choreRepo,
-		assignmentRepo: assignmentRepo,
-		userRepo:       userRepo,
-		seriesRepo:     seriesRepo,
+		choreRepo:          choreRepo,
+		assignmentRepo:     assignmentRepo,
+		userRepo:           userRepo,
+		seriesRepo:         seriesRepo,
+		pointsRepo:         pointsRepo,
+		unavailabilityRepo: unavailabilityRepo,
+		calendarEvents:     calendarEvents,
+		settingsRepo:       settingsRepo,
+		escalationRepo:     escalationRepo,
+		timeSegmentRepo:    timeSegmentRepo,
+		notifications:      notifications,
+		webhooks:           webhooks,
 	}
 }
 
+// Location is the household timezone that day boundaries, due times and
+// overdue checks are evaluated in.
+func (service *ChoreService) Location(ctx context.Context) *time.Location {
+	return HouseholdLocation(ctx, service.settingsRepo)
+}
+
+// now is the current instant in the household timezone.
+func (service *ChoreService) now(ctx context.Context) time.Time {
+	return time.Now().In(service.Location(ctx))
+}
+
 func (service *ChoreService) AssignNextUser(ctx context.Context, chore models.Chore) (models.Chore, error) {
 	return service.assignNextUser(ctx, chore, chore.AssignedToUserID)
 }
 
-// assignNextUser assigns the chore to the next user in rotation. Rotation is
+// assignNextUser assigns the chore to the next user under its series'
+// assignment strategy (round-robin by default, see chooseAssignee). Rotation is
 // anchored to lastAssignedUserID — the previous occupant, or the previous
 // occurrence's assignee when seeding a series — so it survives users being
 // added, removed, or renamed. It falls back to the positional LastAssignedIndex
 // only when no previous assignee is known (e.g. the first assignment of a
-// brand-new chore). A user with outstanding overdue chores is skipped within a
-// single lap, but if everyone is overdue the rotation still advances rather than
-// always landing on the same person.
+// brand-new chore). Round-robin skips a user with outstanding overdue chores
+// within a single lap, but if everyone is overdue the rotation still advances
+// rather than always landing on the same person.
 func (service *ChoreService) assignNextUser(ctx context.Context, chore models.Chore, lastAssignedUserID *string) (models.Chore, error) {
 	series := service.loadSeries(ctx, chore.SeriesID)
 
@@ -77,33 +119,22 @@ func (service *ChoreService) assignNextUser(ctx context.Context, chore models.Ch
 		lastAssignedUserID = series.RotationCursorUserID
 	}
 
-	start := rotationStart(candidates, lastAssignedUserID, chore.LastAssignedIndex)
-
-	chosenIndex := start
-	for attempts := 0; attempts < len(candidates); attempts++ {
-		candidateIndex := (start + attempts) % len(candidates)
-
-		overdueCount, err := service.choreRepo.CountByStatusAndUser(ctx, models.ChoreStatusOverdue, candidates[candidateIndex].ID)
-		if err != nil {
-			return chore, fmt.Errorf("checking overdue chores: %w", err)
-		}
-
-		if overdueCount == 0 {
-			chosenIndex = candidateIndex
-			break
-		}
+	assignedUser, participants, err := service.nextAssignment(ctx, applySeriesRule(chore, series), candidates, lastAssignedUserID)
+	if err != nil {
+		return chore, err
+	}
+	if participants != nil {
+		return service.assignGroup(ctx, chore, participants)
 	}
 
-	assignedUser := candidates[chosenIndex]
-
-	if chore.AssignedToUserID != nil {
+	if chore.AssignedToUserID != nil || len(chore.Participants) > 0 {
 		if err := service.assignmentRepo.MarkReassigned(ctx, chore.ID); err != nil {
 			return chore, fmt.Errorf("marking old assignment: %w", err)
 		}
 	}
 
 	chore.AssignedToUserID = &assignedUser.ID
-	chore.LastAssignedIndex = chosenIndex
+	chore.LastAssignedIndex = candidateIndex(candidates, assignedUser.ID)
 
 	_, err = service.assignmentRepo.Create(ctx, models.ChoreAssignment{
 		ChoreID: chore.ID,
@@ -129,6 +160,29 @@ func (service *ChoreService) assignNextUser(ctx context.Context, chore models.Ch
 	return chore, nil
 }
 
+// nextAssignment picks who the chore goes to from the candidates, continuing
+// the rotation after lastAssignedUserID: the user its assignment strategy
+// chooses from those around on its due date or, for a group chore, all of
+// them as participants (with a nil user). It only reads, so a preview walks a
+// draft series through the same choices that assigning it would make.
+func (service *ChoreService) nextAssignment(ctx context.Context, chore models.Chore, candidates []models.User, lastAssignedUserID *string) (models.User, []models.User, error) {
+	start := rotationStart(candidates, lastAssignedUserID, chore.LastAssignedIndex)
+
+	available, availableStart, err := service.availableOn(ctx, candidates, start, service.occurrenceDay(ctx, chore))
+	if err != nil {
+		return models.User{}, nil, err
+	}
+	if chore.GroupChore {
+		return models.User{}, available, nil
+	}
+
+	chosenIndex, err := service.chooseAssignee(ctx, chore, available, availableStart)
+	if err != nil {
+		return models.User{}, nil, err
+	}
+	return available[chosenIndex], nil, nil
+}
+
 // rotationStart returns the index of the candidate that should be tried first.
 // When the previously assigned user is still in the candidate list, rotation
 // continues from the person after them (robust to membership changes). Otherwise
@@ -145,7 +199,27 @@ func rotationStart(candidates []models.User, lastAssignedUserID *string, lastInd
 	return ((lastIndex+1)%n + n) % n
 }
 
+// candidateIndex is the user's position in the full candidate pool, which is
+// what LastAssignedIndex records.
+func candidateIndex(candidates []models.User, userID string) int {
+	for i, user := range candidates {
+		if user.ID == userID {
+			return i
+		}
+	}
+	return 0
+}
+
+// CompleteChore records userID finishing the chore. See CompleteChoreWithProof.
 func (service *ChoreService) CompleteChore(ctx context.Context, choreID string, userID string) error {
+	return service.CompleteChoreWithProof(ctx, choreID, userID, "")
+}
+
+// CompleteChoreWithProof records userID finishing the chore, with an optional
+// photo (a data URI). When the chore requires approval and the completer is
+// not an admin, it waits in ChoreStatusAwaitingApproval and nothing else
+// happens until ApproveCompletion.
+func (service *ChoreService) CompleteChoreWithProof(ctx context.Context, choreID string, userID string, proofImage string) error {
 	chore, err := service.choreRepo.FindByID(ctx, choreID)
 	if err != nil {
 		return fmt.Errorf("finding chore: %w", err)
@@ -154,19 +228,80 @@ func (service *ChoreService) CompleteChore(ctx context.Context, choreID string,
 	if chore.Status == models.ChoreStatusCompleted {
 		return ErrChoreAlreadyComplete
 	}
+	if chore.Status == models.ChoreStatusAwaitingApproval {
+		return ErrChoreAwaitingApproval
+	}
+	if chore.Status == models.ChoreStatusSkipped || chore.Status == models.ChoreStatusBlocked {
+		return ErrChoreNotOpen
+	}
+	if chore.ChecklistRequired {
+		if err := service.requireChecklistDone(ctx, chore.ID); err != nil {
+			return err
+		}
+	}
+	if chore.GroupChore {
+		return service.completePart(ctx, chore, userID, proofImage)
+	}
 
 	now := time.Now()
 	chore.Status = models.ChoreStatusCompleted
 	chore.CompletedAt = &now
 	chore.CompletedByUserID = &userID
 
+	needsApproval, err := service.needsApproval(ctx, chore, userID)
+	if err != nil {
+		return err
+	}
+	if needsApproval {
+		chore.Status = models.ChoreStatusAwaitingApproval
+	}
+
 	if err := service.choreRepo.Update(ctx, chore); err != nil {
 		return fmt.Errorf("updating chore: %w", err)
 	}
+	service.stopTimers(ctx, chore.ID, nil)
+	if proofImage != "" {
+		if err := service.choreRepo.UpdateProofImage(ctx, chore.ID, proofImage); err != nil {
+			return fmt.Errorf("saving proof: %w", err)
+		}
+	}
+
+	if needsApproval {
+		service.requestApproval(ctx, chore, userID)
+		return nil
+	}
+	return service.finishCompletion(ctx, chore)
+}
+
+// finishCompletion does everything that follows a completion being accepted:
+// it credits the completer, announces the completion and moves the series on.
+func (service *ChoreService) finishCompletion(ctx context.Context, chore models.Chore) error {
+	// A group chore's parts were marked done as they were checked off.
+	completers := chore.PartsDone
+	if !chore.GroupChore {
+		completers = []string{*chore.CompletedByUserID}
+		if err := service.assignmentRepo.MarkCompleted(ctx, chore.ID, *chore.CompletedByUserID); err != nil {
+			return fmt.Errorf("marking assignment completed: %w", err)
+		}
+	}
 
-	if err := service.assignmentRepo.MarkCompleted(ctx, choreID, userID); err != nil {
-		return fmt.Errorf("marking assignment completed: %w", err)
+	// Whoever actually did the chore earns its points, even if it was
+	// assigned to someone else; in a group chore everyone who did their part
+	// earns them in full.
+	if service.pointsRepo != nil {
+		for _, userID := range completers {
+			if _, err := service.pointsRepo.Create(ctx, models.PointsEntry{
+				UserID:  userID,
+				Points:  chore.EffortPoints,
+				Reason:  models.PointsReasonChore,
+				ChoreID: &chore.ID,
+			}); err != nil {
+				return fmt.Errorf("crediting points: %w", err)
+			}
+		}
 	}
+	service.refreshDependents(ctx, chore.SeriesID)
+	service.webhooks.Emit(ctx, models.WebhookChoreCompleted, chore)
 
 	// The series definition is authoritative for the recurrence rule, so a rule
 	// edit is honored even by an in-flight occurrence created before the edit.
@@ -176,11 +311,347 @@ func (service *ChoreService) CompleteChore(ctx context.Context, choreID string,
 		return nil
 	}
 
-	if rule.RecurOnComplete {
-		return service.createNextRecurrence(ctx, rule, now)
+	completedAt := *chore.CompletedAt
+	if rule.RecurOnComplete && rule.RecurrenceType != models.RecurrenceCalendar {
+		return service.createNextRecurrence(ctx, rule, completedAt)
 	}
-	return service.SeedFutureOccurrences(ctx, rule, SeedHorizonFrom(now))
+	return service.SeedFutureOccurrences(ctx, rule, SeedHorizonFrom(time.Now()))
+}
 
+// UncompleteChore undoes a completion (or a completion still awaiting
+// approval) by the actor; admins may undo anyone's. The occurrence goes back
+// to pending, or overdue if its due date has passed, and everything the
+// completion did is reversed: the assignment is reopened, the points credit
+// removed, and for a recur-on-complete series the next occurrence it created
+// is deleted — as long as nobody has touched it yet — with the rotation
+// cursor moved back so completing again picks the same next assignee.
+// Occurrences seeded by a fixed schedule are left alone; they do not depend
+// on the completion. For a group chore the actor undoes their part; see
+// undoParts.
+func (service *ChoreService) UncompleteChore(ctx context.Context, actor models.User, choreID string) error {
+	chore, err := service.choreRepo.FindByID(ctx, choreID)
+	if err != nil {
+		return fmt.Errorf("finding chore: %w", err)
+	}
+	if chore.GroupChore {
+		return service.undoParts(ctx, actor, chore)
+	}
+	if chore.Status != models.ChoreStatusCompleted && chore.Status != models.ChoreStatusAwaitingApproval {
+		return ErrChoreNotCompleted
+	}
+	if actor.Role != models.RoleAdmin && (chore.CompletedByUserID == nil || *chore.CompletedByUserID != actor.ID) {
+		return ErrUndoForbidden
+	}
+
+	// A completion awaiting approval has not marked the assignment yet.
+	if chore.Status == models.ChoreStatusCompleted && chore.CompletedByUserID != nil {
+		if err := service.assignmentRepo.MarkUncompleted(ctx, chore.ID, *chore.CompletedByUserID); err != nil {
+			return fmt.Errorf("reopening assignment: %w", err)
+		}
+	}
+	return service.reopenCompletion(ctx, chore)
+}
+
+// reopenCompletion puts a completed (or awaiting approval) occurrence back to
+// pending or overdue and reverses the rest of what its completion did; the
+// caller has already reopened the assignments.
+func (service *ChoreService) reopenCompletion(ctx context.Context, chore models.Chore) error {
+	wasCompleted := chore.Status == models.ChoreStatusCompleted
+	completedAt := chore.CompletedAt
+
+	chore.Status = models.ChoreStatusPending
+	if repository.IsOverdue(chore, service.now(ctx)) {
+		chore.Status = models.ChoreStatusOverdue
+	}
+	chore.CompletedAt = nil
+	chore.CompletedByUserID = nil
+	if err := service.choreRepo.Update(ctx, chore); err != nil {
+		return fmt.Errorf("updating chore: %w", err)
+	}
+	if err := service.choreRepo.UpdateProofImage(ctx, chore.ID, ""); err != nil {
+		return fmt.Errorf("clearing proof: %w", err)
+	}
+
+	// A completion awaiting approval has not credited anyone or moved the
+	// series on yet.
+	if !wasCompleted {
+		return nil
+	}
+
+	if service.pointsRepo != nil {
+		if err := service.pointsRepo.DeleteChoreCredits(ctx, chore.ID); err != nil {
+			return fmt.Errorf("removing points: %w", err)
+		}
+	}
+	service.refreshDependents(ctx, chore.SeriesID)
+
+	rule := applySeriesRule(chore, service.loadSeries(ctx, chore.SeriesID))
+	if !rule.RecurOnComplete || rule.RecurrenceType == models.RecurrenceNone ||
+		rule.RecurrenceType == models.RecurrenceCalendar || rule.SeriesID == nil || completedAt == nil {
+		return nil
+	}
+	return service.removeNextRecurrence(ctx, chore, *completedAt)
+}
+
+// removeNextRecurrence deletes the occurrence createNextRecurrence made when
+// chore was completed at completedAt, provided it is still exactly as it was
+// created, and hands the rotation back to chore's assignee.
+func (service *ChoreService) removeNextRecurrence(ctx context.Context, chore models.Chore, completedAt time.Time) error {
+	open, err := service.choreRepo.FindAll(ctx, repository.ChoreFilter{
+		SeriesID: chore.SeriesID,
+		Statuses: []models.ChoreStatus{models.ChoreStatusPending, models.ChoreStatusOverdue},
+	})
+	if err != nil {
+		return fmt.Errorf("finding next occurrence: %w", err)
+	}
+
+	for _, next := range open {
+		if next.ID == chore.ID || next.CreatedAt.Before(completedAt) {
+			continue
+		}
+		untouched, err := service.untouchedOccurrence(ctx, next)
+		if err != nil {
+			return err
+		}
+		if !untouched {
+			continue
+		}
+		if err := service.choreRepo.Delete(ctx, next.ID); err != nil {
+			return fmt.Errorf("deleting next occurrence: %w", err)
+		}
+		if chore.AssignedToUserID != nil {
+			if err := service.seriesRepo.SetRotationCursor(ctx, *chore.SeriesID, *chore.AssignedToUserID); err != nil {
+				return fmt.Errorf("restoring rotation cursor: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// untouchedOccurrence reports whether nobody has acted on an occurrence since
+// it was generated: it has not been snoozed, reassigned or swapped, and none
+// of its checklist is ticked.
+func (service *ChoreService) untouchedOccurrence(ctx context.Context, chore models.Chore) (bool, error) {
+	if chore.OriginalDueDate != nil {
+		return false, nil
+	}
+	assignments, err := service.assignmentRepo.FindByChoreID(ctx, chore.ID)
+	if err != nil {
+		return false, fmt.Errorf("finding assignments: %w", err)
+	}
+	for _, assignment := range assignments {
+		if assignment.Status != models.AssignmentStatusAssigned {
+			return false, nil
+		}
+	}
+	items, err := service.choreRepo.GetChecklist(ctx, chore.ID)
+	if err != nil {
+		return false, fmt.Errorf("loading checklist: %w", err)
+	}
+	for _, item := range items {
+		if item.CheckedAt != nil {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// SkipChore closes an open occurrence without completing it and advances the
+// series exactly as a completion would. When advanceRotation is false the
+// skipper keeps their turn: the series' next pending occurrence goes back to
+// them and the rotation continues from there. Skipped occurrences never count
+// towards the leaderboard.
+func (service *ChoreService) SkipChore(ctx context.Context, choreID string, advanceRotation bool) error {
+	chore, err := service.choreRepo.FindByID(ctx, choreID)
+	if err != nil {
+		return fmt.Errorf("finding chore: %w", err)
+	}
+	if !choreIsOpen(chore) {
+		return ErrChoreNotOpen
+	}
+
+	now := time.Now()
+	chore.Status = models.ChoreStatusSkipped
+	if err := service.choreRepo.Update(ctx, chore); err != nil {
+		return fmt.Errorf("updating chore: %w", err)
+	}
+	service.stopTimers(ctx, choreID, nil)
+
+	// Parts of a group chore already done are skipped with the rest.
+	if err := service.reopenParts(ctx, choreID, chore.PartsDone); err != nil {
+		return err
+	}
+	if err := service.assignmentRepo.MarkSkipped(ctx, choreID); err != nil {
+		return fmt.Errorf("marking assignment skipped: %w", err)
+	}
+	service.refreshDependents(ctx, chore.SeriesID)
+
+	rule := applySeriesRule(chore, service.loadSeries(ctx, chore.SeriesID))
+	if rule.RecurrenceType == models.RecurrenceNone {
+		return nil
+	}
+
+	if rule.RecurOnComplete && rule.RecurrenceType != models.RecurrenceCalendar {
+		err = service.createNextRecurrence(ctx, rule, now)
+	} else {
+		err = service.SeedFutureOccurrences(ctx, rule, SeedHorizonFrom(now))
+	}
+	if err != nil {
+		return err
+	}
+
+	if advanceRotation || chore.AssignedToUserID == nil || rule.SeriesID == nil {
+		return nil
+	}
+	return service.handBackTurn(ctx, *rule.SeriesID, *chore.AssignedToUserID)
+}
+
+// SnoozeChore moves an open occurrence to a later due date. The series is left
+// alone: the occurrence remembers its original slot so seeding keeps the
+// series' cadence. An overdue occurrence becomes pending again.
+func (service *ChoreService) SnoozeChore(ctx context.Context, choreID string, dueDate time.Time, dueTime *string) (models.Chore, error) {
+	chore, err := service.choreRepo.FindByID(ctx, choreID)
+	if err != nil {
+		return chore, fmt.Errorf("finding chore: %w", err)
+	}
+	if !choreIsOpen(chore) {
+		return chore, ErrChoreNotOpen
+	}
+
+	snoozed := chore
+	snoozed.DueDate = &dueDate
+	if dueTime != nil {
+		snoozed.DueTime = dueTime
+	}
+	if repository.IsOverdue(snoozed, service.now(ctx)) {
+		return chore, ErrSnoozeInPast
+	}
+
+	if snoozed.OriginalDueDate == nil && chore.DueDate != nil {
+		snoozed.OriginalDueDate = chore.DueDate
+	}
+	snoozed.Status = models.ChoreStatusPending
+
+	if err := service.choreRepo.Update(ctx, snoozed); err != nil {
+		return chore, fmt.Errorf("updating chore: %w", err)
+	}
+	return snoozed, nil
+}
+
+// handBackTurn re-rotates the series' pending occurrences so the rotation
+// resumes with userID: the earliest goes to them and the rest follow on in
+// rotation order. Occurrences edited on their own or handed to someone
+// outside the rotation (a swap, cover or escalation) keep their assignee and
+// are passed over. Everyone given an occurrence is told.
+func (service *ChoreService) handBackTurn(ctx context.Context, seriesID string, userID string) error {
+	pendingStatus := models.ChoreStatusPending
+	pending, err := service.choreRepo.FindAll(ctx, repository.ChoreFilter{
+		SeriesID: &seriesID,
+		Status:   &pendingStatus,
+	})
+	if err != nil {
+		return fmt.Errorf("finding pending occurrences: %w", err)
+	}
+	series := service.loadSeries(ctx, &seriesID)
+
+	var previous *models.Chore
+	for _, occurrence := range pending {
+		rotated, err := service.rotationHeld(ctx, series, occurrence)
+		if err != nil {
+			return err
+		}
+		if !rotated {
+			continue
+		}
+
+		if previous == nil {
+			assigned, err := service.assignTo(ctx, occurrence, userID)
+			if err != nil {
+				return err
+			}
+			previous = &assigned
+			continue
+		}
+		assigned, err := service.assignNextUser(ctx, occurrence, previous.AssignedToUserID)
+		if err != nil {
+			return fmt.Errorf("re-rotating occurrence: %w", err)
+		}
+		if optionalString(assigned.AssignedToUserID) != optionalString(occurrence.AssignedToUserID) {
+			service.notifyAssigned(ctx, assigned)
+		}
+		previous = &assigned
+	}
+	return nil
+}
+
+// rotationHeld reports whether an occurrence's assignee came from the
+// rotation, so re-rotating the series may move it: it has not been edited on
+// its own nor handed over.
+func (service *ChoreService) rotationHeld(ctx context.Context, series *models.ChoreSeries, occurrence models.Chore) (bool, error) {
+	if occurrence.DueDate != nil && exceptedSlot(series, seriesSlot(occurrence)) {
+		return false, nil
+	}
+	assignments, err := service.assignmentRepo.FindByChoreID(ctx, occurrence.ID)
+	if err != nil {
+		return false, fmt.Errorf("finding assignments: %w", err)
+	}
+	for _, assignment := range assignments {
+		if assignment.Status == models.AssignmentStatusAssigned && assignment.HandedOver {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// assignTo assigns the chore to a specific user as its turn in the rotation,
+// recording the previous assignment as reassigned, and moves the series'
+// rotation cursor to them so later occurrences continue from that user.
+func (service *ChoreService) assignTo(ctx context.Context, chore models.Chore, userID string) (models.Chore, error) {
+	chore, err := service.moveTo(ctx, chore, userID, false, nil)
+	if err != nil {
+		return chore, err
+	}
+
+	if service.seriesRepo != nil && chore.SeriesID != nil {
+		if err := service.seriesRepo.SetRotationCursor(ctx, *chore.SeriesID, userID); err != nil {
+			return chore, fmt.Errorf("updating rotation cursor: %w", err)
+		}
+	}
+	return chore, nil
+}
+
+// reassign hands the chore over to userID outside the rotation, recording
+// the previous assignment as reassigned. The series' rotation cursor is left
+// alone.
+func (service *ChoreService) reassign(ctx context.Context, chore models.Chore, userID string) (models.Chore, error) {
+	return service.moveTo(ctx, chore, userID, true, nil)
+}
+
+// moveTo gives the chore to userID, unless they already have it, and tells
+// them. The assignment rows, the chore and the escalation that moved it, when
+// given, are written together.
+func (service *ChoreService) moveTo(ctx context.Context, chore models.Chore, userID string, handedOver bool, escalation *models.ChoreEscalation) (models.Chore, error) {
+	if chore.AssignedToUserID != nil && *chore.AssignedToUserID == userID {
+		return chore, nil
+	}
+	previous := chore.AssignedToUserID
+	chore.AssignedToUserID = &userID
+	if err := service.choreRepo.HandOver(ctx, chore, handedOver, escalation); err != nil {
+		chore.AssignedToUserID = previous
+		return chore, fmt.Errorf("handing over chore: %w", err)
+	}
+	service.notifyAssigned(ctx, chore)
+	return chore, nil
+}
+
+// seriesSlot is the date an occurrence holds in its series' schedule: its
+// original due date when it has been snoozed, otherwise its due date. Callers
+// must ensure DueDate is set.
+func seriesSlot(chore models.Chore) time.Time {
+	if chore.OriginalDueDate != nil {
+		return *chore.OriginalDueDate
+	}
+	return *chore.DueDate
 }
 
 // loadSeries returns the chore_series definition for a chore, or nil when there
@@ -207,21 +678,36 @@ func (service *ChoreService) SeriesByID(ctx context.Context, seriesID string) (*
 	return service.seriesRepo.FindByID(ctx, seriesID)
 }
 
-// applySeriesRule overlays the recurrence rule, end conditions and timing from
-// the series definition onto a chore copy, making the series the source of truth
-// for behaviour. Occurrence rule columns remain as a denormalized read cache for
-// display and filtering. No-op when there is no series definition.
+// applySeriesRule overlays the recurrence rule, end conditions, timing and
+// naming from the series definition onto a chore copy, making the series the
+// source of truth for behaviour and for occurrences generated from the copy
+// (so a name edited on one occurrence only does not spread). Occurrence rule
+// columns remain as a denormalized read cache for display and filtering. No-op
+// when there is no series definition.
 func applySeriesRule(chore models.Chore, series *models.ChoreSeries) models.Chore {
 	if series == nil {
 		return chore
 	}
 	chore.RecurrenceType = series.RecurrenceType
 	chore.RecurrenceValue = series.RecurrenceValue
+	chore.RecurrenceRule = series.RecurrenceRule
 	chore.RecurOnComplete = series.RecurOnComplete
 	chore.RecurrenceUntil = series.RecurrenceUntil
 	chore.RecurrenceCount = series.RecurrenceCount
+	chore.AssignmentStrategy = series.AssignmentStrategy
+	chore.FixedAssigneeUserID = series.FixedAssigneeUserID
+	chore.EffortPoints = series.EffortPoints
+	chore.ChecklistRequired = series.ChecklistRequired
+	chore.RequiresApproval = series.RequiresApproval
+	chore.GroupChore = series.GroupChore
+	chore.GroupQuorum = series.GroupQuorum
+	chore.EscalationRemindHours = series.EscalationRemindHours
+	chore.EscalationReassignHours = series.EscalationReassignHours
+	chore.EscalationNotifyAdmins = series.EscalationNotifyAdmins
 	chore.DueTime = series.DueTime
 	chore.CategoryID = series.CategoryID
+	chore.Name = series.Name
+	chore.Description = series.Description
 	return chore
 }
 
@@ -240,7 +726,12 @@ func (service *ChoreService) findCandidates(ctx context.Context, choreID string,
 		}
 		eligibleIDs = ids
 	}
+	return service.candidatesFrom(ctx, eligibleIDs)
+}
 
+// candidatesFrom resolves an eligible pool to users, everyone when the pool is
+// empty, in rotation order.
+func (service *ChoreService) candidatesFrom(ctx context.Context, eligibleIDs []string) ([]models.User, error) {
 	allUsers, err := service.userRepo.FindAll(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("finding users: %w", err)
@@ -308,18 +799,29 @@ func (service *ChoreService) ensureSeriesRow(ctx context.Context, chore models.C
 		return nil
 	}
 	_, err = service.seriesRepo.Create(ctx, models.ChoreSeries{
-		ID:                   *chore.SeriesID,
-		Name:                 chore.Name,
-		Description:          chore.Description,
-		CreatedByUserID:      chore.CreatedByUserID,
-		CategoryID:           chore.CategoryID,
-		DueTime:              chore.DueTime,
-		RecurrenceType:       chore.RecurrenceType,
-		RecurrenceValue:      chore.RecurrenceValue,
-		RecurOnComplete:      chore.RecurOnComplete,
-		RecurrenceUntil:      chore.RecurrenceUntil,
-		RecurrenceCount:      chore.RecurrenceCount,
-		RotationCursorUserID: chore.AssignedToUserID,
+		ID:                      *chore.SeriesID,
+		Name:                    chore.Name,
+		Description:             chore.Description,
+		CreatedByUserID:         chore.CreatedByUserID,
+		CategoryID:              chore.CategoryID,
+		DueTime:                 chore.DueTime,
+		RecurrenceType:          chore.RecurrenceType,
+		RecurrenceValue:         chore.RecurrenceValue,
+		RecurrenceRule:          chore.RecurrenceRule,
+		RecurOnComplete:         chore.RecurOnComplete,
+		RecurrenceUntil:         chore.RecurrenceUntil,
+		RecurrenceCount:         chore.RecurrenceCount,
+		AssignmentStrategy:      chore.AssignmentStrategy,
+		FixedAssigneeUserID:     chore.FixedAssigneeUserID,
+		EffortPoints:            chore.EffortPoints,
+		ChecklistRequired:       chore.ChecklistRequired,
+		RequiresApproval:        chore.RequiresApproval,
+		GroupChore:              chore.GroupChore,
+		GroupQuorum:             chore.GroupQuorum,
+		EscalationRemindHours:   chore.EscalationRemindHours,
+		EscalationReassignHours: chore.EscalationReassignHours,
+		EscalationNotifyAdmins:  chore.EscalationNotifyAdmins,
+		RotationCursorUserID:    chore.AssignedToUserID,
 	})
 	if err != nil {
 		return fmt.Errorf("creating series row: %w", err)
@@ -329,20 +831,28 @@ func (service *ChoreService) ensureSeriesRow(ctx context.Context, chore models.C
 
 func newChoreFromTemplate(template models.Chore, dueDate *time.Time, lastAssignedIndex int) models.Chore {
 	return models.Chore{
-		Name:              template.Name,
-		Description:       template.Description,
-		CreatedByUserID:   template.CreatedByUserID,
-		CategoryID:        template.CategoryID,
-		SeriesID:          template.SeriesID,
-		LastAssignedIndex: lastAssignedIndex,
-		DueDate:           dueDate,
-		DueTime:           template.DueTime,
-		RecurrenceType:    template.RecurrenceType,
-		RecurrenceValue:   template.RecurrenceValue,
-		RecurOnComplete:   template.RecurOnComplete,
-		RecurrenceUntil:   template.RecurrenceUntil,
-		RecurrenceCount:   template.RecurrenceCount,
-		Status:            models.ChoreStatusPending,
+		Name:                template.Name,
+		Description:         template.Description,
+		CreatedByUserID:     template.CreatedByUserID,
+		CategoryID:          template.CategoryID,
+		SeriesID:            template.SeriesID,
+		LastAssignedIndex:   lastAssignedIndex,
+		DueDate:             dueDate,
+		DueTime:             template.DueTime,
+		RecurrenceType:      template.RecurrenceType,
+		RecurrenceValue:     template.RecurrenceValue,
+		RecurrenceRule:      template.RecurrenceRule,
+		RecurOnComplete:     template.RecurOnComplete,
+		RecurrenceUntil:     template.RecurrenceUntil,
+		RecurrenceCount:     template.RecurrenceCount,
+		AssignmentStrategy:  template.AssignmentStrategy,
+		FixedAssigneeUserID: template.FixedAssigneeUserID,
+		EffortPoints:        template.EffortPoints,
+		ChecklistRequired:   template.ChecklistRequired,
+		RequiresApproval:    template.RequiresApproval,
+		GroupChore:          template.GroupChore,
+		GroupQuorum:         template.GroupQuorum,
+		Status:              models.ChoreStatusPending,
 	}
 }
 
@@ -350,7 +860,9 @@ func (service *ChoreService) createNextRecurrence(ctx context.Context, chore mod
 	series := service.loadSeries(ctx, chore.SeriesID)
 	chore = applySeriesRule(chore, series)
 
-	nextDueDate, err := CalculateNextDueDate(chore, completedAt)
+	// The next occurrence counts from the day the chore was done in the
+	// household's timezone, not the server's.
+	nextDueDate, err := nextRecurrenceDue(chore, series, dateOnly(completedAt.In(service.Location(ctx))))
 	if err != nil {
 		return fmt.Errorf("calculating next due date: %w", err)
 	}
@@ -358,11 +870,6 @@ func (service *ChoreService) createNextRecurrence(ctx context.Context, chore mod
 		return nil
 	}
 
-	// Stop the series once it reaches its end date.
-	if chore.RecurrenceUntil != nil && nextDueDate.After(*chore.RecurrenceUntil) {
-		return nil
-	}
-
 	if err := service.ensureSeriesID(ctx, &chore); err != nil {
 		return fmt.Errorf("setting series_id on legacy chore: %w", err)
 	}
@@ -390,30 +897,81 @@ func (service *ChoreService) createNextRecurrence(ctx context.Context, chore mod
 			return err
 		}
 	}
+	if err := service.copyChecklist(ctx, series, chore.ID, createdChore.ID); err != nil {
+		return err
+	}
 
-	_, err = service.assignNextUser(ctx, createdChore, chore.AssignedToUserID)
+	assigned, err := service.assignNextUser(ctx, createdChore, chore.AssignedToUserID)
 	if err != nil {
 		return fmt.Errorf("assigning next user: %w", err)
 	}
-	return nil
+	service.notifyAssigned(ctx, assigned)
+	return service.syncBlocked(ctx, *chore.SeriesID)
+}
+
+// nextRecurrenceDue is when the next occurrence of a series that recurs on
+// completion falls due after one done on day: the rule's next date or, out of
+// season, the start of the next season. It is nil once the series has ended.
+func nextRecurrenceDue(chore models.Chore, series *models.ChoreSeries, day time.Time) (*time.Time, error) {
+	nextDueDate, err := CalculateNextDueDate(chore, day)
+	if err != nil || nextDueDate == nil {
+		return nil, err
+	}
+	// Out of season, the series picks up again when its next season opens.
+	if !inSeason(series, *nextDueDate) {
+		seasonStart := nextSeasonStart(series, *nextDueDate)
+		nextDueDate = &seasonStart
+	}
+
+	// Stop the series once it reaches its end date.
+	if chore.RecurrenceUntil != nil && nextDueDate.After(*chore.RecurrenceUntil) {
+		return nil, nil
+	}
+	return nextDueDate, nil
 }
 
 // SeedFutureOccurrences creates pending chore instances from the chore's series ahead to `until`.
 // No-op for RecurOnComplete chores (can't predict completion dates) or chores without a DueDate.
 // Idempotent: starts from the last existing future pending instance in the series.
+//
+// Afterwards any upcoming occurrence whose assignee has since become
+// unavailable on its due date is handed to someone who is around, and
+// occurrences are blocked or opened to match their prerequisites, here and in
+// the series waiting on this one.
 func (service *ChoreService) SeedFutureOccurrences(ctx context.Context, chore models.Chore, until time.Time) error {
 	series := service.loadSeries(ctx, chore.SeriesID)
 	chore = applySeriesRule(chore, series)
 
+	if err := service.seedFutureOccurrences(ctx, &chore, series, until); err != nil {
+		return err
+	}
+	if err := service.coverUnavailable(ctx, chore.SeriesID, series); err != nil {
+		return err
+	}
+	if series == nil {
+		return nil
+	}
+	if err := service.syncBlocked(ctx, series.ID); err != nil {
+		return err
+	}
+	service.refreshDependents(ctx, chore.SeriesID)
+	return nil
+}
+
+func (service *ChoreService) seedFutureOccurrences(ctx context.Context, chore *models.Chore, series *models.ChoreSeries, until time.Time) error {
+	if chore.RecurrenceType == models.RecurrenceCalendar {
+		return service.seedCalendarOccurrences(ctx, *chore, series, until)
+	}
+
 	if chore.RecurrenceType == models.RecurrenceNone || chore.RecurOnComplete || chore.DueDate == nil {
 		return nil
 	}
 
-	if err := service.ensureSeriesID(ctx, &chore); err != nil {
+	if err := service.ensureSeriesID(ctx, chore); err != nil {
 		return fmt.Errorf("setting series_id: %w", err)
 	}
 
-	startChore := chore
+	startChore := *chore
 	lastFuture, err := service.choreRepo.FindLastFuturePendingInSeries(ctx, *chore.SeriesID)
 	if err != nil {
 		return fmt.Errorf("finding last future pending: %w", err)
@@ -422,27 +980,70 @@ func (service *ChoreService) SeedFutureOccurrences(ctx context.Context, chore mo
 		startChore = *lastFuture
 	}
 
-	config, err := parseConfig(chore.RecurrenceValue)
-	if err != nil {
-		return fmt.Errorf("parsing recurrence config: %w", err)
-	}
-
-	// Never materialize past the series end date.
-	if chore.RecurrenceUntil != nil && chore.RecurrenceUntil.Before(until) {
-		until = *chore.RecurrenceUntil
-	}
-
 	// Track how many occurrences already exist so we can honor the cap.
+	// Deleted occurrences still count, as EXDATEs do in a calendar.
 	existing := 0
 	if chore.RecurrenceCount != nil {
 		existing, err = service.choreRepo.CountBySeries(ctx, *chore.SeriesID)
 		if err != nil {
 			return fmt.Errorf("counting series occurrences: %w", err)
 		}
+		existing += deletedSlots(series)
 	}
 
-	current := *startChore.DueDate
+	// A seasonal series with nothing upcoming would have nothing left to top
+	// up from once its season ends, so the first occurrence of its next
+	// season is generated even when that lies past the horizon.
+	bridgeSeasons := lastFuture == nil && series != nil && len(series.Seasons) > 0
+
 	currentChore := startChore
+	return service.walkSeriesSlots(ctx, *chore, series, seriesSlot(startChore), until, existing, bridgeSeasons, func(day time.Time) (bool, error) {
+		created, err := service.choreRepo.Create(ctx, newChoreFromTemplate(*chore, &day, currentChore.LastAssignedIndex))
+		if err != nil {
+			return false, fmt.Errorf("creating seeded chore instance: %w", err)
+		}
+
+		// The series owns the eligible pool; only fall back to per-occurrence
+		// copies when there is no series definition yet.
+		if series == nil {
+			if err := service.copyEligibleAssignees(ctx, chore.ID, created.ID); err != nil {
+				return false, err
+			}
+		}
+		if err := service.copyChecklist(ctx, series, chore.ID, created.ID); err != nil {
+			return false, err
+		}
+
+		assigned, err := service.assignNextUser(ctx, created, currentChore.AssignedToUserID)
+		if err != nil {
+			return false, fmt.Errorf("assigning seeded chore: %w", err)
+		}
+		currentChore = assigned
+		return true, nil
+	})
+}
+
+// walkSeriesSlots steps through a fixed-schedule series' slots after from,
+// calling visit with each one that should hold an occurrence until visit
+// returns false. Slots already gone, deleted or edited on their own, or out of
+// season are passed over. The walk stops before until (unbounded when zero)
+// and the series' end date, and once existing plus the slots visited reach
+// its occurrence cap. With bridgeSeasons the first slot visited may lie past
+// until. Seeding and previews both walk a series through here, so a preview
+// shows the dates seeding would generate.
+func (service *ChoreService) walkSeriesSlots(ctx context.Context, chore models.Chore, series *models.ChoreSeries, from, until time.Time, existing int, bridgeSeasons bool, visit func(day time.Time) (bool, error)) error {
+	config, err := parseRecurrence(chore.RecurrenceValue, chore.RecurrenceRule)
+	if err != nil {
+		return fmt.Errorf("parsing recurrence config: %w", err)
+	}
+
+	// Never materialize past the series end date.
+	if chore.RecurrenceUntil != nil && (until.IsZero() || chore.RecurrenceUntil.Before(until)) {
+		until = *chore.RecurrenceUntil
+	}
+
+	current := from
+	location := service.Location(ctx)
 	now := time.Now()
 
 	for i := 0; i < maxExpansionIterations; i++ {
@@ -450,38 +1051,40 @@ func (service *ChoreService) SeedFutureOccurrences(ctx context.Context, chore mo
 			break
 		}
 
-		nextDate := advanceToNextOccurrence(current, chore.RecurrenceType, config)
-		if !nextDate.Before(until) {
+		nextDate, ok := nextOccurrence(current, chore.RecurrenceType, config)
+		if !ok {
 			break
 		}
+		if !until.IsZero() && !nextDate.Before(until) {
+			if !bridgeSeasons || (chore.RecurrenceUntil != nil && !nextDate.Before(*chore.RecurrenceUntil)) {
+				break
+			}
+		}
 		current = nextDate
 
 		// Catch-up policy: occurrences whose due date has already passed are
 		// skipped, not back-filled. The cursor advances so the series resumes
-		// at the next future date.
-		if nextDate.Before(now) {
+		// at the next future date. The slot starts at midnight in the
+		// household timezone.
+		if time.Date(nextDate.Year(), nextDate.Month(), nextDate.Day(), 0, 0, 0, 0, location).Before(now) {
 			continue
 		}
-
-		created, err := service.choreRepo.Create(ctx, newChoreFromTemplate(chore, &nextDate, currentChore.LastAssignedIndex))
-		if err != nil {
-			return fmt.Errorf("creating seeded chore instance: %w", err)
+		// A slot deleted or edited on its own is never regenerated.
+		if exceptedSlot(series, nextDate) {
+			continue
 		}
-
-		// The series owns the eligible pool; only fall back to per-occurrence
-		// copies when there is no series definition yet.
-		if series == nil {
-			if err := service.copyEligibleAssignees(ctx, chore.ID, created.ID); err != nil {
-				return err
-			}
+		// Out-of-season slots are passed over; the rotation carries on from
+		// the last occurrence generated when the next season opens.
+		if !inSeason(series, nextDate) {
+			continue
 		}
 
-		assigned, err := service.assignNextUser(ctx, created, currentChore.AssignedToUserID)
-		if err != nil {
-			return fmt.Errorf("assigning seeded chore: %w", err)
+		more, err := visit(nextDate)
+		if err != nil || !more {
+			return err
 		}
-		currentChore = assigned
 		existing++
+		bridgeSeasons = false
 	}
 
 	return nil
@@ -494,7 +1097,7 @@ func (service *ChoreService) SeedFutureOccurrences(ctx context.Context, chore mo
 // does not abort the rest.
 func (service *ChoreService) TopUpAllSeries(ctx context.Context, until time.Time) error {
 	anchors, err := service.choreRepo.FindAll(ctx, repository.ChoreFilter{
-		Statuses: []models.ChoreStatus{models.ChoreStatusPending, models.ChoreStatusOverdue},
+		Statuses: []models.ChoreStatus{models.ChoreStatusPending, models.ChoreStatusOverdue, models.ChoreStatusBlocked},
 		RecurrenceTypes: []models.RecurrenceType{
 			models.RecurrenceDaily,
 			models.RecurrenceWeekly,
@@ -509,7 +1112,7 @@ func (service *ChoreService) TopUpAllSeries(ctx context.Context, until time.Time
 	}
 
 	for _, anchor := range anchors {
-		if anchor.RecurOnComplete || anchor.DueDate == nil {
+		if anchor.RecurrenceType != models.RecurrenceCalendar && (anchor.RecurOnComplete || anchor.DueDate == nil) {
 			continue
 		}
 		if err := service.SeedFutureOccurrences(ctx, anchor, until); err != nil {
@@ -519,6 +1122,60 @@ func (service *ChoreService) TopUpAllSeries(ctx context.Context, until time.Time
 	return nil
 }
 
+// CreateChore stores a new chore with its eligible pool and first assignee.
+// A recurring chore also gets its series definition, and fixed-schedule series
+// are seeded ahead. The checklist is set last so it reaches the seeded
+// occurrences as well. Only storing the chore itself can fail; problems with
+// the later steps are logged and leave the chore in place.
+func (service *ChoreService) CreateChore(ctx context.Context, chore models.Chore, assignees []string, checklist []string) (models.Chore, error) {
+	created, err := service.choreRepo.Create(ctx, chore)
+	if err != nil {
+		return models.Chore{}, err
+	}
+
+	if len(assignees) > 0 {
+		if err := service.choreRepo.SetEligibleAssignees(ctx, created.ID, assignees); err != nil {
+			slog.Error("setting eligible assignees", "error", err)
+		}
+	}
+
+	assigned, assignErr := service.AssignNextUser(ctx, created)
+	if assignErr != nil {
+		slog.Error("assigning chore", "error", assignErr)
+		assigned = created
+	}
+
+	// Recurring chores (including RecurOnComplete) own their rule in a
+	// chore_series row, which must exist before chores.series_id references it.
+	if created.RecurrenceType != models.RecurrenceNone {
+		seriesID := created.ID
+		assigned.SeriesID = &seriesID
+		if err := service.SyncSeriesDefinition(ctx, assigned, assignees); err != nil {
+			slog.Error("creating series definition for new chore", "error", err)
+		} else if err := service.choreRepo.Update(ctx, assigned); err != nil {
+			slog.Error("setting series_id on new chore", "error", err)
+		} else if !created.RecurOnComplete {
+			if err := service.SeedFutureOccurrences(ctx, assigned, SeedHorizonFrom(time.Now())); err != nil {
+				slog.Error("seeding future occurrences for new chore", "error", err)
+			}
+		}
+	}
+
+	if len(checklist) > 0 {
+		if err := service.SetChecklist(ctx, assigned, checklist); err != nil {
+			slog.Error("setting checklist for new chore", "error", err)
+		}
+	}
+
+	// The chore is announced once it is fully set up, ahead of its
+	// assignment.
+	service.webhooks.Emit(ctx, models.WebhookChoreCreated, assigned)
+	if assignErr == nil {
+		service.notifyAssigned(ctx, assigned)
+	}
+	return assigned, nil
+}
+
 // SyncSeriesDefinition creates or updates the chore_series definition for a
 // chore's series from the chore's current fields and eligible pool. On first
 // creation the rotation cursor is seeded from the chore's current assignee.
@@ -543,9 +1200,22 @@ func (service *ChoreService) SyncSeriesDefinition(ctx context.Context, chore mod
 		DueTime:         chore.DueTime,
 		RecurrenceType:  chore.RecurrenceType,
 		RecurrenceValue: chore.RecurrenceValue,
+		RecurrenceRule:  chore.RecurrenceRule,
 		RecurOnComplete: chore.RecurOnComplete,
 		RecurrenceUntil: chore.RecurrenceUntil,
 		RecurrenceCount: chore.RecurrenceCount,
+
+		AssignmentStrategy:  chore.AssignmentStrategy,
+		FixedAssigneeUserID: chore.FixedAssigneeUserID,
+		EffortPoints:        chore.EffortPoints,
+		ChecklistRequired:   chore.ChecklistRequired,
+		RequiresApproval:    chore.RequiresApproval,
+		GroupChore:          chore.GroupChore,
+		GroupQuorum:         chore.GroupQuorum,
+
+		EscalationRemindHours:   chore.EscalationRemindHours,
+		EscalationReassignHours: chore.EscalationReassignHours,
+		EscalationNotifyAdmins:  chore.EscalationNotifyAdmins,
 	}
 
 	if existing == nil {
@@ -569,16 +1239,20 @@ func (service *ChoreService) SyncSeriesDefinition(ctx context.Context, chore mod
 }
 
 // DeleteSeriesDefinition soft-deletes the series definition (audit trail).
+// Series that waited on it no longer do.
 func (service *ChoreService) DeleteSeriesDefinition(ctx context.Context, seriesID string) error {
 	if service.seriesRepo == nil {
 		return nil
 	}
-	return service.seriesRepo.MarkDeleted(ctx, seriesID)
+	if err := service.seriesRepo.MarkDeleted(ctx, seriesID); err != nil {
+		return err
+	}
+	service.refreshDependents(ctx, &seriesID)
+	return nil
 }
 
-
 func (service *ChoreService) UpdateOverdueChores(ctx context.Context) error {
-	overdueChores, err := service.choreRepo.FindOverdueChores(ctx)
+	overdueChores, err := service.choreRepo.FindOverdueChores(ctx, service.now(ctx))
 	if err != nil {
 		return fmt.Errorf("finding overdue chores: %w", err)
 	}
@@ -593,6 +1267,8 @@ func (service *ChoreService) UpdateOverdueChores(ctx context.Context) error {
 		if err := service.choreRepo.MarkOverdue(ctx, chore.ID); err != nil {
 			return fmt.Errorf("updating overdue chore %s: %w", chore.ID, err)
 		}
+		chore.Status = models.ChoreStatusOverdue
+		service.notifyOverdue(ctx, chore)
 	}
 
 	return nil
@@ -2,6 +2,8 @@ package services_test
 
 import (
 	"context"
+	"errors"
+	"sort"
 	"testing"
 	"time"
 
@@ -24,7 +26,8 @@ func setupChoreServiceWithSeries(t *testing.T) (
 	choreRepo := repository.NewChoreRepository(db)
 	assignmentRepo := repository.NewChoreAssignmentRepository(db)
 	seriesRepo := repository.NewChoreSeriesRepository(db)
-	service := services.NewChoreService(choreRepo, assignmentRepo, userRepo, seriesRepo)
+	pointsRepo := repository.NewPointsRepository(db)
+	service := services.NewChoreService(choreRepo, assignmentRepo, userRepo, seriesRepo, pointsRepo, repository.NewUnavailabilityRepository(db), nil, nil, nil, nil, nil, nil)
 	return service, choreRepo, assignmentRepo, userRepo, seriesRepo
 }
 
@@ -477,7 +480,7 @@ func TestChoreService_CompleteChore_UsesSeriesRule(t *testing.T) {
 	choreRepo := repository.NewChoreRepository(db)
 	assignmentRepo := repository.NewChoreAssignmentRepository(db)
 	seriesRepo := repository.NewChoreSeriesRepository(db)
-	service := services.NewChoreService(choreRepo, assignmentRepo, userRepo, seriesRepo)
+	service := services.NewChoreService(choreRepo, assignmentRepo, userRepo, seriesRepo, nil, nil, nil, nil, nil, nil, nil, nil)
 	ctx := context.Background()
 
 	users := createUsers(t, userRepo, 2)
@@ -524,7 +527,7 @@ func TestChoreService_Assignment_UsesSeriesPoolAndCursor(t *testing.T) {
 	choreRepo := repository.NewChoreRepository(db)
 	assignmentRepo := repository.NewChoreAssignmentRepository(db)
 	seriesRepo := repository.NewChoreSeriesRepository(db)
-	service := services.NewChoreService(choreRepo, assignmentRepo, userRepo, seriesRepo)
+	service := services.NewChoreService(choreRepo, assignmentRepo, userRepo, seriesRepo, nil, nil, nil, nil, nil, nil, nil, nil)
 	ctx := context.Background()
 
 	users := createUsers(t, userRepo, 3)
@@ -641,6 +644,41 @@ func TestChoreService_SeedFutureOccurrences_StopsAfterRecurrenceCount(t *testing
 	}
 }
 
+func TestChoreService_SeedFutureOccurrences_RecurrenceRule(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 1)
+
+	now := time.Now()
+	base := time.Date(now.Year(), now.Month(), 1, 9, 0, 0, 0, time.UTC).AddDate(0, 1, 0)
+	chore := newRecurringChore(t, choreRepo, seriesRepo,
+		models.ChoreSeries{RecurrenceType: models.RecurrenceMonthly, RecurrenceRule: "FREQ=MONTHLY;BYDAY=2TU"},
+		models.Chore{
+			Name:              "Bins",
+			CreatedByUserID:   users[0].ID,
+			DueDate:           &base,
+			Status:            models.ChoreStatusPending,
+			LastAssignedIndex: -1,
+		})
+
+	if err := service.SeedFutureOccurrences(ctx, chore, base.AddDate(0, 3, 0)); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	all, _ := choreRepo.FindAll(ctx, repository.ChoreFilter{})
+	if len(all) != 4 {
+		t.Fatalf("expected anchor plus 3 seeded occurrences, got %d", len(all))
+	}
+	for _, c := range all[1:] {
+		if c.DueDate.Weekday() != time.Tuesday || c.DueDate.Day() < 8 || c.DueDate.Day() > 14 {
+			t.Errorf("occurrence due %v is not the second Tuesday of its month", c.DueDate)
+		}
+		if c.RecurrenceRule != "FREQ=MONTHLY;BYDAY=2TU" {
+			t.Errorf("expected occurrence to project the series rule, got %q", c.RecurrenceRule)
+		}
+	}
+}
+
 func TestChoreService_SeedFutureOccurrences_SkipsPastNoBackfill(t *testing.T) {
 	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
 	ctx := context.Background()
@@ -759,6 +797,187 @@ func TestChoreService_CompleteChore_SeedsAhead(t *testing.T) {
 	}
 }
 
+func TestChoreService_SkipChore_AdvancesSeries(t *testing.T) {
+	service, choreRepo, assignmentRepo, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 2)
+
+	now := time.Now()
+	dueDate := now.AddDate(0, 0, -1)
+	chore := newRecurringChore(t, choreRepo, seriesRepo,
+		models.ChoreSeries{RecurrenceType: models.RecurrenceWeekly, RecurrenceValue: `{"interval":1}`, RecurOnComplete: true},
+		models.Chore{
+			Name:              "Weekly",
+			CreatedByUserID:   users[0].ID,
+			DueDate:           &dueDate,
+			Status:            models.ChoreStatusOverdue,
+			LastAssignedIndex: -1,
+		})
+	chore, _ = service.AssignNextUser(ctx, chore)
+
+	if err := service.SkipChore(ctx, chore.ID, true); err != nil {
+		t.Fatalf("SkipChore: %v", err)
+	}
+
+	skipped, _ := choreRepo.FindByID(ctx, chore.ID)
+	if skipped.Status != models.ChoreStatusSkipped {
+		t.Errorf("expected skipped, got %s", skipped.Status)
+	}
+	if skipped.CompletedAt != nil {
+		t.Error("skipping should not record a completion")
+	}
+
+	pending, _ := choreRepo.FindAll(ctx, repository.ChoreFilter{
+		Statuses: []models.ChoreStatus{models.ChoreStatusPending},
+	})
+	if len(pending) != 1 {
+		t.Fatalf("expected the next occurrence, got %d pending", len(pending))
+	}
+	if *pending[0].AssignedToUserID == *chore.AssignedToUserID {
+		t.Error("rotation should move on after a skip")
+	}
+
+	count, err := assignmentRepo.CompletedCountByUser(ctx, *chore.AssignedToUserID, time.Time{})
+	if err != nil {
+		t.Fatalf("CompletedCountByUser: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("skipped occurrence should not count as completed, got %d", count)
+	}
+
+	if err := service.CompleteChore(ctx, chore.ID, users[0].ID); !errors.Is(err, services.ErrChoreNotOpen) {
+		t.Errorf("completing a skipped chore: got %v, want ErrChoreNotOpen", err)
+	}
+}
+
+func TestChoreService_SkipChore_KeepsTurn(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 3)
+
+	now := time.Now()
+	base := now.AddDate(0, 0, 1)
+	chore := newRecurringChore(t, choreRepo, seriesRepo,
+		models.ChoreSeries{RecurrenceType: models.RecurrenceDaily, RecurrenceValue: `{"interval":1}`},
+		models.Chore{
+			Name:              "Daily",
+			CreatedByUserID:   users[0].ID,
+			DueDate:           &base,
+			Status:            models.ChoreStatusPending,
+			LastAssignedIndex: -1,
+		})
+	chore, _ = service.AssignNextUser(ctx, chore)
+	if err := service.SeedFutureOccurrences(ctx, chore, now.AddDate(0, 0, 4)); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	skipper := *chore.AssignedToUserID
+
+	if err := service.SkipChore(ctx, chore.ID, false); err != nil {
+		t.Fatalf("SkipChore: %v", err)
+	}
+
+	pending, _ := choreRepo.FindAll(ctx, repository.ChoreFilter{
+		Statuses: []models.ChoreStatus{models.ChoreStatusPending},
+		OrderBy:  repository.OrderByDueDateAsc,
+	})
+	if len(pending) < 2 {
+		t.Fatalf("expected pending occurrences, got %d", len(pending))
+	}
+	if *pending[0].AssignedToUserID != skipper {
+		t.Errorf("next occurrence should go back to the skipper")
+	}
+	for i := 1; i < len(pending); i++ {
+		if *pending[i].AssignedToUserID == *pending[i-1].AssignedToUserID {
+			t.Errorf("occurrences %d and %d both assigned to %s", i-1, i, *pending[i].AssignedToUserID)
+		}
+	}
+}
+
+func TestChoreService_SkipChore_RejectsCompleted(t *testing.T) {
+	service, choreRepo, _, userRepo := setupChoreService(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 1)
+
+	chore, _ := choreRepo.Create(ctx, models.Chore{
+		Name:            "Done",
+		CreatedByUserID: users[0].ID,
+		Status:          models.ChoreStatusCompleted,
+	})
+
+	if err := service.SkipChore(ctx, chore.ID, true); !errors.Is(err, services.ErrChoreNotOpen) {
+		t.Errorf("got %v, want ErrChoreNotOpen", err)
+	}
+}
+
+func TestChoreService_SnoozeChore_KeepsSeriesCadence(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 2)
+
+	now := time.Now()
+	yesterday := now.AddDate(0, 0, -1)
+	chore := newRecurringChore(t, choreRepo, seriesRepo,
+		models.ChoreSeries{RecurrenceType: models.RecurrenceWeekly, RecurrenceValue: `{"interval":1}`},
+		models.Chore{
+			Name:              "Weekly",
+			CreatedByUserID:   users[0].ID,
+			DueDate:           &yesterday,
+			Status:            models.ChoreStatusOverdue,
+			LastAssignedIndex: -1,
+		})
+	chore, _ = service.AssignNextUser(ctx, chore)
+
+	snoozeTo := now.AddDate(0, 0, 10)
+	snoozed, err := service.SnoozeChore(ctx, chore.ID, snoozeTo, nil)
+	if err != nil {
+		t.Fatalf("SnoozeChore: %v", err)
+	}
+	if snoozed.Status != models.ChoreStatusPending {
+		t.Errorf("expected overdue chore to become pending, got %s", snoozed.Status)
+	}
+	if snoozed.OriginalDueDate == nil || !snoozed.OriginalDueDate.Equal(yesterday) {
+		t.Errorf("expected original due date %v, got %v", yesterday, snoozed.OriginalDueDate)
+	}
+
+	if err := service.SeedFutureOccurrences(ctx, snoozed, now.AddDate(0, 0, 15)); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	all, _ := choreRepo.FindAll(ctx, repository.ChoreFilter{OrderBy: repository.OrderByDueDateAsc})
+	var weekly int
+	for _, c := range all {
+		if c.ID == chore.ID {
+			continue
+		}
+		weekly++
+		if days := c.DueDate.Sub(yesterday).Hours() / 24; int(days+0.5)%7 != 0 {
+			t.Errorf("series should keep its weekly slots, got an occurrence on %v", c.DueDate)
+		}
+	}
+	if weekly != 2 {
+		t.Errorf("expected 2 seeded occurrences on the original cadence, got %d", weekly)
+	}
+}
+
+func TestChoreService_SnoozeChore_RejectsPastDate(t *testing.T) {
+	service, choreRepo, _, userRepo := setupChoreService(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 1)
+
+	dueDate := time.Now().AddDate(0, 0, 1)
+	chore, _ := choreRepo.Create(ctx, models.Chore{
+		Name:            "Chore",
+		CreatedByUserID: users[0].ID,
+		DueDate:         &dueDate,
+		Status:          models.ChoreStatusPending,
+	})
+
+	_, err := service.SnoozeChore(ctx, chore.ID, time.Now().AddDate(0, 0, -2), nil)
+	if !errors.Is(err, services.ErrSnoozeInPast) {
+		t.Errorf("got %v, want ErrSnoozeInPast", err)
+	}
+}
+
 func TestChoreService_UpdateOverdueChores(t *testing.T) {
 	service, choreRepo, _, userRepo := setupChoreService(t)
 	ctx := context.Background()
@@ -819,3 +1038,255 @@ func TestChoreService_UpdateOverdueChores_DoesNotRewriteAlreadyOverdue(t *testin
 			afterFirst.UpdatedAt, afterSecond.UpdatedAt)
 	}
 }
+
+type stubCalendarEvents struct {
+	events []models.Event
+}
+
+func (stub stubCalendarEvents) FetchSubscriptionEvents(ctx context.Context, subscriptionID string, start, end time.Time) ([]models.Event, error) {
+	var events []models.Event
+	for _, event := range stub.events {
+		if !event.StartTime.Before(start) && event.StartTime.Before(end) {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func TestChoreService_SeedFutureOccurrences_Calendar(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	assignmentRepo := repository.NewChoreAssignmentRepository(db)
+	seriesRepo := repository.NewChoreSeriesRepository(db)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 2)
+
+	// With no household timezone set, events are read in DefaultLocation.
+	day := time.Now().In(services.DefaultLocation).AddDate(0, 0, 3)
+	at := func(offsetDays, hour int) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day()+offsetDays, hour, 0, 0, 0, services.DefaultLocation)
+	}
+	events := stubCalendarEvents{events: []models.Event{
+		{Title: "Bin collection (recycling)", StartTime: at(0, 7)},
+		{Title: "Street party", StartTime: at(3, 7)},
+		{Title: "Bin collection (general)", StartTime: at(7, 7)},
+		{Title: "Bin collection (garden)", StartTime: at(14, 0), AllDay: true},
+	}}
+	service := services.NewChoreService(choreRepo, assignmentRepo, userRepo, seriesRepo, nil, nil, events, nil, nil, nil, nil, nil)
+
+	chore := newRecurringChore(t, choreRepo, seriesRepo,
+		models.ChoreSeries{
+			RecurrenceType:  models.RecurrenceCalendar,
+			RecurrenceValue: `{"subscription_id":"bins","summary":"bin collection","lead_hours":12}`,
+		},
+		models.Chore{
+			Name:              "Put the bins out",
+			CreatedByUserID:   users[0].ID,
+			Status:            models.ChoreStatusPending,
+			LastAssignedIndex: -1,
+		})
+	if err := seriesRepo.SetEligibleAssignees(ctx, *chore.SeriesID, []string{users[0].ID, users[1].ID}); err != nil {
+		t.Fatalf("setting pool: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := service.SeedFutureOccurrences(ctx, chore, time.Now().AddDate(0, 0, 30)); err != nil {
+			t.Fatalf("seed %d: %v", i, err)
+		}
+	}
+
+	all, _ := choreRepo.FindAll(ctx, repository.ChoreFilter{})
+	if len(all) != 3 {
+		t.Fatalf("expected one occurrence per matching event (3), got %d", len(all))
+	}
+	if all[0].ID != chore.ID {
+		t.Errorf("expected the undated anchor to take the first event")
+	}
+
+	want := []time.Time{at(-1, 19), at(6, 19), at(13, 12)}
+	for i, occurrence := range all {
+		var hour, minute int
+		if occurrence.DueTime != nil {
+			parsed, _ := time.Parse("15:04", *occurrence.DueTime)
+			hour, minute = parsed.Hour(), parsed.Minute()
+		}
+		dueAt := time.Date(occurrence.DueDate.Year(), occurrence.DueDate.Month(), occurrence.DueDate.Day(), hour, minute, 0, 0, services.DefaultLocation)
+		if !dueAt.Equal(want[i]) {
+			t.Errorf("occurrence %d due %v, want %v", i, dueAt, want[i])
+		}
+	}
+	if all[1].AssignedToUserID == nil || all[2].AssignedToUserID == nil || *all[1].AssignedToUserID == *all[2].AssignedToUserID {
+		t.Errorf("expected calendar occurrences to rotate between users")
+	}
+}
+
+func TestChoreService_UncompleteChore_RollsBackCompletion(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	assignmentRepo := repository.NewChoreAssignmentRepository(db)
+	seriesRepo := repository.NewChoreSeriesRepository(db)
+	pointsRepo := repository.NewPointsRepository(db)
+	service := services.NewChoreService(choreRepo, assignmentRepo, userRepo, seriesRepo, pointsRepo, nil, nil, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 3)
+
+	dueDate := time.Now().AddDate(0, 0, 1)
+	chore := newRecurringChore(t, choreRepo, seriesRepo,
+		models.ChoreSeries{
+			RecurrenceType:       models.RecurrenceDaily,
+			RecurrenceValue:      `{"interval":1}`,
+			RecurOnComplete:      true,
+			EffortPoints:         2,
+			RotationCursorUserID: &users[0].ID,
+		},
+		models.Chore{
+			Name:             "Empty dishwasher",
+			CreatedByUserID:  users[0].ID,
+			AssignedToUserID: &users[0].ID,
+			Status:           models.ChoreStatusPending,
+			DueDate:          &dueDate,
+		})
+	assignmentRepo.Create(ctx, models.ChoreAssignment{ChoreID: chore.ID, UserID: users[0].ID})
+
+	openStatuses := []models.ChoreStatus{models.ChoreStatusPending, models.ChoreStatusOverdue}
+	nextAssignee := func() string {
+		t.Helper()
+		open, err := choreRepo.FindAll(ctx, repository.ChoreFilter{SeriesID: &chore.ID, Statuses: openStatuses})
+		if err != nil {
+			t.Fatalf("finding open occurrences: %v", err)
+		}
+		for _, occurrence := range open {
+			if occurrence.ID != chore.ID {
+				return *occurrence.AssignedToUserID
+			}
+		}
+		return ""
+	}
+
+	if err := service.CompleteChore(ctx, chore.ID, users[0].ID); err != nil {
+		t.Fatalf("CompleteChore: %v", err)
+	}
+	firstNext := nextAssignee()
+	if firstNext == "" {
+		t.Fatal("expected completion to create the next occurrence")
+	}
+
+	if err := service.UncompleteChore(ctx, users[1], chore.ID); !errors.Is(err, services.ErrUndoForbidden) {
+		t.Errorf("expected ErrUndoForbidden for another member, got %v", err)
+	}
+	if err := service.UncompleteChore(ctx, users[0], chore.ID); err != nil {
+		t.Fatalf("UncompleteChore: %v", err)
+	}
+
+	restored, _ := choreRepo.FindByID(ctx, chore.ID)
+	if restored.Status != models.ChoreStatusPending || restored.CompletedAt != nil || restored.CompletedByUserID != nil {
+		t.Errorf("expected the chore back to pending, got %+v", restored)
+	}
+	if next := nextAssignee(); next != "" {
+		t.Errorf("expected the untouched next occurrence to be removed, still assigned to %s", next)
+	}
+	if balance, _ := pointsRepo.Balance(ctx, users[0].ID); balance != 0 {
+		t.Errorf("expected the points credit reversed, got %d", balance)
+	}
+	if count, _ := assignmentRepo.CompletedCountByUser(ctx, users[0].ID, time.Time{}); count != 0 {
+		t.Errorf("expected the assignment reopened, got %d completed", count)
+	}
+	if series, _ := seriesRepo.FindByID(ctx, chore.ID); series.RotationCursorUserID == nil || *series.RotationCursorUserID != users[0].ID {
+		t.Errorf("expected the rotation cursor back on %s", users[0].Name)
+	}
+
+	// Completing again lands on the same next assignee as the first time.
+	if err := service.CompleteChore(ctx, chore.ID, users[0].ID); err != nil {
+		t.Fatalf("CompleteChore again: %v", err)
+	}
+	if next := nextAssignee(); next != firstNext {
+		t.Errorf("expected the next occurrence to go to %s again, got %s", firstNext, next)
+	}
+}
+
+func TestChoreService_UncompleteChore_KeepsTouchedNextOccurrence(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 2)
+
+	dueDate := time.Now().AddDate(0, 0, 1)
+	chore := newRecurringChore(t, choreRepo, seriesRepo,
+		models.ChoreSeries{RecurrenceType: models.RecurrenceDaily, RecurrenceValue: `{"interval":1}`, RecurOnComplete: true},
+		models.Chore{
+			Name:             "Walk the dog",
+			CreatedByUserID:  users[0].ID,
+			AssignedToUserID: &users[0].ID,
+			Status:           models.ChoreStatusPending,
+			DueDate:          &dueDate,
+		})
+
+	if err := service.CompleteChore(ctx, chore.ID, users[0].ID); err != nil {
+		t.Fatalf("CompleteChore: %v", err)
+	}
+	pendingStatus := models.ChoreStatusPending
+	open, _ := choreRepo.FindAll(ctx, repository.ChoreFilter{SeriesID: &chore.ID, Status: &pendingStatus})
+	if len(open) != 1 {
+		t.Fatalf("expected one next occurrence, got %d", len(open))
+	}
+	if _, err := service.SnoozeChore(ctx, open[0].ID, dueDate.AddDate(0, 0, 3), nil); err != nil {
+		t.Fatalf("SnoozeChore: %v", err)
+	}
+
+	admin := users[1]
+	admin.Role = models.RoleAdmin
+	if err := service.UncompleteChore(ctx, admin, chore.ID); err != nil {
+		t.Fatalf("UncompleteChore: %v", err)
+	}
+	if _, err := choreRepo.FindByID(ctx, open[0].ID); err != nil {
+		t.Errorf("expected the snoozed next occurrence to be kept: %v", err)
+	}
+	if err := service.UncompleteChore(ctx, admin, chore.ID); !errors.Is(err, services.ErrChoreNotCompleted) {
+		t.Errorf("expected ErrChoreNotCompleted undoing twice, got %v", err)
+	}
+}
+
+func TestChoreService_SkipChore_KeepsTurnAroundHandedOverOccurrences(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	seriesRepo := repository.NewChoreSeriesRepository(db)
+	service := services.NewChoreService(choreRepo, repository.NewChoreAssignmentRepository(db), userRepo, seriesRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+	swaps := services.NewChoreSwapService(repository.NewChoreSwapRepository(db), choreRepo, userRepo, nil, service)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 3)
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	chore, first := seedDailyRotation(t, service, choreRepo, seriesRepo, users)
+	if err := service.SeedFutureOccurrences(ctx, chore, first.AddDate(0, 0, 4)); err != nil {
+		t.Fatalf("SeedFutureOccurrences: %v", err)
+	}
+	day := func(offset int) string { return first.AddDate(0, 0, offset).Format("2006-01-02") }
+
+	// The third occurrence is handed from its holder to the first member.
+	swapped := occurrencesByDay(t, choreRepo, chore.ID)[day(2)]
+	swap, err := swaps.ProposeSwap(ctx, *swapped.AssignedToUserID, swapped.ID, users[0].ID, nil)
+	if err != nil {
+		t.Fatalf("ProposeSwap: %v", err)
+	}
+	if err := swaps.AcceptSwap(ctx, swap.ID, users[0].ID); err != nil {
+		t.Fatalf("AcceptSwap: %v", err)
+	}
+
+	if err := service.SkipChore(ctx, chore.ID, false); err != nil {
+		t.Fatalf("SkipChore: %v", err)
+	}
+	got := map[string]string{}
+	for date, occurrence := range occurrencesByDay(t, choreRepo, chore.ID) {
+		if occurrence.Status == models.ChoreStatusPending {
+			got[date] = *occurrence.AssignedToUserID
+		}
+	}
+	want := map[string]string{day(1): users[0].ID, day(2): users[0].ID, day(3): users[1].ID}
+	for date, userID := range want {
+		if got[date] != userID {
+			t.Errorf("%s: expected %s, got %s", date, userID, got[date])
+		}
+	}
+}
@@ -0,0 +1,367 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+)
+
+// DigestPeriod is which digest: the daily one or the week ahead.
+type DigestPeriod string
+
+const (
+	DigestDaily  DigestPeriod = "daily"
+	DigestWeekly DigestPeriod = "weekly"
+)
+
+var (
+	ErrInvalidDigestTime   = errors.New("digest times must be HH:MM, or blank to turn the digest off")
+	ErrInvalidDigestPeriod = errors.New("digest period must be daily or weekly")
+)
+
+// digestCatchUp is how long after its time a digest can still go out, for
+// when the server was down as it fell due.
+const digestCatchUp = 3 * time.Hour
+
+// Digest is what goes into one member's digest. A daily digest covers one day
+// with the member's own chores, and Overdue those they have left over; a
+// weekly digest covers the seven days from the coming Monday with the whole
+// family's chore rota.
+type Digest struct {
+	Period  DigestPeriod
+	User    models.User
+	Days    []DigestDay
+	Overdue []models.Chore
+	// UserNames maps user IDs to names, for who each chore is assigned to.
+	UserNames map[string]string
+	// AppURL is where the app is served, for links back to it.
+	AppURL string
+}
+
+// DigestDay is one day of a digest. Events' start times are in the member's
+// timezone.
+type DigestDay struct {
+	Date   time.Time
+	Chores []models.Chore
+	Meals  []models.MealPlan
+	Events []models.Event
+}
+
+// Title is the digest's subject line.
+func (digest Digest) Title() string {
+	first := digest.Days[0].Date
+	if digest.Period == DigestWeekly {
+		last := digest.Days[len(digest.Days)-1].Date
+		return fmt.Sprintf("The week ahead: %s – %s", first.Format("Mon 2 Jan"), last.Format("Mon 2 Jan"))
+	}
+	return "Your day: " + first.Format("Monday 2 January")
+}
+
+// Empty reports whether the digest has nothing in it.
+func (digest Digest) Empty() bool {
+	if len(digest.Overdue) > 0 {
+		return false
+	}
+	for _, day := range digest.Days {
+		if len(day.Chores) > 0 || len(day.Meals) > 0 || len(day.Events) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Assignees names whoever the chore is assigned to.
+func (digest Digest) Assignees(chore models.Chore) string {
+	var names []string
+	for _, userID := range holders(chore) {
+		if name, ok := digest.UserNames[userID]; ok {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "Unassigned"
+	}
+	return strings.Join(names, ", ")
+}
+
+// DigestRenderer renders a digest as the HTML and plain text of its message.
+type DigestRenderer func(ctx context.Context, digest Digest) (html, text string, err error)
+
+// DigestService builds each member's daily and week-ahead digests and sends
+// them through their notification channels at the times they have chosen.
+type DigestService struct {
+	digestRepo    repository.DigestRepository
+	userRepo      repository.UserRepository
+	choreRepo     repository.ChoreRepository
+	mealPlanRepo  repository.MealPlanRepository
+	settingsRepo  repository.SettingsRepository
+	icalFetcher   *ICalFetcher
+	notifications *NotificationService
+	render        DigestRenderer
+	appURL        string
+}
+
+func NewDigestService(
+	digestRepo repository.DigestRepository,
+	userRepo repository.UserRepository,
+	choreRepo repository.ChoreRepository,
+	mealPlanRepo repository.MealPlanRepository,
+	settingsRepo repository.SettingsRepository,
+	icalFetcher *ICalFetcher,
+	notifications *NotificationService,
+	render DigestRenderer,
+	appURL string,
+) *DigestService {
+	return &DigestService{
+		digestRepo:    digestRepo,
+		userRepo:      userRepo,
+		choreRepo:     choreRepo,
+		mealPlanRepo:  mealPlanRepo,
+		settingsRepo:  settingsRepo,
+		icalFetcher:   icalFetcher,
+		notifications: notifications,
+		render:        render,
+		appURL:        strings.TrimSuffix(appURL, "/"),
+	}
+}
+
+func (service *DigestService) Schedule(ctx context.Context, userID string) (models.DigestSchedule, error) {
+	return service.digestRepo.FindSchedule(ctx, userID)
+}
+
+// SaveSchedule sets the member's digest times; "" turns a digest off.
+func (service *DigestService) SaveSchedule(ctx context.Context, userID, dailyTime, weeklyTime string) error {
+	for _, at := range []string{dailyTime, weeklyTime} {
+		if at == "" {
+			continue
+		}
+		if _, err := time.Parse("15:04", at); err != nil {
+			return ErrInvalidDigestTime
+		}
+	}
+	return service.digestRepo.SaveTimes(ctx, models.DigestSchedule{UserID: userID, DailyTime: dailyTime, WeeklyTime: weeklyTime})
+}
+
+// Build gathers the member's digest for the period as it stands at now: the
+// daily digest for their current day, or the weekly digest for the week from
+// the next Monday.
+func (service *DigestService) Build(ctx context.Context, user models.User, period DigestPeriod, now time.Time) (Digest, error) {
+	location := UserLocation(ctx, service.settingsRepo, user)
+	local := now.In(location)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+
+	digest := Digest{Period: period, User: user, AppURL: service.appURL, UserNames: map[string]string{}}
+	users, err := service.userRepo.FindAll(ctx)
+	if err != nil {
+		return Digest{}, fmt.Errorf("finding users: %w", err)
+	}
+	for _, member := range users {
+		digest.UserNames[member.ID] = member.Name
+	}
+
+	switch period {
+	case DigestDaily:
+		err = service.buildDaily(ctx, &digest, today)
+	case DigestWeekly:
+		err = service.buildWeekly(ctx, &digest, today)
+	default:
+		err = ErrInvalidDigestPeriod
+	}
+	if err != nil {
+		return Digest{}, err
+	}
+	return digest, nil
+}
+
+func (service *DigestService) buildDaily(ctx context.Context, digest *Digest, today time.Time) error {
+	day := DigestDay{Date: today}
+	dueDate := repository.CivilDate(today)
+	chores, err := service.choreRepo.FindAll(ctx, repository.ChoreFilter{
+		AssignedToUser: &digest.User.ID,
+		Statuses:       []models.ChoreStatus{models.ChoreStatusPending, models.ChoreStatusOverdue},
+		DueBefore:      &dueDate,
+	})
+	if err != nil {
+		return fmt.Errorf("finding chores: %w", err)
+	}
+	for _, chore := range chores {
+		if chore.DueDate == nil || !slices.Contains(outstandingUsers(chore), digest.User.ID) {
+			continue
+		}
+		if chore.DueDate.Before(dueDate) {
+			digest.Overdue = append(digest.Overdue, chore)
+		} else {
+			day.Chores = append(day.Chores, chore)
+		}
+	}
+
+	day.Meals, err = service.mealPlanRepo.FindByDate(ctx, today.Format(dayFormat))
+	if err != nil {
+		return fmt.Errorf("finding meals: %w", err)
+	}
+
+	if service.icalFetcher != nil {
+		events, err := service.icalFetcher.FetchForRange(ctx, today, today.AddDate(0, 0, 1))
+		if err != nil {
+			slog.Error("fetching calendar events for digest", "error", err)
+		}
+		for _, event := range events {
+			event.StartTime = event.StartTime.In(today.Location())
+			day.Events = append(day.Events, event)
+		}
+	}
+
+	digest.Days = []DigestDay{day}
+	return nil
+}
+
+func (service *DigestService) buildWeekly(ctx context.Context, digest *Digest, today time.Time) error {
+	// The coming Monday: tomorrow when sent on a Sunday.
+	daysToMonday := (8 - int(today.Weekday())) % 7
+	if daysToMonday == 0 {
+		daysToMonday = 7
+	}
+	start := today.AddDate(0, 0, daysToMonday)
+
+	dayIndex := map[string]int{}
+	for i := range 7 {
+		date := start.AddDate(0, 0, i)
+		digest.Days = append(digest.Days, DigestDay{Date: date})
+		dayIndex[date.Format(dayFormat)] = i
+	}
+
+	from, to := repository.CivilDate(start), repository.CivilDate(start.AddDate(0, 0, 6))
+	pendingStatus := models.ChoreStatusPending
+	chores, err := service.choreRepo.FindAll(ctx, repository.ChoreFilter{
+		Status:    &pendingStatus,
+		DueAfter:  &from,
+		DueBefore: &to,
+	})
+	if err != nil {
+		return fmt.Errorf("finding chores: %w", err)
+	}
+	for _, chore := range chores {
+		if chore.DueDate == nil {
+			continue
+		}
+		if i, ok := dayIndex[chore.DueDate.Format(dayFormat)]; ok {
+			digest.Days[i].Chores = append(digest.Days[i].Chores, chore)
+		}
+	}
+
+	meals, err := service.mealPlanRepo.FindAll(ctx, repository.MealPlanFilter{DateFrom: from.Format(dayFormat), DateTo: to.Format(dayFormat)})
+	if err != nil {
+		return fmt.Errorf("finding meals: %w", err)
+	}
+	for _, meal := range meals {
+		if i, ok := dayIndex[meal.Date]; ok {
+			digest.Days[i].Meals = append(digest.Days[i].Meals, meal)
+		}
+	}
+	return nil
+}
+
+// Render renders the digest as the HTML and plain text of its message.
+func (service *DigestService) Render(ctx context.Context, digest Digest) (html, text string, err error) {
+	return service.render(ctx, digest)
+}
+
+// SendDue sends every member whichever of their digests has fallen due by
+// now in their timezone and not gone out yet today: the daily digest from its
+// time, and the weekly digest from its time on Sundays. A digest that is more
+// than digestCatchUp late is skipped for the day.
+func (service *DigestService) SendDue(ctx context.Context, now time.Time) error {
+	users, err := service.userRepo.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("finding users: %w", err)
+	}
+	for _, user := range users {
+		if err := service.sendDue(ctx, user, now); err != nil {
+			slog.Error("sending digest", "user_id", user.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+func (service *DigestService) sendDue(ctx context.Context, user models.User, now time.Time) error {
+	schedule, err := service.digestRepo.FindSchedule(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	local := now.In(UserLocation(ctx, service.settingsRepo, user))
+	today := local.Format(dayFormat)
+
+	if digestDue(local, schedule.DailyTime, schedule.DailySentOn) {
+		if err := service.send(ctx, user, DigestDaily, now); err != nil {
+			return err
+		}
+		if err := service.digestRepo.MarkDailySent(ctx, user.ID, today); err != nil {
+			return err
+		}
+	}
+	if local.Weekday() == time.Sunday && digestDue(local, schedule.WeeklyTime, schedule.WeeklySentOn) {
+		if err := service.send(ctx, user, DigestWeekly, now); err != nil {
+			return err
+		}
+		if err := service.digestRepo.MarkWeeklySent(ctx, user.ID, today); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// send queues the member's digest on the channels they have sent it to. An
+// empty digest is not sent.
+func (service *DigestService) send(ctx context.Context, user models.User, period DigestPeriod, now time.Time) error {
+	event := models.NotifyDailyDigest
+	if period == DigestWeekly {
+		event = models.NotifyWeeklyDigest
+	}
+	if !service.notifications.Subscribed(ctx, user.ID, event) {
+		return nil
+	}
+
+	digest, err := service.Build(ctx, user, period, now)
+	if err != nil {
+		return err
+	}
+	if digest.Empty() {
+		return nil
+	}
+	html, text, err := service.render(ctx, digest)
+	if err != nil {
+		return fmt.Errorf("rendering digest: %w", err)
+	}
+
+	dedupeKey := fmt.Sprintf("%s:%s", event, digest.Days[0].Date.Format(dayFormat))
+	service.notifications.Notify(ctx, []string{user.ID}, models.Notification{
+		Event:     event,
+		Title:     digest.Title(),
+		Body:      text,
+		HTML:      html,
+		Link:      "/",
+		DedupeKey: &dedupeKey,
+	})
+	return nil
+}
+
+// digestDue reports whether a digest scheduled at the local "HH:MM" time and
+// last sent on sentOn is due at local.
+func digestDue(local time.Time, at, sentOn string) bool {
+	if at == "" || sentOn == local.Format(dayFormat) {
+		return false
+	}
+	parsed, err := time.Parse("15:04", at)
+	if err != nil {
+		return false
+	}
+	due := time.Date(local.Year(), local.Month(), local.Day(), parsed.Hour(), parsed.Minute(), 0, 0, local.Location())
+	return !local.Before(due) && local.Before(due.Add(digestCatchUp))
+}
@@ -0,0 +1,216 @@
+package services_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+	"github.com/bensuskins/family-hub/templates/email"
+)
+
+type digestFixture struct {
+	service          *services.DigestService
+	notifications    *services.NotificationService
+	notificationRepo *repository.SQLiteNotificationRepository
+	users            []models.User
+	london           *time.Location
+}
+
+// setupDigests seeds a family in London on Sunday 5 April 2026: Alice has a
+// chore due that day and one left overdue from Friday, Bob has one that day
+// and one in the week ahead, and meals are planned for both weeks.
+func setupDigests(t *testing.T) digestFixture {
+	t.Helper()
+	db := testutil.NewTestDatabase(t)
+	ctx := context.Background()
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	mealPlanRepo := repository.NewMealPlanRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	notifications := services.NewNotificationService(notificationRepo, userRepo, map[models.ChannelKind]services.ChannelSender{
+		models.ChannelWebhook: services.WebhookSender{Client: http.DefaultClient},
+	}, "https://hub.example.com")
+	service := services.NewDigestService(repository.NewDigestRepository(db), userRepo, choreRepo, mealPlanRepo, nil, nil, notifications, email.RenderDigest, "https://hub.example.com")
+
+	users := createUsers(t, userRepo, 2)
+	for i := range users {
+		if err := userRepo.UpdateTimezone(ctx, users[i].ID, "Europe/London"); err != nil {
+			t.Fatalf("setting timezone: %v", err)
+		}
+		users[i].Timezone = "Europe/London"
+	}
+
+	date := func(day int) *time.Time {
+		due := time.Date(2026, 4, day, 0, 0, 0, 0, time.UTC)
+		return &due
+	}
+	for _, chore := range []models.Chore{
+		{Name: "Feed the cat", AssignedToUserID: &users[0].ID, DueDate: date(5), Status: models.ChoreStatusPending},
+		{Name: "Put the bins out", AssignedToUserID: &users[0].ID, DueDate: date(3), Status: models.ChoreStatusOverdue},
+		{Name: "Hoover the stairs", AssignedToUserID: &users[1].ID, DueDate: date(5), Status: models.ChoreStatusPending},
+		{Name: "Mow the lawn", AssignedToUserID: &users[1].ID, DueDate: date(8), Status: models.ChoreStatusPending},
+		{Name: "Change the beds", AssignedToUserID: &users[0].ID, DueDate: date(13), Status: models.ChoreStatusPending},
+	} {
+		chore.CreatedByUserID = users[0].ID
+		if _, err := choreRepo.Create(ctx, chore); err != nil {
+			t.Fatalf("creating chore: %v", err)
+		}
+	}
+	for _, meal := range []models.MealPlan{
+		{Date: "2026-04-05", MealType: models.MealTypeDinner, Name: "Lasagne"},
+		{Date: "2026-04-08", MealType: models.MealTypeLunch, Name: "Tomato soup"},
+	} {
+		meal.CreatedByUserID = users[0].ID
+		if err := mealPlanRepo.Upsert(ctx, meal); err != nil {
+			t.Fatalf("planning meal: %v", err)
+		}
+	}
+
+	london, _ := time.LoadLocation("Europe/London")
+	return digestFixture{service: service, notifications: notifications, notificationRepo: notificationRepo, users: users, london: london}
+}
+
+func TestDigestService_BuildDaily(t *testing.T) {
+	fixture := setupDigests(t)
+	now := time.Date(2026, 4, 5, 7, 0, 0, 0, fixture.london)
+
+	digest, err := fixture.service.Build(context.Background(), fixture.users[0], services.DigestDaily, now)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	if len(digest.Days) != 1 || digest.Title() != "Your day: Sunday 5 April" {
+		t.Fatalf("unexpected digest %q with %d days", digest.Title(), len(digest.Days))
+	}
+	day := digest.Days[0]
+	if len(day.Chores) != 1 || day.Chores[0].Name != "Feed the cat" {
+		t.Errorf("expected only Alice's chore due today, got %+v", day.Chores)
+	}
+	if len(digest.Overdue) != 1 || digest.Overdue[0].Name != "Put the bins out" {
+		t.Errorf("expected the overdue bins, got %+v", digest.Overdue)
+	}
+	if len(day.Meals) != 1 || day.Meals[0].Name != "Lasagne" {
+		t.Errorf("expected today's dinner, got %+v", day.Meals)
+	}
+}
+
+func TestDigestService_BuildWeekly(t *testing.T) {
+	fixture := setupDigests(t)
+	now := time.Date(2026, 4, 5, 18, 0, 0, 0, fixture.london)
+
+	digest, err := fixture.service.Build(context.Background(), fixture.users[0], services.DigestWeekly, now)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	if len(digest.Days) != 7 || digest.Title() != "The week ahead: Mon 6 Apr – Sun 12 Apr" {
+		t.Fatalf("unexpected digest %q with %d days", digest.Title(), len(digest.Days))
+	}
+	wednesday := digest.Days[2]
+	if len(wednesday.Chores) != 1 || wednesday.Chores[0].Name != "Mow the lawn" || digest.Assignees(wednesday.Chores[0]) != "Bob" {
+		t.Errorf("expected Bob mowing the lawn on Wednesday, got %+v", wednesday.Chores)
+	}
+	if len(wednesday.Meals) != 1 || wednesday.Meals[0].Name != "Tomato soup" {
+		t.Errorf("expected soup on Wednesday, got %+v", wednesday.Meals)
+	}
+	for _, day := range digest.Days {
+		for _, chore := range day.Chores {
+			if chore.Name == "Change the beds" {
+				t.Errorf("chore due after the week ahead included on %s", day.Date.Format("Mon 2 Jan"))
+			}
+		}
+	}
+}
+
+func TestDigestService_SendDue(t *testing.T) {
+	fixture := setupDigests(t)
+	ctx := context.Background()
+	alice := fixture.users[0]
+	channel, err := fixture.notifications.AddChannel(ctx, models.NotificationChannel{UserID: alice.ID, Kind: models.ChannelWebhook, Target: "http://hooks.local/"})
+	if err != nil {
+		t.Fatalf("AddChannel: %v", err)
+	}
+	queued := func() []models.Notification {
+		notifications, _ := fixture.notificationRepo.FindByChannel(ctx, channel.ID, 10)
+		return notifications
+	}
+
+	for _, now := range []time.Time{
+		time.Date(2026, 4, 5, 6, 55, 0, 0, fixture.london),
+		time.Date(2026, 4, 5, 7, 5, 0, 0, fixture.london),
+		time.Date(2026, 4, 5, 7, 10, 0, 0, fixture.london),
+	} {
+		if err := fixture.service.SendDue(ctx, now); err != nil {
+			t.Fatalf("SendDue: %v", err)
+		}
+	}
+	sent := queued()
+	if len(sent) != 1 || sent[0].Event != models.NotifyDailyDigest {
+		t.Fatalf("expected one daily digest, got %+v", sent)
+	}
+	daily := sent[0]
+	for _, want := range []string{"Your day: Sunday 5 April", "OVERDUE", "- Put the bins out (due Fri 3 Apr)", "- Feed the cat", "- Dinner: Lasagne", "https://hub.example.com/"} {
+		if !strings.Contains(daily.Body, want) {
+			t.Errorf("daily text missing %q:\n%s", want, daily.Body)
+		}
+	}
+	if !strings.Contains(daily.HTML, "<h1") || !strings.Contains(daily.HTML, "Feed the cat") {
+		t.Errorf("expected an HTML version, got %q", daily.HTML)
+	}
+	if strings.Contains(daily.Body, "Hoover") {
+		t.Errorf("daily digest includes another member's chore:\n%s", daily.Body)
+	}
+
+	if err := fixture.service.SendDue(ctx, time.Date(2026, 4, 5, 18, 1, 0, 0, fixture.london)); err != nil {
+		t.Fatalf("SendDue: %v", err)
+	}
+	sent = queued()
+	if len(sent) != 2 || sent[0].Event != models.NotifyWeeklyDigest {
+		t.Fatalf("expected the weekly digest on Sunday evening, got %+v", sent)
+	}
+	if !strings.Contains(sent[0].Body, "- Mow the lawn · Bob") || !strings.Contains(sent[0].Body, "WEDNESDAY 8 APRIL") {
+		t.Errorf("unexpected weekly text:\n%s", sent[0].Body)
+	}
+
+	// Missed by more than the catch-up window, Monday's digest is skipped.
+	if err := fixture.service.SendDue(ctx, time.Date(2026, 4, 6, 11, 0, 0, 0, fixture.london)); err != nil {
+		t.Fatalf("SendDue: %v", err)
+	}
+	if len(queued()) != 2 {
+		t.Errorf("expected no late digest, got %d notifications", len(queued()))
+	}
+}
+
+func TestDigestService_SaveSchedule(t *testing.T) {
+	fixture := setupDigests(t)
+	ctx := context.Background()
+	alice := fixture.users[0]
+	if _, err := fixture.notifications.AddChannel(ctx, models.NotificationChannel{UserID: alice.ID, Kind: models.ChannelWebhook, Target: "http://hooks.local/"}); err != nil {
+		t.Fatalf("AddChannel: %v", err)
+	}
+
+	if err := fixture.service.SaveSchedule(ctx, alice.ID, "7am", ""); err != services.ErrInvalidDigestTime {
+		t.Errorf("expected ErrInvalidDigestTime, got %v", err)
+	}
+	if err := fixture.service.SaveSchedule(ctx, alice.ID, "", "19:30"); err != nil {
+		t.Fatalf("SaveSchedule: %v", err)
+	}
+	schedule, _ := fixture.service.Schedule(ctx, alice.ID)
+	if schedule.DailyTime != "" || schedule.WeeklyTime != "19:30" {
+		t.Errorf("unexpected schedule %+v", schedule)
+	}
+
+	if err := fixture.service.SendDue(ctx, time.Date(2026, 4, 5, 7, 5, 0, 0, fixture.london)); err != nil {
+		t.Fatalf("SendDue: %v", err)
+	}
+	channels, _ := fixture.notifications.Channels(ctx, alice.ID)
+	if sent, _ := fixture.notificationRepo.FindByChannel(ctx, channels[0].ID, 10); len(sent) != 0 {
+		t.Errorf("expected no daily digest once turned off, got %+v", sent)
+	}
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+)
+
+// EscalateOverdueChores takes the escalation steps that have fallen due on
+// overdue occurrences under their series' policy. Each step is taken at most
+// once per occurrence: the assignee is reminded once the chore has been
+// overdue EscalationRemindHours, it is handed to the next person in the
+// rotation after EscalationReassignHours, and the admins are told when the
+// last configured step is reached. A chore that fails to escalate is logged
+// and retried on the next run.
+func (service *ChoreService) EscalateOverdueChores(ctx context.Context) error {
+	if service.escalationRepo == nil {
+		return nil
+	}
+
+	overdueStatus := models.ChoreStatusOverdue
+	overdue, err := service.choreRepo.FindAll(ctx, repository.ChoreFilter{Status: &overdueStatus})
+	if err != nil {
+		return fmt.Errorf("finding overdue chores: %w", err)
+	}
+
+	now := service.now(ctx)
+	for _, chore := range overdue {
+		if chore.DueDate == nil || (chore.EscalationRemindHours == 0 && chore.EscalationReassignHours == 0) {
+			continue
+		}
+		if err := service.escalate(ctx, chore, now.Sub(overdueSince(chore, now.Location()))); err != nil {
+			slog.Error("escalating overdue chore", "chore_id", chore.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+// Escalations lists the steps taken on an overdue occurrence, oldest first.
+func (service *ChoreService) Escalations(ctx context.Context, choreID string) ([]models.ChoreEscalation, error) {
+	if service.escalationRepo == nil {
+		return nil, nil
+	}
+	return service.escalationRepo.FindByChore(ctx, choreID)
+}
+
+// escalate takes the steps due on a chore that has been overdue for
+// overdueFor. Once the reassign step is due the reminder is passed over, as
+// handing the chore on supersedes it. A group chore has nobody else to hand
+// it to, so only its reminder and the admins' notice apply.
+func (service *ChoreService) escalate(ctx context.Context, chore models.Chore, overdueFor time.Duration) error {
+	steps, err := service.escalationRepo.FindByChore(ctx, chore.ID)
+	if err != nil {
+		return err
+	}
+	taken := map[models.EscalationKind]bool{}
+	for _, step := range steps {
+		taken[step.Kind] = true
+	}
+
+	reached := func(hours int) bool {
+		return hours > 0 && overdueFor >= time.Duration(hours)*time.Hour
+	}
+	reassignDue := reached(chore.EscalationReassignHours)
+
+	if reached(chore.EscalationRemindHours) && !reassignDue && !taken[models.EscalationReminded] {
+		if err := service.remindOverdue(ctx, chore); err != nil {
+			return err
+		}
+	}
+
+	if reassignDue && !chore.GroupChore && !taken[models.EscalationReassigned] {
+		if err := service.reassignOverdue(ctx, chore); err != nil {
+			return err
+		}
+	}
+
+	finalStep := max(chore.EscalationRemindHours, chore.EscalationReassignHours)
+	if chore.EscalationNotifyAdmins && reached(finalStep) && !taken[models.EscalationAdminsNotified] {
+		if err := service.recordEscalation(ctx, models.ChoreEscalation{
+			ChoreID: chore.ID,
+			Kind:    models.EscalationAdminsNotified,
+		}); err != nil {
+			return err
+		}
+		service.notifications.NotifyAdmins(ctx, "", models.Notification{
+			Event: models.NotifyChoreOverdue,
+			Title: "Overdue chore: " + chore.Name,
+			Body:  "Still not done. " + dueLabel(chore),
+			Link:  "/chores",
+		})
+	}
+	return nil
+}
+
+// remindOverdue reminds whoever still has the chore to do: its assignee, or
+// each participant of a group chore whose part is outstanding.
+func (service *ChoreService) remindOverdue(ctx context.Context, chore models.Chore) error {
+	for _, userID := range outstandingUsers(chore) {
+		if err := service.recordEscalation(ctx, models.ChoreEscalation{
+			ChoreID: chore.ID,
+			Kind:    models.EscalationReminded,
+			UserID:  &userID,
+		}); err != nil {
+			return err
+		}
+		service.notifications.Notify(ctx, []string{userID}, models.Notification{
+			Event: models.NotifyChoreOverdue,
+			Title: "Still overdue: " + chore.Name,
+			Body:  dueLabel(chore),
+			Link:  "/chores",
+		})
+	}
+	return nil
+}
+
+// reassignOverdue hands the chore to the cover pickCover chooses for today.
+// Like cover for an away window it leaves the series' rotation cursor alone.
+// When nobody else is available the chore stays put and the step is tried
+// again on the next run. The step is recorded in the same transaction as the
+// handover, so a failed handover leaves no step behind and is retried too.
+func (service *ChoreService) reassignOverdue(ctx context.Context, chore models.Chore) error {
+	if chore.AssignedToUserID == nil {
+		return nil
+	}
+	current := *chore.AssignedToUserID
+	series := service.loadSeries(ctx, chore.SeriesID)
+
+	next, found, err := service.pickCover(ctx, chore, series, service.now(ctx).Format(dayFormat))
+	if err != nil || !found {
+		return err
+	}
+
+	if _, err := service.moveTo(ctx, chore, next, true, &models.ChoreEscalation{
+		ChoreID:    chore.ID,
+		Kind:       models.EscalationReassigned,
+		UserID:     &next,
+		FromUserID: &current,
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (service *ChoreService) recordEscalation(ctx context.Context, escalation models.ChoreEscalation) error {
+	if _, err := service.escalationRepo.Create(ctx, escalation); err != nil {
+		return fmt.Errorf("recording escalation: %w", err)
+	}
+	return nil
+}
+
+// overdueSince is when the chore fell overdue: its due time on its due date
+// or, without a due time, the end of that day, in location.
+func overdueSince(chore models.Chore, location *time.Location) time.Time {
+	dueDate := *chore.DueDate
+	if chore.DueTime != nil {
+		if parsed, err := time.Parse("15:04", *chore.DueTime); err == nil {
+			return time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), parsed.Hour(), parsed.Minute(), 0, 0, location)
+		}
+	}
+	return time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day()+1, 0, 0, 0, 0, location)
+}
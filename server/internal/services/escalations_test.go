@@ -0,0 +1,179 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+)
+
+// newOverdueChore creates a one-off chore assigned to user that fell due
+// overdueFor ago under the series policy, and marks it overdue.
+func newOverdueChore(t *testing.T, service *services.ChoreService, choreRepo *repository.SQLiteChoreRepository, seriesRepo *repository.SQLiteChoreSeriesRepository, user models.User, overdueFor time.Duration, policy models.ChoreSeries) models.Chore {
+	t.Helper()
+	dueAt := time.Now().In(services.DefaultLocation).Add(-overdueFor)
+	dueDate := time.Date(dueAt.Year(), dueAt.Month(), dueAt.Day(), 0, 0, 0, 0, time.UTC)
+	dueTime := dueAt.Format("15:04")
+
+	policy.Name = "Empty the dishwasher"
+	chore := newRecurringChore(t, choreRepo, seriesRepo, policy, models.Chore{
+		Name:             policy.Name,
+		CreatedByUserID:  user.ID,
+		AssignedToUserID: &user.ID,
+		DueDate:          &dueDate,
+		DueTime:          &dueTime,
+		Status:           models.ChoreStatusPending,
+	})
+	if err := service.UpdateOverdueChores(context.Background()); err != nil {
+		t.Fatalf("UpdateOverdueChores: %v", err)
+	}
+	return chore
+}
+
+func setupEscalation(t *testing.T) (*services.ChoreService, *repository.SQLiteChoreRepository, *repository.SQLiteChoreAssignmentRepository, *repository.SQLiteChoreSeriesRepository, []models.User) {
+	t.Helper()
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	assignmentRepo := repository.NewChoreAssignmentRepository(db)
+	seriesRepo := repository.NewChoreSeriesRepository(db)
+	service := services.NewChoreService(choreRepo, assignmentRepo, userRepo, seriesRepo, repository.NewPointsRepository(db), nil, nil, nil, repository.NewChoreEscalationRepository(db), nil, nil, nil)
+	return service, choreRepo, assignmentRepo, seriesRepo, createUsers(t, userRepo, 3)
+}
+
+func TestChoreService_EscalateOverdueChores_RemindsOnce(t *testing.T) {
+	service, choreRepo, _, seriesRepo, users := setupEscalation(t)
+	ctx := context.Background()
+
+	chore := newOverdueChore(t, service, choreRepo, seriesRepo, users[0], 3*time.Hour,
+		models.ChoreSeries{EscalationRemindHours: 2, EscalationReassignHours: 6, EscalationNotifyAdmins: true})
+
+	for range 2 {
+		if err := service.EscalateOverdueChores(ctx); err != nil {
+			t.Fatalf("EscalateOverdueChores: %v", err)
+		}
+	}
+
+	steps, err := service.Escalations(ctx, chore.ID)
+	if err != nil {
+		t.Fatalf("Escalations: %v", err)
+	}
+	if len(steps) != 1 || steps[0].Kind != models.EscalationReminded || *steps[0].UserID != users[0].ID {
+		t.Fatalf("expected a single reminder to the assignee, got %+v", steps)
+	}
+	unchanged, _ := choreRepo.FindByID(ctx, chore.ID)
+	if *unchanged.AssignedToUserID != users[0].ID {
+		t.Errorf("a reminder should not move the chore, got %s", *unchanged.AssignedToUserID)
+	}
+}
+
+func TestChoreService_EscalateOverdueChores_ReassignsToNextInRotation(t *testing.T) {
+	service, choreRepo, assignmentRepo, seriesRepo, users := setupEscalation(t)
+	ctx := context.Background()
+
+	chore := newOverdueChore(t, service, choreRepo, seriesRepo, users[0], 8*time.Hour,
+		models.ChoreSeries{EscalationRemindHours: 2, EscalationReassignHours: 6, EscalationNotifyAdmins: true})
+	if _, err := assignmentRepo.Create(ctx, models.ChoreAssignment{ChoreID: chore.ID, UserID: users[0].ID, Status: models.AssignmentStatusAssigned}); err != nil {
+		t.Fatalf("creating assignment: %v", err)
+	}
+
+	for range 2 {
+		if err := service.EscalateOverdueChores(ctx); err != nil {
+			t.Fatalf("EscalateOverdueChores: %v", err)
+		}
+	}
+
+	reassigned, _ := choreRepo.FindByID(ctx, chore.ID)
+	if reassigned.AssignedToUserID == nil || *reassigned.AssignedToUserID == users[0].ID {
+		t.Fatalf("expected the chore handed on from %s, got %v", users[0].Name, reassigned.AssignedToUserID)
+	}
+	if reassigned.Status != models.ChoreStatusOverdue {
+		t.Errorf("expected the chore to stay overdue, got %s", reassigned.Status)
+	}
+
+	steps, _ := service.Escalations(ctx, chore.ID)
+	kinds := map[models.EscalationKind]int{}
+	for _, step := range steps {
+		kinds[step.Kind]++
+	}
+	if len(steps) != 2 || kinds[models.EscalationReassigned] != 1 || kinds[models.EscalationAdminsNotified] != 1 {
+		t.Fatalf("expected one hand-on and one admin notice and no stale reminder, got %+v", steps)
+	}
+	for _, step := range steps {
+		if step.Kind == models.EscalationReassigned && (*step.FromUserID != users[0].ID || *step.UserID != *reassigned.AssignedToUserID) {
+			t.Errorf("hand-on recorded as %v -> %v", *step.FromUserID, *step.UserID)
+		}
+	}
+
+	assignments, _ := assignmentRepo.FindByChoreID(ctx, chore.ID)
+	var handedOn bool
+	for _, assignment := range assignments {
+		if assignment.UserID == users[0].ID && assignment.Status == models.AssignmentStatusReassigned {
+			handedOn = true
+		}
+	}
+	if !handedOn {
+		t.Errorf("expected the old assignment recorded as reassigned, got %+v", assignments)
+	}
+}
+
+func TestChoreService_EscalateOverdueChores_FailedHandoverIsRetried(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	assignmentRepo := repository.NewChoreAssignmentRepository(db)
+	seriesRepo := repository.NewChoreSeriesRepository(db)
+	service := services.NewChoreService(choreRepo, assignmentRepo, userRepo, seriesRepo, repository.NewPointsRepository(db), nil, nil, nil, repository.NewChoreEscalationRepository(db), nil, nil, nil)
+	users := createUsers(t, userRepo, 2)
+	ctx := context.Background()
+
+	chore := newOverdueChore(t, service, choreRepo, seriesRepo, users[0], 8*time.Hour,
+		models.ChoreSeries{EscalationReassignHours: 6})
+	if _, err := assignmentRepo.Create(ctx, models.ChoreAssignment{ChoreID: chore.ID, UserID: users[0].ID, Status: models.AssignmentStatusAssigned}); err != nil {
+		t.Fatalf("creating assignment: %v", err)
+	}
+	if _, err := db.Exec(`CREATE TRIGGER refuse_handover BEFORE INSERT ON chore_assignments
+		BEGIN SELECT RAISE(ABORT, 'handover refused'); END`); err != nil {
+		t.Fatalf("creating trigger: %v", err)
+	}
+
+	if err := service.EscalateOverdueChores(ctx); err != nil {
+		t.Fatalf("EscalateOverdueChores: %v", err)
+	}
+
+	steps, err := service.Escalations(ctx, chore.ID)
+	if err != nil {
+		t.Fatalf("Escalations: %v", err)
+	}
+	if len(steps) != 0 {
+		t.Fatalf("expected no step recorded for a failed handover, got %+v", steps)
+	}
+	unchanged, _ := choreRepo.FindByID(ctx, chore.ID)
+	if *unchanged.AssignedToUserID != users[0].ID {
+		t.Errorf("expected the chore left with its assignee, got %s", *unchanged.AssignedToUserID)
+	}
+	assignments, _ := assignmentRepo.FindByChoreID(ctx, chore.ID)
+	if len(assignments) != 1 || assignments[0].UserID != users[0].ID || assignments[0].Status != models.AssignmentStatusAssigned {
+		t.Fatalf("expected the original assignment still open, got %+v", assignments)
+	}
+
+	if _, err := db.Exec(`DROP TRIGGER refuse_handover`); err != nil {
+		t.Fatalf("dropping trigger: %v", err)
+	}
+	if err := service.EscalateOverdueChores(ctx); err != nil {
+		t.Fatalf("EscalateOverdueChores: %v", err)
+	}
+
+	steps, _ = service.Escalations(ctx, chore.ID)
+	if len(steps) != 1 || steps[0].Kind != models.EscalationReassigned || *steps[0].UserID != users[1].ID {
+		t.Fatalf("expected the handover retried and recorded, got %+v", steps)
+	}
+	reassigned, _ := choreRepo.FindByID(ctx, chore.ID)
+	if *reassigned.AssignedToUserID != users[1].ID {
+		t.Errorf("expected the chore handed to %s, got %s", users[1].ID, *reassigned.AssignedToUserID)
+	}
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+)
+
+var (
+	ErrNotParticipant  = errors.New("you are not taking part in this chore")
+	ErrPartAlreadyDone = errors.New("you have already done your part")
+)
+
+// GroupQuorum is how many parts of a group chore must be done before it
+// completes: its quorum, or every participant when the quorum is unset or
+// larger than the group.
+func GroupQuorum(chore models.Chore) int {
+	if chore.GroupQuorum < 1 || chore.GroupQuorum > len(chore.Participants) {
+		return len(chore.Participants)
+	}
+	return chore.GroupQuorum
+}
+
+// assignGroup gives each participant their own part of a group chore. The
+// chore has no single assignee, and the series' rotation cursor is left
+// alone since nobody's turn is used up.
+func (service *ChoreService) assignGroup(ctx context.Context, chore models.Chore, participants []models.User) (models.Chore, error) {
+	if err := service.assignmentRepo.MarkReassigned(ctx, chore.ID); err != nil {
+		return chore, fmt.Errorf("marking old assignment: %w", err)
+	}
+
+	chore.Participants = nil
+	chore.PartsDone = nil
+	for _, user := range participants {
+		if _, err := service.assignmentRepo.Create(ctx, models.ChoreAssignment{
+			ChoreID: chore.ID,
+			UserID:  user.ID,
+			Status:  models.AssignmentStatusAssigned,
+		}); err != nil {
+			return chore, fmt.Errorf("creating assignment: %w", err)
+		}
+		chore.Participants = append(chore.Participants, user.ID)
+	}
+
+	chore.AssignedToUserID = nil
+	if err := service.choreRepo.Update(ctx, chore); err != nil {
+		return chore, fmt.Errorf("updating chore assignment: %w", err)
+	}
+	return chore, nil
+}
+
+// completePart records userID doing their part of a group chore. Once the
+// quorum is reached the chore completes as a whole, dated from this last
+// part, and goes through approval and the series like any other completion.
+func (service *ChoreService) completePart(ctx context.Context, chore models.Chore, userID string, proofImage string) error {
+	if !slices.Contains(chore.Participants, userID) {
+		return ErrNotParticipant
+	}
+	if slices.Contains(chore.PartsDone, userID) {
+		return ErrPartAlreadyDone
+	}
+
+	if err := service.assignmentRepo.MarkCompleted(ctx, chore.ID, userID); err != nil {
+		return fmt.Errorf("marking assignment completed: %w", err)
+	}
+	service.stopTimers(ctx, chore.ID, &userID)
+	if proofImage != "" {
+		if err := service.choreRepo.UpdateProofImage(ctx, chore.ID, proofImage); err != nil {
+			return fmt.Errorf("saving proof: %w", err)
+		}
+	}
+	chore.PartsDone = append(chore.PartsDone, userID)
+	if len(chore.PartsDone) < GroupQuorum(chore) {
+		return nil
+	}
+
+	now := time.Now()
+	chore.Status = models.ChoreStatusCompleted
+	chore.CompletedAt = &now
+	chore.CompletedByUserID = &userID
+
+	needsApproval, err := service.needsApproval(ctx, chore, userID)
+	if err != nil {
+		return err
+	}
+	if needsApproval {
+		chore.Status = models.ChoreStatusAwaitingApproval
+	}
+	if err := service.choreRepo.Update(ctx, chore); err != nil {
+		return fmt.Errorf("updating chore: %w", err)
+	}
+	service.stopTimers(ctx, chore.ID, nil)
+
+	if needsApproval {
+		service.requestApproval(ctx, chore, userID)
+		return nil
+	}
+	return service.finishCompletion(ctx, chore)
+}
+
+// undoParts undoes the actor's own part of a group chore or, for an admin
+// with no part of their own, every part. If the chore had already completed
+// it reopens, undoing everything the completion did as UncompleteChore does;
+// the parts still done are credited again when it next completes.
+func (service *ChoreService) undoParts(ctx context.Context, actor models.User, chore models.Chore) error {
+	parts := []string{actor.ID}
+	if !slices.Contains(chore.PartsDone, actor.ID) {
+		if len(chore.PartsDone) == 0 {
+			return ErrChoreNotCompleted
+		}
+		if actor.Role != models.RoleAdmin {
+			return ErrUndoForbidden
+		}
+		parts = chore.PartsDone
+	}
+
+	if err := service.reopenParts(ctx, chore.ID, parts); err != nil {
+		return err
+	}
+	if chore.Status != models.ChoreStatusCompleted && chore.Status != models.ChoreStatusAwaitingApproval {
+		return nil
+	}
+	return service.reopenCompletion(ctx, chore)
+}
+
+// reopenParts marks the given participants' parts of a chore as not done.
+func (service *ChoreService) reopenParts(ctx context.Context, choreID string, userIDs []string) error {
+	for _, userID := range userIDs {
+		if err := service.assignmentRepo.MarkUncompleted(ctx, choreID, userID); err != nil {
+			return fmt.Errorf("reopening assignment: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+)
+
+func TestChoreService_GroupChore_CompletesWhenEveryoneIsDone(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	assignmentRepo := repository.NewChoreAssignmentRepository(db)
+	pointsRepo := repository.NewPointsRepository(db)
+	service := services.NewChoreService(choreRepo, assignmentRepo, userRepo, repository.NewChoreSeriesRepository(db), pointsRepo, nil, nil, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 3)
+
+	chore, err := service.CreateChore(ctx, models.Chore{
+		Name:            "Tidy the garden",
+		CreatedByUserID: users[0].ID,
+		RecurrenceType:  models.RecurrenceNone,
+		EffortPoints:    4,
+		GroupChore:      true,
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("CreateChore: %v", err)
+	}
+	if chore.AssignedToUserID != nil || len(chore.Participants) != 3 {
+		t.Fatalf("expected the whole family as participants and no single assignee, got %v / %v", chore.AssignedToUserID, chore.Participants)
+	}
+
+	if err := service.CompleteChore(ctx, chore.ID, users[0].ID); err != nil {
+		t.Fatalf("CompleteChore: %v", err)
+	}
+	if err := service.CompleteChore(ctx, chore.ID, users[0].ID); !errors.Is(err, services.ErrPartAlreadyDone) {
+		t.Errorf("expected ErrPartAlreadyDone for a second check-off, got %v", err)
+	}
+	partway, _ := choreRepo.FindByID(ctx, chore.ID)
+	if partway.Status != models.ChoreStatusPending || len(partway.PartsDone) != 1 {
+		t.Fatalf("expected the chore open with one part done, got %s with %v", partway.Status, partway.PartsDone)
+	}
+
+	for _, user := range users[1:] {
+		if err := service.CompleteChore(ctx, chore.ID, user.ID); err != nil {
+			t.Fatalf("CompleteChore: %v", err)
+		}
+	}
+	done, _ := choreRepo.FindByID(ctx, chore.ID)
+	if done.Status != models.ChoreStatusCompleted || *done.CompletedByUserID != users[2].ID {
+		t.Fatalf("expected the chore completed by the last participant, got %s", done.Status)
+	}
+
+	for _, user := range users {
+		if balance, _ := pointsRepo.Balance(ctx, user.ID); balance != 4 {
+			t.Errorf("%s balance: got %d, want 4", user.Name, balance)
+		}
+		if count, _ := assignmentRepo.CompletedCountByUser(ctx, user.ID, time.Time{}); count != 1 {
+			t.Errorf("%s completions: got %d, want 1", user.Name, count)
+		}
+	}
+}
+
+func TestChoreService_GroupChore_QuorumMovesSeriesOn(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 3)
+
+	now := time.Now()
+	first := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	chore := newRecurringChore(t, choreRepo, seriesRepo,
+		models.ChoreSeries{Name: "Wash the car", RecurrenceType: models.RecurrenceWeekly, RecurrenceValue: `{"interval":1}`, GroupChore: true, GroupQuorum: 2},
+		models.Chore{Name: "Wash the car", CreatedByUserID: users[0].ID, DueDate: &first, Status: models.ChoreStatusPending})
+	chore, err := service.AssignNextUser(ctx, chore)
+	if err != nil {
+		t.Fatalf("AssignNextUser: %v", err)
+	}
+	if err := service.SeedFutureOccurrences(ctx, chore, first.AddDate(0, 0, 15)); err != nil {
+		t.Fatalf("SeedFutureOccurrences: %v", err)
+	}
+	for day, occurrence := range occurrencesByDay(t, choreRepo, chore.ID) {
+		if !occurrence.GroupChore || len(occurrence.Participants) != 3 {
+			t.Errorf("%s: expected a group occurrence for all three, got %v", day, occurrence.Participants)
+		}
+	}
+
+	for _, user := range users[:2] {
+		if err := service.CompleteChore(ctx, chore.ID, user.ID); err != nil {
+			t.Fatalf("CompleteChore: %v", err)
+		}
+	}
+	done, _ := choreRepo.FindByID(ctx, chore.ID)
+	if done.Status != models.ChoreStatusCompleted {
+		t.Fatalf("expected two of three parts to complete the chore, got %s", done.Status)
+	}
+	if err := service.CompleteChore(ctx, chore.ID, users[2].ID); !errors.Is(err, services.ErrChoreAlreadyComplete) {
+		t.Errorf("expected ErrChoreAlreadyComplete once the quorum is met, got %v", err)
+	}
+
+	pending := models.ChoreStatusPending
+	open, _ := choreRepo.FindAll(ctx, repository.ChoreFilter{Status: &pending, SeriesID: &chore.ID})
+	mine, err := choreRepo.FindAll(ctx, repository.ChoreFilter{Status: &pending, AssignedToUser: &users[2].ID, SeriesID: &chore.ID})
+	if err != nil || len(open) == 0 || len(mine) != len(open) {
+		t.Errorf("expected all %d open occurrences listed for a participant, got %d (%v)", len(open), len(mine), err)
+	}
+}
+
+func TestChoreService_GroupChore_UndoPart(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	pointsRepo := repository.NewPointsRepository(db)
+	service := services.NewChoreService(choreRepo, repository.NewChoreAssignmentRepository(db), userRepo, repository.NewChoreSeriesRepository(db), pointsRepo, nil, nil, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 2)
+
+	chore, err := service.CreateChore(ctx, models.Chore{
+		Name:            "Clear the loft",
+		CreatedByUserID: users[0].ID,
+		RecurrenceType:  models.RecurrenceNone,
+		GroupChore:      true,
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("CreateChore: %v", err)
+	}
+
+	if err := service.CompleteChore(ctx, chore.ID, users[0].ID); err != nil {
+		t.Fatalf("CompleteChore: %v", err)
+	}
+	if err := service.UncompleteChore(ctx, users[1], chore.ID); !errors.Is(err, services.ErrUndoForbidden) {
+		t.Errorf("expected ErrUndoForbidden for someone else's part, got %v", err)
+	}
+	if err := service.CompleteChore(ctx, chore.ID, users[1].ID); err != nil {
+		t.Fatalf("CompleteChore: %v", err)
+	}
+
+	// Undoing one part of a completed group chore reopens it and takes back
+	// every credit; the other part stays done.
+	if err := service.UncompleteChore(ctx, users[1], chore.ID); err != nil {
+		t.Fatalf("UncompleteChore: %v", err)
+	}
+	reopened, _ := choreRepo.FindByID(ctx, chore.ID)
+	if reopened.Status != models.ChoreStatusPending || len(reopened.PartsDone) != 1 || reopened.PartsDone[0] != users[0].ID {
+		t.Fatalf("expected the chore open with the first part still done, got %s with %v", reopened.Status, reopened.PartsDone)
+	}
+	for _, user := range users {
+		if balance, _ := pointsRepo.Balance(ctx, user.ID); balance != 0 {
+			t.Errorf("%s balance: got %d, want 0", user.Name, balance)
+		}
+	}
+
+	if err := service.CompleteChore(ctx, chore.ID, users[1].ID); err != nil {
+		t.Fatalf("CompleteChore: %v", err)
+	}
+	for _, user := range users {
+		if balance, _ := pointsRepo.Balance(ctx, user.ID); balance != 1 {
+			t.Errorf("%s balance after completing again: got %d, want 1", user.Name, balance)
+		}
+	}
+}
+
+func TestChoreService_GroupChore_PartsCountOnlyOnceCompleted(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	assignmentRepo := repository.NewChoreAssignmentRepository(db)
+	service := services.NewChoreService(choreRepo, assignmentRepo, userRepo, repository.NewChoreSeriesRepository(db), repository.NewPointsRepository(db), nil, nil, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 2)
+
+	chore, err := service.CreateChore(ctx, models.Chore{
+		Name:             "Clean the windows",
+		CreatedByUserID:  users[0].ID,
+		RecurrenceType:   models.RecurrenceNone,
+		EffortPoints:     3,
+		GroupChore:       true,
+		RequiresApproval: true,
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("CreateChore: %v", err)
+	}
+
+	uncounted := func(stage string) {
+		t.Helper()
+		for _, user := range users {
+			if count, _ := assignmentRepo.CompletedCountByUser(ctx, user.ID, time.Time{}); count != 0 {
+				t.Errorf("%s: %s completions: got %d, want 0", stage, user.Name, count)
+			}
+			if points, _ := assignmentRepo.CompletedPointsByUser(ctx, user.ID, time.Time{}); points != 0 {
+				t.Errorf("%s: %s effort points: got %d, want 0", stage, user.Name, points)
+			}
+		}
+	}
+
+	if err := service.CompleteChore(ctx, chore.ID, users[0].ID); err != nil {
+		t.Fatalf("CompleteChore: %v", err)
+	}
+	uncounted("part done")
+
+	if err := service.CompleteChore(ctx, chore.ID, users[1].ID); err != nil {
+		t.Fatalf("CompleteChore: %v", err)
+	}
+	if held, _ := choreRepo.FindByID(ctx, chore.ID); held.Status != models.ChoreStatusAwaitingApproval {
+		t.Fatalf("expected the chore awaiting approval, got %s", held.Status)
+	}
+	uncounted("awaiting approval")
+
+	if err := service.RejectCompletion(ctx, chore.ID); err != nil {
+		t.Fatalf("RejectCompletion: %v", err)
+	}
+	uncounted("rejected")
+}
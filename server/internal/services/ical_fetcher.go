@@ -69,6 +69,35 @@ func (fetcher *ICalFetcher) FetchForRange(ctx context.Context, start, end time.T
 	return events, nil
 }
 
+// FetchSubscriptionEvents returns one subscription's events starting in
+// [start, end), sorted by start time. It shares the cache used by
+// FetchForRange.
+func (fetcher *ICalFetcher) FetchSubscriptionEvents(ctx context.Context, subscriptionID string, start, end time.Time) ([]models.Event, error) {
+	sub, err := fetcher.subRepo.FindByID(ctx, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("finding subscription: %w", err)
+	}
+
+	subEvents, err := fetcher.fetchSubscription(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []models.Event
+	for _, event := range subEvents {
+		inRange := (event.StartTime.Equal(start) || event.StartTime.After(start)) && event.StartTime.Before(end)
+		if inRange {
+			events = append(events, event)
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].StartTime.Before(events[j].StartTime)
+	})
+
+	return events, nil
+}
+
 func (fetcher *ICalFetcher) fetchSubscription(ctx context.Context, sub models.ICalSubscription) ([]models.Event, error) {
 	needsFetch := sub.LastFetchedAt == nil || time.Since(*sub.LastFetchedAt) > fetcher.cacheTTL
 
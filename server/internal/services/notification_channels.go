@@ -0,0 +1,314 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/config"
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+)
+
+const notificationSendTimeout = 15 * time.Second
+
+// ChannelSender delivers a notification through one kind of channel.
+type ChannelSender interface {
+	Send(ctx context.Context, channel models.NotificationChannel, notification models.Notification) error
+}
+
+// NotificationSenders returns the built-in senders: webhook, ntfy and Gotify
+// over HTTP, which like any member-entered URL may not reach private
+// addresses other than the configured NotifyPrivateHosts, email through the configured SMTP server when there is one, Web
+// Push to the subscribed browsers when there is a VAPID key, and APNs when
+// there is a provider for it.
+func NotificationSenders(cfg config.Config, pushRepo repository.PushSubscriptionRepository, vapidKey *ecdsa.PrivateKey, apns *APNsSender) map[models.ChannelKind]ChannelSender {
+	client := NewSafeHTTPClientAllowing(notificationSendTimeout, cfg.NotifyPrivateHosts)
+	senders := map[models.ChannelKind]ChannelSender{
+		models.ChannelWebhook: WebhookSender{Client: client},
+		models.ChannelNtfy:    NtfySender{Client: client},
+		models.ChannelGotify:  GotifySender{Client: client},
+	}
+	if cfg.SMTPHost != "" {
+		senders[models.ChannelEmail] = EmailSender{
+			Address:  net.JoinHostPort(cfg.SMTPHost, cfg.SMTPPort),
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		}
+	}
+	if vapidKey != nil {
+		senders[models.ChannelWebPush] = WebPushSender{
+			Client:        NewSafeHTTPClient(notificationSendTimeout),
+			Subscriptions: pushRepo,
+			Key:           vapidKey,
+			Subject:       cfg.VAPIDSubject,
+		}
+	}
+	if apns != nil {
+		senders[models.ChannelAPNs] = apns
+	}
+	return senders
+}
+
+// webhookPayload is the JSON body a webhook channel is posted.
+type webhookPayload struct {
+	Event  models.NotificationEvent `json:"event"`
+	Title  string                   `json:"title"`
+	Body   string                   `json:"body"`
+	Link   string                   `json:"link,omitempty"`
+	HTML   string                   `json:"html,omitempty"`
+	SentAt time.Time                `json:"sentAt"`
+}
+
+// WebhookSender posts each notification as JSON to the channel's URL.
+type WebhookSender struct {
+	Client *http.Client
+}
+
+func (sender WebhookSender) Send(ctx context.Context, channel models.NotificationChannel, notification models.Notification) error {
+	payload, err := json.Marshal(webhookPayload{
+		Event:  notification.Event,
+		Title:  notification.Title,
+		Body:   notification.Body,
+		Link:   notification.Link,
+		HTML:   notification.HTML,
+		SentAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("encoding webhook payload: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, channel.Target, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("building webhook request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	return doNotificationRequest(sender.Client, request)
+}
+
+// NtfySender publishes to an ntfy topic URL: the body is the message, with
+// the title and link in ntfy's headers. Token, when set, is sent as a bearer
+// access token.
+type NtfySender struct {
+	Client *http.Client
+}
+
+func (sender NtfySender) Send(ctx context.Context, channel models.NotificationChannel, notification models.Notification) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, channel.Target, strings.NewReader(notificationText(notification)))
+	if err != nil {
+		return fmt.Errorf("building ntfy request: %w", err)
+	}
+	request.Header.Set("Title", mime.QEncoding.Encode("utf-8", notification.Title))
+	if notification.Link != "" {
+		request.Header.Set("Click", notification.Link)
+	}
+	if channel.Token != "" {
+		request.Header.Set("Authorization", "Bearer "+channel.Token)
+	}
+	return doNotificationRequest(sender.Client, request)
+}
+
+// GotifySender posts to a Gotify server's message endpoint with the
+// channel's application token.
+type GotifySender struct {
+	Client *http.Client
+}
+
+func (sender GotifySender) Send(ctx context.Context, channel models.NotificationChannel, notification models.Notification) error {
+	message := map[string]any{
+		"title":    notification.Title,
+		"message":  notificationText(notification),
+		"priority": 5,
+	}
+	if notification.Link != "" {
+		message["extras"] = map[string]any{
+			"client::notification": map[string]any{"click": map[string]string{"url": notification.Link}},
+		}
+	}
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("encoding gotify message: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(channel.Target, "/")+"/message", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("building gotify request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Gotify-Key", channel.Token)
+	return doNotificationRequest(sender.Client, request)
+}
+
+func doNotificationRequest(client *http.Client, request *http.Request) error {
+	response, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf("sending to %s: %w", request.URL.Host, err)
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64*1024))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("%s responded %s", request.URL.Host, response.Status)
+	}
+	return nil
+}
+
+// EmailSender mails each notification to the channel's address through an
+// SMTP server, upgrading to TLS when the server offers STARTTLS and logging
+// in when Username is set.
+type EmailSender struct {
+	Address  string
+	Username string
+	Password string
+	From     string
+}
+
+func (sender EmailSender) Send(ctx context.Context, channel models.NotificationChannel, notification models.Notification) error {
+	dialer := net.Dialer{Timeout: notificationSendTimeout}
+	connection, err := dialer.DialContext(ctx, "tcp", sender.Address)
+	if err != nil {
+		return fmt.Errorf("connecting to SMTP server: %w", err)
+	}
+	connection.SetDeadline(time.Now().Add(notificationSendTimeout))
+
+	host, _, _ := net.SplitHostPort(sender.Address)
+	client, err := smtp.NewClient(connection, host)
+	if err != nil {
+		connection.Close()
+		return fmt.Errorf("starting SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return fmt.Errorf("starting TLS: %w", err)
+		}
+	}
+	if sender.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", sender.Username, sender.Password, host)); err != nil {
+			return fmt.Errorf("logging in to SMTP server: %w", err)
+		}
+	}
+	if err := client.Mail(sender.From); err != nil {
+		return fmt.Errorf("setting sender: %w", err)
+	}
+	if err := client.Rcpt(channel.Target); err != nil {
+		return fmt.Errorf("setting recipient: %w", err)
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("starting message: %w", err)
+	}
+	if _, err := writer.Write(sender.message(channel.Target, notification)); err != nil {
+		return fmt.Errorf("writing message: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("sending message: %w", err)
+	}
+	return client.Quit()
+}
+
+func (sender EmailSender) message(to string, notification models.Notification) []byte {
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", sender.From)
+	fmt.Fprintf(&message, "To: %s\r\n", to)
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", notification.Title))
+	fmt.Fprintf(&message, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	message.WriteString("MIME-Version: 1.0\r\n")
+
+	body := notificationText(notification)
+	if notification.Link != "" {
+		body += "\n\n" + notification.Link
+	}
+	body = strings.ReplaceAll(body, "\n", "\r\n") + "\r\n"
+	if notification.HTML == "" {
+		message.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+		message.WriteString("\r\n")
+		message.WriteString(body)
+		return message.Bytes()
+	}
+
+	// With HTML, send both versions and let the mail client pick.
+	parts := multipart.NewWriter(&message)
+	fmt.Fprintf(&message, "Content-Type: multipart/alternative; boundary=%s\r\n", parts.Boundary())
+	message.WriteString("\r\n")
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", body},
+		{"text/html; charset=utf-8", notification.HTML},
+	} {
+		writer, _ := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		encoder := quotedprintable.NewWriter(writer)
+		encoder.Write([]byte(part.content))
+		encoder.Close()
+	}
+	parts.Close()
+	return message.Bytes()
+}
+
+// notificationText is the message of a notification, falling back to its
+// title for channels that need one.
+func notificationText(notification models.Notification) string {
+	if notification.Body != "" {
+		return notification.Body
+	}
+	return notification.Title
+}
+
+// validateChannel checks a channel's target and token suit its kind.
+func validateChannel(channel models.NotificationChannel) error {
+	switch channel.Kind {
+	case models.ChannelWebhook, models.ChannelNtfy, models.ChannelGotify:
+		if err := validateHTTPTarget(channel.Target); err != nil {
+			return err
+		}
+		if channel.Kind == models.ChannelGotify && channel.Token == "" {
+			return fmt.Errorf("%w: a Gotify channel needs an application token", ErrInvalidChannel)
+		}
+	case models.ChannelWebPush, models.ChannelAPNs:
+		// Its browsers or devices are registered apart, so it has no target.
+	case models.ChannelEmail:
+		address, err := mail.ParseAddress(channel.Target)
+		if err != nil || address.Address != channel.Target {
+			return fmt.Errorf("%w: %q is not an email address", ErrInvalidChannel, channel.Target)
+		}
+	default:
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidChannel, channel.Kind)
+	}
+
+	for _, event := range channel.Events {
+		if !isNotificationEvent(event) {
+			return fmt.Errorf("%w: unknown event %q", ErrInvalidChannel, event)
+		}
+	}
+	return nil
+}
+
+// validateHTTPTarget accepts any http or https URL. Private addresses are
+// allowed on purpose: a self-hosted ntfy or Gotify usually lives on the home
+// network.
+func validateHTTPTarget(target string) error {
+	parsed, err := url.Parse(target)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: %q is not an http or https URL", ErrInvalidChannel, target)
+	}
+	return nil
+}
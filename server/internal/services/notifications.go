@@ -0,0 +1,367 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+)
+
+var (
+	ErrInvalidChannel      = errors.New("invalid notification channel")
+	ErrChannelForbidden    = errors.New("notification channel belongs to another user")
+	ErrChannelKindDisabled = errors.New("this kind of notification channel is not set up on the server")
+)
+
+// NotificationEvents are the events a channel can be sent, in the order they
+// are offered.
+var NotificationEvents = []models.NotificationEvent{
+	models.NotifyChoreAssigned,
+	models.NotifyChoreDueSoon,
+	models.NotifyChoreOverdue,
+	models.NotifySwapRequested,
+	models.NotifyApprovalRequested,
+	models.NotifyLowInventory,
+	models.NotifyDailyDigest,
+	models.NotifyWeeklyDigest,
+}
+
+// notificationRetryDelays are the waits before each retry of a failed
+// delivery. Once they are used up the notification is given up on as failed.
+var notificationRetryDelays = []time.Duration{
+	time.Minute,
+	5 * time.Minute,
+	30 * time.Minute,
+	2 * time.Hour,
+	6 * time.Hour,
+}
+
+const (
+	notificationBatchSize = 50
+	// notificationRetention is how long sent and failed notifications are
+	// kept for their channel's delivery history.
+	notificationRetention = 30 * 24 * time.Hour
+)
+
+// NotificationService queues notifications for users through the channels
+// they have set up and delivers the queue, retrying failed deliveries with
+// backoff. Queueing and delivery meet only in the database, so any number of
+// services can queue while one delivers.
+type NotificationService struct {
+	notificationRepo repository.NotificationRepository
+	userRepo         repository.UserRepository
+	senders          map[models.ChannelKind]ChannelSender
+	baseURL          string
+}
+
+func NewNotificationService(
+	notificationRepo repository.NotificationRepository,
+	userRepo repository.UserRepository,
+	senders map[models.ChannelKind]ChannelSender,
+	baseURL string,
+) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		senders:          senders,
+		baseURL:          strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// ChannelKinds lists the kinds of channel this server can deliver through.
+func (service *NotificationService) ChannelKinds() []models.ChannelKind {
+	var kinds []models.ChannelKind
+	for _, kind := range []models.ChannelKind{models.ChannelNtfy, models.ChannelGotify, models.ChannelEmail, models.ChannelWebhook} {
+		if service.senders[kind] != nil {
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds
+}
+
+func (service *NotificationService) Channels(ctx context.Context, userID string) ([]models.NotificationChannel, error) {
+	return service.notificationRepo.FindChannelsByUser(ctx, userID)
+}
+
+// Channel returns one of the user's channels.
+func (service *NotificationService) Channel(ctx context.Context, userID, channelID string) (models.NotificationChannel, error) {
+	channel, err := service.notificationRepo.FindChannelByID(ctx, channelID)
+	if err != nil {
+		return models.NotificationChannel{}, err
+	}
+	if channel.UserID != userID {
+		return models.NotificationChannel{}, ErrChannelForbidden
+	}
+	return channel, nil
+}
+
+// AddChannel sets up a channel for channel.UserID. Nil Events sends it every
+// event. Push channels are set up by registering a browser or device instead.
+func (service *NotificationService) AddChannel(ctx context.Context, channel models.NotificationChannel) (models.NotificationChannel, error) {
+	if channel.Kind == models.ChannelWebPush || channel.Kind == models.ChannelAPNs {
+		return models.NotificationChannel{}, fmt.Errorf("%w: push is turned on from the browser or app", ErrInvalidChannel)
+	}
+	if channel.Events == nil {
+		channel.Events = NotificationEvents
+	}
+	if err := service.validate(channel); err != nil {
+		return models.NotificationChannel{}, err
+	}
+	return service.notificationRepo.CreateChannel(ctx, channel)
+}
+
+// UpdateChannel saves the target, token and events of one of the user's
+// channels. Its kind cannot change.
+func (service *NotificationService) UpdateChannel(ctx context.Context, userID string, channel models.NotificationChannel) error {
+	existing, err := service.Channel(ctx, userID, channel.ID)
+	if err != nil {
+		return err
+	}
+	channel.UserID = existing.UserID
+	channel.Kind = existing.Kind
+	if err := service.validate(channel); err != nil {
+		return err
+	}
+	return service.notificationRepo.UpdateChannel(ctx, channel)
+}
+
+func (service *NotificationService) RemoveChannel(ctx context.Context, userID, channelID string) error {
+	if _, err := service.Channel(ctx, userID, channelID); err != nil {
+		return err
+	}
+	return service.notificationRepo.DeleteChannel(ctx, channelID)
+}
+
+// SendTest queues a test message on one of the user's channels, whatever
+// events it is sent.
+func (service *NotificationService) SendTest(ctx context.Context, userID, channelID string) error {
+	channel, err := service.Channel(ctx, userID, channelID)
+	if err != nil {
+		return err
+	}
+	return service.notificationRepo.Enqueue(ctx, models.Notification{
+		ChannelID: channel.ID,
+		Event:     models.NotifyTest,
+		Title:     "Family Hub test notification",
+		Body:      "Notifications from Family Hub will arrive here.",
+		Link:      service.link("/profile"),
+	})
+}
+
+// LatestDeliveries returns the most recent notification queued on each of the
+// channels that has one, by channel ID.
+func (service *NotificationService) LatestDeliveries(ctx context.Context, channels []models.NotificationChannel) map[string]models.Notification {
+	latest := map[string]models.Notification{}
+	for _, channel := range channels {
+		notifications, err := service.notificationRepo.FindByChannel(ctx, channel.ID, 1)
+		if err != nil {
+			slog.Error("finding latest notification", "channel_id", channel.ID, "error", err)
+			continue
+		}
+		if len(notifications) > 0 {
+			latest[channel.ID] = notifications[0]
+		}
+	}
+	return latest
+}
+
+func (service *NotificationService) validate(channel models.NotificationChannel) error {
+	if err := validateChannel(channel); err != nil {
+		return err
+	}
+	if service.senders[channel.Kind] == nil {
+		return ErrChannelKindDisabled
+	}
+	return nil
+}
+
+// Notify queues the notification for each user on every channel of theirs
+// that is sent its Event. Link is a path within the app. A nil service sends
+// nothing, and failures are logged rather than returned so they never hold up
+// whatever the notification is about.
+func (service *NotificationService) Notify(ctx context.Context, userIDs []string, notification models.Notification) {
+	if service == nil {
+		return
+	}
+	notification.Link = service.link(notification.Link)
+
+	for _, userID := range userIDs {
+		channels, err := service.notificationRepo.FindChannelsByUser(ctx, userID)
+		if err != nil {
+			slog.Error("finding notification channels", "user_id", userID, "error", err)
+			continue
+		}
+		for _, channel := range channels {
+			if !slices.Contains(channel.Events, notification.Event) {
+				continue
+			}
+			queued := notification
+			queued.ChannelID = channel.ID
+			if err := service.notificationRepo.Enqueue(ctx, queued); err != nil {
+				slog.Error("queueing notification", "channel_id", channel.ID, "event", notification.Event, "error", err)
+			}
+		}
+	}
+}
+
+// Subscribed reports whether any of the user's channels is sent the event. A
+// nil service has no channels.
+func (service *NotificationService) Subscribed(ctx context.Context, userID string, event models.NotificationEvent) bool {
+	if service == nil {
+		return false
+	}
+	channels, err := service.notificationRepo.FindChannelsByUser(ctx, userID)
+	if err != nil {
+		slog.Error("finding notification channels", "user_id", userID, "error", err)
+		return false
+	}
+	for _, channel := range channels {
+		if slices.Contains(channel.Events, event) {
+			return true
+		}
+	}
+	return false
+}
+
+// NotifyAdmins notifies every admin but exceptUserID, who is usually the
+// person whose action it is about.
+func (service *NotificationService) NotifyAdmins(ctx context.Context, exceptUserID string, notification models.Notification) {
+	service.notifyMembers(ctx, exceptUserID, notification, func(user models.User) bool {
+		return user.Role == models.RoleAdmin
+	})
+}
+
+// NotifyEveryone notifies the whole family but exceptUserID.
+func (service *NotificationService) NotifyEveryone(ctx context.Context, exceptUserID string, notification models.Notification) {
+	service.notifyMembers(ctx, exceptUserID, notification, func(models.User) bool { return true })
+}
+
+func (service *NotificationService) notifyMembers(ctx context.Context, exceptUserID string, notification models.Notification, include func(models.User) bool) {
+	if service == nil {
+		return
+	}
+	users, err := service.userRepo.FindAll(ctx)
+	if err != nil {
+		slog.Error("finding users to notify", "event", notification.Event, "error", err)
+		return
+	}
+	var userIDs []string
+	for _, user := range users {
+		if user.ID != exceptUserID && include(user) {
+			userIDs = append(userIDs, user.ID)
+		}
+	}
+	service.Notify(ctx, userIDs, notification)
+}
+
+// userName is the user's name for a message, or "Someone" when they cannot
+// be found.
+func (service *NotificationService) userName(ctx context.Context, userID string) string {
+	if service == nil {
+		return "Someone"
+	}
+	user, err := service.userRepo.FindByID(ctx, userID)
+	if err != nil || user.Name == "" {
+		return "Someone"
+	}
+	return user.Name
+}
+
+func (service *NotificationService) link(path string) string {
+	if path == "" || !strings.HasPrefix(path, "/") {
+		return path
+	}
+	return service.baseURL + path
+}
+
+// DeliverPending sends the queued notifications that are due, a batch at a
+// time, and clears out old history. A failed delivery is retried after the
+// next of notificationRetryDelays and marked failed once they run out; a
+// channel whose kind the server can no longer send fails straight away.
+func (service *NotificationService) DeliverPending(ctx context.Context) error {
+	for {
+		due, err := service.notificationRepo.FindDue(ctx, time.Now(), notificationBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, notification := range due {
+			service.deliver(ctx, notification)
+		}
+		if len(due) < notificationBatchSize {
+			break
+		}
+	}
+	return service.notificationRepo.DeleteFinishedBefore(ctx, time.Now().Add(-notificationRetention))
+}
+
+func (service *NotificationService) deliver(ctx context.Context, notification models.Notification) {
+	err := service.send(ctx, notification)
+	if err == nil {
+		if err := service.notificationRepo.MarkSent(ctx, notification.ID, time.Now()); err != nil {
+			slog.Error("marking notification sent", "notification_id", notification.ID, "error", err)
+		}
+		return
+	}
+
+	notification.Attempts++
+	notification.LastError = err.Error()
+	if notification.Attempts > len(notificationRetryDelays) || errors.Is(err, ErrChannelKindDisabled) {
+		notification.Status = models.NotificationFailed
+		slog.Warn("giving up on notification", "notification_id", notification.ID, "channel_id", notification.ChannelID, "error", err)
+	} else {
+		notification.NextAttemptAt = time.Now().Add(notificationRetryDelays[notification.Attempts-1])
+	}
+	if err := service.notificationRepo.RecordFailure(ctx, notification); err != nil {
+		slog.Error("recording notification failure", "notification_id", notification.ID, "error", err)
+	}
+}
+
+func (service *NotificationService) send(ctx context.Context, notification models.Notification) error {
+	channel, err := service.notificationRepo.FindChannelByID(ctx, notification.ChannelID)
+	if err != nil {
+		return err
+	}
+	sender := service.senders[channel.Kind]
+	if sender == nil {
+		return ErrChannelKindDisabled
+	}
+	return sender.Send(ctx, channel, notification)
+}
+
+// InventoryRunningLow reports whether an item is at or below its low mark:
+// its quantity for a count item, its fill level for a level item.
+func InventoryRunningLow(item models.InventoryItem) bool {
+	if item.TrackingMode == models.TrackingModeLevel {
+		return item.Level <= item.LowAt
+	}
+	return item.Quantity <= item.LowAt
+}
+
+// InventoryChanged tells the family, other than whoever changed it, when an
+// item has just run low. previous is the item before the change, or nil for a
+// new item.
+func (service *NotificationService) InventoryChanged(ctx context.Context, userID string, previous *models.InventoryItem, item models.InventoryItem) {
+	if !InventoryRunningLow(item) || (previous != nil && InventoryRunningLow(*previous)) {
+		return
+	}
+
+	left := fmt.Sprintf("%d%% left.", item.Level)
+	if item.TrackingMode != models.TrackingModeLevel {
+		left = strings.TrimSpace(fmt.Sprintf("%d %s", item.Quantity, item.Unit)) + " left."
+	}
+	service.NotifyEveryone(ctx, userID, models.Notification{
+		Event: models.NotifyLowInventory,
+		Title: "Running low: " + item.Name,
+		Body:  left,
+	})
+}
+
+func isNotificationEvent(event models.NotificationEvent) bool {
+	return slices.Contains(NotificationEvents, event)
+}
@@ -0,0 +1,364 @@
+package services_test
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/config"
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+)
+
+func setupNotifications(t *testing.T, senders map[models.ChannelKind]services.ChannelSender) (*services.NotificationService, *repository.SQLiteNotificationRepository, *repository.SQLiteUserRepository, *sql.DB) {
+	t.Helper()
+	db := testutil.NewTestDatabase(t)
+	notificationRepo := repository.NewNotificationRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	return services.NewNotificationService(notificationRepo, userRepo, senders, "https://hub.example.com"), notificationRepo, userRepo, db
+}
+
+// capturedRequest is one request a stand-in HTTP server received.
+type capturedRequest struct {
+	Path   string
+	Header http.Header
+	Body   string
+}
+
+// standInServer records every request and answers with status.
+func standInServer(t *testing.T, status *int) (*httptest.Server, func() []capturedRequest) {
+	t.Helper()
+	var mutex sync.Mutex
+	var requests []capturedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mutex.Lock()
+		requests = append(requests, capturedRequest{Path: r.URL.Path, Header: r.Header.Clone(), Body: string(body)})
+		mutex.Unlock()
+		w.WriteHeader(*status)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []capturedRequest {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]capturedRequest(nil), requests...)
+	}
+}
+
+// standInSMTPServer accepts one plain SMTP session and returns the message it
+// was sent on the channel.
+func standInSMTPServer(t *testing.T) (string, <-chan string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	messages := make(chan string, 1)
+	go func() {
+		connection, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer connection.Close()
+		reader := bufio.NewReader(connection)
+		reply := func(line string) { io.WriteString(connection, line+"\r\n") }
+
+		reply("220 localhost ESMTP")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				reply("250 localhost")
+			case command == "DATA":
+				reply("354 go ahead")
+				var message strings.Builder
+				for {
+					dataLine, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if dataLine == ".\r\n" {
+						break
+					}
+					message.WriteString(dataLine)
+				}
+				messages <- message.String()
+				reply("250 queued")
+			case command == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+	return listener.Addr().String(), messages
+}
+
+func TestNotificationService_DeliversThroughEachChannel(t *testing.T) {
+	status := http.StatusOK
+	server, requests := standInServer(t, &status)
+	smtpAddress, messages := standInSMTPServer(t)
+	client := server.Client()
+	service, notificationRepo, userRepo, _ := setupNotifications(t, map[models.ChannelKind]services.ChannelSender{
+		models.ChannelWebhook: services.WebhookSender{Client: client},
+		models.ChannelNtfy:    services.NtfySender{Client: client},
+		models.ChannelGotify:  services.GotifySender{Client: client},
+		models.ChannelEmail:   services.EmailSender{Address: smtpAddress, From: "hub@example.com"},
+	})
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 1)
+
+	var channels []models.NotificationChannel
+	for _, channel := range []models.NotificationChannel{
+		{Kind: models.ChannelWebhook, Target: server.URL + "/hook"},
+		{Kind: models.ChannelNtfy, Target: server.URL + "/chores", Token: "tk_ntfy"},
+		{Kind: models.ChannelGotify, Target: server.URL + "/gotify/", Token: "app-token"},
+		{Kind: models.ChannelEmail, Target: "alice@example.com"},
+	} {
+		channel.UserID = users[0].ID
+		created, err := service.AddChannel(ctx, channel)
+		if err != nil {
+			t.Fatalf("AddChannel(%s): %v", channel.Kind, err)
+		}
+		channels = append(channels, created)
+	}
+
+	service.Notify(ctx, []string{users[0].ID}, models.Notification{
+		Event: models.NotifyChoreAssigned,
+		Title: "New chore: Take out trash",
+		Body:  "Due Mon 6 Apr.",
+		Link:  "/chores",
+	})
+	if err := service.DeliverPending(ctx); err != nil {
+		t.Fatalf("DeliverPending: %v", err)
+	}
+
+	received := map[string]capturedRequest{}
+	for _, request := range requests() {
+		received[request.Path] = request
+	}
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(received["/hook"].Body), &payload); err != nil {
+		t.Fatalf("webhook body: %v", err)
+	}
+	if payload["event"] != "chore_assigned" || payload["title"] != "New chore: Take out trash" || payload["link"] != "https://hub.example.com/chores" {
+		t.Errorf("unexpected webhook payload %v", payload)
+	}
+	ntfy := received["/chores"]
+	if ntfy.Body != "Due Mon 6 Apr." || ntfy.Header.Get("Title") != "New chore: Take out trash" ||
+		ntfy.Header.Get("Click") != "https://hub.example.com/chores" || ntfy.Header.Get("Authorization") != "Bearer tk_ntfy" {
+		t.Errorf("unexpected ntfy request %+v", ntfy)
+	}
+	gotify := received["/gotify/message"]
+	if gotify.Header.Get("X-Gotify-Key") != "app-token" || !strings.Contains(gotify.Body, `"title":"New chore: Take out trash"`) {
+		t.Errorf("unexpected gotify request %+v", gotify)
+	}
+	select {
+	case message := <-messages:
+		if !strings.Contains(message, "To: alice@example.com") || !strings.Contains(message, "Subject: New chore: Take out trash") ||
+			!strings.Contains(message, "https://hub.example.com/chores") {
+			t.Errorf("unexpected email %q", message)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no email was sent")
+	}
+
+	for _, channel := range channels {
+		sent, _ := notificationRepo.FindByChannel(ctx, channel.ID, 10)
+		if len(sent) != 1 || sent[0].Status != models.NotificationSent {
+			t.Errorf("%s channel: expected one sent notification, got %+v", channel.Kind, sent)
+		}
+	}
+}
+
+func TestNotificationService_RetriesFailedDeliveryThenGivesUp(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	server, requests := standInServer(t, &status)
+	service, notificationRepo, userRepo, db := setupNotifications(t, map[models.ChannelKind]services.ChannelSender{
+		models.ChannelWebhook: services.WebhookSender{Client: server.Client()},
+	})
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 1)
+	channel, err := service.AddChannel(ctx, models.NotificationChannel{UserID: users[0].ID, Kind: models.ChannelWebhook, Target: server.URL})
+	if err != nil {
+		t.Fatalf("AddChannel: %v", err)
+	}
+
+	service.Notify(ctx, []string{users[0].ID}, models.Notification{Event: models.NotifyChoreOverdue, Title: "Overdue: Dishes"})
+	if err := service.DeliverPending(ctx); err != nil {
+		t.Fatalf("DeliverPending: %v", err)
+	}
+	queued, _ := notificationRepo.FindByChannel(ctx, channel.ID, 10)
+	if queued[0].Status != models.NotificationPending || queued[0].Attempts != 1 || !queued[0].NextAttemptAt.After(time.Now()) {
+		t.Fatalf("expected a retry to be scheduled, got %+v", queued[0])
+	}
+
+	// A retry is only made once it is due.
+	if err := service.DeliverPending(ctx); err != nil {
+		t.Fatalf("DeliverPending: %v", err)
+	}
+	if len(requests()) != 1 {
+		t.Fatalf("expected no retry before it is due, got %d requests", len(requests()))
+	}
+
+	for range 5 {
+		if _, err := db.Exec(`UPDATE notifications SET next_attempt_at = ?`, time.Now().Add(-time.Second).UTC()); err != nil {
+			t.Fatalf("bringing retry forward: %v", err)
+		}
+		if err := service.DeliverPending(ctx); err != nil {
+			t.Fatalf("DeliverPending: %v", err)
+		}
+	}
+	queued, _ = notificationRepo.FindByChannel(ctx, channel.ID, 10)
+	if queued[0].Status != models.NotificationFailed || queued[0].Attempts != 6 || !strings.Contains(queued[0].LastError, "503") {
+		t.Errorf("expected the notification given up on after 6 attempts, got %+v", queued[0])
+	}
+}
+
+func TestNotificationService_NotifyFollowsChannelEventsAndDedupes(t *testing.T) {
+	service, notificationRepo, userRepo, _ := setupNotifications(t, map[models.ChannelKind]services.ChannelSender{
+		models.ChannelWebhook: services.WebhookSender{Client: http.DefaultClient},
+	})
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 1)
+
+	everything, _ := service.AddChannel(ctx, models.NotificationChannel{UserID: users[0].ID, Kind: models.ChannelWebhook, Target: "http://hooks.local/all"})
+	overdueOnly, _ := service.AddChannel(ctx, models.NotificationChannel{
+		UserID: users[0].ID, Kind: models.ChannelWebhook, Target: "http://hooks.local/overdue",
+		Events: []models.NotificationEvent{models.NotifyChoreOverdue},
+	})
+
+	dedupeKey := "due_soon:chore-1"
+	for range 2 {
+		service.Notify(ctx, []string{users[0].ID}, models.Notification{Event: models.NotifyChoreDueSoon, Title: "Due soon: Dishes", DedupeKey: &dedupeKey})
+	}
+
+	if queued, _ := notificationRepo.FindByChannel(ctx, everything.ID, 10); len(queued) != 1 {
+		t.Errorf("expected the reminder queued once, got %d", len(queued))
+	}
+	if queued, _ := notificationRepo.FindByChannel(ctx, overdueOnly.ID, 10); len(queued) != 0 {
+		t.Errorf("expected nothing on the overdue-only channel, got %d", len(queued))
+	}
+
+	if _, err := service.AddChannel(ctx, models.NotificationChannel{UserID: users[0].ID, Kind: models.ChannelEmail, Target: "alice@example.com"}); err != services.ErrChannelKindDisabled {
+		t.Errorf("expected email to be unavailable without SMTP, got %v", err)
+	}
+}
+
+func TestChoreService_NotifiesAssigneeAndDueSoon(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	notificationRepo := repository.NewNotificationRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	notifications := services.NewNotificationService(notificationRepo, userRepo, map[models.ChannelKind]services.ChannelSender{
+		models.ChannelWebhook: services.WebhookSender{Client: http.DefaultClient},
+	}, "https://hub.example.com")
+	service := services.NewChoreService(choreRepo, repository.NewChoreAssignmentRepository(db), userRepo, repository.NewChoreSeriesRepository(db), nil, nil, nil, nil, nil, nil, notifications, nil)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 1)
+	channel, _ := notifications.AddChannel(ctx, models.NotificationChannel{UserID: users[0].ID, Kind: models.ChannelWebhook, Target: "http://hooks.local/"})
+
+	now := time.Now().In(services.DefaultLocation)
+	dueAt := now.Add(30 * time.Minute)
+	if dueAt.Day() != now.Day() {
+		t.Skip("the due time would fall tomorrow")
+	}
+	today := repository.CivilDate(now)
+	dueTime := dueAt.Format("15:04")
+	if _, err := service.CreateChore(ctx, models.Chore{
+		Name:            "Feed the cat",
+		CreatedByUserID: users[0].ID,
+		DueDate:         &today,
+		DueTime:         &dueTime,
+		Status:          models.ChoreStatusPending,
+		RecurrenceType:  models.RecurrenceNone,
+	}, nil, nil); err != nil {
+		t.Fatalf("CreateChore: %v", err)
+	}
+
+	for range 2 {
+		if err := service.RemindDueSoon(ctx); err != nil {
+			t.Fatalf("RemindDueSoon: %v", err)
+		}
+	}
+
+	queued, _ := notificationRepo.FindByChannel(ctx, channel.ID, 10)
+	events := map[models.NotificationEvent]int{}
+	for _, notification := range queued {
+		events[notification.Event]++
+	}
+	if events[models.NotifyChoreAssigned] != 1 || events[models.NotifyChoreDueSoon] != 1 || len(queued) != 2 {
+		t.Errorf("expected one assigned and one due-soon notification, got %v", events)
+	}
+}
+
+func TestEmailSender_SendsHTMLAlongsideText(t *testing.T) {
+	smtpAddress, messages := standInSMTPServer(t)
+	sender := services.EmailSender{Address: smtpAddress, From: "hub@example.com"}
+
+	err := sender.Send(context.Background(), models.NotificationChannel{Kind: models.ChannelEmail, Target: "alice@example.com"}, models.Notification{
+		Event: models.NotifyDailyDigest,
+		Title: "Your day: Sunday 5 April",
+		Body:  "Feed the cat",
+		HTML:  "<h1>Your day</h1>",
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	select {
+	case message := <-messages:
+		for _, want := range []string{"Content-Type: multipart/alternative; boundary=", "Content-Type: text/plain; charset=utf-8", "Feed the cat", "Content-Type: text/html; charset=utf-8", "<h1>Your day</h1>"} {
+			if !strings.Contains(message, want) {
+				t.Errorf("email missing %q:\n%s", want, message)
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no email was sent")
+	}
+}
+
+func TestNotificationSenders_RefusePrivateTargetsUnlessAllowed(t *testing.T) {
+	var received int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received++
+	}))
+	defer server.Close()
+
+	channel := models.NotificationChannel{Kind: models.ChannelNtfy, Target: server.URL + "/chores"}
+	notification := models.Notification{Event: models.NotifyChoreAssigned, Title: "Bins"}
+
+	senders := services.NotificationSenders(config.Config{}, nil, nil, nil)
+	if err := senders[models.ChannelNtfy].Send(context.Background(), channel, notification); err == nil {
+		t.Error("expected a private target refused")
+	}
+	if received != 0 {
+		t.Errorf("expected nothing delivered to the private target, got %d", received)
+	}
+
+	senders = services.NotificationSenders(config.Config{NotifyPrivateHosts: []string{"127.0.0.1"}}, nil, nil, nil)
+	if err := senders[models.ChannelNtfy].Send(context.Background(), channel, notification); err != nil {
+		t.Fatalf("expected an allowed private host reached, got %v", err)
+	}
+	if received != 1 {
+		t.Errorf("expected one delivery to the allowed host, got %d", received)
+	}
+}
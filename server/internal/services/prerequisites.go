@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+)
+
+var (
+	ErrPrerequisiteNotFound = errors.New("prerequisite chore not found")
+	ErrPrerequisiteCycle    = errors.New("prerequisites would make chores wait on each other")
+)
+
+// SetPrerequisites makes a series wait on other series: each of its
+// occurrences stays blocked until the prerequisites' occurrences for the same
+// cycle are done, then falls due delayMinutes after the last completion. The
+// prerequisite occurrence for a cycle is the latest one due on or before the
+// dependent occurrence's slot. A series cannot wait on itself, directly or
+// through other series. The series' open occurrences are brought in line
+// straight away.
+func (service *ChoreService) SetPrerequisites(ctx context.Context, seriesID string, prerequisiteIDs []string, delayMinutes int) error {
+	if service.seriesRepo == nil {
+		return nil
+	}
+
+	var unique []string
+	seen := map[string]bool{}
+	for _, prerequisiteID := range prerequisiteIDs {
+		if prerequisiteID == "" || seen[prerequisiteID] {
+			continue
+		}
+		seen[prerequisiteID] = true
+		if prerequisiteID == seriesID {
+			return ErrPrerequisiteCycle
+		}
+		prerequisite, err := service.seriesRepo.FindByID(ctx, prerequisiteID)
+		if err != nil {
+			return fmt.Errorf("finding prerequisite series: %w", err)
+		}
+		if prerequisite == nil || prerequisite.DeletedAt != nil {
+			return ErrPrerequisiteNotFound
+		}
+		waits, err := service.waitsOn(ctx, prerequisiteID, seriesID, map[string]bool{})
+		if err != nil {
+			return err
+		}
+		if waits {
+			return ErrPrerequisiteCycle
+		}
+		unique = append(unique, prerequisiteID)
+	}
+
+	if err := service.seriesRepo.SetPrerequisites(ctx, seriesID, unique, max(delayMinutes, 0)); err != nil {
+		return err
+	}
+	return service.syncBlocked(ctx, seriesID)
+}
+
+// ActiveSeries lists the series that have not been deleted, by name, for
+// choosing prerequisites.
+func (service *ChoreService) ActiveSeries(ctx context.Context) ([]models.ChoreSeries, error) {
+	if service.seriesRepo == nil {
+		return nil, nil
+	}
+	return service.seriesRepo.FindActive(ctx)
+}
+
+// waitsOn reports whether seriesID waits on target, directly or through its
+// own prerequisites.
+func (service *ChoreService) waitsOn(ctx context.Context, seriesID, target string, visited map[string]bool) (bool, error) {
+	if visited[seriesID] {
+		return false, nil
+	}
+	visited[seriesID] = true
+
+	prerequisites, err := service.seriesRepo.GetPrerequisites(ctx, seriesID)
+	if err != nil {
+		return false, fmt.Errorf("finding prerequisites: %w", err)
+	}
+	for _, prerequisiteID := range prerequisites {
+		if prerequisiteID == target {
+			return true, nil
+		}
+		waits, err := service.waitsOn(ctx, prerequisiteID, target, visited)
+		if err != nil || waits {
+			return waits, err
+		}
+	}
+	return false, nil
+}
+
+// syncBlocked brings a series' open occurrences in line with its
+// prerequisites. An occurrence whose prerequisites are not all done is
+// blocked, keeping its dates. A blocked occurrence whose prerequisites are
+// now done opens, due the series' delay after the last of their completions;
+// it keeps its slot like a snoozed occurrence. A skipped prerequisite counts
+// as done but sets no due time.
+func (service *ChoreService) syncBlocked(ctx context.Context, seriesID string) error {
+	series := service.loadSeries(ctx, &seriesID)
+	if series == nil {
+		return nil
+	}
+
+	occurrences, err := service.choreRepo.FindAll(ctx, repository.ChoreFilter{
+		SeriesID: &seriesID,
+		Statuses: []models.ChoreStatus{models.ChoreStatusPending, models.ChoreStatusOverdue, models.ChoreStatusBlocked},
+	})
+	if err != nil {
+		return fmt.Errorf("finding open occurrences: %w", err)
+	}
+
+	for _, occurrence := range occurrences {
+		if occurrence.DueDate == nil {
+			continue
+		}
+		done, doneAt, err := service.prerequisitesDone(ctx, series.Prerequisites, seriesSlot(occurrence))
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case !done && occurrence.Status != models.ChoreStatusBlocked:
+			occurrence.Status = models.ChoreStatusBlocked
+		case done && occurrence.Status == models.ChoreStatusBlocked:
+			occurrence = service.unblock(ctx, occurrence, doneAt, series.PrerequisiteDelayMinutes)
+		default:
+			continue
+		}
+		if err := service.choreRepo.Update(ctx, occurrence); err != nil {
+			return fmt.Errorf("updating blocked occurrence: %w", err)
+		}
+	}
+	return nil
+}
+
+// prerequisitesDone reports whether every prerequisite series' occurrence
+// for the cycle ending on slot is completed or skipped, and when the last of
+// them was completed. A prerequisite with no occurrence by then, or that
+// ended before slot, has nothing to wait for; the latter is what hands a
+// split series' dependents over to the series that took over from it.
+func (service *ChoreService) prerequisitesDone(ctx context.Context, prerequisiteIDs []string, slot time.Time) (bool, *time.Time, error) {
+	var doneAt *time.Time
+	for _, prerequisiteID := range prerequisiteIDs {
+		if series := service.loadSeries(ctx, &prerequisiteID); series != nil && series.RecurrenceUntil != nil && series.RecurrenceUntil.Before(slot) {
+			continue
+		}
+		prerequisite, err := service.choreRepo.FindLatestInSeriesOnOrBefore(ctx, prerequisiteID, slot)
+		if err != nil {
+			return false, nil, fmt.Errorf("finding prerequisite occurrence: %w", err)
+		}
+		if prerequisite == nil {
+			continue
+		}
+		switch prerequisite.Status {
+		case models.ChoreStatusCompleted:
+			if prerequisite.CompletedAt != nil && (doneAt == nil || prerequisite.CompletedAt.After(*doneAt)) {
+				doneAt = prerequisite.CompletedAt
+			}
+		case models.ChoreStatusSkipped:
+		default:
+			return false, nil, nil
+		}
+	}
+	return true, doneAt, nil
+}
+
+// unblock opens a blocked occurrence, due delayMinutes after doneAt when
+// there is a completion to count from.
+func (service *ChoreService) unblock(ctx context.Context, occurrence models.Chore, doneAt *time.Time, delayMinutes int) models.Chore {
+	occurrence.Status = models.ChoreStatusPending
+	if doneAt != nil {
+		dueDate, dueTime := splitDueAt(doneAt.Add(time.Duration(delayMinutes)*time.Minute), service.Location(ctx))
+		if occurrence.OriginalDueDate == nil && !dueDate.Equal(*occurrence.DueDate) {
+			occurrence.OriginalDueDate = occurrence.DueDate
+		}
+		occurrence.DueDate = &dueDate
+		occurrence.DueTime = &dueTime
+	}
+	if repository.IsOverdue(occurrence, service.now(ctx)) {
+		occurrence.Status = models.ChoreStatusOverdue
+	}
+	return occurrence
+}
+
+// refreshDependents re-checks the occurrences of every series waiting on
+// seriesID after one of its occurrences changed. Failures are logged: the
+// periodic top-up re-checks them anyway.
+func (service *ChoreService) refreshDependents(ctx context.Context, seriesID *string) {
+	if service.seriesRepo == nil || seriesID == nil {
+		return
+	}
+	dependents, err := service.seriesRepo.FindDependents(ctx, *seriesID)
+	if err != nil {
+		slog.Error("finding dependent series", "series_id", *seriesID, "error", err)
+		return
+	}
+	for _, dependentID := range dependents {
+		if err := service.syncBlocked(ctx, dependentID); err != nil {
+			slog.Error("updating blocked occurrences", "series_id", dependentID, "error", err)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+)
+
+// newDailySeries creates a daily series named name whose anchor is due on
+// first, assigned to user.
+func newDailySeries(t *testing.T, choreRepo *repository.SQLiteChoreRepository, seriesRepo *repository.SQLiteChoreSeriesRepository, name string, user models.User, first time.Time) models.Chore {
+	t.Helper()
+	return newRecurringChore(t, choreRepo, seriesRepo,
+		models.ChoreSeries{Name: name, RecurrenceType: models.RecurrenceDaily, RecurrenceValue: `{"interval":1}`},
+		models.Chore{
+			Name:             name,
+			CreatedByUserID:  user.ID,
+			AssignedToUserID: &user.ID,
+			DueDate:          &first,
+			Status:           models.ChoreStatusPending,
+		})
+}
+
+func TestChoreService_Prerequisites_BlockUntilDone(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 1)
+
+	now := time.Now()
+	first := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	until := first.AddDate(0, 0, 3)
+	washing := newDailySeries(t, choreRepo, seriesRepo, "Run the washing machine", users[0], first)
+	hanging := newDailySeries(t, choreRepo, seriesRepo, "Hang out the washing", users[0], first)
+	for _, chore := range []models.Chore{washing, hanging} {
+		if err := service.SeedFutureOccurrences(ctx, chore, until); err != nil {
+			t.Fatalf("SeedFutureOccurrences: %v", err)
+		}
+	}
+
+	if err := service.SetPrerequisites(ctx, hanging.ID, []string{washing.ID}, 90); err != nil {
+		t.Fatalf("SetPrerequisites: %v", err)
+	}
+	for day, occurrence := range occurrencesByDay(t, choreRepo, hanging.ID) {
+		if occurrence.Status != models.ChoreStatusBlocked {
+			t.Errorf("%s: expected blocked, got %s", day, occurrence.Status)
+		}
+	}
+	if err := service.CompleteChore(ctx, hanging.ID, users[0].ID); !errors.Is(err, services.ErrChoreNotOpen) {
+		t.Errorf("expected a blocked chore to refuse completion, got %v", err)
+	}
+
+	if err := service.CompleteChore(ctx, washing.ID, users[0].ID); err != nil {
+		t.Fatalf("CompleteChore: %v", err)
+	}
+	completed, _ := choreRepo.FindByID(ctx, washing.ID)
+
+	byDay := occurrencesByDay(t, choreRepo, hanging.ID)
+	dueAt := completed.CompletedAt.Add(90 * time.Minute).In(services.DefaultLocation)
+	opened, ok := byDay[dueAt.Format("2006-01-02")]
+	if !ok || opened.ID != hanging.ID {
+		t.Fatalf("expected the first occurrence due on the day of completion plus the delay, got %+v", byDay)
+	}
+	if opened.Status != models.ChoreStatusPending {
+		t.Errorf("expected the first occurrence opened, got %s", opened.Status)
+	}
+	wantTime := dueAt.Format("15:04")
+	if opened.DueTime == nil || *opened.DueTime != wantTime {
+		t.Errorf("expected due at %s, got %v", wantTime, opened.DueTime)
+	}
+	for _, occurrence := range byDay {
+		if occurrence.ID != hanging.ID && occurrence.Status != models.ChoreStatusBlocked {
+			t.Errorf("later cycle opened early: %s is %s", occurrence.DueDate.Format("2006-01-02"), occurrence.Status)
+		}
+	}
+
+	// Undoing the prerequisite blocks the occurrence again.
+	if err := service.UncompleteChore(ctx, users[0], washing.ID); err != nil {
+		t.Fatalf("UncompleteChore: %v", err)
+	}
+	reblocked, _ := choreRepo.FindByID(ctx, hanging.ID)
+	if reblocked.Status != models.ChoreStatusBlocked {
+		t.Errorf("expected the occurrence blocked again, got %s", reblocked.Status)
+	}
+
+	// Dropping the prerequisite opens everything.
+	if err := service.SetPrerequisites(ctx, hanging.ID, nil, 0); err != nil {
+		t.Fatalf("SetPrerequisites: %v", err)
+	}
+	for day, occurrence := range occurrencesByDay(t, choreRepo, hanging.ID) {
+		if occurrence.Status == models.ChoreStatusBlocked {
+			t.Errorf("%s: still blocked without prerequisites", day)
+		}
+	}
+}
+
+func TestChoreService_Prerequisites_SeededOccurrencesBlocked(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 1)
+
+	now := time.Now()
+	first := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	binsOut := newDailySeries(t, choreRepo, seriesRepo, "Put the bins out", users[0], first)
+	binsIn := newDailySeries(t, choreRepo, seriesRepo, "Bring the bins in", users[0], first.AddDate(0, 0, 1))
+	if err := service.SetPrerequisites(ctx, binsIn.ID, []string{binsOut.ID}, 0); err != nil {
+		t.Fatalf("SetPrerequisites: %v", err)
+	}
+
+	until := first.AddDate(0, 0, 5)
+	if err := service.TopUpAllSeries(ctx, until); err != nil {
+		t.Fatalf("TopUpAllSeries: %v", err)
+	}
+
+	byDay := occurrencesByDay(t, choreRepo, binsIn.ID)
+	if len(byDay) < 2 {
+		t.Fatalf("expected the dependent series topped up, got %d occurrences", len(byDay))
+	}
+	for day, occurrence := range byDay {
+		if occurrence.Status != models.ChoreStatusBlocked {
+			t.Errorf("%s: expected a newly seeded occurrence blocked, got %s", day, occurrence.Status)
+		}
+	}
+
+	dueToday, err := choreRepo.FindAll(ctx, repository.ChoreFilter{
+		Statuses: []models.ChoreStatus{models.ChoreStatusPending, models.ChoreStatusOverdue},
+		SeriesID: &binsIn.ID,
+	})
+	if err != nil || len(dueToday) != 0 {
+		t.Errorf("expected no open occurrences of a blocked series, got %d (%v)", len(dueToday), err)
+	}
+}
+
+func TestChoreService_Prerequisites_RejectsCycles(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 1)
+
+	first := time.Now().AddDate(0, 0, 1)
+	a := newDailySeries(t, choreRepo, seriesRepo, "A", users[0], first)
+	b := newDailySeries(t, choreRepo, seriesRepo, "B", users[0], first)
+	c := newDailySeries(t, choreRepo, seriesRepo, "C", users[0], first)
+
+	if err := service.SetPrerequisites(ctx, b.ID, []string{a.ID}, 0); err != nil {
+		t.Fatalf("SetPrerequisites: %v", err)
+	}
+	if err := service.SetPrerequisites(ctx, c.ID, []string{b.ID}, 0); err != nil {
+		t.Fatalf("SetPrerequisites: %v", err)
+	}
+
+	if err := service.SetPrerequisites(ctx, a.ID, []string{c.ID}, 0); !errors.Is(err, services.ErrPrerequisiteCycle) {
+		t.Errorf("expected ErrPrerequisiteCycle for a loop through B, got %v", err)
+	}
+	if err := service.SetPrerequisites(ctx, a.ID, []string{a.ID}, 0); !errors.Is(err, services.ErrPrerequisiteCycle) {
+		t.Errorf("expected ErrPrerequisiteCycle for a self prerequisite, got %v", err)
+	}
+	if err := service.SetPrerequisites(ctx, a.ID, []string{"missing"}, 0); !errors.Is(err, services.ErrPrerequisiteNotFound) {
+		t.Errorf("expected ErrPrerequisiteNotFound, got %v", err)
+	}
+
+	series, _ := seriesRepo.FindByID(ctx, a.ID)
+	if len(series.Prerequisites) != 0 {
+		t.Errorf("refused prerequisites should not be stored, got %v", series.Prerequisites)
+	}
+}
+
+func TestChoreService_Prerequisites_KeptAcrossSplit(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 1)
+
+	now := time.Now()
+	first := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	until := first.AddDate(0, 0, 7)
+	washing := newDailySeries(t, choreRepo, seriesRepo, "Run the washing machine", users[0], first)
+	hanging := newDailySeries(t, choreRepo, seriesRepo, "Hang out the washing", users[0], first)
+	for _, chore := range []models.Chore{washing, hanging} {
+		if err := service.SeedFutureOccurrences(ctx, chore, until); err != nil {
+			t.Fatalf("SeedFutureOccurrences: %v", err)
+		}
+	}
+	if err := service.SetPrerequisites(ctx, hanging.ID, []string{washing.ID}, 0); err != nil {
+		t.Fatalf("SetPrerequisites: %v", err)
+	}
+	day := func(offset int) string { return first.AddDate(0, 0, offset).Format("2006-01-02") }
+
+	// Split the prerequisite: the dependent now waits on the new series from
+	// the split on, and on the old one before it.
+	split := occurrencesByDay(t, choreRepo, washing.ID)[day(3)]
+	edited := split
+	edited.Name = "Run the washing machine (eco)"
+	newWashing, err := service.SplitSeries(ctx, split, edited, nil)
+	if err != nil {
+		t.Fatalf("SplitSeries: %v", err)
+	}
+	if dependents, _ := seriesRepo.FindDependents(ctx, newWashing.ID); len(dependents) != 1 || dependents[0] != hanging.ID {
+		t.Fatalf("expected the dependent to wait on the new series, got %v", dependents)
+	}
+
+	hangingByDay := occurrencesByDay(t, choreRepo, hanging.ID)
+	if err := service.CompleteChore(ctx, newWashing.ID, users[0].ID); err != nil {
+		t.Fatalf("CompleteChore: %v", err)
+	}
+	for offset, blocked := range map[int]bool{2: true, 3: false, 4: true} {
+		occurrence, _ := choreRepo.FindByID(ctx, hangingByDay[day(offset)].ID)
+		if (occurrence.Status == models.ChoreStatusBlocked) != blocked {
+			t.Errorf("%s: expected blocked=%v, got %s", day(offset), blocked, occurrence.Status)
+		}
+	}
+
+	// Split the dependent: the new series keeps waiting.
+	split = hangingByDay[day(5)]
+	edited = split
+	edited.Name = "Hang out the washing indoors"
+	newHanging, err := service.SplitSeries(ctx, split, edited, nil)
+	if err != nil {
+		t.Fatalf("SplitSeries: %v", err)
+	}
+	if prerequisites, _ := seriesRepo.GetPrerequisites(ctx, newHanging.ID); len(prerequisites) != 2 {
+		t.Errorf("expected the new series to keep both prerequisites, got %v", prerequisites)
+	}
+	for occurrenceDay, occurrence := range occurrencesByDay(t, choreRepo, newHanging.ID) {
+		if occurrence.Status != models.ChoreStatusBlocked {
+			t.Errorf("%s: expected the split dependent blocked, got %s", occurrenceDay, occurrence.Status)
+		}
+	}
+}
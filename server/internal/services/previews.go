@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+)
+
+var (
+	ErrPreviewCalendar   = errors.New("chores that follow a calendar take their dates from its events and cannot be previewed")
+	ErrPreviewNoAssignee = errors.New("no users available for assignment")
+)
+
+const (
+	DefaultPreviewOccurrences = 5
+	MaxPreviewOccurrences     = 20
+)
+
+// PreviewOccurrence is one occurrence a draft series would generate: its due
+// date and who it would go to. A group chore has Participants and no
+// AssigneeID.
+type PreviewOccurrence struct {
+	DueDate      time.Time
+	AssigneeID   *string
+	Participants []string
+}
+
+// PreviewSeries works out the first count occurrences of a draft series
+// without storing anything. The first is the draft's due date (today when
+// unset); later dates come from the same walk through the recurrence rule,
+// end conditions, seasons and exceptions that seeds a fixed schedule, or the
+// same next-date rule as completing a chore that recurs on completion (taken
+// to be done on each due date). Each occurrence is assigned through the same
+// strategy, availability and rotation as a real one, continuing from the
+// previous occurrence's assignee. When draft.SeriesID is set the preview
+// continues that series: its rotation, the slots deleted or edited on their
+// own, and the occurrences that already count towards its cap. Loads the
+// strategies look at are today's, and a random strategy's picks are only an
+// example.
+func (service *ChoreService) PreviewSeries(ctx context.Context, draft models.Chore, eligibleIDs []string, seasons []models.SeasonWindow, count int) ([]PreviewOccurrence, error) {
+	if draft.RecurrenceType == models.RecurrenceCalendar {
+		return nil, ErrPreviewCalendar
+	}
+	if count <= 0 {
+		count = DefaultPreviewOccurrences
+	}
+	count = min(count, MaxPreviewOccurrences)
+
+	if _, err := parseRecurrence(draft.RecurrenceValue, draft.RecurrenceRule); err != nil {
+		return nil, err
+	}
+	candidates, err := service.candidatesFrom(ctx, eligibleIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, ErrPreviewNoAssignee
+	}
+
+	first := dateOnly(service.now(ctx))
+	if draft.DueDate != nil {
+		first = *draft.DueDate
+	}
+
+	// The draft is walked as its series would be: with its seasons and,
+	// continuing a series, the slots already deleted or edited on their own
+	// and the occurrences that already use up its cap.
+	walked := &models.ChoreSeries{Seasons: seasons}
+	var lastAssignedUserID *string
+	used := 0
+	if series := service.loadSeries(ctx, draft.SeriesID); series != nil {
+		walked.Exceptions = series.Exceptions
+		lastAssignedUserID = series.RotationCursorUserID
+		used, err = service.occurrencesUsed(ctx, *series, first)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	occurrences := []PreviewOccurrence{}
+	visit := func(day time.Time) (bool, error) {
+		draft.DueDate = &day
+		assignee, participants, err := service.nextAssignment(ctx, draft, candidates, lastAssignedUserID)
+		if err != nil {
+			return false, err
+		}
+		occurrence := PreviewOccurrence{DueDate: day}
+		if participants != nil {
+			for _, participant := range participants {
+				occurrence.Participants = append(occurrence.Participants, participant.ID)
+			}
+		} else {
+			occurrence.AssigneeID = &assignee.ID
+			lastAssignedUserID = &assignee.ID
+			draft.LastAssignedIndex = candidateIndex(candidates, assignee.ID)
+		}
+		occurrences = append(occurrences, occurrence)
+		used++
+		return len(occurrences) < count, nil
+	}
+
+	// The first occurrence is the draft's own due date, as given.
+	more, err := visit(first)
+	if err != nil {
+		return nil, err
+	}
+	if !more || draft.RecurrenceType == models.RecurrenceNone {
+		return occurrences, nil
+	}
+
+	if !draft.RecurOnComplete {
+		if err := service.walkSeriesSlots(ctx, draft, walked, first, time.Time{}, used, false, visit); err != nil {
+			return nil, err
+		}
+		return occurrences, nil
+	}
+
+	current := first
+	for more && (draft.RecurrenceCount == nil || used < *draft.RecurrenceCount) {
+		next, err := nextRecurrenceDue(draft, walked, current)
+		if err != nil {
+			return nil, err
+		}
+		if next == nil {
+			break
+		}
+		current = *next
+		if more, err = visit(current); err != nil {
+			return nil, err
+		}
+	}
+	return occurrences, nil
+}
+
+// occurrencesUsed counts the occurrences of the series that would still use
+// up its cap were it seeded again from day: those before it, the slots
+// deleted, and the occurrences edited on their own, which re-seeding keeps.
+func (service *ChoreService) occurrencesUsed(ctx context.Context, series models.ChoreSeries, day time.Time) (int, error) {
+	used, err := service.occurrencesBefore(ctx, series.ID, day)
+	if err != nil {
+		return 0, err
+	}
+	for _, exception := range series.Exceptions {
+		if !exception.OccurrenceDate.Before(day) {
+			used++
+		}
+	}
+	return used, nil
+}
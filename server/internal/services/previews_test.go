@@ -0,0 +1,107 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+)
+
+func TestChoreService_PreviewSeries_RotatesWithoutSaving(t *testing.T) {
+	service, choreRepo, _, userRepo, _ := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 2)
+
+	start := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 3)
+	count := 3
+	draft := models.Chore{
+		DueDate:            &start,
+		RecurrenceType:     models.RecurrenceWeekly,
+		RecurrenceValue:    `{"interval":1}`,
+		RecurrenceCount:    &count,
+		AssignmentStrategy: models.AssignmentRoundRobin,
+		LastAssignedIndex:  -1,
+	}
+
+	occurrences, err := service.PreviewSeries(ctx, draft, []string{users[0].ID, users[1].ID}, nil, 10)
+	if err != nil {
+		t.Fatalf("PreviewSeries: %v", err)
+	}
+	if len(occurrences) != 3 {
+		t.Fatalf("expected the preview to stop at the series' 3 occurrences, got %d", len(occurrences))
+	}
+	for i, occurrence := range occurrences {
+		if want := start.AddDate(0, 0, 7*i); !occurrence.DueDate.Equal(want) {
+			t.Errorf("occurrence %d due %s, want %s", i, occurrence.DueDate.Format("2006-01-02"), want.Format("2006-01-02"))
+		}
+		if occurrence.AssigneeID == nil {
+			t.Fatalf("occurrence %d has no assignee", i)
+		}
+		if i > 0 && *occurrence.AssigneeID == *occurrences[i-1].AssigneeID {
+			t.Errorf("occurrences %d and %d both went to %s", i-1, i, *occurrence.AssigneeID)
+		}
+	}
+
+	chores, _ := choreRepo.FindAll(ctx, repository.ChoreFilter{})
+	if len(chores) != 0 {
+		t.Errorf("previewing should not save chores, found %d", len(chores))
+	}
+}
+
+func TestChoreService_PreviewSeries_RejectsCalendarAndEmptyPool(t *testing.T) {
+	service, _, _, _, _ := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+
+	_, err := service.PreviewSeries(ctx, models.Chore{RecurrenceType: models.RecurrenceCalendar}, nil, nil, 0)
+	if !errors.Is(err, services.ErrPreviewCalendar) {
+		t.Errorf("expected ErrPreviewCalendar, got %v", err)
+	}
+	_, err = service.PreviewSeries(ctx, models.Chore{RecurrenceType: models.RecurrenceDaily, RecurrenceValue: `{"interval":1}`}, nil, nil, 0)
+	if !errors.Is(err, services.ErrPreviewNoAssignee) {
+		t.Errorf("expected ErrPreviewNoAssignee, got %v", err)
+	}
+}
+
+func TestChoreService_PreviewSeries_ContinuesExistingSeries(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 2)
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	chore, first := seedDailyRotation(t, service, choreRepo, seriesRepo, users)
+	if err := service.SeedFutureOccurrences(ctx, chore, first.AddDate(0, 0, 7)); err != nil {
+		t.Fatalf("SeedFutureOccurrences: %v", err)
+	}
+	deleted := first.AddDate(0, 0, 2)
+	if err := service.DeleteChore(ctx, occurrencesByDay(t, choreRepo, chore.ID)[deleted.Format("2006-01-02")], services.EditScopeOccurrence); err != nil {
+		t.Fatalf("DeleteChore: %v", err)
+	}
+
+	// Capped at four, the deleted slot uses up one of them.
+	limit := 4
+	draft := models.Chore{
+		DueDate:         &first,
+		SeriesID:        &chore.ID,
+		RecurrenceType:  models.RecurrenceDaily,
+		RecurrenceValue: `{"interval":1}`,
+		RecurrenceCount: &limit,
+	}
+	occurrences, err := service.PreviewSeries(ctx, draft, nil, nil, 10)
+	if err != nil {
+		t.Fatalf("PreviewSeries: %v", err)
+	}
+	var days []string
+	for _, occurrence := range occurrences {
+		days = append(days, occurrence.DueDate.Format("2006-01-02"))
+	}
+	want := []string{first.Format("2006-01-02"), first.AddDate(0, 0, 1).Format("2006-01-02"), first.AddDate(0, 0, 3).Format("2006-01-02")}
+	if !slices.Equal(days, want) {
+		t.Errorf("expected %v, skipping the deleted slot and stopping at the cap, got %v", want, days)
+	}
+}
@@ -14,6 +14,16 @@ type RecurrenceConfig struct {
 	Days     []string `json:"days,omitempty"`
 	DayOfMonth int    `json:"day_of_month,omitempty"`
 	Pattern  string   `json:"pattern,omitempty"`
+
+	// Calendar recurrence: one occurrence per event of the linked iCal
+	// subscription whose summary contains Summary, due LeadHours before it.
+	SubscriptionID string `json:"subscription_id,omitempty"`
+	Summary        string `json:"summary,omitempty"`
+	LeadHours      int    `json:"lead_hours,omitempty"`
+
+	// Rule is the parsed RRULE when the series has one; it is never part of
+	// the stored JSON value.
+	Rule *RRule `json:"-"`
 }
 
 func parseConfig(recurrenceValue string) (RecurrenceConfig, error) {
@@ -26,6 +36,23 @@ func parseConfig(recurrenceValue string) (RecurrenceConfig, error) {
 	return config, nil
 }
 
+// parseRecurrence builds the config for a series from its stored value and
+// optional RRULE.
+func parseRecurrence(recurrenceValue, recurrenceRule string) (RecurrenceConfig, error) {
+	config, err := parseConfig(recurrenceValue)
+	if err != nil {
+		return config, err
+	}
+	if recurrenceRule != "" {
+		rule, err := ParseRRule(recurrenceRule)
+		if err != nil {
+			return config, err
+		}
+		config.Rule = rule
+	}
+	return config, nil
+}
+
 func intervalOrDefault(interval int) int {
 	if interval <= 0 {
 		return 1
@@ -61,17 +88,25 @@ func advanceToNextOccurrence(from time.Time, recurrenceType models.RecurrenceTyp
 			return from.AddDate(0, 0, interval)
 		}
 
-	case models.RecurrenceCalendar:
-		// Intentional alias of daily for now. Real ICS/calendar-driven
-		// scheduling is not yet implemented.
-		// TODO: drive `calendar` occurrences from a linked iCal feed.
-		return from.AddDate(0, 0, 1)
-
 	default:
 		return from
 	}
 }
 
+// nextOccurrence returns the first occurrence after from. An RRULE takes
+// precedence over the simple recurrence type; ok is false once the rule has no
+// further occurrences. Calendar series have no computable next date: their
+// occurrences come from the linked feed (see seedCalendarOccurrences).
+func nextOccurrence(from time.Time, recurrenceType models.RecurrenceType, config RecurrenceConfig) (time.Time, bool) {
+	if recurrenceType == models.RecurrenceCalendar {
+		return time.Time{}, false
+	}
+	if config.Rule != nil {
+		return config.Rule.Next(from)
+	}
+	return advanceToNextOccurrence(from, recurrenceType, config), true
+}
+
 // CalculateNextDueDate returns the next due date after a completion. It is only
 // valid for RecurOnComplete chores, where the schedule advances relative to the
 // completion time. Fixed-schedule (non-RecurOnComplete) chores are materialized
@@ -86,12 +121,15 @@ func CalculateNextDueDate(chore models.Chore, completedAt time.Time) (*time.Time
 		baseDate = *chore.DueDate
 	}
 
-	config, err := parseConfig(chore.RecurrenceValue)
+	config, err := parseRecurrence(chore.RecurrenceValue, chore.RecurrenceRule)
 	if err != nil {
 		return nil, err
 	}
 
-	nextDate := advanceToNextOccurrence(baseDate, chore.RecurrenceType, config)
+	nextDate, ok := nextOccurrence(baseDate, chore.RecurrenceType, config)
+	if !ok {
+		return nil, nil
+	}
 	return &nextDate, nil
 }
 
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+)
+
+var (
+	ErrRewardUnavailable = errors.New("reward is not available")
+	ErrRewardInvalid     = errors.New("reward needs a name and a positive cost")
+	ErrInvalidAllowance  = errors.New("allowance per point must be a non-negative amount")
+)
+
+// PointsSummary is a member's points position. Available is what they can
+// still redeem: the balance less redemptions awaiting approval. Allowance is
+// the balance converted at the family's money-per-point rate, empty when no
+// rate is configured.
+type PointsSummary struct {
+	Balance   int
+	Pending   int
+	Available int
+	Allowance string
+}
+
+// RewardService runs the rewards catalogue. Members redeem against their
+// available points; nothing is debited until an admin approves.
+type RewardService struct {
+	rewardRepo    repository.RewardRepository
+	pointsRepo    repository.PointsRepository
+	settingsRepo  repository.SettingsRepository
+	notifications *NotificationService
+}
+
+func NewRewardService(
+	rewardRepo repository.RewardRepository,
+	pointsRepo repository.PointsRepository,
+	settingsRepo repository.SettingsRepository,
+	notifications *NotificationService,
+) *RewardService {
+	return &RewardService{
+		rewardRepo:    rewardRepo,
+		pointsRepo:    pointsRepo,
+		settingsRepo:  settingsRepo,
+		notifications: notifications,
+	}
+}
+
+func (service *RewardService) Summary(ctx context.Context, userID string) (PointsSummary, error) {
+	balance, err := service.pointsRepo.Balance(ctx, userID)
+	if err != nil {
+		return PointsSummary{}, err
+	}
+	pending, err := service.rewardRepo.PendingCost(ctx, userID)
+	if err != nil {
+		return PointsSummary{}, err
+	}
+
+	summary := PointsSummary{
+		Balance:   balance,
+		Pending:   pending,
+		Available: balance - pending,
+	}
+	if rate, ok := service.AllowanceRate(ctx); ok {
+		summary.Allowance = FormatAllowance(balance, rate)
+	}
+	return summary, nil
+}
+
+// AllowanceRate returns the configured money-per-point rate, or false when the
+// allowance is disabled.
+func (service *RewardService) AllowanceRate(ctx context.Context) (float64, bool) {
+	value, err := service.settingsRepo.Get(ctx, repository.SettingsKeyAllowancePerPoint)
+	if err != nil || value == "" {
+		return 0, false
+	}
+	rate, err := ParseAllowanceRate(value)
+	if err != nil || rate == 0 {
+		return 0, false
+	}
+	return rate, true
+}
+
+// ParseAllowanceRate parses a decimal money-per-point rate such as "0.25".
+func ParseAllowanceRate(value string) (float64, error) {
+	rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || rate < 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+		return 0, ErrInvalidAllowance
+	}
+	return rate, nil
+}
+
+// FormatAllowance converts points to money at rate, rounded to the cent.
+func FormatAllowance(points int, rate float64) string {
+	cents := math.Round(float64(points) * rate * 100)
+	return strconv.FormatFloat(cents/100, 'f', 2, 64)
+}
+
+// Ledger returns the user's most recent points entries, newest first.
+func (service *RewardService) Ledger(ctx context.Context, userID string, limit int) ([]models.PointsEntry, error) {
+	return service.pointsRepo.FindByUser(ctx, userID, limit)
+}
+
+// Catalogue lists the rewards members can redeem; admins managing the
+// catalogue also see retired ones.
+func (service *RewardService) Catalogue(ctx context.Context, includeInactive bool) ([]models.Reward, error) {
+	return service.rewardRepo.FindAll(ctx, includeInactive)
+}
+
+func (service *RewardService) FindReward(ctx context.Context, rewardID string) (models.Reward, error) {
+	return service.rewardRepo.FindByID(ctx, rewardID)
+}
+
+func (service *RewardService) CreateReward(ctx context.Context, reward models.Reward) (models.Reward, error) {
+	reward.Name = strings.TrimSpace(reward.Name)
+	if reward.Name == "" || reward.Cost < 1 {
+		return models.Reward{}, ErrRewardInvalid
+	}
+	reward.Active = true
+	return service.rewardRepo.Create(ctx, reward)
+}
+
+// UpdateReward saves an edited reward. Retiring a reward (Active false) hides
+// it from members but keeps its redemption history.
+func (service *RewardService) UpdateReward(ctx context.Context, reward models.Reward) error {
+	reward.Name = strings.TrimSpace(reward.Name)
+	if reward.Name == "" || reward.Cost < 1 {
+		return ErrRewardInvalid
+	}
+	return service.rewardRepo.Update(ctx, reward)
+}
+
+func (service *RewardService) Redemptions(ctx context.Context, filter repository.RedemptionFilter) ([]models.RewardRedemption, error) {
+	return service.rewardRepo.FindRedemptions(ctx, filter)
+}
+
+// Redeem requests a reward on the member's behalf. The reward must be active
+// and its cost covered by the member's available points.
+func (service *RewardService) Redeem(ctx context.Context, userID, rewardID string) (models.RewardRedemption, error) {
+	reward, err := service.rewardRepo.FindByID(ctx, rewardID)
+	if err != nil {
+		return models.RewardRedemption{}, fmt.Errorf("finding reward: %w", err)
+	}
+	if !reward.Active {
+		return models.RewardRedemption{}, ErrRewardUnavailable
+	}
+
+	summary, err := service.Summary(ctx, userID)
+	if err != nil {
+		return models.RewardRedemption{}, err
+	}
+	if summary.Available < reward.Cost {
+		return models.RewardRedemption{}, repository.ErrInsufficientPoints
+	}
+
+	redemption, err := service.rewardRepo.CreateRedemption(ctx, models.RewardRedemption{
+		RewardID: reward.ID,
+		UserID:   userID,
+		Cost:     reward.Cost,
+	})
+	if err != nil {
+		return models.RewardRedemption{}, err
+	}
+
+	service.notifications.NotifyAdmins(ctx, userID, models.Notification{
+		Event: models.NotifyApprovalRequested,
+		Title: "Reward requested: " + reward.Name,
+		Body:  fmt.Sprintf("%s wants to redeem it for %d points.", service.notifications.userName(ctx, userID), reward.Cost),
+		Link:  "/rewards",
+	})
+	return redemption, nil
+}
+
+// ApproveRedemption debits the redemption's cost from the member's ledger.
+func (service *RewardService) ApproveRedemption(ctx context.Context, redemptionID, adminID string) error {
+	redemption, err := service.rewardRepo.FindRedemptionByID(ctx, redemptionID)
+	if err != nil {
+		return err
+	}
+	if redemption.Status != models.RedemptionPending {
+		return repository.ErrRedemptionResolved
+	}
+	return service.rewardRepo.ApproveRedemption(ctx, redemption, adminID)
+}
+
+func (service *RewardService) RejectRedemption(ctx context.Context, redemptionID, adminID string) error {
+	if _, err := service.rewardRepo.FindRedemptionByID(ctx, redemptionID); err != nil {
+		return err
+	}
+	return service.rewardRepo.RejectRedemption(ctx, redemptionID, adminID)
+}
@@ -0,0 +1,132 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+)
+
+func TestRewardService_CompletingChoreEarnsEffortPoints(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	assignmentRepo := repository.NewChoreAssignmentRepository(db)
+	pointsRepo := repository.NewPointsRepository(db)
+	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, repository.NewChoreSeriesRepository(db), pointsRepo, nil, nil, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	users := createUsers(t, userRepo, 2)
+	alice, bob := users[0], users[1]
+
+	chore, err := choreRepo.Create(ctx, models.Chore{
+		Name:             "Mow lawn",
+		CreatedByUserID:  alice.ID,
+		AssignedToUserID: &alice.ID,
+		Status:           models.ChoreStatusPending,
+		EffortPoints:     5,
+	})
+	if err != nil {
+		t.Fatalf("creating chore: %v", err)
+	}
+
+	// Bob covers for Alice, so Bob earns the points.
+	if err := choreService.CompleteChore(ctx, chore.ID, bob.ID); err != nil {
+		t.Fatalf("CompleteChore: %v", err)
+	}
+
+	if balance, _ := pointsRepo.Balance(ctx, bob.ID); balance != 5 {
+		t.Errorf("bob balance: got %d, want 5", balance)
+	}
+	if balance, _ := pointsRepo.Balance(ctx, alice.ID); balance != 0 {
+		t.Errorf("alice balance: got %d, want 0", balance)
+	}
+}
+
+func TestRewardService_RedeemReservesAvailablePoints(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	pointsRepo := repository.NewPointsRepository(db)
+	settingsRepo := repository.NewSettingsRepository(db)
+	service := services.NewRewardService(repository.NewRewardRepository(db), pointsRepo, settingsRepo, nil)
+	ctx := context.Background()
+
+	users := createUsers(t, userRepo, 2)
+	parent, kid := users[0], users[1]
+	pointsRepo.Create(ctx, models.PointsEntry{UserID: kid.ID, Points: 10, Reason: models.PointsReasonChore})
+
+	if _, err := service.CreateReward(ctx, models.Reward{Name: " ", Cost: 3, CreatedByUserID: parent.ID}); !errors.Is(err, services.ErrRewardInvalid) {
+		t.Errorf("blank name: got %v, want ErrRewardInvalid", err)
+	}
+	reward, err := service.CreateReward(ctx, models.Reward{Name: "Pocket money", Cost: 6, CreatedByUserID: parent.ID})
+	if err != nil {
+		t.Fatalf("CreateReward: %v", err)
+	}
+
+	redemption, err := service.Redeem(ctx, kid.ID, reward.ID)
+	if err != nil {
+		t.Fatalf("Redeem: %v", err)
+	}
+
+	// The first request holds 6 of the 10 points, so a second can't be covered
+	// even though nothing has been debited yet.
+	if _, err := service.Redeem(ctx, kid.ID, reward.ID); !errors.Is(err, repository.ErrInsufficientPoints) {
+		t.Errorf("second redeem: got %v, want ErrInsufficientPoints", err)
+	}
+
+	summary, _ := service.Summary(ctx, kid.ID)
+	if summary.Balance != 10 || summary.Pending != 6 || summary.Available != 4 {
+		t.Errorf("summary before approval: %+v", summary)
+	}
+
+	if err := service.ApproveRedemption(ctx, redemption.ID, parent.ID); err != nil {
+		t.Fatalf("ApproveRedemption: %v", err)
+	}
+	if err := service.RejectRedemption(ctx, redemption.ID, parent.ID); !errors.Is(err, repository.ErrRedemptionResolved) {
+		t.Errorf("rejecting approved redemption: got %v, want ErrRedemptionResolved", err)
+	}
+
+	summary, _ = service.Summary(ctx, kid.ID)
+	if summary.Balance != 4 || summary.Pending != 0 || summary.Available != 4 {
+		t.Errorf("summary after approval: %+v", summary)
+	}
+
+	reward.Active = false
+	if err := service.UpdateReward(ctx, reward); err != nil {
+		t.Fatalf("UpdateReward: %v", err)
+	}
+	if _, err := service.Redeem(ctx, kid.ID, reward.ID); !errors.Is(err, services.ErrRewardUnavailable) {
+		t.Errorf("redeeming retired reward: got %v, want ErrRewardUnavailable", err)
+	}
+}
+
+func TestRewardService_Allowance(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	pointsRepo := repository.NewPointsRepository(db)
+	settingsRepo := repository.NewSettingsRepository(db)
+	service := services.NewRewardService(repository.NewRewardRepository(db), pointsRepo, settingsRepo, nil)
+	ctx := context.Background()
+
+	kid := createUsers(t, userRepo, 1)[0]
+	pointsRepo.Create(ctx, models.PointsEntry{UserID: kid.ID, Points: 7, Reason: models.PointsReasonChore})
+
+	if summary, _ := service.Summary(ctx, kid.ID); summary.Allowance != "" {
+		t.Errorf("allowance without a rate: got %q, want empty", summary.Allowance)
+	}
+
+	settingsRepo.Set(ctx, repository.SettingsKeyAllowancePerPoint, "0.25")
+	if summary, _ := service.Summary(ctx, kid.ID); summary.Allowance != "1.75" {
+		t.Errorf("allowance: got %q, want 1.75", summary.Allowance)
+	}
+
+	for _, value := range []string{"-1", "abc", "NaN"} {
+		if _, err := services.ParseAllowanceRate(value); !errors.Is(err, services.ErrInvalidAllowance) {
+			t.Errorf("ParseAllowanceRate(%q): got %v, want ErrInvalidAllowance", value, err)
+		}
+	}
+}
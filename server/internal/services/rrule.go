@@ -0,0 +1,539 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+)
+
+// ErrInvalidRecurrenceRule is returned (wrapped) for RRULE strings that cannot
+// be parsed or use parts that are not meaningful for chores.
+var ErrInvalidRecurrenceRule = errors.New("invalid recurrence rule")
+
+type RRuleFrequency string
+
+const (
+	FrequencyDaily   RRuleFrequency = "DAILY"
+	FrequencyWeekly  RRuleFrequency = "WEEKLY"
+	FrequencyMonthly RRuleFrequency = "MONTHLY"
+	FrequencyYearly  RRuleFrequency = "YEARLY"
+)
+
+// RRuleWeekday is one BYDAY entry. N is the optional ordinal ("2TU" is N=2,
+// "-1FR" is N=-1); zero means every matching weekday in the period.
+type RRuleWeekday struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// RRule is a parsed RFC 5545 recurrence rule. Only date-level parts are
+// supported: an occurrence's time of day comes from the chore's due time, so
+// sub-daily frequencies and BYHOUR/BYMINUTE/BYSECOND are rejected.
+type RRule struct {
+	Freq       RRuleFrequency
+	Interval   int
+	Count      int
+	Until      *time.Time
+	ByDay      []RRuleWeekday
+	ByMonthDay []int
+	ByYearDay  []int
+	ByWeekNo   []int
+	ByMonth    []time.Month
+	BySetPos   []int
+	WeekStart  time.Weekday
+}
+
+// maxRRulePeriods bounds the search for the next occurrence so rules that can
+// never match (e.g. BYMONTH=2;BYMONTHDAY=30) terminate.
+const maxRRulePeriods = 1000
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+var rruleWeekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// ParseRRule parses an RRULE value such as "FREQ=MONTHLY;BYDAY=2TU". A leading
+// "RRULE:" prefix is accepted and case is ignored.
+func ParseRRule(raw string) (*RRule, error) {
+	value := strings.ToUpper(strings.TrimSpace(raw))
+	value = strings.TrimPrefix(value, "RRULE:")
+	if value == "" {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRecurrenceRule)
+	}
+
+	rule := &RRule{WeekStart: time.Monday}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+		name, partValue, ok := strings.Cut(part, "=")
+		if !ok || partValue == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRecurrenceRule, part)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%w: %s given more than once", ErrInvalidRecurrenceRule, name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			err = rule.parseFrequency(partValue)
+		case "INTERVAL":
+			rule.Interval, err = parsePositiveInt(partValue)
+		case "COUNT":
+			rule.Count, err = parsePositiveInt(partValue)
+		case "UNTIL":
+			rule.Until, err = parseRRuleUntil(partValue)
+		case "WKST":
+			weekday, ok := rruleWeekdays[partValue]
+			if !ok {
+				err = fmt.Errorf("unknown weekday %q", partValue)
+			}
+			rule.WeekStart = weekday
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(partValue)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseIntList(partValue, 1, 31, true)
+		case "BYYEARDAY":
+			rule.ByYearDay, err = parseIntList(partValue, 1, 366, true)
+		case "BYWEEKNO":
+			rule.ByWeekNo, err = parseIntList(partValue, 1, 53, true)
+		case "BYSETPOS":
+			rule.BySetPos, err = parseIntList(partValue, 1, 366, true)
+		case "BYMONTH":
+			var months []int
+			months, err = parseIntList(partValue, 1, 12, false)
+			for _, month := range months {
+				rule.ByMonth = append(rule.ByMonth, time.Month(month))
+			}
+		case "BYHOUR", "BYMINUTE", "BYSECOND":
+			err = errors.New("time of day is set by the chore's due time")
+		default:
+			err = errors.New("unknown part")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidRecurrenceRule, name, err)
+		}
+	}
+
+	if err := rule.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecurrenceRule, err)
+	}
+	return rule, nil
+}
+
+func (rule *RRule) parseFrequency(value string) error {
+	switch RRuleFrequency(value) {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+		rule.Freq = RRuleFrequency(value)
+		return nil
+	case "HOURLY", "MINUTELY", "SECONDLY":
+		return errors.New("sub-daily frequencies are not supported")
+	default:
+		return fmt.Errorf("unknown frequency %q", value)
+	}
+}
+
+func (rule *RRule) validate() error {
+	if rule.Freq == "" {
+		return errors.New("FREQ is required")
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return errors.New("COUNT and UNTIL cannot both be set")
+	}
+	if len(rule.ByWeekNo) > 0 && rule.Freq != FrequencyYearly {
+		return errors.New("BYWEEKNO is only valid with FREQ=YEARLY")
+	}
+	if len(rule.ByYearDay) > 0 && rule.Freq != FrequencyYearly {
+		return errors.New("BYYEARDAY is only valid with FREQ=YEARLY")
+	}
+	if len(rule.ByMonthDay) > 0 && rule.Freq == FrequencyWeekly {
+		return errors.New("BYMONTHDAY is not valid with FREQ=WEEKLY")
+	}
+	for _, day := range rule.ByDay {
+		if day.N == 0 {
+			continue
+		}
+		if rule.Freq != FrequencyMonthly && rule.Freq != FrequencyYearly {
+			return errors.New("BYDAY ordinals need FREQ=MONTHLY or FREQ=YEARLY")
+		}
+		if rule.Freq == FrequencyYearly && len(rule.ByWeekNo) > 0 {
+			return errors.New("BYDAY ordinals cannot be combined with BYWEEKNO")
+		}
+	}
+	if len(rule.BySetPos) > 0 && len(rule.ByDay)+len(rule.ByMonthDay)+len(rule.ByYearDay)+len(rule.ByWeekNo)+len(rule.ByMonth) == 0 {
+		return errors.New("BYSETPOS needs another BYxxx part")
+	}
+	return nil
+}
+
+func parsePositiveInt(value string) (int, error) {
+	number, err := strconv.Atoi(value)
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("%q is not a positive integer", value)
+	}
+	return number, nil
+}
+
+// parseIntList parses a comma separated list of integers whose magnitude is in
+// [min, max]. Negative values count back from the end of the period and are
+// only accepted when allowNegative is set.
+func parseIntList(value string, min, max int, allowNegative bool) ([]int, error) {
+	var numbers []int
+	for _, item := range strings.Split(value, ",") {
+		number, err := strconv.Atoi(strings.TrimPrefix(item, "+"))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", item)
+		}
+		magnitude := number
+		if magnitude < 0 {
+			if !allowNegative {
+				return nil, fmt.Errorf("%d must be positive", number)
+			}
+			magnitude = -magnitude
+		}
+		if magnitude < min || magnitude > max {
+			return nil, fmt.Errorf("%d is out of range", number)
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, nil
+}
+
+func parseByDay(value string) ([]RRuleWeekday, error) {
+	var days []RRuleWeekday
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("%q is not a weekday", item)
+		}
+		weekday, ok := rruleWeekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("%q is not a weekday", item)
+		}
+		day := RRuleWeekday{Weekday: weekday}
+		if prefix := item[:len(item)-2]; prefix != "" {
+			ordinal, err := strconv.Atoi(strings.TrimPrefix(prefix, "+"))
+			if err != nil || ordinal == 0 || ordinal < -53 || ordinal > 53 {
+				return nil, fmt.Errorf("%q has an invalid ordinal", item)
+			}
+			day.N = ordinal
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// parseRRuleUntil accepts the DATE and DATE-TIME forms. A bare date is
+// inclusive of the whole day.
+func parseRRuleUntil(value string) (*time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405"} {
+		if until, err := time.Parse(layout, value); err == nil {
+			return &until, nil
+		}
+	}
+	date, err := time.Parse("20060102", value)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a date", value)
+	}
+	until := date.Add(24*time.Hour - time.Second)
+	return &until, nil
+}
+
+// RecurrenceType maps the rule onto the simple recurrence type stored beside
+// it, so filters on recurrence type keep working for RRULE series.
+func (rule *RRule) RecurrenceType() models.RecurrenceType {
+	switch rule.Freq {
+	case FrequencyDaily:
+		return models.RecurrenceDaily
+	case FrequencyWeekly:
+		return models.RecurrenceWeekly
+	case FrequencyMonthly:
+		return models.RecurrenceMonthly
+	default:
+		return models.RecurrenceCustom
+	}
+}
+
+// String formats the rule in a canonical part order, without the "RRULE:"
+// prefix.
+func (rule *RRule) String() string {
+	parts := []string{"FREQ=" + string(rule.Freq)}
+	if rule.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", rule.Interval))
+	}
+	if rule.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", rule.Count))
+	}
+	if rule.Until != nil {
+		parts = append(parts, "UNTIL="+rule.Until.UTC().Format("20060102T150405Z"))
+	}
+	if len(rule.ByMonth) > 0 {
+		months := make([]int, len(rule.ByMonth))
+		for i, month := range rule.ByMonth {
+			months[i] = int(month)
+		}
+		parts = append(parts, "BYMONTH="+joinInts(months))
+	}
+	if len(rule.ByWeekNo) > 0 {
+		parts = append(parts, "BYWEEKNO="+joinInts(rule.ByWeekNo))
+	}
+	if len(rule.ByYearDay) > 0 {
+		parts = append(parts, "BYYEARDAY="+joinInts(rule.ByYearDay))
+	}
+	if len(rule.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(rule.ByMonthDay))
+	}
+	if len(rule.ByDay) > 0 {
+		days := make([]string, len(rule.ByDay))
+		for i, day := range rule.ByDay {
+			days[i] = rruleWeekdayNames[day.Weekday]
+			if day.N != 0 {
+				days[i] = strconv.Itoa(day.N) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(rule.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(rule.BySetPos))
+	}
+	if rule.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+rruleWeekdayNames[rule.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+func joinInts(numbers []int) string {
+	items := make([]string, len(numbers))
+	for i, number := range numbers {
+		items[i] = strconv.Itoa(number)
+	}
+	return strings.Join(items, ",")
+}
+
+// Next returns the first occurrence strictly after from, keeping from's time
+// of day and location. from also stands in for DTSTART: parts the rule leaves
+// implicit (the day of the month for a plain FREQ=MONTHLY, say) are taken from
+// it, and INTERVAL counts periods from the one containing it. ok is false once
+// the rule is exhausted by UNTIL or can never match again.
+func (rule *RRule) Next(from time.Time) (next time.Time, ok bool) {
+	interval := intervalOrDefault(rule.Interval)
+	anchor := dateOnly(from)
+	periodStart := rule.periodStart(anchor)
+
+	for i := 0; i < maxRRulePeriods; i++ {
+		for _, day := range rule.expandPeriod(periodStart, anchor) {
+			if !day.After(anchor) {
+				continue
+			}
+			candidate := time.Date(day.Year(), day.Month(), day.Day(), from.Hour(), from.Minute(), from.Second(), 0, from.Location())
+			if rule.Until != nil && candidate.After(*rule.Until) {
+				return time.Time{}, false
+			}
+			return candidate, true
+		}
+		periodStart = rule.advancePeriod(periodStart, interval)
+	}
+	return time.Time{}, false
+}
+
+// dateOnly returns t's calendar date as midnight UTC so day arithmetic is not
+// disturbed by DST transitions in t's location.
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (rule *RRule) periodStart(day time.Time) time.Time {
+	switch rule.Freq {
+	case FrequencyWeekly:
+		return startOfWeekOn(day, rule.WeekStart)
+	case FrequencyMonthly:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case FrequencyYearly:
+		return time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+func (rule *RRule) advancePeriod(periodStart time.Time, interval int) time.Time {
+	switch rule.Freq {
+	case FrequencyWeekly:
+		return periodStart.AddDate(0, 0, 7*interval)
+	case FrequencyMonthly:
+		return periodStart.AddDate(0, interval, 0)
+	case FrequencyYearly:
+		return periodStart.AddDate(interval, 0, 0)
+	default:
+		return periodStart.AddDate(0, 0, interval)
+	}
+}
+
+// expandPeriod returns the sorted occurrence dates within the period starting
+// at periodStart, after BYSETPOS has been applied.
+func (rule *RRule) expandPeriod(periodStart, anchor time.Time) []time.Time {
+	first, end := periodStart, rule.advancePeriod(periodStart, 1)
+	if rule.Freq == FrequencyYearly && len(rule.ByWeekNo) > 0 {
+		// Week numbers belong to a week-numbering year that can start in the
+		// previous December or end in the next January.
+		first = weekOneStart(periodStart.Year(), rule.WeekStart)
+		end = weekOneStart(periodStart.Year()+1, rule.WeekStart)
+	}
+
+	var days []time.Time
+	for day := first; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if rule.matches(day, anchor, first, end) {
+			days = append(days, day)
+		}
+	}
+
+	if len(rule.BySetPos) == 0 {
+		return days
+	}
+	var selected []time.Time
+	for _, position := range rule.BySetPos {
+		index := position - 1
+		if position < 0 {
+			index = len(days) + position
+		}
+		if index >= 0 && index < len(days) {
+			selected = append(selected, days[index])
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].Before(selected[j]) })
+	return uniqueDays(selected)
+}
+
+func uniqueDays(days []time.Time) []time.Time {
+	var unique []time.Time
+	for _, day := range days {
+		if len(unique) == 0 || !unique[len(unique)-1].Equal(day) {
+			unique = append(unique, day)
+		}
+	}
+	return unique
+}
+
+// matches reports whether day satisfies every BYxxx part, filling in the parts
+// RFC 5545 implies from DTSTART (here: anchor) when none are given.
+func (rule *RRule) matches(day, anchor, weekYearStart, weekYearEnd time.Time) bool {
+	if len(rule.ByMonth) > 0 && !containsMonth(rule.ByMonth, day.Month()) {
+		return false
+	}
+	if len(rule.ByWeekNo) > 0 {
+		week := int(day.Sub(weekYearStart).Hours()/24)/7 + 1
+		weeks := int(weekYearEnd.Sub(weekYearStart).Hours()/24) / 7
+		if !matchesOrdinal(rule.ByWeekNo, week, weeks) {
+			return false
+		}
+	}
+	if len(rule.ByYearDay) > 0 && !matchesOrdinal(rule.ByYearDay, day.YearDay(), daysInYear(day.Year())) {
+		return false
+	}
+	if len(rule.ByMonthDay) > 0 && !matchesOrdinal(rule.ByMonthDay, day.Day(), daysInMonth(day.Year(), day.Month())) {
+		return false
+	}
+	if len(rule.ByDay) > 0 && !rule.matchesByDay(day) {
+		return false
+	}
+
+	hasDayPart := len(rule.ByDay) > 0 || len(rule.ByMonthDay) > 0 || len(rule.ByYearDay) > 0
+	switch rule.Freq {
+	case FrequencyWeekly:
+		if len(rule.ByDay) == 0 {
+			return day.Weekday() == anchor.Weekday()
+		}
+	case FrequencyMonthly:
+		if !hasDayPart {
+			return day.Day() == anchor.Day()
+		}
+	case FrequencyYearly:
+		if hasDayPart {
+			return true
+		}
+		if len(rule.ByWeekNo) > 0 {
+			return day.Weekday() == anchor.Weekday()
+		}
+		if len(rule.ByMonth) == 0 && day.Month() != anchor.Month() {
+			return false
+		}
+		return day.Day() == anchor.Day()
+	}
+	return true
+}
+
+func (rule *RRule) matchesByDay(day time.Time) bool {
+	// Ordinals count within the month for MONTHLY rules and for YEARLY rules
+	// narrowed by BYMONTH, otherwise within the year.
+	withinMonth := rule.Freq == FrequencyMonthly || len(rule.ByMonth) > 0
+	position, total := day.Day(), daysInMonth(day.Year(), day.Month())
+	if !withinMonth {
+		position, total = day.YearDay(), daysInYear(day.Year())
+	}
+
+	for _, entry := range rule.ByDay {
+		if entry.Weekday != day.Weekday() {
+			continue
+		}
+		if entry.N == 0 {
+			return true
+		}
+		if entry.N > 0 && (position-1)/7+1 == entry.N {
+			return true
+		}
+		if entry.N < 0 && (total-position)/7+1 == -entry.N {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesOrdinal reports whether value (1-based, out of total) is selected by
+// a list where negative entries count back from the end.
+func matchesOrdinal(ordinals []int, value, total int) bool {
+	for _, ordinal := range ordinals {
+		if ordinal == value || (ordinal < 0 && total+ordinal+1 == value) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsMonth(months []time.Month, month time.Month) bool {
+	for _, candidate := range months {
+		if candidate == month {
+			return true
+		}
+	}
+	return false
+}
+
+func daysInYear(year int) int {
+	return time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+}
+
+// startOfWeekOn returns the date of the weekStart day on or before day.
+func startOfWeekOn(day time.Time, weekStart time.Weekday) time.Time {
+	offset := (int(day.Weekday()) - int(weekStart) + 7) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// weekOneStart returns the first day of week 1 of year: the week, starting on
+// weekStart, that contains at least four days of the year, i.e. the one
+// containing January 4th.
+func weekOneStart(year int, weekStart time.Weekday) time.Time {
+	return startOfWeekOn(time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC), weekStart)
+}
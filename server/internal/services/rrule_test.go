@@ -0,0 +1,241 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+)
+
+func expandRule(t *testing.T, raw string, from time.Time, n int) []time.Time {
+	t.Helper()
+	rule, err := ParseRRule(raw)
+	if err != nil {
+		t.Fatalf("ParseRRule(%q): %v", raw, err)
+	}
+	var occurrences []time.Time
+	cursor := from
+	for len(occurrences) < n {
+		next, ok := rule.Next(cursor)
+		if !ok {
+			break
+		}
+		occurrences = append(occurrences, next)
+		cursor = next
+	}
+	return occurrences
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 9, 0, 0, 0, time.UTC)
+}
+
+func TestRRule_Next(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+		from time.Time
+		want []time.Time
+		// ends marks want as the complete remaining series.
+		ends bool
+	}{
+		{
+			name: "second tuesday of the month",
+			rule: "FREQ=MONTHLY;BYDAY=2TU",
+			from: date(2025, time.January, 1),
+			want: []time.Time{
+				date(2025, time.January, 14),
+				date(2025, time.February, 11),
+				date(2025, time.March, 11),
+			},
+		},
+		{
+			name: "last weekday of the month",
+			rule: "RRULE:FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			from: date(2025, time.January, 1),
+			want: []time.Time{
+				date(2025, time.January, 31),  // Friday
+				date(2025, time.February, 28), // Friday
+				date(2025, time.March, 31),    // Monday
+				date(2025, time.April, 30),    // Wednesday
+			},
+		},
+		{
+			name: "every other friday except in august",
+			rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR;BYMONTH=1,2,3,4,5,6,7,9,10,11,12",
+			from: date(2025, time.July, 18), // Friday
+			want: []time.Time{
+				date(2025, time.September, 12), // Aug 1, 15, 29 are excluded
+				date(2025, time.September, 26),
+			},
+		},
+		{
+			name: "first and last day of the month",
+			rule: "freq=monthly;bymonthday=1,-1",
+			from: date(2025, time.February, 1),
+			want: []time.Time{
+				date(2025, time.February, 28),
+				date(2025, time.March, 1),
+				date(2025, time.March, 31),
+			},
+		},
+		{
+			name: "plain monthly skips months without the day",
+			rule: "FREQ=MONTHLY",
+			from: date(2025, time.January, 31),
+			want: []time.Time{
+				date(2025, time.March, 31),
+				date(2025, time.May, 31),
+			},
+		},
+		{
+			name: "yearly on thanksgiving",
+			rule: "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH",
+			from: date(2025, time.January, 1),
+			want: []time.Time{
+				date(2025, time.November, 27),
+				date(2026, time.November, 26),
+			},
+		},
+		{
+			name: "second to last day of the year",
+			rule: "FREQ=YEARLY;BYYEARDAY=-2",
+			from: date(2024, time.January, 1),
+			want: []time.Time{
+				date(2024, time.December, 30),
+				date(2025, time.December, 30),
+			},
+		},
+		{
+			name: "monday of week 1 belongs to the week-numbering year",
+			rule: "FREQ=YEARLY;BYWEEKNO=1;BYDAY=MO",
+			from: date(2025, time.June, 1),
+			want: []time.Time{
+				date(2025, time.December, 29),
+				date(2027, time.January, 4),
+			},
+		},
+		{
+			name: "every third day",
+			rule: "FREQ=DAILY;INTERVAL=3",
+			from: date(2025, time.February, 27),
+			want: []time.Time{
+				date(2025, time.March, 2),
+				date(2025, time.March, 5),
+			},
+		},
+		{
+			name: "until stops the series",
+			rule: "FREQ=WEEKLY;UNTIL=20250115",
+			from: date(2025, time.January, 1),
+			want: []time.Time{
+				date(2025, time.January, 8),
+				date(2025, time.January, 15),
+			},
+			ends: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := expandRule(t, test.rule, test.from, len(test.want)+1)
+			if test.ends && len(got) != len(test.want) {
+				t.Fatalf("got %d occurrences, want %d: %v", len(got), len(test.want), got)
+			}
+			if len(got) < len(test.want) {
+				t.Fatalf("got %d occurrences, want at least %d: %v", len(got), len(test.want), got)
+			}
+			for i, want := range test.want {
+				if !got[i].Equal(want) {
+					t.Errorf("occurrence %d = %v (%s), want %v (%s)", i, got[i], got[i].Weekday(), want, want.Weekday())
+				}
+			}
+		})
+	}
+}
+
+func TestRRule_NextKeepsWallClockAcrossDST(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	rule, _ := ParseRRule("FREQ=WEEKLY;BYDAY=SU")
+	from := time.Date(2025, time.March, 23, 18, 30, 0, 0, london)
+
+	next, ok := rule.Next(from)
+	want := time.Date(2025, time.March, 30, 18, 30, 0, 0, london)
+	if !ok || !next.Equal(want) {
+		t.Errorf("got %v, want %v", next, want)
+	}
+}
+
+func TestRRule_NeverMatchingRuleEnds(t *testing.T) {
+	rule, err := ParseRRule("FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if next, ok := rule.Next(date(2025, time.January, 1)); ok {
+		t.Errorf("expected no occurrence, got %v", next)
+	}
+}
+
+func TestParseRRule_Invalid(t *testing.T) {
+	rules := []string{
+		"",
+		"BYDAY=MO",
+		"FREQ=HOURLY",
+		"FREQ=FORTNIGHTLY",
+		"FREQ=WEEKLY;BYDAY=2MO",
+		"FREQ=WEEKLY;BYMONTHDAY=3",
+		"FREQ=MONTHLY;BYWEEKNO=3",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=MONTHLY;BYSETPOS=1",
+		"FREQ=DAILY;COUNT=3;UNTIL=20250101",
+		"FREQ=DAILY;BYHOUR=9",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ",
+	}
+	for _, raw := range rules {
+		if _, err := ParseRRule(raw); !errors.Is(err, ErrInvalidRecurrenceRule) {
+			t.Errorf("ParseRRule(%q) error = %v, want ErrInvalidRecurrenceRule", raw, err)
+		}
+	}
+}
+
+func TestRRule_StringRoundTrip(t *testing.T) {
+	rule, err := ParseRRule("rrule:bysetpos=-1;byday=mo,tu,we,th,fr;freq=monthly;interval=2")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := "FREQ=MONTHLY;INTERVAL=2;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1"
+	if got := rule.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if rule.RecurrenceType() != models.RecurrenceMonthly {
+		t.Errorf("RecurrenceType() = %q, want monthly", rule.RecurrenceType())
+	}
+	reparsed, err := ParseRRule(rule.String())
+	if err != nil || reparsed.String() != want {
+		t.Errorf("reparse = %v, %v", reparsed, err)
+	}
+}
+
+func TestCalculateNextDueDate_RecurrenceRule(t *testing.T) {
+	completedAt := time.Date(2025, time.January, 15, 20, 0, 0, 0, time.UTC)
+	chore := models.Chore{
+		RecurrenceType:  models.RecurrenceMonthly,
+		RecurrenceRule:  "FREQ=MONTHLY;BYDAY=2TU",
+		RecurOnComplete: true,
+	}
+
+	next, err := CalculateNextDueDate(chore, completedAt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := time.Date(2025, time.February, 11, 20, 0, 0, 0, time.UTC)
+	if next == nil || !next.Equal(want) {
+		t.Errorf("got %v, want %v", next, want)
+	}
+}
@@ -86,6 +86,21 @@ func parseCIDR(cidr string) *net.IPNet {
 }
 
 func NewSafeHTTPClient(timeout time.Duration) *http.Client {
+	return NewSafeHTTPClientAllowing(timeout, nil)
+}
+
+// NewSafeHTTPClientAllowing is NewSafeHTTPClient with an operator-configured
+// list of hostnames that may resolve to private addresses, such as a
+// household's own ntfy server on the LAN.
+func NewSafeHTTPClientAllowing(timeout time.Duration, allowedHosts []string) *http.Client {
+	allowed := func(host string) bool {
+		for _, allowedHost := range allowedHosts {
+			if strings.EqualFold(host, allowedHost) {
+				return true
+			}
+		}
+		return false
+	}
 	dialer := &net.Dialer{Timeout: 5 * time.Second}
 
 	transport := &http.Transport{
@@ -101,7 +116,7 @@ func NewSafeHTTPClient(timeout time.Duration) *http.Client {
 			}
 
 			for _, ip := range ips {
-				if isPrivateIP(ip.IP) {
+				if isPrivateIP(ip.IP) && !allowed(host) {
 					return nil, fmt.Errorf("blocked connection to private IP: %s resolves to %s", host, ip.IP)
 				}
 			}
@@ -121,6 +136,9 @@ func NewSafeHTTPClient(timeout time.Duration) *http.Client {
 			if len(via) >= 10 {
 				return fmt.Errorf("too many redirects")
 			}
+			if allowed(req.URL.Hostname()) {
+				return nil
+			}
 			if err := ValidateExternalURL(req.URL.String()); err != nil {
 				return fmt.Errorf("redirect blocked: %w", err)
 			}
@@ -133,3 +133,17 @@ func TestSafeHTTPClient_BlocksTooManyRedirects(t *testing.T) {
 		t.Error("expected error after too many redirects, got nil")
 	}
 }
+
+func TestSafeHTTPClient_AllowsConfiguredPrivateHost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := services.NewSafeHTTPClientAllowing(5*time.Second, []string{"127.0.0.1"})
+	response, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected the allowed host to be reachable, got %v", err)
+	}
+	response.Body.Close()
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+)
+
+var ErrInvalidSeason = errors.New("seasons must be written as MM-DD..MM-DD, such as 03-01..10-31")
+
+// seasonDayFormat is how a season's first and last days are written.
+const seasonDayFormat = "01-02"
+
+// ParseSeasons reads active seasons written as "MM-DD..MM-DD", one window per
+// entry, with entries separated by commas or new lines. Blank entries are
+// dropped, so an empty text means all year. A window may wrap over the new
+// year, as "11-01..02-28" does.
+func ParseSeasons(text string) ([]models.SeasonWindow, error) {
+	var seasons []models.SeasonWindow
+	for _, entry := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == '\n' }) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		start, end, ok := strings.Cut(entry, "..")
+		if !ok {
+			return nil, ErrInvalidSeason
+		}
+		// Parsing in a leap year lets a season start or end on 29 February.
+		first, err := time.Parse("2006-"+seasonDayFormat, "2024-"+strings.TrimSpace(start))
+		if err != nil {
+			return nil, ErrInvalidSeason
+		}
+		last, err := time.Parse("2006-"+seasonDayFormat, "2024-"+strings.TrimSpace(end))
+		if err != nil {
+			return nil, ErrInvalidSeason
+		}
+		seasons = append(seasons, models.SeasonWindow{
+			StartMonth: first.Month(),
+			StartDay:   first.Day(),
+			EndMonth:   last.Month(),
+			EndDay:     last.Day(),
+		})
+	}
+	return seasons, nil
+}
+
+// FormatSeasons writes seasons the way ParseSeasons reads them.
+func FormatSeasons(seasons []models.SeasonWindow) string {
+	entries := make([]string, 0, len(seasons))
+	for _, season := range seasons {
+		entries = append(entries, fmt.Sprintf("%02d-%02d..%02d-%02d", season.StartMonth, season.StartDay, season.EndMonth, season.EndDay))
+	}
+	return strings.Join(entries, ", ")
+}
+
+// SetSeasons limits a chore's series to the given stretches of the year; none
+// lifts the limit. The series' occurrences after this one are generated again
+// so that they follow the new seasons, carrying the rotation on from this
+// occurrence's assignee. A chore without a series has no schedule to limit.
+func (service *ChoreService) SetSeasons(ctx context.Context, chore models.Chore, seasons []models.SeasonWindow) error {
+	series := service.loadSeries(ctx, chore.SeriesID)
+	if series == nil || slices.Equal(series.Seasons, seasons) {
+		return nil
+	}
+	if err := service.seriesRepo.SetSeasons(ctx, series.ID, seasons); err != nil {
+		return err
+	}
+
+	chore = applySeriesRule(chore, series)
+	if chore.RecurOnComplete || chore.RecurrenceType == models.RecurrenceNone {
+		return nil
+	}
+	return service.reseedAfter(ctx, chore)
+}
+
+// inSeason reports whether day falls in one of the series' seasons. A series
+// without seasons, or no series at all, is always in season.
+func inSeason(series *models.ChoreSeries, day time.Time) bool {
+	if series == nil || len(series.Seasons) == 0 {
+		return true
+	}
+	for _, season := range series.Seasons {
+		if seasonContains(season, day) {
+			return true
+		}
+	}
+	return false
+}
+
+func seasonContains(season models.SeasonWindow, day time.Time) bool {
+	key := int(day.Month())*100 + day.Day()
+	start := int(season.StartMonth)*100 + season.StartDay
+	end := int(season.EndMonth)*100 + season.EndDay
+	if start <= end {
+		return key >= start && key <= end
+	}
+	return key >= start || key <= end
+}
+
+// nextSeasonStart returns the first day on or after day that is in one of the
+// series' seasons. Four years are searched so that a season of 29 February
+// alone is still found; day itself is returned if nothing is.
+func nextSeasonStart(series *models.ChoreSeries, day time.Time) time.Time {
+	for candidate := day; candidate.Before(day.AddDate(4, 0, 1)); candidate = candidate.AddDate(0, 0, 1) {
+		if inSeason(series, candidate) {
+			return candidate
+		}
+	}
+	return day
+}
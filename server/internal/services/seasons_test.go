@@ -0,0 +1,166 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+)
+
+// seasonAround is the window of the year from from to to.
+func seasonAround(from, to time.Time) models.SeasonWindow {
+	return models.SeasonWindow{StartMonth: from.Month(), StartDay: from.Day(), EndMonth: to.Month(), EndDay: to.Day()}
+}
+
+func TestParseSeasons(t *testing.T) {
+	seasons, err := services.ParseSeasons("03-01..10-31,\n 11-15..02-29")
+	if err != nil {
+		t.Fatalf("ParseSeasons: %v", err)
+	}
+	want := []models.SeasonWindow{
+		{StartMonth: time.March, StartDay: 1, EndMonth: time.October, EndDay: 31},
+		{StartMonth: time.November, StartDay: 15, EndMonth: time.February, EndDay: 29},
+	}
+	if len(seasons) != 2 || seasons[0] != want[0] || seasons[1] != want[1] {
+		t.Fatalf("got %+v, want %+v", seasons, want)
+	}
+	if got := services.FormatSeasons(seasons); got != "03-01..10-31, 11-15..02-29" {
+		t.Errorf("FormatSeasons = %q", got)
+	}
+
+	for _, text := range []string{"03-01", "13-01..10-31", "03-01..04-31", "spring"} {
+		if _, err := services.ParseSeasons(text); !errors.Is(err, services.ErrInvalidSeason) {
+			t.Errorf("ParseSeasons(%q): expected ErrInvalidSeason, got %v", text, err)
+		}
+	}
+}
+
+func TestChoreService_SetSeasons_SeedsOnlyInSeasonAndKeepsRotation(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 2)
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	base := today.AddDate(0, 0, 1)
+	chore := newRecurringChore(t, choreRepo, seriesRepo,
+		models.ChoreSeries{RecurrenceType: models.RecurrenceDaily, RecurrenceValue: `{"interval":1}`},
+		models.Chore{
+			Name:              "Water the tomatoes",
+			CreatedByUserID:   users[0].ID,
+			DueDate:           &base,
+			Status:            models.ChoreStatusPending,
+			LastAssignedIndex: -1,
+		})
+	chore, _ = service.AssignNextUser(ctx, chore)
+
+	seasonStart, seasonEnd := today.AddDate(0, 0, 10), today.AddDate(0, 0, 14)
+	if err := service.SetSeasons(ctx, chore, []models.SeasonWindow{seasonAround(seasonStart, seasonEnd)}); err != nil {
+		t.Fatalf("SetSeasons: %v", err)
+	}
+
+	pending, _ := choreRepo.FindAll(ctx, repository.ChoreFilter{
+		SeriesID: chore.SeriesID,
+		Statuses: []models.ChoreStatus{models.ChoreStatusPending},
+		OrderBy:  repository.OrderByDueDateAsc,
+	})
+	var seeded []models.Chore
+	for _, occurrence := range pending {
+		if occurrence.ID != chore.ID {
+			seeded = append(seeded, occurrence)
+		}
+	}
+	if len(seeded) != 5 {
+		t.Fatalf("expected the 5 in-season days seeded, got %d", len(seeded))
+	}
+	for i, occurrence := range seeded {
+		if day := occurrence.DueDate.UTC().Truncate(24 * time.Hour); !day.Equal(seasonStart.AddDate(0, 0, i)) {
+			t.Errorf("occurrence %d due %s, want %s", i, day.Format("2006-01-02"), seasonStart.AddDate(0, 0, i).Format("2006-01-02"))
+		}
+	}
+	if *seeded[0].AssignedToUserID == *chore.AssignedToUserID {
+		t.Errorf("the rotation should carry on from %s into the season", *chore.AssignedToUserID)
+	}
+	for i := 1; i < len(seeded); i++ {
+		if *seeded[i].AssignedToUserID == *seeded[i-1].AssignedToUserID {
+			t.Errorf("occurrences %d and %d both went to %s", i-1, i, *seeded[i].AssignedToUserID)
+		}
+	}
+}
+
+func TestChoreService_SeedFutureOccurrences_ReachesNextSeasonPastHorizon(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 1)
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	base := today.AddDate(0, 0, 1)
+	seasonStart := today.AddDate(0, 0, 120)
+	chore := newRecurringChore(t, choreRepo, seriesRepo,
+		models.ChoreSeries{RecurrenceType: models.RecurrenceDaily, RecurrenceValue: `{"interval":1}`},
+		models.Chore{
+			Name:            "Clear the gutters",
+			CreatedByUserID: users[0].ID,
+			DueDate:         &base,
+			Status:          models.ChoreStatusPending,
+		})
+	if err := seriesRepo.SetSeasons(ctx, *chore.SeriesID, []models.SeasonWindow{seasonAround(seasonStart, seasonStart.AddDate(0, 0, 30))}); err != nil {
+		t.Fatalf("SetSeasons: %v", err)
+	}
+	chore.Status = models.ChoreStatusCompleted
+	if err := choreRepo.Update(ctx, chore); err != nil {
+		t.Fatalf("completing chore: %v", err)
+	}
+
+	if err := service.SeedFutureOccurrences(ctx, chore, services.SeedHorizonFrom(time.Now())); err != nil {
+		t.Fatalf("SeedFutureOccurrences: %v", err)
+	}
+
+	pending, _ := choreRepo.FindAll(ctx, repository.ChoreFilter{
+		SeriesID: chore.SeriesID,
+		Statuses: []models.ChoreStatus{models.ChoreStatusPending},
+	})
+	if len(pending) != 1 || !pending[0].DueDate.UTC().Truncate(24*time.Hour).Equal(seasonStart) {
+		t.Fatalf("expected one occurrence waiting at the start of next season %s, got %+v", seasonStart.Format("2006-01-02"), pending)
+	}
+}
+
+func TestChoreService_CompleteChore_RecurOnCompleteWaitsForSeason(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 1)
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	seasonStart := today.AddDate(0, 0, 30)
+	chore := newRecurringChore(t, choreRepo, seriesRepo,
+		models.ChoreSeries{RecurrenceType: models.RecurrenceDaily, RecurrenceValue: `{"interval":1}`, RecurOnComplete: true},
+		models.Chore{
+			Name:             "Mow the lawn",
+			CreatedByUserID:  users[0].ID,
+			AssignedToUserID: &users[0].ID,
+			DueDate:          &today,
+			RecurOnComplete:  true,
+			Status:           models.ChoreStatusPending,
+		})
+	if err := seriesRepo.SetSeasons(ctx, *chore.SeriesID, []models.SeasonWindow{seasonAround(seasonStart, seasonStart.AddDate(0, 0, 60))}); err != nil {
+		t.Fatalf("SetSeasons: %v", err)
+	}
+
+	if err := service.CompleteChore(ctx, chore.ID, users[0].ID); err != nil {
+		t.Fatalf("CompleteChore: %v", err)
+	}
+
+	pending, _ := choreRepo.FindAll(ctx, repository.ChoreFilter{
+		SeriesID: chore.SeriesID,
+		Statuses: []models.ChoreStatus{models.ChoreStatusPending},
+	})
+	if len(pending) != 1 {
+		t.Fatalf("expected the next occurrence created, got %d", len(pending))
+	}
+	if due := pending[0].DueDate.UTC().Truncate(24 * time.Hour); !due.Equal(seasonStart) {
+		t.Errorf("next occurrence due %s, want the season's start %s", due.Format("2006-01-02"), seasonStart.Format("2006-01-02"))
+	}
+}
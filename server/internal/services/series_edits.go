@@ -0,0 +1,426 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+)
+
+// EditScope picks which occurrences of a recurring chore an edit or delete
+// applies to, as in a calendar. For a chore outside a series every scope
+// behaves like EditScopeAll.
+type EditScope string
+
+const (
+	// EditScopeAll changes the whole series. It is the default.
+	EditScopeAll EditScope = "all"
+	// EditScopeOccurrence changes one occurrence without detaching it from
+	// its series.
+	EditScopeOccurrence EditScope = "occurrence"
+	// EditScopeFollowing splits the series at the occurrence: it and every
+	// later occurrence take the edit while earlier ones keep the old
+	// definition and history.
+	EditScopeFollowing EditScope = "following"
+)
+
+var (
+	ErrInvalidEditScope   = errors.New("scope must be all, occurrence or following")
+	ErrAssigneeNotFound   = errors.New("assignee is not one of the chore's eligible members")
+	ErrGroupChoreAssignee = errors.New("a group chore cannot be assigned to one member")
+)
+
+// ParseEditScope reads a scope from a form or query value; empty means all.
+func ParseEditScope(value string) (EditScope, error) {
+	switch scope := EditScope(strings.TrimSpace(value)); scope {
+	case "":
+		return EditScopeAll, nil
+	case EditScopeAll, EditScopeOccurrence, EditScopeFollowing:
+		return scope, nil
+	}
+	return "", ErrInvalidEditScope
+}
+
+// OccurrenceEdit is what an edit to a single occurrence may change. A nil
+// DueDate keeps the current date and a nil AssignedToUserID keeps the current
+// assignee.
+type OccurrenceEdit struct {
+	Name             string
+	Description      string
+	DueDate          *time.Time
+	DueTime          *string
+	AssignedToUserID *string
+}
+
+// EditOccurrence overrides one open occurrence's name, description, due date
+// and time, or assignee without detaching it from its series. The occurrence
+// is recorded as an exception on the series, so it keeps its slot when moved
+// (like a snooze), is never regenerated, and is left alone by later
+// series-wide edits. The assignee must be in the chore's eligible pool, and a
+// group chore cannot be given to one member. Assigning it to someone does not
+// move the rotation. The edit, the new assignment and the exception are saved
+// together or not at all.
+func (service *ChoreService) EditOccurrence(ctx context.Context, choreID string, edit OccurrenceEdit) (models.Chore, error) {
+	chore, err := service.choreRepo.FindByID(ctx, choreID)
+	if err != nil {
+		return chore, fmt.Errorf("finding chore: %w", err)
+	}
+	if !choreIsOpen(chore) {
+		return chore, ErrChoreNotOpen
+	}
+	series := service.loadSeries(ctx, chore.SeriesID)
+
+	reassigned := false
+	if edit.AssignedToUserID != nil && (chore.AssignedToUserID == nil || *chore.AssignedToUserID != *edit.AssignedToUserID) {
+		if err := service.checkOccurrenceAssignee(ctx, chore, series, *edit.AssignedToUserID); err != nil {
+			return chore, err
+		}
+		reassigned = true
+	}
+
+	edited := chore
+	edited.Name = edit.Name
+	edited.Description = edit.Description
+	edited.DueTime = edit.DueTime
+	if edit.DueDate != nil {
+		edited.DueDate = edit.DueDate
+		if chore.SeriesID != nil && chore.DueDate != nil && chore.OriginalDueDate == nil && !edit.DueDate.Equal(*chore.DueDate) {
+			edited.OriginalDueDate = chore.DueDate
+		}
+	}
+	if reassigned {
+		edited.AssignedToUserID = edit.AssignedToUserID
+	}
+
+	var exception *models.SeriesException
+	if series != nil && edited.DueDate != nil {
+		exception = &models.SeriesException{SeriesID: series.ID, OccurrenceDate: seriesSlot(edited), ChoreID: &edited.ID}
+	}
+	if err := service.choreRepo.UpdateOccurrence(ctx, edited, reassigned, exception); err != nil {
+		return chore, fmt.Errorf("saving occurrence edit: %w", err)
+	}
+
+	if reassigned {
+		service.notifyAssigned(ctx, edited)
+	}
+	return edited, nil
+}
+
+// checkOccurrenceAssignee reports whether the occurrence may be handed to
+// userID on its own: it is not a group chore and they are in its eligible
+// pool.
+func (service *ChoreService) checkOccurrenceAssignee(ctx context.Context, chore models.Chore, series *models.ChoreSeries, userID string) error {
+	if applySeriesRule(chore, series).GroupChore {
+		return ErrGroupChoreAssignee
+	}
+	candidates, err := service.findCandidates(ctx, chore.ID, series)
+	if err != nil {
+		return err
+	}
+	for _, candidate := range candidates {
+		if candidate.ID == userID {
+			return nil
+		}
+	}
+	return ErrAssigneeNotFound
+}
+
+// UpdateChore saves an edit to a chore and, for a recurring chore, to its
+// whole series: the definition is synced and, when the edit changes what the
+// series generates, the occurrences after this one are re-seeded. Occurrences
+// edited on their own keep their edits. A nil assignees leaves the eligible
+// pool unchanged. Only storing the chore itself can fail; problems with the
+// series are logged.
+func (service *ChoreService) UpdateChore(ctx context.Context, before, chore models.Chore, assignees []string) error {
+	if err := service.choreRepo.Update(ctx, chore); err != nil {
+		return err
+	}
+	if assignees != nil {
+		if err := service.choreRepo.SetEligibleAssignees(ctx, chore.ID, assignees); err != nil {
+			slog.Error("setting eligible assignees", "error", err)
+		}
+	}
+	if chore.SeriesID != nil {
+		if err := service.SyncSeriesDefinition(ctx, chore, service.seriesPool(ctx, chore.SeriesID, assignees)); err != nil {
+			slog.Error("syncing series definition", "error", err)
+			return nil
+		}
+	}
+
+	// Turning group mode on or off hands the open chore out again.
+	if before.GroupChore != chore.GroupChore && choreIsOpen(chore) {
+		if _, err := service.AssignNextUser(ctx, chore); err != nil {
+			slog.Error("reassigning after group change", "error", err)
+		}
+	}
+	if chore.SeriesID == nil {
+		return nil
+	}
+
+	// Occurrences are already generated and assigned, so a new rule,
+	// assignment or name only reaches them by re-seeding.
+	if chore.RecurOnComplete || !seriesOutputChanged(before, chore) {
+		return nil
+	}
+	if err := service.reseedAfter(ctx, chore); err != nil {
+		slog.Error("re-seeding after series edit", "error", err)
+	}
+	return nil
+}
+
+// reseedAfter replaces the upcoming occurrences of the chore's series after
+// this one with freshly seeded ones. Occurrences edited on their own are kept.
+func (service *ChoreService) reseedAfter(ctx context.Context, chore models.Chore) error {
+	from := time.Now()
+	if chore.DueDate != nil {
+		if next := seriesSlot(chore).AddDate(0, 0, 1); next.After(from) {
+			from = next
+		}
+	}
+	if err := service.choreRepo.DeleteUpcomingBySeries(ctx, *chore.SeriesID, from, true); err != nil {
+		return fmt.Errorf("deleting stale future occurrences: %w", err)
+	}
+	return service.SeedFutureOccurrences(ctx, chore, SeedHorizonFrom(time.Now()))
+}
+
+// SplitSeries applies an edit to an occurrence and every later one. The old
+// series ends the day before the occurrence's slot and drops its later open
+// occurrences; the edited occurrence starts a new series (whose id is its own,
+// as for a new chore) that is seeded ahead. An occurrence cap left unchanged
+// carries over what the old series had not used. Editing the first
+// occurrence, or a series that recurs on completion, edits the whole series.
+func (service *ChoreService) SplitSeries(ctx context.Context, before, chore models.Chore, assignees []string) (models.Chore, error) {
+	old := service.loadSeries(ctx, before.SeriesID)
+	if old == nil || before.DueDate == nil || before.ID == old.ID || old.RecurOnComplete {
+		return chore, service.UpdateChore(ctx, before, chore, assignees)
+	}
+	slot := seriesSlot(before)
+
+	if chore.RecurrenceCount != nil && old.RecurrenceCount != nil && *chore.RecurrenceCount == *old.RecurrenceCount {
+		kept, err := service.occurrencesBefore(ctx, old.ID, slot)
+		if err != nil {
+			return chore, err
+		}
+		remaining := max(*old.RecurrenceCount-kept, 1)
+		chore.RecurrenceCount = &remaining
+	}
+
+	chore.OriginalDueDate = nil
+	// Dropping the recurrence from here on leaves a one-off chore.
+	if chore.RecurrenceType == models.RecurrenceNone {
+		chore.SeriesID = nil
+		if err := service.UpdateChore(ctx, before, chore, assignees); err != nil {
+			return chore, err
+		}
+		return chore, service.endSeriesBefore(ctx, *old, slot)
+	}
+
+	pool := assignees
+	if pool == nil {
+		pool = old.EligibleAssignees
+	}
+	chore.SeriesID = &chore.ID
+	if err := service.SyncSeriesDefinition(ctx, chore, pool); err != nil {
+		return chore, err
+	}
+	if err := service.seriesRepo.SetChecklist(ctx, chore.ID, old.Checklist); err != nil {
+		return chore, fmt.Errorf("copying series checklist: %w", err)
+	}
+	if err := service.seriesRepo.SetSeasons(ctx, chore.ID, old.Seasons); err != nil {
+		return chore, fmt.Errorf("copying series seasons: %w", err)
+	}
+	// The new series waits on what the old one did, and whatever waited on
+	// the old one now waits on both: the old series for the cycles before the
+	// split and, once it has ended, the new one from the split on.
+	if err := service.seriesRepo.CopyPrerequisites(ctx, old.ID, chore.ID); err != nil {
+		return chore, fmt.Errorf("copying series prerequisites: %w", err)
+	}
+	if err := service.choreRepo.Update(ctx, chore); err != nil {
+		return chore, fmt.Errorf("moving occurrence to new series: %w", err)
+	}
+	if assignees != nil {
+		if err := service.choreRepo.SetEligibleAssignees(ctx, chore.ID, assignees); err != nil {
+			slog.Error("setting eligible assignees", "error", err)
+		}
+	}
+
+	if err := service.endSeriesBefore(ctx, *old, slot); err != nil {
+		return chore, err
+	}
+
+	if !chore.RecurOnComplete {
+		if err := service.SeedFutureOccurrences(ctx, chore, SeedHorizonFrom(time.Now())); err != nil {
+			slog.Error("seeding split series", "error", err)
+		}
+		return chore, nil
+	}
+	if err := service.syncBlocked(ctx, chore.ID); err != nil {
+		return chore, err
+	}
+	service.refreshDependents(ctx, &chore.ID)
+	return chore, nil
+}
+
+// DeleteChore deletes a chore. For a recurring chore the scope decides how
+// much of the series goes with it: just this occurrence (its slot is recorded
+// so it is not regenerated), this and every later occurrence (the series ends
+// the day before), or the whole series, whose definition is soft-deleted.
+func (service *ChoreService) DeleteChore(ctx context.Context, chore models.Chore, scope EditScope) error {
+	series := service.loadSeries(ctx, chore.SeriesID)
+	if series != nil && chore.DueDate != nil && !series.RecurOnComplete {
+		switch {
+		case scope == EditScopeOccurrence:
+			if err := service.seriesRepo.AddException(ctx, series.ID, seriesSlot(chore), nil); err != nil {
+				return fmt.Errorf("recording deleted occurrence: %w", err)
+			}
+			return service.choreRepo.Delete(ctx, chore.ID)
+		case scope == EditScopeFollowing && chore.ID != series.ID:
+			if err := service.endSeriesBefore(ctx, *series, seriesSlot(chore)); err != nil {
+				return err
+			}
+			return service.choreRepo.Delete(ctx, chore.ID)
+		}
+	}
+
+	if chore.SeriesID != nil {
+		if err := service.choreRepo.DeleteFuturePendingBySeries(ctx, *chore.SeriesID); err != nil {
+			slog.Error("deleting future pending siblings", "error", err)
+		}
+		if err := service.DeleteSeriesDefinition(ctx, *chore.SeriesID); err != nil {
+			slog.Error("soft-deleting series definition", "error", err)
+		}
+	}
+	return service.choreRepo.Delete(ctx, chore.ID)
+}
+
+// endSeriesBefore ends a series the day before slot: that day becomes its end
+// date, and its pending occurrences from slot on are dropped along with their
+// exceptions.
+func (service *ChoreService) endSeriesBefore(ctx context.Context, series models.ChoreSeries, slot time.Time) error {
+	until := slot.AddDate(0, 0, -1)
+	if series.RecurrenceUntil == nil || until.Before(*series.RecurrenceUntil) {
+		series.RecurrenceUntil = &until
+		if err := service.seriesRepo.Update(ctx, series); err != nil {
+			return fmt.Errorf("ending series: %w", err)
+		}
+	}
+	if err := service.choreRepo.DeleteUpcomingBySeries(ctx, series.ID, slot, false); err != nil {
+		return err
+	}
+	if err := service.seriesRepo.DeleteExceptionsFrom(ctx, series.ID, slot); err != nil {
+		return err
+	}
+	return nil
+}
+
+// occurrencesBefore counts the series' occurrences, of any status, whose slot
+// is before slot, plus the slots deleted before it.
+func (service *ChoreService) occurrencesBefore(ctx context.Context, seriesID string, slot time.Time) (int, error) {
+	occurrences, err := service.choreRepo.FindAll(ctx, repository.ChoreFilter{SeriesID: &seriesID})
+	if err != nil {
+		return 0, fmt.Errorf("finding series occurrences: %w", err)
+	}
+	count := 0
+	for _, occurrence := range occurrences {
+		if occurrence.DueDate != nil && seriesSlot(occurrence).Before(slot) {
+			count++
+		}
+	}
+	if series := service.loadSeries(ctx, &seriesID); series != nil {
+		for _, exception := range series.Exceptions {
+			if exception.ChoreID == nil && exception.OccurrenceDate.Before(slot) {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+// seriesPool is the eligible pool to sync onto a series: the given assignees,
+// or the series' current pool when they are nil.
+func (service *ChoreService) seriesPool(ctx context.Context, seriesID *string, assignees []string) []string {
+	if assignees != nil {
+		return assignees
+	}
+	if series := service.loadSeries(ctx, seriesID); series != nil {
+		return series.EligibleAssignees
+	}
+	return nil
+}
+
+// exceptedSlot reports whether the series has an exception for the slot on
+// day, meaning the occurrence there was deleted or edited on its own.
+func exceptedSlot(series *models.ChoreSeries, day time.Time) bool {
+	if series == nil {
+		return false
+	}
+	key := day.Format(dayFormat)
+	for _, exception := range series.Exceptions {
+		if exception.OccurrenceDate.Format(dayFormat) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// deletedSlots counts the series' deleted occurrences, which still use up an
+// occurrence cap.
+func deletedSlots(series *models.ChoreSeries) int {
+	if series == nil {
+		return 0
+	}
+	count := 0
+	for _, exception := range series.Exceptions {
+		if exception.ChoreID == nil {
+			count++
+		}
+	}
+	return count
+}
+
+// seriesOutputChanged reports whether an edit changes what a series generates:
+// its rule or end, who occurrences go to, or the name and timing copied onto
+// each occurrence.
+func seriesOutputChanged(before, after models.Chore) bool {
+	return before.RecurrenceType != after.RecurrenceType ||
+		before.RecurrenceValue != after.RecurrenceValue ||
+		before.RecurrenceRule != after.RecurrenceRule ||
+		recurrenceEndKey(before) != recurrenceEndKey(after) ||
+		assignmentKey(before) != assignmentKey(after) ||
+		before.Name != after.Name ||
+		before.Description != after.Description ||
+		optionalString(before.CategoryID) != optionalString(after.CategoryID) ||
+		optionalString(before.DueTime) != optionalString(after.DueTime)
+}
+
+// recurrenceEndKey renders the end conditions as a comparable string.
+func recurrenceEndKey(chore models.Chore) string {
+	key := "until="
+	if chore.RecurrenceUntil != nil {
+		key += chore.RecurrenceUntil.Format(dayFormat)
+	}
+	key += ";count="
+	if chore.RecurrenceCount != nil {
+		key += strconv.Itoa(*chore.RecurrenceCount)
+	}
+	return key
+}
+
+// assignmentKey identifies a chore's assignment settings.
+func assignmentKey(chore models.Chore) string {
+	return string(chore.AssignmentStrategy) + "|" + optionalString(chore.FixedAssigneeUserID) +
+		"|" + strconv.FormatBool(chore.GroupChore)
+}
+
+func optionalString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
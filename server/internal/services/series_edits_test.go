@@ -0,0 +1,211 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+)
+
+// occurrencesByDay maps each occurrence of a series to its due date.
+func occurrencesByDay(t *testing.T, choreRepo *repository.SQLiteChoreRepository, seriesID string) map[string]models.Chore {
+	t.Helper()
+	occurrences, err := choreRepo.FindAll(context.Background(), repository.ChoreFilter{SeriesID: &seriesID})
+	if err != nil {
+		t.Fatalf("finding occurrences: %v", err)
+	}
+	byDay := map[string]models.Chore{}
+	for _, occurrence := range occurrences {
+		byDay[occurrence.DueDate.Format("2006-01-02")] = occurrence
+	}
+	return byDay
+}
+
+func TestChoreService_DeleteOccurrence_NotRegenerated(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 2)
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	chore, first := seedDailyRotation(t, service, choreRepo, seriesRepo, users)
+	until := first.AddDate(0, 0, 7)
+	if err := service.SeedFutureOccurrences(ctx, chore, until); err != nil {
+		t.Fatalf("SeedFutureOccurrences: %v", err)
+	}
+
+	skipped := first.AddDate(0, 0, 2).Format("2006-01-02")
+	occurrence := occurrencesByDay(t, choreRepo, chore.ID)[skipped]
+	if err := service.DeleteChore(ctx, occurrence, services.EditScopeOccurrence); err != nil {
+		t.Fatalf("DeleteChore: %v", err)
+	}
+
+	if err := service.TopUpAllSeries(ctx, until); err != nil {
+		t.Fatalf("TopUpAllSeries: %v", err)
+	}
+	if err := service.SeedFutureOccurrences(ctx, chore, until); err != nil {
+		t.Fatalf("SeedFutureOccurrences: %v", err)
+	}
+
+	byDay := occurrencesByDay(t, choreRepo, chore.ID)
+	if _, ok := byDay[skipped]; ok {
+		t.Errorf("deleted occurrence on %s was regenerated", skipped)
+	}
+	if len(byDay) != 6 {
+		t.Errorf("expected the other 6 occurrences kept, got %d", len(byDay))
+	}
+	if _, err := seriesRepo.FindByID(ctx, chore.ID); err != nil {
+		t.Errorf("series should survive deleting one occurrence: %v", err)
+	}
+}
+
+func TestChoreService_EditOccurrence_SurvivesSeriesEdit(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 2)
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	chore, first := seedDailyRotation(t, service, choreRepo, seriesRepo, users)
+	until := first.AddDate(0, 0, 7)
+	if err := service.SeedFutureOccurrences(ctx, chore, until); err != nil {
+		t.Fatalf("SeedFutureOccurrences: %v", err)
+	}
+
+	slot := first.AddDate(0, 0, 3)
+	occurrence := occurrencesByDay(t, choreRepo, chore.ID)[slot.Format("2006-01-02")]
+	moved := first.AddDate(0, 0, 20)
+	dueTime := "18:00"
+	edited, err := service.EditOccurrence(ctx, occurrence.ID, services.OccurrenceEdit{
+		Name:             "Vet visit",
+		DueDate:          &moved,
+		DueTime:          &dueTime,
+		AssignedToUserID: &users[0].ID,
+	})
+	if err != nil {
+		t.Fatalf("EditOccurrence: %v", err)
+	}
+	if edited.SeriesID == nil || *edited.SeriesID != chore.ID {
+		t.Fatal("edited occurrence should stay in its series")
+	}
+	if edited.OriginalDueDate == nil || !edited.OriginalDueDate.Equal(slot) {
+		t.Errorf("expected the original slot kept, got %v", edited.OriginalDueDate)
+	}
+
+	if err := service.TopUpAllSeries(ctx, until); err != nil {
+		t.Fatalf("TopUpAllSeries: %v", err)
+	}
+
+	// Renaming the whole series re-seeds the other occurrences but leaves
+	// the edited one as it is.
+	renamed := chore
+	renamed.Name = "Feed the dog"
+	if err := service.UpdateChore(ctx, chore, renamed, nil); err != nil {
+		t.Fatalf("UpdateChore: %v", err)
+	}
+
+	occurrences, _ := choreRepo.FindAll(ctx, repository.ChoreFilter{SeriesID: &chore.ID})
+	for _, other := range occurrences {
+		switch {
+		case other.ID == occurrence.ID:
+			if other.Name != "Vet visit" || !other.DueDate.Equal(moved) || *other.AssignedToUserID != users[0].ID {
+				t.Errorf("edited occurrence lost its edits: %+v", other)
+			}
+		case other.DueDate.Equal(slot):
+			t.Errorf("the edited occurrence's slot was regenerated")
+		case other.Name != "Feed the dog":
+			t.Errorf("occurrence on %s not renamed: %q", other.DueDate.Format("2006-01-02"), other.Name)
+		}
+	}
+}
+
+func TestChoreService_SplitSeries(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 2)
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	chore, first := seedDailyRotation(t, service, choreRepo, seriesRepo, users)
+	if err := service.SeedFutureOccurrences(ctx, chore, first.AddDate(0, 0, 7)); err != nil {
+		t.Fatalf("SeedFutureOccurrences: %v", err)
+	}
+
+	split := first.AddDate(0, 0, 3)
+	occurrence := occurrencesByDay(t, choreRepo, chore.ID)[split.Format("2006-01-02")]
+	edited := occurrence
+	edited.RecurrenceValue = `{"interval":2}`
+	result, err := service.SplitSeries(ctx, occurrence, edited, nil)
+	if err != nil {
+		t.Fatalf("SplitSeries: %v", err)
+	}
+	if result.SeriesID == nil || *result.SeriesID != occurrence.ID {
+		t.Fatalf("expected the occurrence to start a new series, got %v", result.SeriesID)
+	}
+
+	old, err := seriesRepo.FindByID(ctx, chore.ID)
+	if err != nil {
+		t.Fatalf("finding old series: %v", err)
+	}
+	if old.RecurrenceUntil == nil || !old.RecurrenceUntil.Equal(split.AddDate(0, 0, -1)) {
+		t.Errorf("old series should end the day before the split, got %v", old.RecurrenceUntil)
+	}
+	oldDays := occurrencesByDay(t, choreRepo, chore.ID)
+	if len(oldDays) != 3 {
+		t.Errorf("expected the 3 earlier occurrences kept on the old series, got %d", len(oldDays))
+	}
+
+	newDays := occurrencesByDay(t, choreRepo, occurrence.ID)
+	for offset := 3; offset < 9; offset++ {
+		day := first.AddDate(0, 0, offset).Format("2006-01-02")
+		if _, ok := newDays[day]; ok != (offset%2 == 1) {
+			t.Errorf("%s: new series present=%v, want every other day from the split", day, ok)
+		}
+	}
+
+	if err := service.TopUpAllSeries(ctx, first.AddDate(0, 0, 7)); err != nil {
+		t.Fatalf("TopUpAllSeries: %v", err)
+	}
+	if len(occurrencesByDay(t, choreRepo, chore.ID)) != 3 {
+		t.Error("top-up should not extend the ended series")
+	}
+}
+
+func TestChoreService_EditOccurrence_RefusesIneligibleAssignee(t *testing.T) {
+	service, choreRepo, _, userRepo, seriesRepo := setupChoreServiceWithSeries(t)
+	ctx := context.Background()
+	users := createUsers(t, userRepo, 3)
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	chore, first := seedDailyRotation(t, service, choreRepo, seriesRepo, users)
+	if err := seriesRepo.SetEligibleAssignees(ctx, chore.ID, []string{users[0].ID, users[1].ID}); err != nil {
+		t.Fatalf("SetEligibleAssignees: %v", err)
+	}
+	if err := service.SeedFutureOccurrences(ctx, chore, first.AddDate(0, 0, 4)); err != nil {
+		t.Fatalf("SeedFutureOccurrences: %v", err)
+	}
+
+	slot := first.AddDate(0, 0, 2)
+	occurrence := occurrencesByDay(t, choreRepo, chore.ID)[slot.Format("2006-01-02")]
+	moved := first.AddDate(0, 0, 10)
+	for _, assignee := range []string{users[2].ID, "missing"} {
+		_, err := service.EditOccurrence(ctx, occurrence.ID, services.OccurrenceEdit{
+			Name:             "Vet visit",
+			DueDate:          &moved,
+			AssignedToUserID: &assignee,
+		})
+		if !errors.Is(err, services.ErrAssigneeNotFound) {
+			t.Errorf("expected ErrAssigneeNotFound for %s, got %v", assignee, err)
+		}
+	}
+
+	unchanged, _ := choreRepo.FindByID(ctx, occurrence.ID)
+	if unchanged.Name != occurrence.Name || !unchanged.DueDate.Equal(slot) {
+		t.Errorf("expected the refused edit to change nothing, got %+v", unchanged)
+	}
+	series, _ := seriesRepo.FindByID(ctx, chore.ID)
+	if len(series.Exceptions) != 0 {
+		t.Errorf("expected no exception recorded, got %+v", series.Exceptions)
+	}
+}
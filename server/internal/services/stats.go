@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+)
+
+const (
+	DefaultStatsDays = 30
+	MaxStatsDays     = 365
+
+	// streakLookbackDays bounds how far back streaks are searched, so the
+	// longest streak is the longest within the last year.
+	streakLookbackDays = 366
+	mostSkippedLimit   = 5
+)
+
+var ErrInvalidStatsRange = errors.New("days must be between 1 and 365")
+
+// StatsReport is the household's completion history over the last len(Days)
+// days. Days are YYYY-MM-DD dates in the household timezone (Timezone), oldest
+// first, and every Daily series is aligned with them.
+type StatsReport struct {
+	Timezone    string
+	Days        []string
+	Users       []UserStats
+	Categories  []CategoryStats
+	MostSkipped []repository.SkippedChore
+	// TimeTaken averages the time timed on each series' occurrences completed
+	// in the range, longest first.
+	TimeTaken []repository.SeriesDuration
+}
+
+// UserStats is one member's record over the report range. OnTimeRate is the
+// share of dated completions finished by their deadline, zero when there were
+// none. Streaks count consecutive days with at least one completion; the
+// current streak survives until a whole day passes without one.
+type UserStats struct {
+	User               models.User
+	Daily              []int
+	Total              int
+	OnTime             int
+	Late               int
+	OnTimeRate         float64
+	AverageLateMinutes float64
+	CurrentStreak      int
+	LongestStreak      int
+}
+
+// CategoryStats is the completion series for one category. Category is the
+// zero value for uncategorised chores.
+type CategoryStats struct {
+	Category models.Category
+	Daily    []int
+	Total    int
+}
+
+// StatsService builds completion analytics. All of the counting happens in
+// aggregate queries; the service only lays the rows out per member and day.
+type StatsService struct {
+	statsRepo    repository.StatsRepository
+	userRepo     repository.UserRepository
+	categoryRepo repository.CategoryRepository
+	settingsRepo repository.SettingsRepository
+}
+
+func NewStatsService(
+	statsRepo repository.StatsRepository,
+	userRepo repository.UserRepository,
+	categoryRepo repository.CategoryRepository,
+	settingsRepo repository.SettingsRepository,
+) *StatsService {
+	return &StatsService{
+		statsRepo:    statsRepo,
+		userRepo:     userRepo,
+		categoryRepo: categoryRepo,
+		settingsRepo: settingsRepo,
+	}
+}
+
+// Report covers the last days days, today included.
+func (service *StatsService) Report(ctx context.Context, days int) (StatsReport, error) {
+	if days < 1 || days > MaxStatsDays {
+		return StatsReport{}, ErrInvalidStatsRange
+	}
+
+	location := HouseholdLocation(ctx, service.settingsRepo)
+	now := time.Now().In(location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+	from := today.AddDate(0, 0, -(days - 1))
+	to := today.AddDate(0, 0, 1)
+
+	report := StatsReport{Timezone: location.String(), Days: make([]string, days)}
+	dayIndex := make(map[string]int, days)
+	for i := range report.Days {
+		day := from.AddDate(0, 0, i).Format("2006-01-02")
+		report.Days[i] = day
+		dayIndex[day] = i
+	}
+
+	users, err := service.userRepo.FindAll(ctx)
+	if err != nil {
+		return StatsReport{}, err
+	}
+	categories, err := service.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return StatsReport{}, err
+	}
+
+	userIndex := make(map[string]int, len(users))
+	report.Users = make([]UserStats, len(users))
+	for i, user := range users {
+		userIndex[user.ID] = i
+		report.Users[i] = UserStats{User: user, Daily: make([]int, days)}
+	}
+	// Uncategorised chores come last.
+	categoryIndex := make(map[string]int, len(categories))
+	report.Categories = make([]CategoryStats, len(categories)+1)
+	for i, category := range categories {
+		categoryIndex[category.ID] = i
+		report.Categories[i] = CategoryStats{Category: category, Daily: make([]int, days)}
+	}
+	uncategorised := len(categories)
+	report.Categories[uncategorised] = CategoryStats{Daily: make([]int, days)}
+
+	counts, err := service.statsRepo.DailyCompletions(ctx, from, to, location)
+	if err != nil {
+		return StatsReport{}, err
+	}
+	for _, count := range counts {
+		day, ok := dayIndex[count.Day]
+		if !ok {
+			continue
+		}
+		if i, ok := userIndex[count.UserID]; ok {
+			report.Users[i].Daily[day] += count.Count
+			report.Users[i].Total += count.Count
+		}
+		i := uncategorised
+		if count.CategoryID != nil {
+			if index, ok := categoryIndex[*count.CategoryID]; ok {
+				i = index
+			}
+		}
+		report.Categories[i].Daily[day] += count.Count
+		report.Categories[i].Total += count.Count
+	}
+
+	punctuality, err := service.statsRepo.Punctuality(ctx, from, to, location)
+	if err != nil {
+		return StatsReport{}, err
+	}
+	for _, result := range punctuality {
+		i, ok := userIndex[result.UserID]
+		if !ok {
+			continue
+		}
+		stats := &report.Users[i]
+		stats.OnTime = result.OnTime
+		stats.Late = result.Late
+		stats.AverageLateMinutes = result.AverageLateMinutes
+		if dated := result.OnTime + result.Late; dated > 0 {
+			stats.OnTimeRate = float64(result.OnTime) / float64(dated)
+		}
+	}
+
+	runs, err := service.statsRepo.CompletionRuns(ctx, today.AddDate(0, 0, -streakLookbackDays), to, location)
+	if err != nil {
+		return StatsReport{}, err
+	}
+	todayKey := today.Format("2006-01-02")
+	yesterdayKey := today.AddDate(0, 0, -1).Format("2006-01-02")
+	for _, run := range runs {
+		i, ok := userIndex[run.UserID]
+		if !ok {
+			continue
+		}
+		stats := &report.Users[i]
+		stats.LongestStreak = max(stats.LongestStreak, run.Days)
+		if run.LastDay == todayKey || run.LastDay == yesterdayKey {
+			stats.CurrentStreak = run.Days
+		}
+	}
+
+	report.MostSkipped, err = service.statsRepo.MostSkipped(ctx, report.Days[0], todayKey, mostSkippedLimit)
+	if err != nil {
+		return StatsReport{}, err
+	}
+	report.TimeTaken, err = service.statsRepo.SeriesDurations(ctx, from, to)
+	if err != nil {
+		return StatsReport{}, err
+	}
+	return report, nil
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+)
+
+var (
+	ErrTimerRunning    = errors.New("your timer is already running")
+	ErrTimerNotRunning = errors.New("no timer is running")
+)
+
+// ChoreTimer is the time tracked on an occurrence. Elapsed is person-time:
+// the segments of everyone who timed it added together, counting running
+// segments up to now. Running lists the members whose timers are going.
+// SeriesAverage is the average time taken by the series' completed
+// occurrences, over SeriesSamples of them that were timed.
+type ChoreTimer struct {
+	Segments       []models.ChoreTimeSegment
+	ElapsedSeconds int
+	Running        []string
+	// SeriesAverageSeconds is 0 when no completed occurrence was timed.
+	SeriesAverageSeconds int
+	SeriesSamples        int
+}
+
+// Elapsed is the timer's total as a duration.
+func (timer ChoreTimer) Elapsed() time.Duration {
+	return time.Duration(timer.ElapsedSeconds) * time.Second
+}
+
+// SeriesAverage is the series' average time taken as a duration.
+func (timer ChoreTimer) SeriesAverage() time.Duration {
+	return time.Duration(timer.SeriesAverageSeconds) * time.Second
+}
+
+// RunningFor reports whether userID's timer is going.
+func (timer ChoreTimer) RunningFor(userID string) bool {
+	return slices.Contains(timer.Running, userID)
+}
+
+// StartTimer starts, or resumes after a pause, userID's timer on an open
+// occurrence. Each member has their own timer, so several can time the same
+// chore together.
+func (service *ChoreService) StartTimer(ctx context.Context, choreID, userID string) error {
+	if service.timeSegmentRepo == nil {
+		return nil
+	}
+	chore, err := service.choreRepo.FindByID(ctx, choreID)
+	if err != nil {
+		return fmt.Errorf("finding chore: %w", err)
+	}
+	if !choreIsOpen(chore) {
+		return ErrChoreNotOpen
+	}
+
+	segments, err := service.timeSegmentRepo.FindByChore(ctx, choreID)
+	if err != nil {
+		return err
+	}
+	for _, segment := range segments {
+		if segment.UserID == userID && segment.EndedAt == nil {
+			return ErrTimerRunning
+		}
+	}
+	_, err = service.timeSegmentRepo.Start(ctx, choreID, userID, time.Now())
+	return err
+}
+
+// PauseTimer stops userID's timer; starting it again resumes the total.
+func (service *ChoreService) PauseTimer(ctx context.Context, choreID, userID string) error {
+	if service.timeSegmentRepo == nil {
+		return nil
+	}
+	ended, err := service.timeSegmentRepo.EndRunning(ctx, choreID, &userID, time.Now())
+	if err != nil {
+		return err
+	}
+	if ended == 0 {
+		return ErrTimerNotRunning
+	}
+	return nil
+}
+
+// StopTimer stops every timer running on the occurrence, for when the work
+// is over. Completing or skipping the chore does the same.
+func (service *ChoreService) StopTimer(ctx context.Context, choreID string) error {
+	if service.timeSegmentRepo == nil {
+		return nil
+	}
+	ended, err := service.timeSegmentRepo.EndRunning(ctx, choreID, nil, time.Now())
+	if err != nil {
+		return err
+	}
+	if ended == 0 {
+		return ErrTimerNotRunning
+	}
+	return nil
+}
+
+// Timer returns the time tracked on an occurrence and the average for its
+// series.
+func (service *ChoreService) Timer(ctx context.Context, chore models.Chore) (ChoreTimer, error) {
+	timer := ChoreTimer{Segments: []models.ChoreTimeSegment{}, Running: []string{}}
+	if service.timeSegmentRepo == nil {
+		return timer, nil
+	}
+
+	segments, err := service.timeSegmentRepo.FindByChore(ctx, chore.ID)
+	if err != nil {
+		return timer, err
+	}
+	now := time.Now()
+	var elapsed time.Duration
+	for _, segment := range segments {
+		end := now
+		if segment.EndedAt != nil {
+			end = *segment.EndedAt
+		} else {
+			timer.Running = append(timer.Running, segment.UserID)
+		}
+		elapsed += end.Sub(segment.StartedAt)
+	}
+	if segments != nil {
+		timer.Segments = segments
+	}
+	timer.ElapsedSeconds = int(elapsed / time.Second)
+
+	if chore.SeriesID != nil {
+		average, samples, err := service.timeSegmentRepo.SeriesAverage(ctx, *chore.SeriesID)
+		if err != nil {
+			return timer, err
+		}
+		timer.SeriesAverageSeconds = int(average / time.Second)
+		timer.SeriesSamples = samples
+	}
+	return timer, nil
+}
+
+// stopTimers ends the running timers on a chore, only userID's when it is
+// set, as the chore (or their part of it) is done. Failures are logged: a
+// timer left running only overstates the time until someone stops it.
+func (service *ChoreService) stopTimers(ctx context.Context, choreID string, userID *string) {
+	if service.timeSegmentRepo == nil {
+		return
+	}
+	if _, err := service.timeSegmentRepo.EndRunning(ctx, choreID, userID, time.Now()); err != nil {
+		slog.Error("stopping chore timers", "chore_id", choreID, "error", err)
+	}
+}
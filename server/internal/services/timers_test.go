@@ -0,0 +1,106 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+)
+
+func setupTimers(t *testing.T) (*services.ChoreService, *repository.SQLiteChoreRepository, *repository.SQLiteChoreSeriesRepository, *repository.SQLiteChoreTimeSegmentRepository, []models.User) {
+	t.Helper()
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	seriesRepo := repository.NewChoreSeriesRepository(db)
+	timeSegmentRepo := repository.NewChoreTimeSegmentRepository(db)
+	service := services.NewChoreService(choreRepo, repository.NewChoreAssignmentRepository(db), userRepo, seriesRepo, repository.NewPointsRepository(db), nil, nil, nil, nil, timeSegmentRepo, nil, nil)
+	return service, choreRepo, seriesRepo, timeSegmentRepo, createUsers(t, userRepo, 2)
+}
+
+func TestChoreService_Timer_StartPauseResume(t *testing.T) {
+	service, choreRepo, seriesRepo, _, users := setupTimers(t)
+	ctx := context.Background()
+	chore := newRecurringChore(t, choreRepo, seriesRepo, models.ChoreSeries{Name: "Mow the lawn"}, models.Chore{
+		Name:             "Mow the lawn",
+		CreatedByUserID:  users[0].ID,
+		AssignedToUserID: &users[0].ID,
+		Status:           models.ChoreStatusPending,
+	})
+
+	if err := service.StartTimer(ctx, chore.ID, users[0].ID); err != nil {
+		t.Fatalf("StartTimer: %v", err)
+	}
+	if err := service.StartTimer(ctx, chore.ID, users[0].ID); !errors.Is(err, services.ErrTimerRunning) {
+		t.Fatalf("expected ErrTimerRunning starting twice, got %v", err)
+	}
+	if err := service.StartTimer(ctx, chore.ID, users[1].ID); err != nil {
+		t.Fatalf("a second member should time alongside: %v", err)
+	}
+
+	if err := service.PauseTimer(ctx, chore.ID, users[0].ID); err != nil {
+		t.Fatalf("PauseTimer: %v", err)
+	}
+	if err := service.PauseTimer(ctx, chore.ID, users[0].ID); !errors.Is(err, services.ErrTimerNotRunning) {
+		t.Fatalf("expected ErrTimerNotRunning pausing twice, got %v", err)
+	}
+	timer, err := service.Timer(ctx, chore)
+	if err != nil {
+		t.Fatalf("Timer: %v", err)
+	}
+	if timer.RunningFor(users[0].ID) || !timer.RunningFor(users[1].ID) {
+		t.Fatalf("expected only %s still timing, got %v", users[1].Name, timer.Running)
+	}
+
+	if err := service.StartTimer(ctx, chore.ID, users[0].ID); err != nil {
+		t.Fatalf("resuming: %v", err)
+	}
+	timer, _ = service.Timer(ctx, chore)
+	if len(timer.Segments) != 3 || len(timer.Running) != 2 {
+		t.Fatalf("expected a paused and two running segments, got %+v", timer.Segments)
+	}
+}
+
+func TestChoreService_Timer_CompletingStopsTimersAndFeedsAverage(t *testing.T) {
+	service, choreRepo, seriesRepo, timeSegmentRepo, users := setupTimers(t)
+	ctx := context.Background()
+	chore := newRecurringChore(t, choreRepo, seriesRepo, models.ChoreSeries{Name: "Clean the bathroom"}, models.Chore{
+		Name:             "Clean the bathroom",
+		CreatedByUserID:  users[0].ID,
+		AssignedToUserID: &users[0].ID,
+		Status:           models.ChoreStatusPending,
+	})
+
+	if _, err := timeSegmentRepo.Start(ctx, chore.ID, users[0].ID, time.Now().Add(-20*time.Minute)); err != nil {
+		t.Fatalf("starting segment: %v", err)
+	}
+	if _, err := timeSegmentRepo.Start(ctx, chore.ID, users[1].ID, time.Now().Add(-10*time.Minute)); err != nil {
+		t.Fatalf("starting segment: %v", err)
+	}
+	if err := service.CompleteChoreWithProof(ctx, chore.ID, users[0].ID, ""); err != nil {
+		t.Fatalf("CompleteChoreWithProof: %v", err)
+	}
+
+	completed, _ := choreRepo.FindByID(ctx, chore.ID)
+	timer, err := service.Timer(ctx, completed)
+	if err != nil {
+		t.Fatalf("Timer: %v", err)
+	}
+	if len(timer.Running) != 0 {
+		t.Fatalf("expected completion to stop every timer, still running %v", timer.Running)
+	}
+	if timer.Elapsed().Round(time.Minute) != 30*time.Minute {
+		t.Errorf("expected 30 minutes of person-time, got %s", timer.Elapsed())
+	}
+	if timer.SeriesSamples != 1 || timer.SeriesAverage().Round(time.Minute) != 30*time.Minute {
+		t.Errorf("expected a 30 minute average over 1 occurrence, got %s over %d", timer.SeriesAverage(), timer.SeriesSamples)
+	}
+	if err := service.StartTimer(ctx, chore.ID, users[0].ID); !errors.Is(err, services.ErrChoreNotOpen) {
+		t.Errorf("expected ErrChoreNotOpen timing a completed chore, got %v", err)
+	}
+}
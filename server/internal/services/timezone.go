@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	// Embed the zone database so timezone settings work in minimal images
+	// without /usr/share/zoneinfo.
+	_ "time/tzdata"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+)
+
+var ErrInvalidTimezone = errors.New("timezone must be an IANA zone name such as Europe/London")
+
+// DefaultLocation is the zone used when the household has not chosen one: the
+// host's zone. Repositories store every instant in UTC whatever it is.
+var DefaultLocation = time.Local
+
+// ParseTimezone validates an IANA zone name. "Local" is rejected because it
+// means whatever zone the server happens to run in.
+func ParseTimezone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name == "Local" {
+		return nil, ErrInvalidTimezone
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+	return location, nil
+}
+
+// HouseholdLocation returns the household timezone, falling back to
+// DefaultLocation when it is unset or no longer valid.
+func HouseholdLocation(ctx context.Context, settingsRepo repository.SettingsRepository) *time.Location {
+	if settingsRepo == nil {
+		return DefaultLocation
+	}
+	name, err := settingsRepo.Get(ctx, repository.SettingsKeyTimezone)
+	if err != nil || name == "" {
+		return DefaultLocation
+	}
+	location, err := ParseTimezone(name)
+	if err != nil {
+		return DefaultLocation
+	}
+	return location
+}
+
+// UserLocation returns the member's own timezone when they have set one, or
+// the household's.
+func UserLocation(ctx context.Context, settingsRepo repository.SettingsRepository, user models.User) *time.Location {
+	if user.Timezone != "" {
+		if location, err := ParseTimezone(user.Timezone); err == nil {
+			return location
+		}
+	}
+	return HouseholdLocation(ctx, settingsRepo)
+}
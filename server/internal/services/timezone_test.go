@@ -0,0 +1,129 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+)
+
+func TestParseTimezone(t *testing.T) {
+	if location, err := services.ParseTimezone(" Europe/London "); err != nil || location.String() != "Europe/London" {
+		t.Errorf("expected Europe/London, got %v, %v", location, err)
+	}
+	for _, name := range []string{"", "Local", "Mars/Olympus_Mons"} {
+		if _, err := services.ParseTimezone(name); !errors.Is(err, services.ErrInvalidTimezone) {
+			t.Errorf("%q: expected ErrInvalidTimezone, got %v", name, err)
+		}
+	}
+}
+
+func TestUserLocation_OverrideThenHousehold(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	settingsRepo := repository.NewSettingsRepository(db)
+	ctx := context.Background()
+
+	if got := services.UserLocation(ctx, settingsRepo, models.User{}); got != services.DefaultLocation {
+		t.Errorf("expected the default zone when nothing is set, got %v", got)
+	}
+
+	settingsRepo.Set(ctx, repository.SettingsKeyTimezone, "Europe/London")
+	if got := services.UserLocation(ctx, settingsRepo, models.User{}); got.String() != "Europe/London" {
+		t.Errorf("expected the household zone, got %v", got)
+	}
+	if got := services.UserLocation(ctx, settingsRepo, models.User{Timezone: "America/New_York"}); got.String() != "America/New_York" {
+		t.Errorf("expected the member's override, got %v", got)
+	}
+}
+
+// The zones sit either side of the date line, so at any moment at least one
+// of them is on a different calendar day from UTC.
+var farZones = []string{"Pacific/Kiritimati", "Etc/GMT+12"}
+
+func setupChoreServiceInZone(t *testing.T, zone string) (
+	*services.ChoreService,
+	*repository.SQLiteChoreRepository,
+	*repository.SQLiteUserRepository,
+	*repository.SQLiteChoreSeriesRepository,
+	*time.Location,
+) {
+	t.Helper()
+	db := testutil.NewTestDatabase(t)
+	userRepo := repository.NewUserRepository(db)
+	choreRepo := repository.NewChoreRepository(db)
+	seriesRepo := repository.NewChoreSeriesRepository(db)
+	settingsRepo := repository.NewSettingsRepository(db)
+	if err := settingsRepo.Set(context.Background(), repository.SettingsKeyTimezone, zone); err != nil {
+		t.Fatalf("setting timezone: %v", err)
+	}
+	location, err := services.ParseTimezone(zone)
+	if err != nil {
+		t.Fatalf("ParseTimezone: %v", err)
+	}
+	service := services.NewChoreService(choreRepo, repository.NewChoreAssignmentRepository(db), userRepo, seriesRepo, nil, nil, nil, settingsRepo, nil, nil, nil, nil)
+	return service, choreRepo, userRepo, seriesRepo, location
+}
+
+func TestChoreService_RecurOnCompleteCountsFromHouseholdDay(t *testing.T) {
+	for _, zone := range farZones {
+		t.Run(zone, func(t *testing.T) {
+			service, choreRepo, userRepo, seriesRepo, location := setupChoreServiceInZone(t, zone)
+			ctx := context.Background()
+			users := createUsers(t, userRepo, 1)
+
+			chore := newRecurringChore(t, choreRepo, seriesRepo,
+				models.ChoreSeries{RecurrenceType: models.RecurrenceDaily, RecurrenceValue: `{"interval": 1}`, RecurOnComplete: true},
+				models.Chore{
+					Name:             "Feed the fish",
+					CreatedByUserID:  users[0].ID,
+					AssignedToUserID: &users[0].ID,
+					Status:           models.ChoreStatusPending,
+				})
+
+			if err := service.CompleteChore(ctx, chore.ID, users[0].ID); err != nil {
+				t.Fatalf("CompleteChore: %v", err)
+			}
+
+			want := repository.CivilDate(time.Now().In(location)).AddDate(0, 0, 1)
+			pendingStatus := models.ChoreStatusPending
+			next, _ := choreRepo.FindAll(ctx, repository.ChoreFilter{SeriesID: chore.SeriesID, Status: &pendingStatus})
+			if len(next) != 1 || next[0].DueDate == nil {
+				t.Fatalf("expected one dated next occurrence, got %+v", next)
+			}
+			if !next[0].DueDate.Equal(want) {
+				t.Errorf("next occurrence due %v, want %v", next[0].DueDate, want)
+			}
+		})
+	}
+}
+
+func TestChoreService_UpdateOverdueChores_UsesHouseholdDay(t *testing.T) {
+	for _, zone := range farZones {
+		t.Run(zone, func(t *testing.T) {
+			service, choreRepo, userRepo, _, location := setupChoreServiceInZone(t, zone)
+			ctx := context.Background()
+			users := createUsers(t, userRepo, 1)
+
+			today := repository.CivilDate(time.Now().In(location))
+			yesterday := today.AddDate(0, 0, -1)
+			late, _ := choreRepo.Create(ctx, models.Chore{Name: "Late", CreatedByUserID: users[0].ID, DueDate: &yesterday, Status: models.ChoreStatusPending})
+			onTime, _ := choreRepo.Create(ctx, models.Chore{Name: "On time", CreatedByUserID: users[0].ID, DueDate: &today, Status: models.ChoreStatusPending})
+
+			if err := service.UpdateOverdueChores(ctx); err != nil {
+				t.Fatalf("UpdateOverdueChores: %v", err)
+			}
+
+			if got, _ := choreRepo.FindByID(ctx, late.ID); got.Status != models.ChoreStatusOverdue {
+				t.Errorf("expected yesterday's chore overdue, got %s", got.Status)
+			}
+			if got, _ := choreRepo.FindByID(ctx, onTime.ID); got.Status != models.ChoreStatusPending {
+				t.Errorf("expected today's chore still pending, got %s", got.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,423 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+)
+
+var (
+	ErrInvalidPushSubscription = errors.New("invalid push subscription")
+	errNoPushSubscriptions     = errors.New("no browsers have push notifications turned on")
+)
+
+// PushEvents are what a member's browsers and devices are pushed until they
+// choose otherwise: their chore reminders.
+var PushEvents = []models.NotificationEvent{
+	models.NotifyChoreAssigned,
+	models.NotifyChoreDueSoon,
+	models.NotifyChoreOverdue,
+}
+
+const (
+	// pushTTL is how long a Web Push service or APNs holds a message for a
+	// browser or device that is offline.
+	pushTTL = 24 * time.Hour
+	// webPushRecordSize is the aes128gcm record size; a payload is sent as
+	// one record, so it must fit in it.
+	webPushRecordSize = 4096
+	// pushMaxBody keeps a pushed message's text well inside the 4 KB both
+	// Web Push and APNs allow.
+	pushMaxBody = 1000
+)
+
+// LoadVAPIDKey returns the server's VAPID signing key, generating and storing
+// one the first time. Should two servers start together on one database,
+// both keep whichever key was stored first.
+func LoadVAPIDKey(ctx context.Context, settingsRepo repository.SettingsRepository) (*ecdsa.PrivateKey, error) {
+	if encoded, err := settingsRepo.Get(ctx, repository.SettingsKeyVAPIDPrivateKey); err == nil && encoded != "" {
+		return parseVAPIDKey(encoded)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating VAPID key: %w", err)
+	}
+	raw, err := key.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("encoding VAPID key: %w", err)
+	}
+	if err := settingsRepo.SetIfAbsent(ctx, repository.SettingsKeyVAPIDPrivateKey, base64.RawURLEncoding.EncodeToString(raw)); err != nil {
+		return nil, err
+	}
+	encoded, err := settingsRepo.Get(ctx, repository.SettingsKeyVAPIDPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	return parseVAPIDKey(encoded)
+}
+
+func parseVAPIDKey(encoded string) (*ecdsa.PrivateKey, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decoding VAPID key: %w", err)
+	}
+	key, err := ecdsa.ParseRawPrivateKey(elliptic.P256(), raw)
+	if err != nil {
+		return nil, fmt.Errorf("parsing VAPID key: %w", err)
+	}
+	return key, nil
+}
+
+// VAPIDPublicKey is the key browsers subscribe with (their
+// applicationServerKey): the uncompressed P-256 point, base64url encoded.
+func VAPIDPublicKey(key *ecdsa.PrivateKey) string {
+	raw, _ := key.PublicKey.Bytes()
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// WebPushService keeps track of the browsers each member has turned push
+// notifications on in. Without a VAPID key Web Push is off.
+type WebPushService struct {
+	pushRepo         repository.PushSubscriptionRepository
+	notificationRepo repository.NotificationRepository
+	key              *ecdsa.PrivateKey
+}
+
+func NewWebPushService(
+	pushRepo repository.PushSubscriptionRepository,
+	notificationRepo repository.NotificationRepository,
+	key *ecdsa.PrivateKey,
+) *WebPushService {
+	return &WebPushService{
+		pushRepo:         pushRepo,
+		notificationRepo: notificationRepo,
+		key:              key,
+	}
+}
+
+// PublicKey is the VAPID public key, or empty while Web Push is off.
+func (service *WebPushService) PublicKey() string {
+	if service.key == nil {
+		return ""
+	}
+	return VAPIDPublicKey(service.key)
+}
+
+// Subscribe registers a browser for the user and, the first time, sets up
+// their Web Push channel, sent PushEvents.
+func (service *WebPushService) Subscribe(ctx context.Context, subscription models.PushSubscription) error {
+	if service.key == nil {
+		return ErrChannelKindDisabled
+	}
+	if err := validatePushSubscription(subscription); err != nil {
+		return err
+	}
+	if _, err := service.pushRepo.Save(ctx, subscription); err != nil {
+		return err
+	}
+	return ensurePushChannel(ctx, service.notificationRepo, subscription.UserID, models.ChannelWebPush)
+}
+
+// ensurePushChannel sets up the user's channel of a push kind, sent
+// PushEvents, unless they have one.
+func ensurePushChannel(ctx context.Context, notificationRepo repository.NotificationRepository, userID string, kind models.ChannelKind) error {
+	channels, err := notificationRepo.FindChannelsByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, channel := range channels {
+		if channel.Kind == kind {
+			return nil
+		}
+	}
+	_, err = notificationRepo.CreateChannel(ctx, models.NotificationChannel{
+		UserID: userID,
+		Kind:   kind,
+		Events: PushEvents,
+	})
+	return err
+}
+
+// Unsubscribe forgets one of the user's browsers. Their channel stays, for
+// their other browsers and any they turn push on in later.
+func (service *WebPushService) Unsubscribe(ctx context.Context, userID, endpoint string) error {
+	return service.pushRepo.DeleteForUser(ctx, userID, endpoint)
+}
+
+// Devices counts the browsers the user has push notifications on in.
+func (service *WebPushService) Devices(ctx context.Context, userID string) int {
+	subscriptions, err := service.pushRepo.FindByUser(ctx, userID)
+	if err != nil {
+		slog.Error("finding push subscriptions", "user_id", userID, "error", err)
+	}
+	return len(subscriptions)
+}
+
+func validatePushSubscription(subscription models.PushSubscription) error {
+	endpoint, err := url.Parse(subscription.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		return fmt.Errorf("%w: the endpoint must be an https URL", ErrInvalidPushSubscription)
+	}
+	publicKey, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(subscription.P256DH, "="))
+	if err != nil {
+		return fmt.Errorf("%w: p256dh is not base64url", ErrInvalidPushSubscription)
+	}
+	if _, err := ecdh.P256().NewPublicKey(publicKey); err != nil {
+		return fmt.Errorf("%w: p256dh is not a P-256 public key", ErrInvalidPushSubscription)
+	}
+	auth, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(subscription.Auth, "="))
+	if err != nil || len(auth) != 16 {
+		return fmt.Errorf("%w: auth must be a 16 byte secret", ErrInvalidPushSubscription)
+	}
+	return nil
+}
+
+// webPushPayload is the JSON a browser's service worker is pushed. Tag is the
+// notification's ID, so a retried push replaces rather than repeats it.
+type webPushPayload struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	URL   string `json:"url,omitempty"`
+	Tag   string `json:"tag"`
+}
+
+// WebPushSender pushes each notification to every browser its channel's user
+// has subscribed, encrypted to the browser (RFC 8291) and signed with the
+// server's VAPID key (RFC 8292). Subject is the contact URL or mailto: the
+// push services are given. A subscription the push service reports gone
+// (404 or 410) is removed.
+type WebPushSender struct {
+	Client        *http.Client
+	Subscriptions repository.PushSubscriptionRepository
+	Key           *ecdsa.PrivateKey
+	Subject       string
+}
+
+func (sender WebPushSender) Send(ctx context.Context, channel models.NotificationChannel, notification models.Notification) error {
+	subscriptions, err := sender.Subscriptions.FindByUser(ctx, channel.UserID)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(webPushPayload{
+		Title: notification.Title,
+		Body:  pushText(notification),
+		URL:   notification.Link,
+		Tag:   notification.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding push payload: %w", err)
+	}
+
+	// Succeed once any browser has it, so a retry does not repeat it on the
+	// others.
+	var failures []error
+	delivered := false
+	for _, subscription := range subscriptions {
+		gone, err := sender.push(ctx, subscription, payload)
+		switch {
+		case gone:
+			if err := sender.Subscriptions.DeleteByEndpoint(ctx, subscription.Endpoint); err != nil {
+				slog.Error("removing expired push subscription", "subscription_id", subscription.ID, "error", err)
+			}
+		case err != nil:
+			failures = append(failures, err)
+		default:
+			delivered = true
+		}
+	}
+	if delivered {
+		return nil
+	}
+	if len(failures) > 0 {
+		return errors.Join(failures...)
+	}
+	return errNoPushSubscriptions
+}
+
+// pushText is a notification's text cut to fit a push.
+func pushText(notification models.Notification) string {
+	text := notificationText(notification)
+	if len(text) > pushMaxBody {
+		text = strings.ToValidUTF8(text[:pushMaxBody], "") + "…"
+	}
+	return text
+}
+
+// push posts the payload to one subscription, reporting whether the push
+// service says the subscription has gone.
+func (sender WebPushSender) push(ctx context.Context, subscription models.PushSubscription, payload []byte) (bool, error) {
+	encrypted, err := encryptPushPayload(subscription, payload)
+	if err != nil {
+		return false, err
+	}
+	authorization, err := vapidAuthorization(subscription.Endpoint, sender.Key, sender.Subject, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(encrypted))
+	if err != nil {
+		return false, fmt.Errorf("building push request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/octet-stream")
+	request.Header.Set("Content-Encoding", "aes128gcm")
+	request.Header.Set("TTL", strconv.Itoa(int(pushTTL.Seconds())))
+	request.Header.Set("Urgency", "normal")
+	request.Header.Set("Authorization", authorization)
+
+	response, err := sender.Client.Do(request)
+	if err != nil {
+		return false, fmt.Errorf("pushing to %s: %w", request.URL.Host, err)
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64*1024))
+
+	if response.StatusCode == http.StatusNotFound || response.StatusCode == http.StatusGone {
+		return true, nil
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return false, fmt.Errorf("%s responded %s", request.URL.Host, response.Status)
+	}
+	return false, nil
+}
+
+// encryptPushPayload encrypts a push message to a subscription as RFC 8291
+// describes: an ephemeral ECDH key agreed with the browser's key, mixed with
+// its auth secret, keys a single aes128gcm record (RFC 8188) whose header
+// carries the salt and the ephemeral public key.
+func encryptPushPayload(subscription models.PushSubscription, plaintext []byte) ([]byte, error) {
+	browserKeyBytes, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(subscription.P256DH, "="))
+	if err != nil {
+		return nil, fmt.Errorf("decoding p256dh: %w", err)
+	}
+	browserKey, err := ecdh.P256().NewPublicKey(browserKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing p256dh: %w", err)
+	}
+	authSecret, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(subscription.Auth, "="))
+	if err != nil {
+		return nil, fmt.Errorf("decoding auth secret: %w", err)
+	}
+
+	serverKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating push key: %w", err)
+	}
+	sharedSecret, err := serverKey.ECDH(browserKey)
+	if err != nil {
+		return nil, fmt.Errorf("agreeing push key: %w", err)
+	}
+	serverPublic := serverKey.PublicKey().Bytes()
+
+	keyInfo := slices.Concat([]byte("WebPush: info\x00"), browserKeyBytes, serverPublic)
+	inputKey, err := hkdf.Key(sha256.New, sharedSecret, authSecret, string(keyInfo), 32)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	pseudorandomKey, err := hkdf.Extract(sha256.New, inputKey, salt)
+	if err != nil {
+		return nil, err
+	}
+	contentKey, err := hkdf.Expand(sha256.New, pseudorandomKey, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Expand(sha256.New, pseudorandomKey, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	// 0x02 marks the last (and only) record.
+	record := append(slices.Clone(plaintext), 0x02)
+	if len(record)+gcm.Overhead() > webPushRecordSize {
+		return nil, fmt.Errorf("push payload of %d bytes does not fit in one record", len(plaintext))
+	}
+
+	header := make([]byte, 0, 16+4+1+len(serverPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, webPushRecordSize)
+	header = append(header, byte(len(serverPublic)))
+	header = append(header, serverPublic...)
+	return gcm.Seal(header, nonce, record, nil), nil
+}
+
+// vapidAuthorization is the Authorization header identifying the server to
+// the endpoint's push service: a short-lived ES256 JWT for the endpoint's
+// origin and the server's public key.
+func vapidAuthorization(endpoint string, key *ecdsa.PrivateKey, subject string, now time.Time) (string, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("parsing push endpoint: %w", err)
+	}
+	token, err := signES256JWT(key, map[string]string{"typ": "JWT", "alg": "ES256"}, map[string]any{
+		"aud": parsed.Scheme + "://" + parsed.Host,
+		"exp": now.Add(12 * time.Hour).Unix(),
+		"sub": subject,
+	})
+	if err != nil {
+		return "", fmt.Errorf("signing VAPID token: %w", err)
+	}
+	return fmt.Sprintf("vapid t=%s, k=%s", token, VAPIDPublicKey(key)), nil
+}
+
+// signES256JWT signs a JWT with a P-256 key, as VAPID and APNs provider
+// tokens are.
+func signES256JWT(key *ecdsa.PrivateKey, header, claims any) (string, error) {
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	unsigned := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+
+	digest := sha256.Sum256([]byte(unsigned))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		return "", err
+	}
+	// JWS wants the raw r||s pair, not ASN.1.
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
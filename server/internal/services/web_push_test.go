@@ -0,0 +1,246 @@
+package services_test
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+)
+
+// standInBrowser is the key pair and auth secret a browser subscribes with.
+type standInBrowser struct {
+	key  *ecdh.PrivateKey
+	auth []byte
+}
+
+func newStandInBrowser(t *testing.T) standInBrowser {
+	t.Helper()
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generating browser key: %v", err)
+	}
+	auth := make([]byte, 16)
+	rand.Read(auth)
+	return standInBrowser{key: key, auth: auth}
+}
+
+func (browser standInBrowser) subscription(userID, endpoint string) models.PushSubscription {
+	return models.PushSubscription{
+		UserID:   userID,
+		Endpoint: endpoint,
+		P256DH:   base64.RawURLEncoding.EncodeToString(browser.key.PublicKey().Bytes()),
+		Auth:     base64.RawURLEncoding.EncodeToString(browser.auth),
+	}
+}
+
+// decrypt opens an aes128gcm push message the way the browser would.
+func (browser standInBrowser) decrypt(t *testing.T, body []byte) []byte {
+	t.Helper()
+	salt, recordSize, keyLength := body[:16], binary.BigEndian.Uint32(body[16:20]), int(body[20])
+	serverPublic, ciphertext := body[21:21+keyLength], body[21+keyLength:]
+	if recordSize != 4096 || keyLength != 65 {
+		t.Fatalf("unexpected record size %d or key length %d", recordSize, keyLength)
+	}
+
+	serverKey, err := ecdh.P256().NewPublicKey(serverPublic)
+	if err != nil {
+		t.Fatalf("parsing server key: %v", err)
+	}
+	sharedSecret, _ := browser.key.ECDH(serverKey)
+	keyInfo := slices.Concat([]byte("WebPush: info\x00"), browser.key.PublicKey().Bytes(), serverPublic)
+	inputKey, _ := hkdf.Key(sha256.New, sharedSecret, browser.auth, string(keyInfo), 32)
+	pseudorandomKey, _ := hkdf.Extract(sha256.New, inputKey, salt)
+	contentKey, _ := hkdf.Expand(sha256.New, pseudorandomKey, "Content-Encoding: aes128gcm\x00", 16)
+	nonce, _ := hkdf.Expand(sha256.New, pseudorandomKey, "Content-Encoding: nonce\x00", 12)
+
+	block, _ := aes.NewCipher(contentKey)
+	gcm, _ := cipher.NewGCM(block)
+	record, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		t.Fatalf("decrypting push message: %v", err)
+	}
+	if record[len(record)-1] != 0x02 {
+		t.Fatalf("expected the last record delimiter, got %x", record[len(record)-1])
+	}
+	return record[:len(record)-1]
+}
+
+// verifyVAPID checks the Authorization header's JWT is signed by its key for
+// the audience, returning its claims.
+func verifyVAPID(t *testing.T, authorization string) map[string]any {
+	t.Helper()
+	var token, publicKey string
+	for _, part := range strings.Split(strings.TrimPrefix(authorization, "vapid "), ", ") {
+		name, value, _ := strings.Cut(part, "=")
+		switch name {
+		case "t":
+			token = value
+		case "k":
+			publicKey = value
+		}
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("malformed VAPID header %q", authorization)
+	}
+
+	rawKey, _ := base64.RawURLEncoding.DecodeString(publicKey)
+	key, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), rawKey)
+	if err != nil {
+		t.Fatalf("parsing VAPID public key: %v", err)
+	}
+	signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+	if !ecdsa.Verify(key, digest[:], r, s) {
+		t.Fatal("VAPID signature does not verify")
+	}
+
+	claimsJSON, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	var claims map[string]any
+	json.Unmarshal(claimsJSON, &claims)
+	return claims
+}
+
+func setupWebPush(t *testing.T, client *http.Client) (*services.WebPushService, *services.NotificationService, *repository.SQLitePushSubscriptionRepository, models.User) {
+	t.Helper()
+	db := testutil.NewTestDatabase(t)
+	ctx := context.Background()
+	key, err := services.LoadVAPIDKey(ctx, repository.NewSettingsRepository(db))
+	if err != nil {
+		t.Fatalf("LoadVAPIDKey: %v", err)
+	}
+	pushRepo := repository.NewPushSubscriptionRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	notifications := services.NewNotificationService(notificationRepo, userRepo, map[models.ChannelKind]services.ChannelSender{
+		models.ChannelWebPush: services.WebPushSender{Client: client, Subscriptions: pushRepo, Key: key, Subject: "mailto:admin@example.com"},
+	}, "https://hub.example.com")
+	return services.NewWebPushService(pushRepo, notificationRepo, key), notifications, pushRepo, createUsers(t, userRepo, 1)[0]
+}
+
+func TestLoadVAPIDKey_KeepsTheFirstKey(t *testing.T) {
+	settingsRepo := repository.NewSettingsRepository(testutil.NewTestDatabase(t))
+	ctx := context.Background()
+
+	first, err := services.LoadVAPIDKey(ctx, settingsRepo)
+	if err != nil {
+		t.Fatalf("LoadVAPIDKey: %v", err)
+	}
+	second, err := services.LoadVAPIDKey(ctx, settingsRepo)
+	if err != nil {
+		t.Fatalf("LoadVAPIDKey: %v", err)
+	}
+	if services.VAPIDPublicKey(first) != services.VAPIDPublicKey(second) {
+		t.Error("expected the stored key to be reused")
+	}
+}
+
+func TestWebPushSender_EncryptsAndSigns(t *testing.T) {
+	var mutex sync.Mutex
+	var received []*http.Request
+	var bodies [][]byte
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mutex.Lock()
+		received = append(received, r)
+		bodies = append(bodies, body)
+		mutex.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(server.Close)
+	webPush, notifications, _, user := setupWebPush(t, server.Client())
+	ctx := context.Background()
+	browser := newStandInBrowser(t)
+
+	if err := webPush.Subscribe(ctx, browser.subscription(user.ID, server.URL+"/push/abc")); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	channels, _ := notifications.Channels(ctx, user.ID)
+	if len(channels) != 1 || channels[0].Kind != models.ChannelWebPush || !slices.Equal(channels[0].Events, services.PushEvents) {
+		t.Fatalf("expected a Web Push channel for chore reminders, got %+v", channels)
+	}
+
+	notifications.Notify(ctx, []string{user.ID}, models.Notification{Event: models.NotifyChoreDueSoon, Title: "Feed the cat is due soon", Link: "/chores"})
+	if err := notifications.DeliverPending(ctx); err != nil {
+		t.Fatalf("DeliverPending: %v", err)
+	}
+	if len(received) != 1 {
+		t.Fatalf("expected one push, got %d", len(received))
+	}
+	request := received[0]
+	if request.URL.Path != "/push/abc" || request.Header.Get("Content-Encoding") != "aes128gcm" || request.Header.Get("TTL") == "" {
+		t.Errorf("unexpected push request %s %v", request.URL.Path, request.Header)
+	}
+
+	claims := verifyVAPID(t, request.Header.Get("Authorization"))
+	if claims["aud"] != server.URL || claims["sub"] != "mailto:admin@example.com" {
+		t.Errorf("unexpected VAPID claims %v", claims)
+	}
+
+	var payload map[string]string
+	if err := json.Unmarshal(browser.decrypt(t, bodies[0]), &payload); err != nil {
+		t.Fatalf("decoding payload: %v", err)
+	}
+	if payload["title"] != "Feed the cat is due soon" || payload["url"] != "https://hub.example.com/chores" {
+		t.Errorf("unexpected payload %v", payload)
+	}
+}
+
+func TestWebPushSender_RemovesExpiredSubscriptions(t *testing.T) {
+	pushes := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pushes++
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(server.Close)
+	webPush, notifications, pushRepo, user := setupWebPush(t, server.Client())
+	ctx := context.Background()
+
+	for _, path := range []string{"/gone", "/phone"} {
+		if err := webPush.Subscribe(ctx, newStandInBrowser(t).subscription(user.ID, server.URL+path)); err != nil {
+			t.Fatalf("Subscribe: %v", err)
+		}
+	}
+	if err := webPush.Subscribe(ctx, models.PushSubscription{UserID: user.ID, Endpoint: "http://push.example.com/x"}); err == nil {
+		t.Error("expected a plain http endpoint to be refused")
+	}
+
+	notifications.Notify(ctx, []string{user.ID}, models.Notification{Event: models.NotifyChoreAssigned, Title: "You have a new chore"})
+	if err := notifications.DeliverPending(ctx); err != nil {
+		t.Fatalf("DeliverPending: %v", err)
+	}
+
+	remaining, _ := pushRepo.FindByUser(ctx, user.ID)
+	if pushes != 2 || len(remaining) != 1 || remaining[0].Endpoint != server.URL+"/phone" {
+		t.Errorf("expected only the expired subscription removed after %d pushes, got %+v", pushes, remaining)
+	}
+	if webPush.Devices(ctx, user.ID) != 1 {
+		t.Errorf("expected one device left")
+	}
+}
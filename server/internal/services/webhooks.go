@@ -0,0 +1,360 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidWebhook = errors.New("invalid webhook")
+
+// WebhookEvents are the events a webhook can subscribe to, in the order they
+// are offered.
+var WebhookEvents = []models.WebhookEvent{
+	models.WebhookChoreCreated,
+	models.WebhookChoreAssigned,
+	models.WebhookChoreCompleted,
+	models.WebhookChoreOverdue,
+	models.WebhookMealPlanned,
+	models.WebhookRecipeCreated,
+	models.WebhookInventoryLow,
+	models.WebhookInventoryChanged,
+}
+
+const (
+	// webhookRetryBase is the wait before the first retry of a failed
+	// delivery; each retry after waits twice as long as the one before.
+	webhookRetryBase = time.Minute
+	// webhookMaxAttempts is how many times a delivery is tried before it is
+	// given up on as failed, a little over two hours after the first.
+	webhookMaxAttempts = 8
+	webhookBatchSize   = 50
+	// webhookLogSize is how many recent deliveries a webhook's log shows.
+	webhookLogSize = 20
+	// webhookRetention is how long sent and failed deliveries are kept in
+	// the log.
+	webhookRetention = 30 * 24 * time.Hour
+	// webhookMinSecretLength keeps a chosen secret from being guessable.
+	webhookMinSecretLength = 16
+)
+
+// Headers each delivery is posted with. The signature is
+// "sha256=" and the hex HMAC-SHA256, keyed with the webhook's secret, of the
+// timestamp, a dot and the body.
+const (
+	WebhookEventHeader     = "X-FamilyHub-Event"
+	WebhookDeliveryHeader  = "X-FamilyHub-Delivery"
+	WebhookTimestampHeader = "X-FamilyHub-Timestamp"
+	WebhookSignatureHeader = "X-FamilyHub-Signature"
+)
+
+// webhookEnvelope is the JSON body a webhook is posted. ID identifies the
+// event, so it is the same on a replay; Data is the chore, meal, recipe or
+// inventory change it is about.
+type webhookEnvelope struct {
+	ID         string              `json:"id"`
+	Event      models.WebhookEvent `json:"event"`
+	OccurredAt time.Time           `json:"occurredAt"`
+	Data       any                 `json:"data"`
+}
+
+// InventoryChange is the data of an inventory.changed event: Change is
+// "created", "updated" or "deleted", and Item the item as it now is or, when
+// deleted, as it was.
+type InventoryChange struct {
+	Change string
+	Item   models.InventoryItem
+}
+
+// WebhookService manages the outgoing webhooks admins register, queues the
+// domain events each subscribes to and delivers the queue, signed, retrying
+// failed deliveries with exponential backoff. Like notifications, queueing and
+// delivery meet only in the database.
+type WebhookService struct {
+	webhookRepo repository.WebhookRepository
+	client      *http.Client
+}
+
+// NewWebhookService returns the webhook service. Webhooks may point at the
+// home network, as Home Assistant and Node-RED usually live there, so
+// deliveries are not restricted to public addresses.
+func NewWebhookService(webhookRepo repository.WebhookRepository) *WebhookService {
+	return &WebhookService{
+		webhookRepo: webhookRepo,
+		client:      &http.Client{Timeout: notificationSendTimeout},
+	}
+}
+
+func (service *WebhookService) Webhooks(ctx context.Context) ([]models.Webhook, error) {
+	return service.webhookRepo.FindAll(ctx)
+}
+
+// Register adds a webhook. A blank Secret is generated.
+func (service *WebhookService) Register(ctx context.Context, webhook models.Webhook) (models.Webhook, error) {
+	if webhook.Secret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return models.Webhook{}, err
+		}
+		webhook.Secret = secret
+	}
+	if err := validateWebhook(webhook); err != nil {
+		return models.Webhook{}, err
+	}
+	return service.webhookRepo.Create(ctx, webhook)
+}
+
+// Update saves a webhook's URL, description, events and active flag, and its
+// secret when a new one is given.
+func (service *WebhookService) Update(ctx context.Context, webhook models.Webhook) error {
+	existing, err := service.webhookRepo.FindByID(ctx, webhook.ID)
+	if err != nil {
+		return err
+	}
+	if webhook.Secret == "" {
+		webhook.Secret = existing.Secret
+	}
+	if err := validateWebhook(webhook); err != nil {
+		return err
+	}
+	return service.webhookRepo.Update(ctx, webhook)
+}
+
+func (service *WebhookService) Remove(ctx context.Context, id string) error {
+	return service.webhookRepo.Delete(ctx, id)
+}
+
+// Deliveries returns the webhook's log: its most recent deliveries, newest
+// first.
+func (service *WebhookService) Deliveries(ctx context.Context, webhookID string) ([]models.WebhookDelivery, error) {
+	return service.webhookRepo.FindDeliveriesByWebhook(ctx, webhookID, webhookLogSize)
+}
+
+// Ping queues a ping to the webhook, whatever events it subscribes to.
+func (service *WebhookService) Ping(ctx context.Context, webhookID string) error {
+	webhook, err := service.webhookRepo.FindByID(ctx, webhookID)
+	if err != nil {
+		return err
+	}
+	payload, err := encodeWebhookEvent(models.WebhookPing, map[string]string{"Message": "Family Hub webhooks will arrive here."})
+	if err != nil {
+		return err
+	}
+	_, err = service.webhookRepo.EnqueueDelivery(ctx, models.WebhookDelivery{
+		WebhookID: webhook.ID,
+		Event:     models.WebhookPing,
+		Payload:   payload,
+	})
+	return err
+}
+
+// Replay queues a delivery's payload again as a new delivery, with the same
+// event ID so a receiver can tell it has seen the event before.
+func (service *WebhookService) Replay(ctx context.Context, deliveryID string) (models.WebhookDelivery, error) {
+	delivery, err := service.webhookRepo.FindDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return models.WebhookDelivery{}, err
+	}
+	return service.webhookRepo.EnqueueDelivery(ctx, models.WebhookDelivery{
+		WebhookID: delivery.WebhookID,
+		Event:     delivery.Event,
+		Payload:   delivery.Payload,
+	})
+}
+
+// Emit queues the event for every active webhook subscribed to it, with data
+// as its payload. A nil service sends nothing, and failures are logged rather
+// than returned so they never hold up whatever the event is about.
+func (service *WebhookService) Emit(ctx context.Context, event models.WebhookEvent, data any) {
+	if service == nil {
+		return
+	}
+	webhooks, err := service.webhookRepo.FindActiveByEvent(ctx, event)
+	if err != nil {
+		slog.Error("finding webhooks", "event", event, "error", err)
+		return
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+
+	payload, err := encodeWebhookEvent(event, data)
+	if err != nil {
+		slog.Error("encoding webhook payload", "event", event, "error", err)
+		return
+	}
+	for _, webhook := range webhooks {
+		if _, err := service.webhookRepo.EnqueueDelivery(ctx, models.WebhookDelivery{
+			WebhookID: webhook.ID,
+			Event:     event,
+			Payload:   payload,
+		}); err != nil {
+			slog.Error("queueing webhook delivery", "webhook_id", webhook.ID, "event", event, "error", err)
+		}
+	}
+}
+
+// InventoryChanged emits inventory.changed for an item being created
+// (previous is nil), updated or deleted (item is nil), and inventory.low when
+// the change has just taken it to its low mark.
+func (service *WebhookService) InventoryChanged(ctx context.Context, previous, item *models.InventoryItem) {
+	switch {
+	case previous == nil:
+		service.Emit(ctx, models.WebhookInventoryChanged, InventoryChange{Change: "created", Item: *item})
+	case item == nil:
+		service.Emit(ctx, models.WebhookInventoryChanged, InventoryChange{Change: "deleted", Item: *previous})
+		return
+	default:
+		service.Emit(ctx, models.WebhookInventoryChanged, InventoryChange{Change: "updated", Item: *item})
+	}
+	if InventoryRunningLow(*item) && (previous == nil || !InventoryRunningLow(*previous)) {
+		service.Emit(ctx, models.WebhookInventoryLow, *item)
+	}
+}
+
+// encodeWebhookEvent is the JSON body posted for a new event.
+func encodeWebhookEvent(event models.WebhookEvent, data any) (string, error) {
+	payload, err := json.Marshal(webhookEnvelope{
+		ID:         uuid.New().String(),
+		Event:      event,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	})
+	if err != nil {
+		return "", fmt.Errorf("encoding webhook payload: %w", err)
+	}
+	return string(payload), nil
+}
+
+// DeliverPending posts the queued deliveries that are due, a batch at a time,
+// and clears out old log entries. A failed delivery is retried after
+// webhookRetryBase, doubling each time, until webhookMaxAttempts have been
+// made.
+func (service *WebhookService) DeliverPending(ctx context.Context) error {
+	for {
+		due, err := service.webhookRepo.FindDueDeliveries(ctx, time.Now(), webhookBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, delivery := range due {
+			service.deliver(ctx, delivery)
+		}
+		if len(due) < webhookBatchSize {
+			break
+		}
+	}
+	return service.webhookRepo.DeleteDeliveriesFinishedBefore(ctx, time.Now().Add(-webhookRetention))
+}
+
+func (service *WebhookService) deliver(ctx context.Context, delivery models.WebhookDelivery) {
+	status, err := service.post(ctx, delivery)
+	if err == nil {
+		if err := service.webhookRepo.MarkDelivered(ctx, delivery.ID, status, time.Now()); err != nil {
+			slog.Error("marking webhook delivery sent", "delivery_id", delivery.ID, "error", err)
+		}
+		return
+	}
+
+	delivery.Attempts++
+	delivery.ResponseStatus = status
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= webhookMaxAttempts {
+		delivery.Status = models.WebhookDeliveryFailed
+		slog.Warn("giving up on webhook delivery", "delivery_id", delivery.ID, "webhook_id", delivery.WebhookID, "error", err)
+	} else {
+		delivery.NextAttemptAt = time.Now().Add(webhookRetryDelay(delivery.Attempts))
+	}
+	if err := service.webhookRepo.RecordDeliveryFailure(ctx, delivery); err != nil {
+		slog.Error("recording webhook delivery failure", "delivery_id", delivery.ID, "error", err)
+	}
+}
+
+// webhookRetryDelay is the wait after a delivery's attempts-th failure.
+func webhookRetryDelay(attempts int) time.Duration {
+	return webhookRetryBase << (attempts - 1)
+}
+
+// post sends the delivery, returning the response status, 0 when there was
+// none. Anything but a 2xx status is a failure.
+func (service *WebhookService) post(ctx context.Context, delivery models.WebhookDelivery) (int, error) {
+	webhook, err := service.webhookRepo.FindByID(ctx, delivery.WebhookID)
+	if err != nil {
+		return 0, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, strings.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("building webhook request: %w", err)
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "FamilyHub-Webhook/1.0")
+	request.Header.Set(WebhookEventHeader, string(delivery.Event))
+	request.Header.Set(WebhookDeliveryHeader, delivery.ID)
+	request.Header.Set(WebhookTimestampHeader, timestamp)
+	request.Header.Set(WebhookSignatureHeader, SignWebhook(webhook.Secret, timestamp, []byte(delivery.Payload)))
+
+	response, err := service.client.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("posting webhook: %w", err)
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64*1024))
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("webhook responded %s", response.Status)
+	}
+	return response.StatusCode, nil
+}
+
+// SignWebhook is the signature header value for a delivery body posted at
+// timestamp (Unix seconds): "sha256=" and the hex HMAC-SHA256 of
+// "timestamp.body" keyed with the webhook's secret. A receiver recomputes it
+// to check the delivery came from the hub and was not altered.
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("generating webhook secret: %w", err)
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+func validateWebhook(webhook models.Webhook) error {
+	if err := validateHTTPTarget(webhook.URL); err != nil {
+		return fmt.Errorf("%w: %q is not an http or https URL", ErrInvalidWebhook, webhook.URL)
+	}
+	if len(webhook.Secret) < webhookMinSecretLength {
+		return fmt.Errorf("%w: the secret must be at least %d characters", ErrInvalidWebhook, webhookMinSecretLength)
+	}
+	if len(webhook.Events) == 0 {
+		return fmt.Errorf("%w: choose at least one event", ErrInvalidWebhook)
+	}
+	for _, event := range webhook.Events {
+		if !slices.Contains(WebhookEvents, event) {
+			return fmt.Errorf("%w: unknown event %q", ErrInvalidWebhook, event)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package services_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bensuskins/family-hub/internal/models"
+	"github.com/bensuskins/family-hub/internal/repository"
+	"github.com/bensuskins/family-hub/internal/services"
+	"github.com/bensuskins/family-hub/internal/testutil"
+)
+
+func setupWebhooks(t *testing.T) (*services.WebhookService, *repository.SQLiteWebhookRepository, *sql.DB) {
+	t.Helper()
+	db := testutil.NewTestDatabase(t)
+	webhookRepo := repository.NewWebhookRepository(db)
+	return services.NewWebhookService(webhookRepo), webhookRepo, db
+}
+
+// verifyWebhookSignature recomputes a delivery's signature as a receiver
+// would.
+func verifyWebhookSignature(t *testing.T, secret string, request capturedRequest) {
+	t.Helper()
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(request.Header.Get("X-FamilyHub-Timestamp") + "." + request.Body))
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(request.Header.Get("X-FamilyHub-Signature")), []byte(expected)) {
+		t.Errorf("signature %q does not verify", request.Header.Get("X-FamilyHub-Signature"))
+	}
+}
+
+func TestWebhookService_DeliversSubscribedEventsSigned(t *testing.T) {
+	status := http.StatusNoContent
+	server, received := standInServer(t, &status)
+	webhooks, _, db := setupWebhooks(t)
+	ctx := context.Background()
+
+	webhook, err := webhooks.Register(ctx, models.Webhook{
+		URL:    server.URL + "/hook",
+		Events: []models.WebhookEvent{models.WebhookChoreCompleted, models.WebhookInventoryLow},
+		Active: true,
+	})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if len(webhook.Secret) != 64 {
+		t.Errorf("expected a generated secret, got %q", webhook.Secret)
+	}
+	if _, err := webhooks.Register(ctx, models.Webhook{URL: server.URL, Secret: "short"}); err == nil {
+		t.Error("expected a webhook without events or a long enough secret to be refused")
+	}
+
+	userRepo := repository.NewUserRepository(db)
+	user := createUsers(t, userRepo, 1)[0]
+	chores := services.NewChoreService(repository.NewChoreRepository(db), repository.NewChoreAssignmentRepository(db), userRepo, repository.NewChoreSeriesRepository(db), nil, nil, nil, nil, nil, nil, nil, webhooks)
+	chore, err := chores.CreateChore(ctx, models.Chore{Name: "Feed the cat", CreatedByUserID: user.ID}, []string{user.ID}, nil)
+	if err != nil {
+		t.Fatalf("CreateChore: %v", err)
+	}
+	if err := chores.CompleteChore(ctx, chore.ID, user.ID); err != nil {
+		t.Fatalf("CompleteChore: %v", err)
+	}
+	// Only the change that takes it to its low mark is inventory.low.
+	item := models.InventoryItem{Name: "Cat food", Quantity: 3, LowAt: 1}
+	low := item
+	low.Quantity = 1
+	lower := low
+	lower.Quantity = 0
+	webhooks.InventoryChanged(ctx, nil, &item)
+	webhooks.InventoryChanged(ctx, &item, &low)
+	webhooks.InventoryChanged(ctx, &low, &lower)
+
+	if err := webhooks.DeliverPending(ctx); err != nil {
+		t.Fatalf("DeliverPending: %v", err)
+	}
+	requests := received()
+	if len(requests) != 2 {
+		t.Fatalf("expected chore.completed and one inventory.low, got %d deliveries", len(requests))
+	}
+
+	completed := requests[0]
+	if completed.Path != "/hook" || completed.Header.Get("X-FamilyHub-Event") != "chore.completed" || completed.Header.Get("X-FamilyHub-Delivery") == "" {
+		t.Errorf("unexpected delivery %s %v", completed.Path, completed.Header)
+	}
+	verifyWebhookSignature(t, webhook.Secret, completed)
+
+	var payload struct {
+		ID    string       `json:"id"`
+		Event string       `json:"event"`
+		Data  models.Chore `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(completed.Body), &payload); err != nil {
+		t.Fatalf("decoding payload: %v", err)
+	}
+	if payload.ID == "" || payload.Event != "chore.completed" || payload.Data.ID != chore.ID || payload.Data.Status != models.ChoreStatusCompleted {
+		t.Errorf("unexpected payload %s", completed.Body)
+	}
+	if requests[1].Header.Get("X-FamilyHub-Event") != "inventory.low" {
+		t.Errorf("expected inventory.low, got %q", requests[1].Header.Get("X-FamilyHub-Event"))
+	}
+
+	// An inactive webhook is posted nothing.
+	webhook.Active = false
+	webhook.Secret = ""
+	if err := webhooks.Update(ctx, webhook); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	webhooks.InventoryChanged(ctx, &item, &low)
+	if err := webhooks.DeliverPending(ctx); err != nil {
+		t.Fatalf("DeliverPending: %v", err)
+	}
+	if len(received()) != 2 {
+		t.Error("expected nothing posted to an inactive webhook")
+	}
+}
+
+func TestWebhookService_RetriesWithBackoffThenReplays(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	server, received := standInServer(t, &status)
+	webhooks, webhookRepo, db := setupWebhooks(t)
+	ctx := context.Background()
+
+	webhook, err := webhooks.Register(ctx, models.Webhook{
+		URL:    server.URL,
+		Secret: "correct horse battery staple",
+		Events: []models.WebhookEvent{models.WebhookMealPlanned},
+		Active: true,
+	})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	webhooks.Emit(ctx, models.WebhookMealPlanned, models.MealPlan{Date: "2026-10-17", MealType: models.MealTypeDinner, Name: "Lasagne"})
+
+	// Each retry waits twice as long as the one before.
+	var waits []time.Duration
+	for range 8 {
+		makeDue := time.Now()
+		if err := webhooks.DeliverPending(ctx); err != nil {
+			t.Fatalf("DeliverPending: %v", err)
+		}
+		deliveries, _ := webhooks.Deliveries(ctx, webhook.ID)
+		if deliveries[0].Status == models.WebhookDeliveryPending {
+			waits = append(waits, deliveries[0].NextAttemptAt.Sub(makeDue).Round(time.Minute))
+		}
+		if _, err := db.Exec(`UPDATE webhook_deliveries SET next_attempt_at = ?`, time.Now().Add(-time.Second).UTC()); err != nil {
+			t.Fatalf("making retry due: %v", err)
+		}
+	}
+	if len(waits) != 7 || waits[0] != time.Minute || waits[1] != 2*time.Minute || waits[6] != 64*time.Minute {
+		t.Errorf("expected exponential backoff, got %v", waits)
+	}
+
+	deliveries, _ := webhooks.Deliveries(ctx, webhook.ID)
+	failed := deliveries[0]
+	if failed.Status != models.WebhookDeliveryFailed || failed.Attempts != 8 || failed.ResponseStatus != http.StatusServiceUnavailable {
+		t.Fatalf("expected the delivery given up on after 8 attempts, got %+v", failed)
+	}
+
+	status = http.StatusOK
+	if _, err := webhooks.Replay(ctx, failed.ID); err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	if err := webhooks.DeliverPending(ctx); err != nil {
+		t.Fatalf("DeliverPending: %v", err)
+	}
+
+	requests := received()
+	if len(requests) != 9 {
+		t.Fatalf("expected eight attempts and a replay, got %d requests", len(requests))
+	}
+	replayed := requests[8]
+	if replayed.Body != requests[0].Body || replayed.Header.Get("X-FamilyHub-Delivery") == requests[0].Header.Get("X-FamilyHub-Delivery") {
+		t.Error("expected the replay to post the same event as a new delivery")
+	}
+	verifyWebhookSignature(t, webhook.Secret, replayed)
+
+	deliveries, _ = webhookRepo.FindDeliveriesByWebhook(ctx, webhook.ID, 10)
+	if len(deliveries) != 2 || deliveries[0].Status != models.WebhookDeliverySent || deliveries[0].ResponseStatus != http.StatusOK {
+		t.Errorf("expected the replay logged as sent, got %+v", deliveries)
+	}
+}
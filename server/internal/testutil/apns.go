@@ -0,0 +1,166 @@
+package testutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// APNsPush is one notification MockAPNs accepted.
+type APNsPush struct {
+	DeviceToken   string
+	Topic         string
+	PushType      string
+	CollapseID    string
+	ProviderToken string
+	KeyID         string
+	TeamID        string
+	Payload       map[string]any
+}
+
+// MockAPNs is a local stand-in for the APNs provider API: an HTTP/2 TLS
+// server that checks each push is signed by the provider key it was given,
+// as APNs does, and records what it accepts. Reject makes it answer for a
+// device token as APNs would for one that has gone.
+type MockAPNs struct {
+	server    *httptest.Server
+	publicKey *ecdsa.PublicKey
+
+	mutex    sync.Mutex
+	pushes   []APNsPush
+	rejected map[string]apnsRejection
+}
+
+type apnsRejection struct {
+	status int
+	reason string
+}
+
+func NewMockAPNs(t *testing.T, publicKey *ecdsa.PublicKey) *MockAPNs {
+	t.Helper()
+	mock := &MockAPNs{publicKey: publicKey, rejected: map[string]apnsRejection{}}
+	mock.server = httptest.NewUnstartedServer(http.HandlerFunc(mock.serve))
+	mock.server.EnableHTTP2 = true
+	mock.server.StartTLS()
+	t.Cleanup(mock.server.Close)
+	return mock
+}
+
+// URL is the base URL to send to in place of https://api.push.apple.com.
+func (mock *MockAPNs) URL() string {
+	return mock.server.URL
+}
+
+// Client trusts the mock's certificate and speaks HTTP/2 to it.
+func (mock *MockAPNs) Client() *http.Client {
+	return mock.server.Client()
+}
+
+// Reject answers pushes to a device token with status and an APNs reason,
+// such as 410 and "Unregistered".
+func (mock *MockAPNs) Reject(deviceToken string, status int, reason string) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	mock.rejected[deviceToken] = apnsRejection{status: status, reason: reason}
+}
+
+func (mock *MockAPNs) Pushes() []APNsPush {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	return append([]APNsPush(nil), mock.pushes...)
+}
+
+func (mock *MockAPNs) serve(w http.ResponseWriter, r *http.Request) {
+	fail := func(status int, reason string) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]string{"reason": reason})
+	}
+
+	deviceToken, ok := strings.CutPrefix(r.URL.Path, "/3/device/")
+	switch {
+	case r.ProtoMajor != 2:
+		fail(http.StatusBadRequest, "BadRequest")
+		return
+	case r.Method != http.MethodPost:
+		fail(http.StatusMethodNotAllowed, "MethodNotAllowed")
+		return
+	case !ok || deviceToken == "":
+		fail(http.StatusNotFound, "BadPath")
+		return
+	case r.Header.Get("apns-topic") == "":
+		fail(http.StatusBadRequest, "MissingTopic")
+		return
+	}
+
+	push := APNsPush{
+		DeviceToken: deviceToken,
+		Topic:       r.Header.Get("apns-topic"),
+		PushType:    r.Header.Get("apns-push-type"),
+		CollapseID:  r.Header.Get("apns-collapse-id"),
+	}
+	providerToken, ok := strings.CutPrefix(r.Header.Get("Authorization"), "bearer ")
+	if !ok {
+		fail(http.StatusForbidden, "MissingProviderToken")
+		return
+	}
+	header, claims, ok := mock.verify(providerToken)
+	if !ok {
+		fail(http.StatusForbidden, "InvalidProviderToken")
+		return
+	}
+	push.ProviderToken = providerToken
+	push.KeyID, _ = header["kid"].(string)
+	push.TeamID, _ = claims["iss"].(string)
+
+	if err := json.NewDecoder(r.Body).Decode(&push.Payload); err != nil {
+		fail(http.StatusBadRequest, "PayloadEmpty")
+		return
+	}
+
+	mock.mutex.Lock()
+	rejection, rejected := mock.rejected[deviceToken]
+	if !rejected {
+		mock.pushes = append(mock.pushes, push)
+	}
+	mock.mutex.Unlock()
+	if rejected {
+		fail(rejection.status, rejection.reason)
+		return
+	}
+	w.Header().Set("apns-id", push.CollapseID)
+	w.WriteHeader(http.StatusOK)
+}
+
+// verify checks an ES256 provider token against the mock's key.
+func (mock *MockAPNs) verify(token string) (map[string]any, map[string]any, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, nil, false
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || len(signature) != 64 {
+		return nil, nil, false
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+	if !ecdsa.Verify(mock.publicKey, digest[:], r, s) {
+		return nil, nil, false
+	}
+
+	var header, claims map[string]any
+	for i, into := range []*map[string]any{&header, &claims} {
+		decoded, err := base64.RawURLEncoding.DecodeString(parts[i])
+		if err != nil || json.Unmarshal(decoded, into) != nil {
+			return nil, nil, false
+		}
+	}
+	return header, claims, header["alg"] == "ES256"
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"log/slog"
 	"os"
-	"time"
 
 	"github.com/bensuskins/family-hub/internal/config"
 	"github.com/bensuskins/family-hub/internal/database"
@@ -41,46 +40,13 @@ func main() {
 		os.Exit(1)
 	}
 
-	choreRepo := repository.NewChoreRepository(db)
-	assignmentRepo := repository.NewChoreAssignmentRepository(db)
-	seriesRepo := repository.NewChoreSeriesRepository(db)
-	choreService := services.NewChoreService(choreRepo, assignmentRepo, userRepo, seriesRepo)
-
-	go runOverdueChecker(choreService)
-	go runSeriesTopUp(choreService)
-
-	srv := server.New(db, cfg, authService)
+	srv, err := server.New(db, cfg, authService)
+	if err != nil {
+		slog.Error("setting up server", "error", err)
+		os.Exit(1)
+	}
 	if err := srv.Start(); err != nil {
 		slog.Error("server error", "error", err)
 		os.Exit(1)
 	}
 }
-
-func runOverdueChecker(choreService *services.ChoreService) {
-	ticker := time.NewTicker(5 * time.Minute)
-	defer ticker.Stop()
-
-	for {
-		ctx := context.Background()
-		if err := choreService.UpdateOverdueChores(ctx); err != nil {
-			slog.Error("updating overdue chores", "error", err)
-		}
-		<-ticker.C
-	}
-}
-
-// runSeriesTopUp keeps the bounded materialization window full for every active
-// recurring series so a never-completed chore does not run out of future
-// occurrences. Runs once on startup, then every 6 hours.
-func runSeriesTopUp(choreService *services.ChoreService) {
-	ticker := time.NewTicker(6 * time.Hour)
-	defer ticker.Stop()
-
-	for {
-		ctx := context.Background()
-		if err := choreService.TopUpAllSeries(ctx, services.SeedHorizonFrom(time.Now())); err != nil {
-			slog.Error("topping up recurring series", "error", err)
-		}
-		<-ticker.C
-	}
-}
@@ -0,0 +1,26 @@
+// Family Hub's service worker shows the Web Push notifications the server
+// sends and opens the page they link to when one is clicked.
+self.addEventListener('push', function(event) {
+	var message = {};
+	try {
+		message = event.data ? event.data.json() : {};
+	} catch (error) {
+		message = { body: event.data.text() };
+	}
+	event.waitUntil(self.registration.showNotification(message.title || 'Family Hub', {
+		body: message.body || '',
+		tag: message.tag,
+		data: { url: message.url || '/' }
+	}));
+});
+
+self.addEventListener('notificationclick', function(event) {
+	event.notification.close();
+	var url = new URL(event.notification.data.url, self.location.origin).href;
+	event.waitUntil(clients.matchAll({ type: 'window', includeUncontrolled: true }).then(function(windows) {
+		for (var i = 0; i < windows.length; i++) {
+			if (windows[i].url === url && 'focus' in windows[i]) return windows[i].focus();
+		}
+		return clients.openWindow(url);
+	}));
+});